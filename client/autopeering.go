@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -9,13 +10,19 @@ import (
 
 const (
 	routeGetAutopeeringNeighbors = "autopeering/neighbors"
+	routeGetAutopeeringDiversity = "autopeering/neighbors/diversity"
 )
 
 // GetAutopeeringNeighbors gets the chosen/accepted neighbors.
 // If knownPeers is set, also all known peers to the node are returned additionally.
 func (api *GoShimmerAPI) GetAutopeeringNeighbors(knownPeers bool) (*jsonmodels.GetNeighborsResponse, error) {
+	return api.GetAutopeeringNeighborsContext(context.Background(), knownPeers)
+}
+
+// GetAutopeeringNeighborsContext is GetAutopeeringNeighbors with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetAutopeeringNeighborsContext(ctx context.Context, knownPeers bool) (*jsonmodels.GetNeighborsResponse, error) {
 	res := &jsonmodels.GetNeighborsResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		if !knownPeers {
 			return routeGetAutopeeringNeighbors
 		}
@@ -25,3 +32,17 @@ func (api *GoShimmerAPI) GetAutopeeringNeighbors(knownPeers bool) (*jsonmodels.G
 	}
 	return res, nil
 }
+
+// GetAutopeeringDiversity gets the diversity diagnostics of the autopeering neighborhood.
+func (api *GoShimmerAPI) GetAutopeeringDiversity() (*jsonmodels.GetNeighborhoodDiversityResponse, error) {
+	return api.GetAutopeeringDiversityContext(context.Background())
+}
+
+// GetAutopeeringDiversityContext is GetAutopeeringDiversity with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetAutopeeringDiversityContext(ctx context.Context) (*jsonmodels.GetNeighborhoodDiversityResponse, error) {
+	res := &jsonmodels.GetNeighborhoodDiversityResponse{}
+	if err := api.do(ctx, http.MethodGet, routeGetAutopeeringDiversity, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
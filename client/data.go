@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
@@ -12,8 +13,13 @@ const (
 
 // Data sends the given data (payload) by creating a message in the backend.
 func (api *GoShimmerAPI) Data(data []byte) (string, error) {
+	return api.DataContext(context.Background(), data)
+}
+
+// DataContext is Data with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) DataContext(ctx context.Context, data []byte) (string, error) {
 	res := &jsonmodels.DataResponse{}
-	if err := api.do(http.MethodPost, routeData,
+	if err := api.do(ctx, http.MethodPost, routeData,
 		&jsonmodels.DataRequest{Data: data}, res); err != nil {
 		return "", err
 	}
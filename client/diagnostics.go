@@ -1,9 +1,17 @@
 package client
 
 import (
+	"context"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 )
 
 const (
@@ -41,7 +49,12 @@ const (
 //	PayloadOpinionFormed TimestampOpinionFormed MessageOpinionFormed MessageOpinionTriggered TimestampOpinion
 //	TimestampLoK
 func (api *GoShimmerAPI) GetDiagnosticsMessages() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticMessages)
+	return api.GetDiagnosticsMessagesContext(context.Background())
+}
+
+// GetDiagnosticsMessagesContext is GetDiagnosticsMessages with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetDiagnosticsMessagesContext(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticMessages)
 }
 
 // GetDiagnosticsFirstWeakMessageReferences runs diagnostics over weak references only.
@@ -53,7 +66,13 @@ func (api *GoShimmerAPI) GetDiagnosticsMessages() (*csv.Reader, error) {
 //	PayloadOpinionFormed TimestampOpinionFormed MessageOpinionFormed MessageOpinionTriggered TimestampOpinion
 //	TimestampLoK
 func (api *GoShimmerAPI) GetDiagnosticsFirstWeakMessageReferences() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsFirstWeakMessageReferences)
+	return api.GetDiagnosticsFirstWeakMessageReferencesContext(context.Background())
+}
+
+// GetDiagnosticsFirstWeakMessageReferencesContext is GetDiagnosticsFirstWeakMessageReferences with a context that
+// controls the lifetime of the request.
+func (api *GoShimmerAPI) GetDiagnosticsFirstWeakMessageReferencesContext(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsFirstWeakMessageReferences)
 }
 
 // GetDiagnosticsMessagesByRank run diagnostics for messages whose markers are equal or above a certain rank
@@ -65,7 +84,13 @@ func (api *GoShimmerAPI) GetDiagnosticsFirstWeakMessageReferences() (*csv.Reader
 //	PayloadOpinionFormed TimestampOpinionFormed MessageOpinionFormed MessageOpinionTriggered TimestampOpinion
 //	TimestampLoK
 func (api *GoShimmerAPI) GetDiagnosticsMessagesByRank(rank uint64) (*csv.Reader, error) {
-	return api.diagnose(fmt.Sprintf("%s?rank=%d", RouteDiagnosticMessages, rank))
+	return api.GetDiagnosticsMessagesByRankContext(context.Background(), rank)
+}
+
+// GetDiagnosticsMessagesByRankContext is GetDiagnosticsMessagesByRank with a context that controls the lifetime of the
+// request.
+func (api *GoShimmerAPI) GetDiagnosticsMessagesByRankContext(ctx context.Context, rank uint64) (*csv.Reader, error) {
+	return api.diagnose(ctx, fmt.Sprintf("%s/rank/%d", RouteDiagnosticMessages, rank))
 }
 
 // GetDiagnosticsUtxoDag runs diagnostics over utxo dag.
@@ -75,7 +100,12 @@ func (api *GoShimmerAPI) GetDiagnosticsMessagesByRank(rank uint64) (*csv.Reader,
 //	BranchID,BranchLiked,BranchMonotonicallyLiked,Conflicting,InclusionState,Finalized,LazyBooked,Liked,LoK,FCOB1Time,
 //	FCOB2Time
 func (api *GoShimmerAPI) GetDiagnosticsUtxoDag() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsUtxoDag)
+	return api.GetDiagnosticsUtxoDagContext(context.Background())
+}
+
+// GetDiagnosticsUtxoDagContext is GetDiagnosticsUtxoDag with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetDiagnosticsUtxoDagContext(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsUtxoDag)
 }
 
 // GetDiagnosticsBranches runs diagnostics over branches.
@@ -84,7 +114,12 @@ func (api *GoShimmerAPI) GetDiagnosticsUtxoDag() (*csv.Reader, error) {
 //	ID,ConflictSet,IssuanceTime,SolidTime,OpinionFormedTime,Liked,MonotonicallyLiked,InclusionState,Finalized,
 //	LazyBooked,TransactionLiked
 func (api *GoShimmerAPI) GetDiagnosticsBranches() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsBranches)
+	return api.GetDiagnosticsBranchesContext(context.Background())
+}
+
+// GetDiagnosticsBranchesContext is GetDiagnosticsBranches with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetDiagnosticsBranchesContext(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsBranches)
 }
 
 // GetDiagnosticsLazyBookedBranches runs diagnostics over lazy booked branches.
@@ -93,7 +128,13 @@ func (api *GoShimmerAPI) GetDiagnosticsBranches() (*csv.Reader, error) {
 //	ID,ConflictSet,IssuanceTime,SolidTime,OpinionFormedTime,Liked,MonotonicallyLiked,InclusionState,Finalized,
 //	LazyBooked,TransactionLiked
 func (api *GoShimmerAPI) GetDiagnosticsLazyBookedBranches() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsLazyBookedBranches)
+	return api.GetDiagnosticsLazyBookedBranchesContext(context.Background())
+}
+
+// GetDiagnosticsLazyBookedBranchesContext is GetDiagnosticsLazyBookedBranches with a context that controls the lifetime
+// of the request.
+func (api *GoShimmerAPI) GetDiagnosticsLazyBookedBranchesContext(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsLazyBookedBranches)
 }
 
 // GetDiagnosticsInvalidBranches runs diagnostics over invalid branches.
@@ -102,7 +143,13 @@ func (api *GoShimmerAPI) GetDiagnosticsLazyBookedBranches() (*csv.Reader, error)
 //	ID,ConflictSet,IssuanceTime,SolidTime,OpinionFormedTime,Liked,MonotonicallyLiked,InclusionState,Finalized,
 //	LazyBooked,TransactionLiked
 func (api *GoShimmerAPI) GetDiagnosticsInvalidBranches() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsInvalidBranches)
+	return api.GetDiagnosticsInvalidBranchesContext(context.Background())
+}
+
+// GetDiagnosticsInvalidBranchesContext is GetDiagnosticsInvalidBranches with a context that controls the lifetime of
+// the request.
+func (api *GoShimmerAPI) GetDiagnosticsInvalidBranchesContext(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsInvalidBranches)
 }
 
 // GetDiagnosticsTips runs diagnostics over tips
@@ -113,7 +160,12 @@ func (api *GoShimmerAPI) GetDiagnosticsInvalidBranches() (*csv.Reader, error) {
 //	Eligible,Invalid,Finalized,Rank,IsPastMarker,PastMarkers,PMHI,PMLI,FutureMarkers,FMHI,FMLI,PayloadType,TransactionID,
 //	PayloadOpinionFormed,TimestampOpinionFormed,MessageOpinionFormed,MessageOpinionTriggered,TimestampOpinion,TimestampLoK
 func (api *GoShimmerAPI) GetDiagnosticsTips() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsTips)
+	return api.GetDiagnosticsTipsContext(context.Background())
+}
+
+// GetDiagnosticsTipsContext is GetDiagnosticsTips with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetDiagnosticsTipsContext(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsTips)
 }
 
 // GetDiagnosticsStrongTips runs diagnostics over strong tips
@@ -124,7 +176,12 @@ func (api *GoShimmerAPI) GetDiagnosticsTips() (*csv.Reader, error) {
 //	Eligible,Invalid,Finalized,Rank,IsPastMarker,PastMarkers,PMHI,PMLI,FutureMarkers,FMHI,FMLI,PayloadType,TransactionID,
 //	PayloadOpinionFormed,TimestampOpinionFormed,MessageOpinionFormed,MessageOpinionTriggered,TimestampOpinion,TimestampLoK
 func (api *GoShimmerAPI) GetDiagnosticsStrongTips() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsStrongTips)
+	return api.GetDiagnosticsStrongTipsContext(context.Background())
+}
+
+// GetDiagnosticsStrongTipsContext is GetDiagnosticsStrongTips with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetDiagnosticsStrongTipsContext(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsStrongTips)
 }
 
 // GetDiagnosticsWeakTips runs diagnostics over weak tips
@@ -135,7 +192,12 @@ func (api *GoShimmerAPI) GetDiagnosticsStrongTips() (*csv.Reader, error) {
 //	Eligible,Invalid,Finalized,Rank,IsPastMarker,PastMarkers,PMHI,PMLI,FutureMarkers,FMHI,FMLI,PayloadType,TransactionID,
 //	PayloadOpinionFormed,TimestampOpinionFormed,MessageOpinionFormed,MessageOpinionTriggered,TimestampOpinion,TimestampLoK
 func (api *GoShimmerAPI) GetDiagnosticsWeakTips() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsWeakTips)
+	return api.GetDiagnosticsWeakTipsContext(context.Background())
+}
+
+// GetDiagnosticsWeakTipsContext is GetDiagnosticsWeakTips with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetDiagnosticsWeakTipsContext(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsWeakTips)
 }
 
 // GetDiagnosticsDRNG runs diagnostics for DRNG
@@ -144,14 +206,381 @@ func (api *GoShimmerAPI) GetDiagnosticsWeakTips() (*csv.Reader, error) {
 // 	ID,IssuerID,IssuerPublicKey,IssuanceTime,ArrivalTime,SolidTime,ScheduledTime,BookedTime,OpinionFormedTime,
 //	dRNGPayloadType,InstanceID,Round,PreviousSignature,Signature,DistributedPK
 func (api *GoShimmerAPI) GetDiagnosticsDRNG() (*csv.Reader, error) {
-	return api.diagnose(RouteDiagnosticsDRNG)
+	return api.GetDiagnosticsDRNGContext(context.Background())
+}
+
+// GetDiagnosticsDRNGContext is GetDiagnosticsDRNG with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetDiagnosticsDRNGContext(ctx context.Context) (*csv.Reader, error) {
+	return api.diagnose(ctx, RouteDiagnosticsDRNG)
 }
 
 // run an api call on a certain route and return a csv
-func (api *GoShimmerAPI) diagnose(route string) (*csv.Reader, error) {
+func (api *GoShimmerAPI) diagnose(ctx context.Context, route string) (*csv.Reader, error) {
 	reader := &csv.Reader{}
-	if err := api.do(http.MethodGet, route, nil, reader); err != nil {
+	if err := api.do(ctx, http.MethodGet, route, nil, reader); err != nil {
 		return nil, err
 	}
 	return reader, nil
 }
+
+// region typed diagnostics ////////////////////////////////////////////////////////////////////////////////////////////
+
+// IterateDiagnosticsMessages runs full message diagnostics and returns an iterator over the decoded rows.
+func (api *GoShimmerAPI) IterateDiagnosticsMessages() (*DiagnosticMessageIterator, error) {
+	return api.IterateDiagnosticsMessagesContext(context.Background())
+}
+
+// IterateDiagnosticsMessagesContext is IterateDiagnosticsMessages with a context that controls the lifetime of the
+// request, including the reading of the rows.
+func (api *GoShimmerAPI) IterateDiagnosticsMessagesContext(ctx context.Context) (*DiagnosticMessageIterator, error) {
+	return api.iterateDiagnosticMessages(ctx, RouteDiagnosticMessages)
+}
+
+// IterateDiagnosticsFirstWeakMessageReferences runs diagnostics over weak references only and returns an iterator over
+// the decoded rows.
+func (api *GoShimmerAPI) IterateDiagnosticsFirstWeakMessageReferences() (*DiagnosticMessageIterator, error) {
+	return api.IterateDiagnosticsFirstWeakMessageReferencesContext(context.Background())
+}
+
+// IterateDiagnosticsFirstWeakMessageReferencesContext is IterateDiagnosticsFirstWeakMessageReferences with a context
+// that controls the lifetime of the request, including the reading of the rows.
+func (api *GoShimmerAPI) IterateDiagnosticsFirstWeakMessageReferencesContext(ctx context.Context) (*DiagnosticMessageIterator, error) {
+	return api.iterateDiagnosticMessages(ctx, RouteDiagnosticsFirstWeakMessageReferences)
+}
+
+// IterateDiagnosticsMessagesByRank runs diagnostics for messages whose markers are equal or above a certain rank and
+// returns an iterator over the decoded rows.
+func (api *GoShimmerAPI) IterateDiagnosticsMessagesByRank(rank uint64) (*DiagnosticMessageIterator, error) {
+	return api.IterateDiagnosticsMessagesByRankContext(context.Background(), rank)
+}
+
+// IterateDiagnosticsMessagesByRankContext is IterateDiagnosticsMessagesByRank with a context that controls the lifetime
+// of the request, including the reading of the rows.
+func (api *GoShimmerAPI) IterateDiagnosticsMessagesByRankContext(ctx context.Context, rank uint64) (*DiagnosticMessageIterator, error) {
+	return api.iterateDiagnosticMessages(ctx, fmt.Sprintf("%s/rank/%d", RouteDiagnosticMessages, rank))
+}
+
+// IterateDiagnosticsUtxoDag runs diagnostics over the utxo dag and returns an iterator over the decoded transactions.
+func (api *GoShimmerAPI) IterateDiagnosticsUtxoDag() (*DiagnosticTransactionIterator, error) {
+	return api.IterateDiagnosticsUtxoDagContext(context.Background())
+}
+
+// IterateDiagnosticsUtxoDagContext is IterateDiagnosticsUtxoDag with a context that controls the lifetime of the
+// request, including the reading of the rows.
+func (api *GoShimmerAPI) IterateDiagnosticsUtxoDagContext(ctx context.Context) (*DiagnosticTransactionIterator, error) {
+	stream, err := api.streamDiagnostics(ctx, RouteDiagnosticsUtxoDag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiagnosticTransactionIterator{diagnosticsStream: stream}, nil
+}
+
+// IterateDiagnosticsBranches runs diagnostics over all branches and returns an iterator over the decoded rows.
+func (api *GoShimmerAPI) IterateDiagnosticsBranches() (*DiagnosticBranchIterator, error) {
+	return api.IterateDiagnosticsBranchesContext(context.Background())
+}
+
+// IterateDiagnosticsBranchesContext is IterateDiagnosticsBranches with a context that controls the lifetime of the
+// request, including the reading of the rows.
+func (api *GoShimmerAPI) IterateDiagnosticsBranchesContext(ctx context.Context) (*DiagnosticBranchIterator, error) {
+	return api.iterateDiagnosticBranches(ctx, RouteDiagnosticsBranches)
+}
+
+// IterateDiagnosticsLazyBookedBranches runs diagnostics over the lazy booked branches and returns an iterator over the
+// decoded rows.
+func (api *GoShimmerAPI) IterateDiagnosticsLazyBookedBranches() (*DiagnosticBranchIterator, error) {
+	return api.IterateDiagnosticsLazyBookedBranchesContext(context.Background())
+}
+
+// IterateDiagnosticsLazyBookedBranchesContext is IterateDiagnosticsLazyBookedBranches with a context that controls the
+// lifetime of the request, including the reading of the rows.
+func (api *GoShimmerAPI) IterateDiagnosticsLazyBookedBranchesContext(ctx context.Context) (*DiagnosticBranchIterator, error) {
+	return api.iterateDiagnosticBranches(ctx, RouteDiagnosticsLazyBookedBranches)
+}
+
+// IterateDiagnosticsInvalidBranches runs diagnostics over the invalid branches and returns an iterator over the decoded
+// rows.
+func (api *GoShimmerAPI) IterateDiagnosticsInvalidBranches() (*DiagnosticBranchIterator, error) {
+	return api.IterateDiagnosticsInvalidBranchesContext(context.Background())
+}
+
+// IterateDiagnosticsInvalidBranchesContext is IterateDiagnosticsInvalidBranches with a context that controls the
+// lifetime of the request, including the reading of the rows.
+func (api *GoShimmerAPI) IterateDiagnosticsInvalidBranchesContext(ctx context.Context) (*DiagnosticBranchIterator, error) {
+	return api.iterateDiagnosticBranches(ctx, RouteDiagnosticsInvalidBranches)
+}
+
+// IterateDiagnosticsTips runs diagnostics over all tips and returns an iterator over the decoded rows.
+func (api *GoShimmerAPI) IterateDiagnosticsTips() (*DiagnosticTipIterator, error) {
+	return api.IterateDiagnosticsTipsContext(context.Background())
+}
+
+// IterateDiagnosticsTipsContext is IterateDiagnosticsTips with a context that controls the lifetime of the request,
+// including the reading of the rows.
+func (api *GoShimmerAPI) IterateDiagnosticsTipsContext(ctx context.Context) (*DiagnosticTipIterator, error) {
+	return api.iterateDiagnosticTips(ctx, RouteDiagnosticsTips)
+}
+
+// IterateDiagnosticsStrongTips runs diagnostics over the strong tips and returns an iterator over the decoded rows.
+func (api *GoShimmerAPI) IterateDiagnosticsStrongTips() (*DiagnosticTipIterator, error) {
+	return api.IterateDiagnosticsStrongTipsContext(context.Background())
+}
+
+// IterateDiagnosticsStrongTipsContext is IterateDiagnosticsStrongTips with a context that controls the lifetime of the
+// request, including the reading of the rows.
+func (api *GoShimmerAPI) IterateDiagnosticsStrongTipsContext(ctx context.Context) (*DiagnosticTipIterator, error) {
+	return api.iterateDiagnosticTips(ctx, RouteDiagnosticsStrongTips)
+}
+
+// IterateDiagnosticsWeakTips runs diagnostics over the weak tips and returns an iterator over the decoded rows.
+func (api *GoShimmerAPI) IterateDiagnosticsWeakTips() (*DiagnosticTipIterator, error) {
+	return api.IterateDiagnosticsWeakTipsContext(context.Background())
+}
+
+// IterateDiagnosticsWeakTipsContext is IterateDiagnosticsWeakTips with a context that controls the lifetime of the
+// request, including the reading of the rows.
+func (api *GoShimmerAPI) IterateDiagnosticsWeakTipsContext(ctx context.Context) (*DiagnosticTipIterator, error) {
+	return api.iterateDiagnosticTips(ctx, RouteDiagnosticsWeakTips)
+}
+
+// IterateDiagnosticsDRNG runs diagnostics for DRNG and returns an iterator over the decoded rows.
+func (api *GoShimmerAPI) IterateDiagnosticsDRNG() (*DiagnosticDRNGMessageIterator, error) {
+	return api.IterateDiagnosticsDRNGContext(context.Background())
+}
+
+// IterateDiagnosticsDRNGContext is IterateDiagnosticsDRNG with a context that controls the lifetime of the request,
+// including the reading of the rows.
+func (api *GoShimmerAPI) IterateDiagnosticsDRNGContext(ctx context.Context) (*DiagnosticDRNGMessageIterator, error) {
+	stream, err := api.streamDiagnostics(ctx, RouteDiagnosticsDRNG)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiagnosticDRNGMessageIterator{diagnosticsStream: stream}, nil
+}
+
+func (api *GoShimmerAPI) iterateDiagnosticMessages(ctx context.Context, route string) (*DiagnosticMessageIterator, error) {
+	stream, err := api.streamDiagnostics(ctx, route)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiagnosticMessageIterator{diagnosticsStream: stream}, nil
+}
+
+func (api *GoShimmerAPI) iterateDiagnosticTips(ctx context.Context, route string) (*DiagnosticTipIterator, error) {
+	stream, err := api.streamDiagnostics(ctx, route)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiagnosticTipIterator{diagnosticsStream: stream}, nil
+}
+
+func (api *GoShimmerAPI) iterateDiagnosticBranches(ctx context.Context, route string) (*DiagnosticBranchIterator, error) {
+	stream, err := api.streamDiagnostics(ctx, route)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiagnosticBranchIterator{diagnosticsStream: stream}, nil
+}
+
+// streamDiagnostics requests the rows of a diagnostic as JSON Lines. Nodes that only know the CSV format ignore the
+// format parameter, so the rows are decoded according to the content type of the response.
+func (api *GoShimmerAPI) streamDiagnostics(ctx context.Context, route string) (*diagnosticsStream, error) {
+	var res *http.Response
+	if err := api.do(ctx, http.MethodGet, route+"?"+jsonmodels.DiagnosticsFormatParameter+"="+jsonmodels.DiagnosticsFormatJSONLines, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return newDiagnosticsStream(res)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region diagnosticsStream ////////////////////////////////////////////////////////////////////////////////////////////
+
+// diagnosticsStream decodes the rows of a diagnostic while they are read from the response.
+type diagnosticsStream struct {
+	body        io.ReadCloser
+	csvReader   *csv.Reader
+	csvHeader   []string
+	jsonDecoder *json.Decoder
+	err         error
+}
+
+func newDiagnosticsStream(res *http.Response) (stream *diagnosticsStream, err error) {
+	stream = &diagnosticsStream{body: res.Body}
+
+	switch contType := res.Header.Get(contentType); {
+	case strings.HasPrefix(contType, contentTypeJSONLines):
+		stream.jsonDecoder = json.NewDecoder(res.Body)
+	case strings.HasPrefix(contType, contentTypeCSV):
+		stream.csvReader = csv.NewReader(res.Body)
+		if stream.csvHeader, err = stream.csvReader.Read(); err != nil && !errors.Is(err, io.EOF) {
+			_ = res.Body.Close()
+			return nil, errors.Errorf("failed to read table description row: %w", err)
+		}
+	default:
+		_ = res.Body.Close()
+		return nil, errors.Errorf("can't decode diagnostics of content-type %s", contType)
+	}
+
+	return stream, nil
+}
+
+// next decodes the next row into the given row and returns false if there are no more rows or an error occurred.
+func (d *diagnosticsStream) next(row jsonmodels.DiagnosticRow) bool {
+	if d.err != nil {
+		return false
+	}
+
+	var err error
+	if d.jsonDecoder != nil {
+		err = d.jsonDecoder.Decode(row)
+	} else if d.csvHeader != nil {
+		var record []string
+		if record, err = d.csvReader.Read(); err == nil {
+			err = row.ParseCSVRow(d.csvHeader, record)
+		}
+	} else {
+		err = io.EOF
+	}
+
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			d.err = errors.Errorf("failed to decode diagnostic row: %w", err)
+		} else {
+			d.err = io.EOF
+		}
+		_ = d.body.Close()
+
+		return false
+	}
+
+	return true
+}
+
+// Err returns the error that stopped the iteration, it is nil if all rows were read.
+func (d *diagnosticsStream) Err() error {
+	if errors.Is(d.err, io.EOF) {
+		return nil
+	}
+
+	return d.err
+}
+
+// Close stops the iteration and closes the response. It only needs to be called if the iteration is stopped before all
+// rows were read.
+func (d *diagnosticsStream) Close() error {
+	if d.err == nil {
+		d.err = io.EOF
+	}
+
+	return d.body.Close()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region iterators ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DiagnosticMessageIterator iterates over the rows of a message diagnostic:
+//
+//	for iterator.Next() {
+//		message := iterator.Message()
+//	}
+//	if err := iterator.Err(); err != nil {
+//		...
+//	}
+type DiagnosticMessageIterator struct {
+	*diagnosticsStream
+	message *jsonmodels.DiagnosticMessage
+}
+
+// Next decodes the next row and returns false if there are no more rows or an error occurred.
+func (d *DiagnosticMessageIterator) Next() bool {
+	d.message = &jsonmodels.DiagnosticMessage{}
+
+	return d.next(d.message)
+}
+
+// Message returns the row that was decoded by the last call of Next.
+func (d *DiagnosticMessageIterator) Message() *jsonmodels.DiagnosticMessage {
+	return d.message
+}
+
+// DiagnosticTipIterator iterates over the rows of a tips diagnostic, like the DiagnosticMessageIterator.
+type DiagnosticTipIterator struct {
+	*diagnosticsStream
+	tip *jsonmodels.DiagnosticTip
+}
+
+// Next decodes the next row and returns false if there are no more rows or an error occurred.
+func (d *DiagnosticTipIterator) Next() bool {
+	d.tip = &jsonmodels.DiagnosticTip{}
+
+	return d.next(d.tip)
+}
+
+// Tip returns the row that was decoded by the last call of Next.
+func (d *DiagnosticTipIterator) Tip() *jsonmodels.DiagnosticTip {
+	return d.tip
+}
+
+// DiagnosticBranchIterator iterates over the rows of a branch diagnostic, like the DiagnosticMessageIterator.
+type DiagnosticBranchIterator struct {
+	*diagnosticsStream
+	branch *jsonmodels.DiagnosticBranch
+}
+
+// Next decodes the next row and returns false if there are no more rows or an error occurred.
+func (d *DiagnosticBranchIterator) Next() bool {
+	d.branch = &jsonmodels.DiagnosticBranch{}
+
+	return d.next(d.branch)
+}
+
+// Branch returns the row that was decoded by the last call of Next.
+func (d *DiagnosticBranchIterator) Branch() *jsonmodels.DiagnosticBranch {
+	return d.branch
+}
+
+// DiagnosticTransactionIterator iterates over the rows of the utxo dag diagnostic, like the DiagnosticMessageIterator.
+type DiagnosticTransactionIterator struct {
+	*diagnosticsStream
+	transaction *jsonmodels.DiagnosticTransaction
+}
+
+// Next decodes the next row and returns false if there are no more rows or an error occurred.
+func (d *DiagnosticTransactionIterator) Next() bool {
+	d.transaction = &jsonmodels.DiagnosticTransaction{}
+
+	return d.next(d.transaction)
+}
+
+// Transaction returns the row that was decoded by the last call of Next.
+func (d *DiagnosticTransactionIterator) Transaction() *jsonmodels.DiagnosticTransaction {
+	return d.transaction
+}
+
+// DiagnosticDRNGMessageIterator iterates over the rows of the DRNG diagnostic, like the DiagnosticMessageIterator.
+type DiagnosticDRNGMessageIterator struct {
+	*diagnosticsStream
+	drngMessage *jsonmodels.DiagnosticDRNGMessage
+}
+
+// Next decodes the next row and returns false if there are no more rows or an error occurred.
+func (d *DiagnosticDRNGMessageIterator) Next() bool {
+	d.drngMessage = &jsonmodels.DiagnosticDRNGMessage{}
+
+	return d.next(d.drngMessage)
+}
+
+// DRNGMessage returns the row that was decoded by the last call of Next.
+func (d *DiagnosticDRNGMessageIterator) DRNGMessage() *jsonmodels.DiagnosticDRNGMessage {
+	return d.drngMessage
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,237 @@
+package client
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+)
+
+// newDiagnosticsNode returns a node that answers the diagnostic routes with the given rows. A node that supports
+// JSON Lines honours the format parameter, the other nodes always answer with CSV like older nodes do.
+func newDiagnosticsNode(t *testing.T, supportsJSONLines bool, header []string, rows ...jsonmodels.DiagnosticRow) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if supportsJSONLines && r.URL.Query().Get(jsonmodels.DiagnosticsFormatParameter) == jsonmodels.DiagnosticsFormatJSONLines {
+			w.Header().Set(contentType, contentTypeJSONLines)
+			encoder := json.NewEncoder(w)
+			for _, row := range rows {
+				require.NoError(t, encoder.Encode(row))
+			}
+			return
+		}
+
+		w.Header().Set(contentType, contentTypeCSV)
+		writer := csv.NewWriter(w)
+		require.NoError(t, writer.Write(header))
+		for _, row := range rows {
+			require.NoError(t, writer.Write(row.CSVRow()))
+		}
+		writer.Flush()
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestGoShimmerAPI_IterateDiagnostics(t *testing.T) {
+	issuanceTime := time.Unix(0, 1625000000123456789)
+	message := jsonmodels.DiagnosticMessage{
+		ID:            "message",
+		IssuanceTime:  issuanceTime,
+		StrongParents: []string{"parent1", "parent2"},
+		Booked:        true,
+		Rank:          7,
+	}
+
+	testCases := []struct {
+		name    string
+		header  []string
+		rows    []jsonmodels.DiagnosticRow
+		iterate func(api *GoShimmerAPI) ([]jsonmodels.DiagnosticRow, error)
+	}{
+		{
+			name:   "messages",
+			header: jsonmodels.DiagnosticMessagesTableDescription,
+			rows:   []jsonmodels.DiagnosticRow{&message, &jsonmodels.DiagnosticMessage{ID: "empty"}},
+			iterate: func(api *GoShimmerAPI) (rows []jsonmodels.DiagnosticRow, err error) {
+				iterator, err := api.IterateDiagnosticsMessages()
+				if err != nil {
+					return nil, err
+				}
+				for iterator.Next() {
+					rows = append(rows, iterator.Message())
+				}
+				return rows, iterator.Err()
+			},
+		},
+		{
+			name:   "tips",
+			header: jsonmodels.DiagnosticTipsTableDescription,
+			rows:   []jsonmodels.DiagnosticRow{&jsonmodels.DiagnosticTip{TipType: "StrongTip", DiagnosticMessage: message}},
+			iterate: func(api *GoShimmerAPI) (rows []jsonmodels.DiagnosticRow, err error) {
+				iterator, err := api.IterateDiagnosticsTips()
+				if err != nil {
+					return nil, err
+				}
+				for iterator.Next() {
+					rows = append(rows, iterator.Tip())
+				}
+				return rows, iterator.Err()
+			},
+		},
+		{
+			name:   "branches",
+			header: jsonmodels.DiagnosticBranchesTableDescription,
+			rows: []jsonmodels.DiagnosticRow{
+				&jsonmodels.DiagnosticBranch{ID: "branch", ConflictSet: []string{"conflict"}, SolidTime: issuanceTime, Liked: true},
+			},
+			iterate: func(api *GoShimmerAPI) (rows []jsonmodels.DiagnosticRow, err error) {
+				iterator, err := api.IterateDiagnosticsBranches()
+				if err != nil {
+					return nil, err
+				}
+				for iterator.Next() {
+					rows = append(rows, iterator.Branch())
+				}
+				return rows, iterator.Err()
+			},
+		},
+		{
+			name:   "transactions",
+			header: jsonmodels.DiagnosticUTXODAGTableDescription,
+			rows: []jsonmodels.DiagnosticRow{
+				&jsonmodels.DiagnosticTransaction{ID: "transaction", Inputs: []string{"input"}, FCOBTime1: issuanceTime},
+			},
+			iterate: func(api *GoShimmerAPI) (rows []jsonmodels.DiagnosticRow, err error) {
+				iterator, err := api.IterateDiagnosticsUtxoDag()
+				if err != nil {
+					return nil, err
+				}
+				for iterator.Next() {
+					rows = append(rows, iterator.Transaction())
+				}
+				return rows, iterator.Err()
+			},
+		},
+		{
+			name:   "dRNG messages",
+			header: jsonmodels.DiagnosticDRNGMessagesTableDescription,
+			rows: []jsonmodels.DiagnosticRow{
+				&jsonmodels.DiagnosticDRNGMessage{ID: "message", IssuanceTime: issuanceTime, InstanceID: 1, Round: 2},
+			},
+			iterate: func(api *GoShimmerAPI) (rows []jsonmodels.DiagnosticRow, err error) {
+				iterator, err := api.IterateDiagnosticsDRNG()
+				if err != nil {
+					return nil, err
+				}
+				for iterator.Next() {
+					rows = append(rows, iterator.DRNGMessage())
+				}
+				return rows, iterator.Err()
+			},
+		},
+		{
+			name:   "no rows",
+			header: jsonmodels.DiagnosticMessagesTableDescription,
+			iterate: func(api *GoShimmerAPI) (rows []jsonmodels.DiagnosticRow, err error) {
+				iterator, err := api.IterateDiagnosticsMessages()
+				if err != nil {
+					return nil, err
+				}
+				for iterator.Next() {
+					rows = append(rows, iterator.Message())
+				}
+				return rows, iterator.Err()
+			},
+		},
+	}
+
+	for _, format := range []string{jsonmodels.DiagnosticsFormatJSONLines, jsonmodels.DiagnosticsFormatCSV} {
+		for _, tc := range testCases {
+			t.Run(format+"/"+tc.name, func(t *testing.T) {
+				node := newDiagnosticsNode(t, format == jsonmodels.DiagnosticsFormatJSONLines, tc.header, tc.rows...)
+
+				rows, err := tc.iterate(NewGoShimmerAPI(node.URL))
+				require.NoError(t, err)
+				assertDiagnosticRows(t, tc.rows, rows)
+			})
+		}
+	}
+}
+
+func TestGoShimmerAPI_IterateDiagnostics_Invalid(t *testing.T) {
+	testCases := []struct {
+		name        string
+		contentType string
+		body        string
+		err         string
+	}{
+		{
+			name:        "invalid JSON line",
+			contentType: contentTypeJSONLines,
+			body:        "{\"id\":\"message\"}\n{\"rank\":\"high\"}\n",
+			err:         "failed to decode diagnostic row",
+		},
+		{
+			name:        "invalid CSV value",
+			contentType: contentTypeCSV,
+			body:        "ID,Rank\nmessage,1\nmessage,high\n",
+			err:         "failed to parse column Rank",
+		},
+		{
+			name:        "CSV row with missing columns",
+			contentType: contentTypeCSV,
+			body:        "ID,Rank\nmessage,1\nmessage\n",
+			err:         "failed to decode diagnostic row",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set(contentType, tc.contentType)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer node.Close()
+
+			iterator, err := NewGoShimmerAPI(node.URL).IterateDiagnosticsMessages()
+			require.NoError(t, err)
+
+			// the valid first row is returned before the iteration stops at the invalid one
+			require.True(t, iterator.Next())
+			assert.Equal(t, "message", iterator.Message().ID)
+			assert.False(t, iterator.Next())
+			require.Error(t, iterator.Err())
+			assert.Contains(t, iterator.Err().Error(), tc.err)
+		})
+	}
+}
+
+func TestGoShimmerAPI_IterateDiagnostics_Close(t *testing.T) {
+	rows := []jsonmodels.DiagnosticRow{&jsonmodels.DiagnosticBranch{ID: "branch1"}, &jsonmodels.DiagnosticBranch{ID: "branch2"}}
+	node := newDiagnosticsNode(t, true, jsonmodels.DiagnosticBranchesTableDescription, rows...)
+
+	iterator, err := NewGoShimmerAPI(node.URL).IterateDiagnosticsBranches()
+	require.NoError(t, err)
+	require.True(t, iterator.Next())
+	require.NoError(t, iterator.Close())
+
+	assert.False(t, iterator.Next())
+	assert.NoError(t, iterator.Err())
+}
+
+// assertDiagnosticRows compares the rows by their CSV columns, since the times that were decoded from JSON and from CSV
+// only differ in their location.
+func assertDiagnosticRows(t *testing.T, expected, actual []jsonmodels.DiagnosticRow) {
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		assert.Equal(t, expected[i].CSVRow(), actual[i].CSVRow())
+	}
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
@@ -14,8 +15,14 @@ const (
 
 // BroadcastCollectiveBeacon sends the given collective beacon (payload) by creating a message in the backend.
 func (api *GoShimmerAPI) BroadcastCollectiveBeacon(payload []byte) (string, error) {
+	return api.BroadcastCollectiveBeaconContext(context.Background(), payload)
+}
+
+// BroadcastCollectiveBeaconContext is BroadcastCollectiveBeacon with a context that controls the lifetime of the
+// request.
+func (api *GoShimmerAPI) BroadcastCollectiveBeaconContext(ctx context.Context, payload []byte) (string, error) {
 	res := &jsonmodels.CollectiveBeaconResponse{}
-	if err := api.do(http.MethodPost, routeCollectiveBeacon,
+	if err := api.do(ctx, http.MethodPost, routeCollectiveBeacon,
 		&jsonmodels.CollectiveBeaconRequest{Payload: payload}, res); err != nil {
 		return "", err
 	}
@@ -25,8 +32,13 @@ func (api *GoShimmerAPI) BroadcastCollectiveBeacon(payload []byte) (string, erro
 
 // GetRandomness gets the current randomness.
 func (api *GoShimmerAPI) GetRandomness() (*jsonmodels.RandomnessResponse, error) {
+	return api.GetRandomnessContext(context.Background())
+}
+
+// GetRandomnessContext is GetRandomness with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetRandomnessContext(ctx context.Context) (*jsonmodels.RandomnessResponse, error) {
 	res := &jsonmodels.RandomnessResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return routeRandomness
 	}(), nil, res); err != nil {
 		return nil, err
@@ -36,8 +48,13 @@ func (api *GoShimmerAPI) GetRandomness() (*jsonmodels.RandomnessResponse, error)
 
 // GetCommittee gets the current committee.
 func (api *GoShimmerAPI) GetCommittee() (*jsonmodels.CommitteeResponse, error) {
+	return api.GetCommitteeContext(context.Background())
+}
+
+// GetCommitteeContext is GetCommittee with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetCommitteeContext(ctx context.Context) (*jsonmodels.CommitteeResponse, error) {
 	res := &jsonmodels.CommitteeResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return routeCommittee
 	}(), nil, res); err != nil {
 		return nil, err
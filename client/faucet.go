@@ -26,6 +26,11 @@ var (
 
 // SendFaucetRequest requests funds from faucet nodes by sending a faucet request payload message.
 func (api *GoShimmerAPI) SendFaucetRequest(base58EncodedAddr string, powTarget int, pledgeIDs ...string) (*jsonmodels.FaucetResponse, error) {
+	return api.SendFaucetRequestContext(context.Background(), base58EncodedAddr, powTarget, pledgeIDs...)
+}
+
+// SendFaucetRequestContext is SendFaucetRequest with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) SendFaucetRequestContext(ctx context.Context, base58EncodedAddr string, powTarget int, pledgeIDs ...string) (*jsonmodels.FaucetResponse, error) {
 	var aManaPledgeID identity.ID
 	var cManaPledgeID identity.ID
 	if len(pledgeIDs) > 1 {
@@ -44,13 +49,13 @@ func (api *GoShimmerAPI) SendFaucetRequest(base58EncodedAddr string, powTarget i
 		return nil, errors.Errorf("could not decode address from string: %w", err)
 	}
 
-	nonce, err := computeFaucetPoW(address, aManaPledgeID, cManaPledgeID, powTarget)
+	nonce, err := computeFaucetPoW(ctx, address, aManaPledgeID, cManaPledgeID, powTarget)
 	if err != nil {
 		return nil, errors.Errorf("could not compute faucet PoW: %w", err)
 	}
 
 	res := &jsonmodels.FaucetResponse{}
-	if err := api.do(http.MethodPost, routeFaucet,
+	if err := api.do(ctx, http.MethodPost, routeFaucet,
 		&jsonmodels.FaucetRequest{
 			Address:               base58EncodedAddr,
 			AccessManaPledgeID:    base58.Encode(aManaPledgeID.Bytes()),
@@ -63,7 +68,7 @@ func (api *GoShimmerAPI) SendFaucetRequest(base58EncodedAddr string, powTarget i
 	return res, nil
 }
 
-func computeFaucetPoW(address ledgerstate.Address, aManaPledgeID, cManaPledgeID identity.ID, powTarget int) (nonce uint64, err error) {
+func computeFaucetPoW(ctx context.Context, address ledgerstate.Address, aManaPledgeID, cManaPledgeID identity.ID, powTarget int) (nonce uint64, err error) {
 	if powTarget < 0 {
 		powTarget = defaultPOWTarget
 	}
@@ -73,5 +78,5 @@ func computeFaucetPoW(address ledgerstate.Address, aManaPledgeID, cManaPledgeID
 	objectBytes := faucetRequest.Bytes()
 	powRelevantBytes := objectBytes[:len(objectBytes)-pow.NonceBytes]
 
-	return powWorker.Mine(context.Background(), powRelevantBytes, powTarget)
+	return powWorker.Mine(ctx, powRelevantBytes, powTarget)
 }
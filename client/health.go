@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -10,5 +11,10 @@ const (
 
 // HealthCheck checks whether the node is running and healthy.
 func (api *GoShimmerAPI) HealthCheck() error {
-	return api.do(http.MethodGet, routeHealth, nil, nil)
+	return api.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext is HealthCheck with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) HealthCheckContext(ctx context.Context) error {
+	return api.do(ctx, http.MethodGet, routeHealth, nil, nil)
 }
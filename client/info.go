@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
@@ -12,8 +13,13 @@ const (
 
 // Info gets the info of the node.
 func (api *GoShimmerAPI) Info() (*jsonmodels.InfoResponse, error) {
+	return api.InfoContext(context.Background())
+}
+
+// InfoContext is Info with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) InfoContext(ctx context.Context) (*jsonmodels.InfoResponse, error) {
 	res := &jsonmodels.InfoResponse{}
-	if err := api.do(http.MethodGet, routeInfo, nil, res); err != nil {
+	if err := api.do(ctx, http.MethodGet, routeInfo, nil, res); err != nil {
 		return nil, err
 	}
 	return res, nil
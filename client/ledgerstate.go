@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
@@ -23,13 +24,19 @@ const (
 	pathMetadata       = "/metadata"
 	pathInclusionState = "/inclusionState"
 	pathConsensus      = "/consensus"
+	pathConsensusTrace = "/consensus/trace"
 	pathAttachments    = "/attachments"
 )
 
 // GetAddressOutputs gets the spent and unspent outputs of an address.
 func (api *GoShimmerAPI) GetAddressOutputs(base58EncodedAddress string) (*jsonmodels.GetAddressResponse, error) {
+	return api.GetAddressOutputsContext(context.Background(), base58EncodedAddress)
+}
+
+// GetAddressOutputsContext is GetAddressOutputs with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetAddressOutputsContext(ctx context.Context, base58EncodedAddress string) (*jsonmodels.GetAddressResponse, error) {
 	res := &jsonmodels.GetAddressResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetAddresses, base58EncodedAddress}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -39,8 +46,13 @@ func (api *GoShimmerAPI) GetAddressOutputs(base58EncodedAddress string) (*jsonmo
 
 // GetAddressUnspentOutputs gets the unspent outputs of an address.
 func (api *GoShimmerAPI) GetAddressUnspentOutputs(base58EncodedAddress string) (*jsonmodels.GetAddressResponse, error) {
+	return api.GetAddressUnspentOutputsContext(context.Background(), base58EncodedAddress)
+}
+
+// GetAddressUnspentOutputsContext is GetAddressUnspentOutputs with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetAddressUnspentOutputsContext(ctx context.Context, base58EncodedAddress string) (*jsonmodels.GetAddressResponse, error) {
 	res := &jsonmodels.GetAddressResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetAddresses, base58EncodedAddress, pathUnspentOutputs}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -50,8 +62,14 @@ func (api *GoShimmerAPI) GetAddressUnspentOutputs(base58EncodedAddress string) (
 
 // PostAddressUnspentOutputs gets the unspent outputs of several addresses.
 func (api *GoShimmerAPI) PostAddressUnspentOutputs(base58EncodedAddresses []string) (*jsonmodels.PostAddressesUnspentOutputsResponse, error) {
+	return api.PostAddressUnspentOutputsContext(context.Background(), base58EncodedAddresses)
+}
+
+// PostAddressUnspentOutputsContext is PostAddressUnspentOutputs with a context that controls the lifetime of the
+// request.
+func (api *GoShimmerAPI) PostAddressUnspentOutputsContext(ctx context.Context, base58EncodedAddresses []string) (*jsonmodels.PostAddressesUnspentOutputsResponse, error) {
 	res := &jsonmodels.PostAddressesUnspentOutputsResponse{}
-	if err := api.do(http.MethodPost, func() string {
+	if err := api.do(ctx, http.MethodPost, func() string {
 		return strings.Join([]string{routeGetAddresses, "unspentOutputs"}, "")
 	}(), &jsonmodels.PostAddressesUnspentOutputsRequest{Addresses: base58EncodedAddresses}, res); err != nil {
 		return nil, err
@@ -61,8 +79,13 @@ func (api *GoShimmerAPI) PostAddressUnspentOutputs(base58EncodedAddresses []stri
 
 // GetBranch gets the branch information.
 func (api *GoShimmerAPI) GetBranch(base58EncodedBranchID string) (*jsonmodels.Branch, error) {
+	return api.GetBranchContext(context.Background(), base58EncodedBranchID)
+}
+
+// GetBranchContext is GetBranch with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetBranchContext(ctx context.Context, base58EncodedBranchID string) (*jsonmodels.Branch, error) {
 	res := &jsonmodels.Branch{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetBranches, base58EncodedBranchID}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -72,8 +95,13 @@ func (api *GoShimmerAPI) GetBranch(base58EncodedBranchID string) (*jsonmodels.Br
 
 // GetBranchChildren gets the children of a branch.
 func (api *GoShimmerAPI) GetBranchChildren(base58EncodedBranchID string) (*jsonmodels.GetBranchChildrenResponse, error) {
+	return api.GetBranchChildrenContext(context.Background(), base58EncodedBranchID)
+}
+
+// GetBranchChildrenContext is GetBranchChildren with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetBranchChildrenContext(ctx context.Context, base58EncodedBranchID string) (*jsonmodels.GetBranchChildrenResponse, error) {
 	res := &jsonmodels.GetBranchChildrenResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetBranches, base58EncodedBranchID, pathChildren}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -83,8 +111,13 @@ func (api *GoShimmerAPI) GetBranchChildren(base58EncodedBranchID string) (*jsonm
 
 // GetBranchConflicts gets the conflict branches of a branch.
 func (api *GoShimmerAPI) GetBranchConflicts(base58EncodedBranchID string) (*jsonmodels.GetBranchConflictsResponse, error) {
+	return api.GetBranchConflictsContext(context.Background(), base58EncodedBranchID)
+}
+
+// GetBranchConflictsContext is GetBranchConflicts with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetBranchConflictsContext(ctx context.Context, base58EncodedBranchID string) (*jsonmodels.GetBranchConflictsResponse, error) {
 	res := &jsonmodels.GetBranchConflictsResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetBranches, base58EncodedBranchID, pathConflicts}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -94,8 +127,13 @@ func (api *GoShimmerAPI) GetBranchConflicts(base58EncodedBranchID string) (*json
 
 // GetOutput gets the output corresponding to OutputID.
 func (api *GoShimmerAPI) GetOutput(base58EncodedOutputID string) (*jsonmodels.Output, error) {
+	return api.GetOutputContext(context.Background(), base58EncodedOutputID)
+}
+
+// GetOutputContext is GetOutput with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetOutputContext(ctx context.Context, base58EncodedOutputID string) (*jsonmodels.Output, error) {
 	res := &jsonmodels.Output{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetOutputs, base58EncodedOutputID}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -105,8 +143,13 @@ func (api *GoShimmerAPI) GetOutput(base58EncodedOutputID string) (*jsonmodels.Ou
 
 // GetOutputConsumers gets the consumers of the output corresponding to OutputID.
 func (api *GoShimmerAPI) GetOutputConsumers(base58EncodedOutputID string) (*jsonmodels.GetOutputConsumersResponse, error) {
+	return api.GetOutputConsumersContext(context.Background(), base58EncodedOutputID)
+}
+
+// GetOutputConsumersContext is GetOutputConsumers with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetOutputConsumersContext(ctx context.Context, base58EncodedOutputID string) (*jsonmodels.GetOutputConsumersResponse, error) {
 	res := &jsonmodels.GetOutputConsumersResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetOutputs, base58EncodedOutputID, pathConsumers}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -116,8 +159,13 @@ func (api *GoShimmerAPI) GetOutputConsumers(base58EncodedOutputID string) (*json
 
 // GetOutputMetadata gets the metadata of the output corresponding to OutputID.
 func (api *GoShimmerAPI) GetOutputMetadata(base58EncodedOutputID string) (*jsonmodels.OutputMetadata, error) {
+	return api.GetOutputMetadataContext(context.Background(), base58EncodedOutputID)
+}
+
+// GetOutputMetadataContext is GetOutputMetadata with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetOutputMetadataContext(ctx context.Context, base58EncodedOutputID string) (*jsonmodels.OutputMetadata, error) {
 	res := &jsonmodels.OutputMetadata{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetOutputs, base58EncodedOutputID, pathMetadata}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -127,8 +175,13 @@ func (api *GoShimmerAPI) GetOutputMetadata(base58EncodedOutputID string) (*jsonm
 
 // GetTransaction gets the transaction of the corresponding to TransactionID.
 func (api *GoShimmerAPI) GetTransaction(base58EncodedTransactionID string) (*jsonmodels.Transaction, error) {
+	return api.GetTransactionContext(context.Background(), base58EncodedTransactionID)
+}
+
+// GetTransactionContext is GetTransaction with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetTransactionContext(ctx context.Context, base58EncodedTransactionID string) (*jsonmodels.Transaction, error) {
 	res := &jsonmodels.Transaction{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetTransactions, base58EncodedTransactionID}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -138,8 +191,13 @@ func (api *GoShimmerAPI) GetTransaction(base58EncodedTransactionID string) (*jso
 
 // GetTransactionMetadata gets metadata of the transaction corresponding to TransactionID.
 func (api *GoShimmerAPI) GetTransactionMetadata(base58EncodedTransactionID string) (*jsonmodels.TransactionMetadata, error) {
+	return api.GetTransactionMetadataContext(context.Background(), base58EncodedTransactionID)
+}
+
+// GetTransactionMetadataContext is GetTransactionMetadata with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetTransactionMetadataContext(ctx context.Context, base58EncodedTransactionID string) (*jsonmodels.TransactionMetadata, error) {
 	res := &jsonmodels.TransactionMetadata{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetTransactions, base58EncodedTransactionID, pathMetadata}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -149,8 +207,14 @@ func (api *GoShimmerAPI) GetTransactionMetadata(base58EncodedTransactionID strin
 
 // GetTransactionInclusionState gets inclusion state of the transaction corresponding to TransactionID.
 func (api *GoShimmerAPI) GetTransactionInclusionState(base58EncodedTransactionID string) (*jsonmodels.TransactionInclusionState, error) {
+	return api.GetTransactionInclusionStateContext(context.Background(), base58EncodedTransactionID)
+}
+
+// GetTransactionInclusionStateContext is GetTransactionInclusionState with a context that controls the lifetime of the
+// request.
+func (api *GoShimmerAPI) GetTransactionInclusionStateContext(ctx context.Context, base58EncodedTransactionID string) (*jsonmodels.TransactionInclusionState, error) {
 	res := &jsonmodels.TransactionInclusionState{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetTransactions, base58EncodedTransactionID, pathInclusionState}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -160,8 +224,14 @@ func (api *GoShimmerAPI) GetTransactionInclusionState(base58EncodedTransactionID
 
 // GetTransactionConsensusMetadata gets the consensus metadata of the transaction corresponding to TransactionID.
 func (api *GoShimmerAPI) GetTransactionConsensusMetadata(base58EncodedTransactionID string) (*jsonmodels.TransactionConsensusMetadata, error) {
+	return api.GetTransactionConsensusMetadataContext(context.Background(), base58EncodedTransactionID)
+}
+
+// GetTransactionConsensusMetadataContext is GetTransactionConsensusMetadata with a context that controls the lifetime
+// of the request.
+func (api *GoShimmerAPI) GetTransactionConsensusMetadataContext(ctx context.Context, base58EncodedTransactionID string) (*jsonmodels.TransactionConsensusMetadata, error) {
 	res := &jsonmodels.TransactionConsensusMetadata{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetTransactions, base58EncodedTransactionID, pathConsensus}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -169,10 +239,34 @@ func (api *GoShimmerAPI) GetTransactionConsensusMetadata(base58EncodedTransactio
 	return res, nil
 }
 
+// GetTransactionConsensusTrace gets the trace of the FCoB rules that formed the opinion about the transaction
+// corresponding to TransactionID.
+func (api *GoShimmerAPI) GetTransactionConsensusTrace(base58EncodedTransactionID string) (*jsonmodels.TransactionConsensusTrace, error) {
+	return api.GetTransactionConsensusTraceContext(context.Background(), base58EncodedTransactionID)
+}
+
+// GetTransactionConsensusTraceContext is GetTransactionConsensusTrace with a context that controls the lifetime of the
+// request.
+func (api *GoShimmerAPI) GetTransactionConsensusTraceContext(ctx context.Context, base58EncodedTransactionID string) (*jsonmodels.TransactionConsensusTrace, error) {
+	res := &jsonmodels.TransactionConsensusTrace{}
+	if err := api.do(ctx, http.MethodGet, func() string {
+		return strings.Join([]string{routeGetTransactions, base58EncodedTransactionID, pathConsensusTrace}, "")
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // GetTransactionAttachments gets the attachments (messageIDs) of the transaction corresponding to TransactionID.
 func (api *GoShimmerAPI) GetTransactionAttachments(base58EncodedTransactionID string) (*jsonmodels.GetTransactionAttachmentsResponse, error) {
+	return api.GetTransactionAttachmentsContext(context.Background(), base58EncodedTransactionID)
+}
+
+// GetTransactionAttachmentsContext is GetTransactionAttachments with a context that controls the lifetime of the
+// request.
+func (api *GoShimmerAPI) GetTransactionAttachmentsContext(ctx context.Context, base58EncodedTransactionID string) (*jsonmodels.GetTransactionAttachmentsResponse, error) {
 	res := &jsonmodels.GetTransactionAttachmentsResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return strings.Join([]string{routeGetTransactions, base58EncodedTransactionID, pathAttachments}, "")
 	}(), nil, res); err != nil {
 		return nil, err
@@ -182,8 +276,13 @@ func (api *GoShimmerAPI) GetTransactionAttachments(base58EncodedTransactionID st
 
 // PostTransaction sends the transaction(bytes) to the Tangle and returns its transaction ID.
 func (api *GoShimmerAPI) PostTransaction(transactionBytes []byte) (*jsonmodels.PostTransactionResponse, error) {
+	return api.PostTransactionContext(context.Background(), transactionBytes)
+}
+
+// PostTransactionContext is PostTransaction with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) PostTransactionContext(ctx context.Context, transactionBytes []byte) (*jsonmodels.PostTransactionResponse, error) {
 	res := &jsonmodels.PostTransactionResponse{}
-	if err := api.do(http.MethodPost, routePostTransactions,
+	if err := api.do(ctx, http.MethodPost, routePostTransactions,
 		&jsonmodels.PostTransactionRequest{TransactionBytes: transactionBytes}, res); err != nil {
 		return nil, err
 	}
@@ -11,8 +11,13 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/tangle"
 )
 
 var (
@@ -28,12 +33,25 @@ var (
 	ErrUnknownError = errors.New("unknown error")
 	// ErrNotImplemented defines the "operation not implemented/supported/available" error.
 	ErrNotImplemented = errors.New("operation not implemented/supported/available")
+	// ErrNotSynced defines the "node not synced" error, which nodes return for requests that need a synced tangle.
+	ErrNotSynced = errors.New("node not synced")
+	// ErrRateLimited defines the "rate limited" error.
+	ErrRateLimited = errors.New("rate limited")
 )
 
+// notSyncedMessages are the messages of the errors with which nodes reject requests because they are not synced. The
+// nodes wrap them into the messages of their responses.
+var notSyncedMessages = []string{tangle.ErrNotSynced.Error(), mana.ErrQueryNotAllowed.Error()}
+
 const (
 	contentType     = "Content-Type"
 	contentTypeJSON = "application/json"
 	contentTypeCSV  = "text/csv"
+	// contentTypeJSONLines is the content type of JSON Lines, with one JSON object per line.
+	contentTypeJSONLines = "application/x-ndjson"
+
+	// healthCheckTimeout bounds the time that a health check of a node may take.
+	healthCheckTimeout = 5 * time.Second
 )
 
 // Option is a function which sets the given option.
@@ -64,6 +82,34 @@ func WithHTTPClient(c http.Client) Option {
 	}
 }
 
+// WithNodes adds further nodes to the client. Idempotent requests are load balanced over the healthy nodes and fail
+// over to the next node, transactions are only submitted to synced nodes and all other requests are sent to the first
+// healthy node. Node specific requests, like manual peering or diagnostics, should use a client with a single node.
+func WithNodes(baseURLs ...string) Option {
+	return func(g *GoShimmerAPI) {
+		g.nodes.add(baseURLs...)
+	}
+}
+
+// WithRetryPolicy sets the policy that idempotent requests are retried with. By default, a client with several nodes
+// uses the DefaultRetryPolicy and a client with a single node does not retry (NoRetry).
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(g *GoShimmerAPI) {
+		if policy.MaxAttempts < 1 {
+			policy.MaxAttempts = 1
+		}
+		g.retryPolicy = policy
+	}
+}
+
+// WithHealthCheckInterval sets the interval in which the health and the sync state of the nodes are checked. The checks
+// are only made if the client has several nodes.
+func WithHealthCheckInterval(interval time.Duration) Option {
+	return func(g *GoShimmerAPI) {
+		g.nodes.healthCheckInterval = interval
+	}
+}
+
 // IsEnabled returns the enabled state of a given BasicAuth.
 func (b BasicAuth) IsEnabled() bool {
 	return b.Enabled
@@ -78,24 +124,95 @@ func (b BasicAuth) Credentials() (username, password string) {
 func NewGoShimmerAPI(baseURL string, setters ...Option) *GoShimmerAPI {
 	g := &GoShimmerAPI{
 		baseURL: baseURL,
+		nodes:   newNodePool(baseURL),
 	}
 	for _, setter := range setters {
 		setter(g)
 	}
+	if g.retryPolicy.MaxAttempts == 0 {
+		g.retryPolicy = NoRetry
+		if g.nodes.size() > 1 {
+			g.retryPolicy = DefaultRetryPolicy
+		}
+	}
 	return g
 }
 
-// GoShimmerAPI is an API wrapper over the web API of GoShimmer.
+// GoShimmerAPI is an API wrapper over the web API of GoShimmer. It can use several nodes, see WithNodes.
 type GoShimmerAPI struct {
-	baseURL    string
-	httpClient http.Client
-	basicAuth  BasicAuth
+	baseURL     string
+	httpClient  http.Client
+	basicAuth   BasicAuth
+	nodes       *nodePool
+	retryPolicy RetryPolicy
 }
 
 type errorresponse struct {
 	Error string `json:"error"`
 }
 
+// region APIError /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// APIError is the error of a request that the node answered with an error status. It matches the error variables of
+// this package that describe its status, e.g. errors.Is(err, ErrNotFound) holds for an APIError with status 404.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Message is the error message of the node, or the URL of the request if the node did not send a message.
+	Message string
+	// kinds are the error variables that the error matches, the first one describes the status code.
+	kinds []error
+}
+
+// newAPIError creates the APIError of a response with the given status code and error message.
+func newAPIError(statusCode int, message string) *APIError {
+	var kind error
+	switch statusCode {
+	case http.StatusBadRequest:
+		kind = ErrBadRequest
+	case http.StatusUnauthorized:
+		kind = ErrUnauthorized
+	case http.StatusNotFound:
+		kind = ErrNotFound
+	case http.StatusTooManyRequests:
+		kind = ErrRateLimited
+	case http.StatusInternalServerError:
+		kind = ErrInternalServerError
+	case http.StatusNotImplemented:
+		kind = ErrNotImplemented
+	default:
+		kind = ErrUnknownError
+	}
+
+	apiError := &APIError{StatusCode: statusCode, Message: message, kinds: []error{kind}}
+	for _, notSyncedMessage := range notSyncedMessages {
+		if strings.Contains(message, notSyncedMessage) {
+			apiError.kinds = append(apiError.kinds, ErrNotSynced)
+			break
+		}
+	}
+
+	return apiError
+}
+
+// Error returns the description of the status code followed by the message of the node.
+func (a *APIError) Error() string {
+	return fmt.Sprintf("%s: %s", a.kinds[0], a.Message)
+}
+
+// Is returns true if the target is one of the error variables that the error matches.
+func (a *APIError) Is(target error) bool {
+	for _, kind := range a.kinds {
+		if kind == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 func interpretBody(res *http.Response, decodeTo interface{}) error {
 	resBody, err := io.ReadAll(res.Body)
 	if err != nil {
@@ -114,47 +231,82 @@ func interpretBody(res *http.Response, decodeTo interface{}) error {
 			return fmt.Errorf("Can't decode %s content-type", contType)
 		}
 	}
+
+	// errors of the API handlers are JSON, but errors of the web server itself are plain text
+	message := strings.TrimSpace(string(resBody))
 	errRes := &errorresponse{}
-	if err := json.Unmarshal(resBody, errRes); err != nil {
-		return fmt.Errorf("unable to read error from response body: %w repsonseBody: %s", err, resBody)
+	if err := json.Unmarshal(resBody, errRes); err == nil {
+		message = errRes.Error
 	}
-
-	switch res.StatusCode {
-	case http.StatusInternalServerError:
-		return fmt.Errorf("%w: %s", ErrInternalServerError, errRes.Error)
-	case http.StatusNotFound:
-		return fmt.Errorf("%w: %s", ErrNotFound, res.Request.URL.String())
-	case http.StatusBadRequest:
-		return fmt.Errorf("%w: %s", ErrBadRequest, errRes.Error)
-	case http.StatusUnauthorized:
-		return fmt.Errorf("%w: %s", ErrUnauthorized, errRes.Error)
-	case http.StatusNotImplemented:
-		return fmt.Errorf("%w: %s", ErrNotImplemented, errRes.Error)
+	if res.StatusCode == http.StatusNotFound || message == "" {
+		message = res.Request.URL.String()
 	}
 
-	return fmt.Errorf("%w: %s", ErrUnknownError, errRes.Error)
+	return newAPIError(res.StatusCode, message)
 }
 
-func (api *GoShimmerAPI) do(method string, route string, reqObj interface{}, resObj interface{}) error {
+func (api *GoShimmerAPI) do(ctx context.Context, method string, route string, reqObj interface{}, resObj interface{}) (err error) {
 	// marshal request object
 	var data []byte
 	if reqObj != nil {
-		var err error
 		data, err = json.Marshal(reqObj)
 		if err != nil {
 			return err
 		}
 	}
-	ctx := context.TODO()
+
+	kind := requestKindOf(method, route)
+	attempts := 1
+	if kind != writeRequest {
+		attempts = api.retryPolicy.MaxAttempts
+	}
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(api.retryPolicy.backoff(attempt)):
+			case <-ctx.Done():
+				return errors.Errorf("request canceled after %d attempts: %w", attempt, ctx.Err())
+			}
+		}
+
+		candidates, selectErr := api.nodes.candidates(kind, api.checkNode)
+		if selectErr != nil {
+			if err == nil {
+				err = selectErr
+			}
+			return err
+		}
+		// failed nodes are no candidates until their next check, so that retries fail over to another node
+		baseURL := candidates[0]
+
+		var nodeFailed bool
+		if nodeFailed, err = api.doOnNode(ctx, baseURL, method, route, data, resObj); !nodeFailed {
+			return err
+		}
+		// a canceled request says nothing about the health of the node
+		if ctx.Err() != nil {
+			return err
+		}
+		api.nodes.markFailed(baseURL, err)
+	}
+
+	return err
+}
+
+// doOnNode sends the request to the node with the given base URL. It reports whether the request failed because of the
+// node, i.e. because it could not be reached, had an internal error or limited the rate of the requests, so that the
+// request may be retried elsewhere.
+func (api *GoShimmerAPI) doOnNode(ctx context.Context, baseURL, method, route string, data []byte, resObj interface{}) (nodeFailed bool, err error) {
 	// construct request
-	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s", api.baseURL, route), func() io.Reader {
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s", baseURL, route), func() io.Reader {
 		if data == nil {
 			return nil
 		}
 		return bytes.NewReader(data)
 	}())
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if data != nil {
@@ -169,18 +321,61 @@ func (api *GoShimmerAPI) do(method string, route string, reqObj interface{}, res
 	// make the request
 	res, err := api.httpClient.Do(req)
 	if err != nil {
-		return err
+		return true, err
+	}
+	nodeFailed = res.StatusCode == http.StatusTooManyRequests ||
+		(res.StatusCode >= http.StatusInternalServerError && res.StatusCode != http.StatusNotImplemented)
+
+	if resObj == nil && !nodeFailed {
+		_ = res.Body.Close()
+		return false, nil
 	}
 
-	if resObj == nil {
-		return nil
+	// streamed responses are handed over unread, the caller has to close their body
+	if streamedRes, ok := resObj.(**http.Response); ok && res.StatusCode == http.StatusOK {
+		*streamedRes = res
+		return false, nil
 	}
 
 	// write response into response object
-	if err := interpretBody(res, resObj); err != nil {
-		return err
+	return nodeFailed, interpretBody(res, resObj)
+}
+
+// checkNode checks the health and the sync state of the node with the given base URL.
+func (api *GoShimmerAPI) checkNode(ctx context.Context, baseURL string) (synced bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	res := &jsonmodels.InfoResponse{}
+	if _, err = api.doOnNode(ctx, baseURL, http.MethodGet, routeInfo, nil, res); err != nil {
+		return false, err
 	}
-	return nil
+
+	return res.TangleTime.Synced, nil
+}
+
+// Nodes returns the health of the nodes of the client as of their last check.
+func (api *GoShimmerAPI) Nodes() []NodeStatus {
+	return api.nodes.status()
+}
+
+// CheckNodes checks the health and the sync state of all nodes of the client and returns the result.
+func (api *GoShimmerAPI) CheckNodes() []NodeStatus {
+	return api.CheckNodesContext(context.Background())
+}
+
+// CheckNodesContext is CheckNodes with a context that controls the lifetime of the checks.
+func (api *GoShimmerAPI) CheckNodesContext(ctx context.Context) []NodeStatus {
+	for _, node := range api.nodes.status() {
+		synced, err := api.checkNode(ctx, node.BaseURL)
+		// a canceled check says nothing about the health of the node
+		if ctx.Err() != nil {
+			break
+		}
+		api.nodes.update(node.BaseURL, synced, err)
+	}
+
+	return api.nodes.status()
 }
 
 // BaseURL returns the baseURL of the API.
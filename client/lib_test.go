@@ -0,0 +1,72 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	kinds := []error{
+		ErrBadRequest,
+		ErrUnauthorized,
+		ErrNotFound,
+		ErrRateLimited,
+		ErrInternalServerError,
+		ErrNotImplemented,
+		ErrUnknownError,
+		ErrNotSynced,
+	}
+
+	testCases := []struct {
+		name       string
+		statusCode int
+		message    string
+		expected   []error
+	}{
+		{name: "bad request", statusCode: http.StatusBadRequest, expected: []error{ErrBadRequest}},
+		{name: "unauthorized", statusCode: http.StatusUnauthorized, expected: []error{ErrUnauthorized}},
+		{name: "not found", statusCode: http.StatusNotFound, expected: []error{ErrNotFound}},
+		{name: "rate limited", statusCode: http.StatusTooManyRequests, expected: []error{ErrRateLimited}},
+		{name: "internal server error", statusCode: http.StatusInternalServerError, expected: []error{ErrInternalServerError}},
+		{name: "not implemented", statusCode: http.StatusNotImplemented, expected: []error{ErrNotImplemented}},
+		{name: "other status", statusCode: http.StatusServiceUnavailable, expected: []error{ErrUnknownError}},
+		{
+			name:       "tangle not synced",
+			statusCode: http.StatusBadRequest,
+			message:    errors.Errorf("can't issue payload: %w", tangle.ErrNotSynced).Error(),
+			expected:   []error{ErrBadRequest, ErrNotSynced},
+		},
+		{
+			name:       "mana query not allowed",
+			statusCode: http.StatusInternalServerError,
+			message:    mana.ErrQueryNotAllowed.Error(),
+			expected:   []error{ErrInternalServerError, ErrNotSynced},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := error(newAPIError(testCase.statusCode, testCase.message))
+			for _, kind := range kinds {
+				assert.Equal(t, containsError(testCase.expected, kind), errors.Is(err, kind), "errors.Is(err, %v)", kind)
+			}
+			assert.True(t, errors.Is(errors.Errorf("request failed: %w", err), testCase.expected[0]))
+		})
+	}
+}
+
+func containsError(errs []error, target error) bool {
+	for _, err := range errs {
+		if err == target {
+			return true
+		}
+	}
+
+	return false
+}
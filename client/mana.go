@@ -1,10 +1,12 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/mana"
 )
 
 const (
@@ -18,13 +20,26 @@ const (
 	routePending                  = "mana/pending"
 	routePastConsensusVector      = "mana/consensus/past"
 	routePastConsensusEventLogs   = "mana/consensus/logs"
+	routePastConsensusMetadata    = "mana/consensus/metadata"
+	routePastMana                 = "mana/past"
 	routeAllowedPledgeNodeIDs     = "mana/allowedManaPledge"
+	routeDelegations              = "mana/delegations"
+	routeDelegationReceivers      = "mana/delegations/receivers"
+	routeManaFlow                 = "mana/flow"
+	routeManaFlowPledgers         = "mana/flow/pledgers"
+	routeManaFlowReceivers        = "mana/flow/receivers"
+	routeManaFlowTransaction      = "mana/flow/transaction"
 )
 
 // GetOwnMana returns the access and consensus mana of the node this api client is communicating with.
 func (api *GoShimmerAPI) GetOwnMana() (*jsonmodels.GetManaResponse, error) {
+	return api.GetOwnManaContext(context.Background())
+}
+
+// GetOwnManaContext is GetOwnMana with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetOwnManaContext(ctx context.Context) (*jsonmodels.GetManaResponse, error) {
 	res := &jsonmodels.GetManaResponse{}
-	if err := api.do(http.MethodGet, routeGetMana,
+	if err := api.do(ctx, http.MethodGet, routeGetMana,
 		&jsonmodels.GetManaRequest{NodeID: ""}, res); err != nil {
 		return nil, err
 	}
@@ -34,8 +49,13 @@ func (api *GoShimmerAPI) GetOwnMana() (*jsonmodels.GetManaResponse, error) {
 // GetManaFullNodeID returns the access and consensus mana of the node specified in the argument.
 // Note, that for the node to understand which nodeID we are referring to, short node ID is not sufficient.
 func (api *GoShimmerAPI) GetManaFullNodeID(fullNodeID string) (*jsonmodels.GetManaResponse, error) {
+	return api.GetManaFullNodeIDContext(context.Background(), fullNodeID)
+}
+
+// GetManaFullNodeIDContext is GetManaFullNodeID with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetManaFullNodeIDContext(ctx context.Context, fullNodeID string) (*jsonmodels.GetManaResponse, error) {
 	res := &jsonmodels.GetManaResponse{}
-	if err := api.do(http.MethodGet, routeGetMana,
+	if err := api.do(ctx, http.MethodGet, routeGetMana,
 		&jsonmodels.GetManaRequest{NodeID: fullNodeID}, res); err != nil {
 		return nil, err
 	}
@@ -44,9 +64,14 @@ func (api *GoShimmerAPI) GetManaFullNodeID(fullNodeID string) (*jsonmodels.GetMa
 
 // GetMana returns the access and consensus mana a node has based on its shortNodeID.
 func (api *GoShimmerAPI) GetMana(shortNodeID string) (*jsonmodels.GetManaResponse, error) {
+	return api.GetManaContext(context.Background(), shortNodeID)
+}
+
+// GetManaContext is GetMana with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetManaContext(ctx context.Context, shortNodeID string) (*jsonmodels.GetManaResponse, error) {
 	// ask the node about the full mana map and filter out based on shortID
 	allManaRes := &jsonmodels.GetAllManaResponse{}
-	if err := api.do(http.MethodGet, routeGetAllMana,
+	if err := api.do(ctx, http.MethodGet, routeGetAllMana,
 		nil, allManaRes); err != nil {
 		return nil, err
 	}
@@ -69,8 +94,13 @@ func (api *GoShimmerAPI) GetMana(shortNodeID string) (*jsonmodels.GetManaRespons
 
 // GetAllMana returns the mana perception of the node in the network.
 func (api *GoShimmerAPI) GetAllMana() (*jsonmodels.GetAllManaResponse, error) {
+	return api.GetAllManaContext(context.Background())
+}
+
+// GetAllManaContext is GetAllMana with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetAllManaContext(ctx context.Context) (*jsonmodels.GetAllManaResponse, error) {
 	res := &jsonmodels.GetAllManaResponse{}
-	if err := api.do(http.MethodGet, routeGetAllMana,
+	if err := api.do(ctx, http.MethodGet, routeGetAllMana,
 		nil, res); err != nil {
 		return nil, err
 	}
@@ -79,8 +109,13 @@ func (api *GoShimmerAPI) GetAllMana() (*jsonmodels.GetAllManaResponse, error) {
 
 // GetManaPercentile returns the mana percentile for access and consensus mana of a node.
 func (api *GoShimmerAPI) GetManaPercentile(fullNodeID string) (*jsonmodels.GetPercentileResponse, error) {
+	return api.GetManaPercentileContext(context.Background(), fullNodeID)
+}
+
+// GetManaPercentileContext is GetManaPercentile with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetManaPercentileContext(ctx context.Context, fullNodeID string) (*jsonmodels.GetPercentileResponse, error) {
 	res := &jsonmodels.GetPercentileResponse{}
-	if err := api.do(http.MethodGet, routeGetManaPercentile,
+	if err := api.do(ctx, http.MethodGet, routeGetManaPercentile,
 		&jsonmodels.GetPercentileRequest{NodeID: fullNodeID}, res); err != nil {
 		return nil, err
 	}
@@ -89,8 +124,13 @@ func (api *GoShimmerAPI) GetManaPercentile(fullNodeID string) (*jsonmodels.GetPe
 
 // GetOnlineAccessMana returns the sorted list of online access mana of nodes.
 func (api *GoShimmerAPI) GetOnlineAccessMana() (*jsonmodels.GetOnlineResponse, error) {
+	return api.GetOnlineAccessManaContext(context.Background())
+}
+
+// GetOnlineAccessManaContext is GetOnlineAccessMana with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetOnlineAccessManaContext(ctx context.Context) (*jsonmodels.GetOnlineResponse, error) {
 	res := &jsonmodels.GetOnlineResponse{}
-	if err := api.do(http.MethodGet, routeGetOnlineAccessMana,
+	if err := api.do(ctx, http.MethodGet, routeGetOnlineAccessMana,
 		nil, res); err != nil {
 		return nil, err
 	}
@@ -99,8 +139,13 @@ func (api *GoShimmerAPI) GetOnlineAccessMana() (*jsonmodels.GetOnlineResponse, e
 
 // GetOnlineConsensusMana returns the sorted list of online consensus mana of nodes.
 func (api *GoShimmerAPI) GetOnlineConsensusMana() (*jsonmodels.GetOnlineResponse, error) {
+	return api.GetOnlineConsensusManaContext(context.Background())
+}
+
+// GetOnlineConsensusManaContext is GetOnlineConsensusMana with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetOnlineConsensusManaContext(ctx context.Context) (*jsonmodels.GetOnlineResponse, error) {
 	res := &jsonmodels.GetOnlineResponse{}
-	if err := api.do(http.MethodGet, routeGetOnlineConsensusMana,
+	if err := api.do(ctx, http.MethodGet, routeGetOnlineConsensusMana,
 		nil, res); err != nil {
 		return nil, err
 	}
@@ -109,8 +154,13 @@ func (api *GoShimmerAPI) GetOnlineConsensusMana() (*jsonmodels.GetOnlineResponse
 
 // GetNHighestAccessMana returns the N highest access mana holders in the network, sorted in descending order.
 func (api *GoShimmerAPI) GetNHighestAccessMana(n int) (*jsonmodels.GetNHighestResponse, error) {
+	return api.GetNHighestAccessManaContext(context.Background(), n)
+}
+
+// GetNHighestAccessManaContext is GetNHighestAccessMana with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetNHighestAccessManaContext(ctx context.Context, n int) (*jsonmodels.GetNHighestResponse, error) {
 	res := &jsonmodels.GetNHighestResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return fmt.Sprintf("%s?number=%d", routeGetNHighestAccessMana, n)
 	}(), nil, res); err != nil {
 		return nil, err
@@ -120,8 +170,13 @@ func (api *GoShimmerAPI) GetNHighestAccessMana(n int) (*jsonmodels.GetNHighestRe
 
 // GetNHighestConsensusMana returns the N highest consensus mana holders in the network, sorted in descending order.
 func (api *GoShimmerAPI) GetNHighestConsensusMana(n int) (*jsonmodels.GetNHighestResponse, error) {
+	return api.GetNHighestConsensusManaContext(context.Background(), n)
+}
+
+// GetNHighestConsensusManaContext is GetNHighestConsensusMana with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetNHighestConsensusManaContext(ctx context.Context, n int) (*jsonmodels.GetNHighestResponse, error) {
 	res := &jsonmodels.GetNHighestResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		return fmt.Sprintf("%s?number=%d", routeGetNHighestConsensusMana, n)
 	}(), nil, res); err != nil {
 		return nil, err
@@ -131,8 +186,13 @@ func (api *GoShimmerAPI) GetNHighestConsensusMana(n int) (*jsonmodels.GetNHighes
 
 // GetPending returns the mana (bm2) that will be pledged by spending the output specified.
 func (api *GoShimmerAPI) GetPending(outputID string) (*jsonmodels.PendingResponse, error) {
+	return api.GetPendingContext(context.Background(), outputID)
+}
+
+// GetPendingContext is GetPending with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetPendingContext(ctx context.Context, outputID string) (*jsonmodels.PendingResponse, error) {
 	res := &jsonmodels.PendingResponse{}
-	if err := api.do(http.MethodGet, routePending,
+	if err := api.do(ctx, http.MethodGet, routePending,
 		&jsonmodels.PendingRequest{OutputID: outputID}, res); err != nil {
 		return nil, err
 	}
@@ -141,8 +201,14 @@ func (api *GoShimmerAPI) GetPending(outputID string) (*jsonmodels.PendingRespons
 
 // GetPastConsensusManaVector returns the consensus base mana vector of a time in the past.
 func (api *GoShimmerAPI) GetPastConsensusManaVector(t int64) (*jsonmodels.PastConsensusManaVectorResponse, error) {
+	return api.GetPastConsensusManaVectorContext(context.Background(), t)
+}
+
+// GetPastConsensusManaVectorContext is GetPastConsensusManaVector with a context that controls the lifetime of the
+// request.
+func (api *GoShimmerAPI) GetPastConsensusManaVectorContext(ctx context.Context, t int64) (*jsonmodels.PastConsensusManaVectorResponse, error) {
 	res := &jsonmodels.PastConsensusManaVectorResponse{}
-	if err := api.do(http.MethodGet, routePastConsensusVector,
+	if err := api.do(ctx, http.MethodGet, routePastConsensusVector,
 		&jsonmodels.PastConsensusManaVectorRequest{Timestamp: t}, res); err != nil {
 		return nil, err
 	}
@@ -151,8 +217,47 @@ func (api *GoShimmerAPI) GetPastConsensusManaVector(t int64) (*jsonmodels.PastCo
 
 // GetPastConsensusVectorMetadata returns the consensus base mana vector metadata of a time in the past.
 func (api *GoShimmerAPI) GetPastConsensusVectorMetadata() (*jsonmodels.PastConsensusVectorMetadataResponse, error) {
+	return api.GetPastConsensusVectorMetadataContext(context.Background())
+}
+
+// GetPastConsensusVectorMetadataContext is GetPastConsensusVectorMetadata with a context that controls the lifetime of
+// the request.
+func (api *GoShimmerAPI) GetPastConsensusVectorMetadataContext(ctx context.Context) (*jsonmodels.PastConsensusVectorMetadataResponse, error) {
 	res := &jsonmodels.PastConsensusVectorMetadataResponse{}
-	if err := api.do(http.MethodGet, routePastConsensusVector, nil, res); err != nil {
+	if err := api.do(ctx, http.MethodGet, routePastConsensusMetadata, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetPastMana returns the access and consensus mana the node specified by its full node ID had at the given unix
+// timestamp. If fullNodeID is empty, the mana of the node this api client is communicating with is returned.
+func (api *GoShimmerAPI) GetPastMana(fullNodeID string, t int64) (*jsonmodels.GetPastManaResponse, error) {
+	return api.GetPastManaContext(context.Background(), fullNodeID, t)
+}
+
+// GetPastManaContext is GetPastMana with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetPastManaContext(ctx context.Context, fullNodeID string, t int64) (*jsonmodels.GetPastManaResponse, error) {
+	res := &jsonmodels.GetPastManaResponse{}
+	if err := api.do(ctx, http.MethodGet, routePastMana,
+		&jsonmodels.GetPastManaRequest{NodeID: fullNodeID, Timestamp: t}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetConsensusEventLogsInRange returns the consensus event logs of the nodeIDs specified between the given unix
+// timestamps. If endTime is 0, the logs up to now are returned.
+func (api *GoShimmerAPI) GetConsensusEventLogsInRange(nodeIDs []string, startTime, endTime int64) (*jsonmodels.GetEventLogsResponse, error) {
+	return api.GetConsensusEventLogsInRangeContext(context.Background(), nodeIDs, startTime, endTime)
+}
+
+// GetConsensusEventLogsInRangeContext is GetConsensusEventLogsInRange with a context that controls the lifetime of the
+// request.
+func (api *GoShimmerAPI) GetConsensusEventLogsInRangeContext(ctx context.Context, nodeIDs []string, startTime, endTime int64) (*jsonmodels.GetEventLogsResponse, error) {
+	res := &jsonmodels.GetEventLogsResponse{}
+	if err := api.do(ctx, http.MethodGet, routePastConsensusEventLogs,
+		&jsonmodels.GetEventLogsRequest{NodeIDs: nodeIDs, StartTime: startTime, EndTime: endTime}, res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -160,8 +265,13 @@ func (api *GoShimmerAPI) GetPastConsensusVectorMetadata() (*jsonmodels.PastConse
 
 // GetConsensusEventLogs returns the consensus event logs or the nodeIDs specified.
 func (api *GoShimmerAPI) GetConsensusEventLogs(nodeIDs []string) (*jsonmodels.GetEventLogsResponse, error) {
+	return api.GetConsensusEventLogsContext(context.Background(), nodeIDs)
+}
+
+// GetConsensusEventLogsContext is GetConsensusEventLogs with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetConsensusEventLogsContext(ctx context.Context, nodeIDs []string) (*jsonmodels.GetEventLogsResponse, error) {
 	res := &jsonmodels.GetEventLogsResponse{}
-	if err := api.do(http.MethodGet, routePastConsensusEventLogs,
+	if err := api.do(ctx, http.MethodGet, routePastConsensusEventLogs,
 		&jsonmodels.GetEventLogsRequest{NodeIDs: nodeIDs}, res); err != nil {
 		return nil, err
 	}
@@ -170,10 +280,111 @@ func (api *GoShimmerAPI) GetConsensusEventLogs(nodeIDs []string) (*jsonmodels.Ge
 
 // GetAllowedManaPledgeNodeIDs returns the list of allowed mana pledge IDs.
 func (api *GoShimmerAPI) GetAllowedManaPledgeNodeIDs() (*jsonmodels.AllowedManaPledgeResponse, error) {
+	return api.GetAllowedManaPledgeNodeIDsContext(context.Background())
+}
+
+// GetAllowedManaPledgeNodeIDsContext is GetAllowedManaPledgeNodeIDs with a context that controls the lifetime of the
+// request.
+func (api *GoShimmerAPI) GetAllowedManaPledgeNodeIDsContext(ctx context.Context) (*jsonmodels.AllowedManaPledgeResponse, error) {
 	res := &jsonmodels.AllowedManaPledgeResponse{}
-	if err := api.do(http.MethodGet, routeAllowedPledgeNodeIDs, nil, res); err != nil {
+	if err := api.do(ctx, http.MethodGet, routeAllowedPledgeNodeIDs, nil, res); err != nil {
 		return nil, err
 	}
 
 	return res, nil
 }
+
+// GetDelegations returns the delegations to the given delegation address. If receiver is empty, the delegations to the
+// node this api client is communicating with are returned.
+func (api *GoShimmerAPI) GetDelegations(receiver string) (*jsonmodels.GetDelegationsResponse, error) {
+	return api.GetDelegationsContext(context.Background(), receiver)
+}
+
+// GetDelegationsContext is GetDelegations with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetDelegationsContext(ctx context.Context, receiver string) (*jsonmodels.GetDelegationsResponse, error) {
+	res := &jsonmodels.GetDelegationsResponse{}
+	if err := api.do(ctx, http.MethodGet, routeDelegations,
+		&jsonmodels.GetDelegationsRequest{Receiver: receiver}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetDelegationReceivers returns all delegation addresses the node knows delegations to, together with the amount of
+// funds delegated to them.
+func (api *GoShimmerAPI) GetDelegationReceivers() (*jsonmodels.GetDelegationReceiversResponse, error) {
+	return api.GetDelegationReceiversContext(context.Background())
+}
+
+// GetDelegationReceiversContext is GetDelegationReceivers with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetDelegationReceiversContext(ctx context.Context) (*jsonmodels.GetDelegationReceiversResponse, error) {
+	res := &jsonmodels.GetDelegationReceiversResponse{}
+	if err := api.do(ctx, http.MethodGet, routeDelegationReceivers, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetManaFlow returns the largest flows of mana of the given type from pledger addresses to nodes in the given time
+// range. A zero start and end select the last 24 hours, a zero limit returns all flows.
+func (api *GoShimmerAPI) GetManaFlow(manaType mana.Type, start, end int64, limit int) (*jsonmodels.GetManaFlowResponse, error) {
+	return api.GetManaFlowContext(context.Background(), manaType, start, end, limit)
+}
+
+// GetManaFlowContext is GetManaFlow with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetManaFlowContext(ctx context.Context, manaType mana.Type, start, end int64, limit int) (*jsonmodels.GetManaFlowResponse, error) {
+	res := &jsonmodels.GetManaFlowResponse{}
+	if err := api.do(ctx, http.MethodGet, routeManaFlow,
+		&jsonmodels.GetManaFlowRequest{ManaType: manaType.String(), Start: start, End: end, Limit: limit}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetTopPledgers returns the addresses that pledged the most mana of the given type to the node specified by its full
+// node ID in the given time range. If fullNodeID is empty, the pledgers to the node this api client is communicating
+// with are returned.
+func (api *GoShimmerAPI) GetTopPledgers(fullNodeID string, manaType mana.Type, start, end int64, limit int) (*jsonmodels.GetManaFlowResponse, error) {
+	return api.GetTopPledgersContext(context.Background(), fullNodeID, manaType, start, end, limit)
+}
+
+// GetTopPledgersContext is GetTopPledgers with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetTopPledgersContext(ctx context.Context, fullNodeID string, manaType mana.Type, start, end int64, limit int) (*jsonmodels.GetManaFlowResponse, error) {
+	res := &jsonmodels.GetManaFlowResponse{}
+	if err := api.do(ctx, http.MethodGet, routeManaFlowPledgers,
+		&jsonmodels.GetManaFlowRequest{NodeID: fullNodeID, ManaType: manaType.String(), Start: start, End: end, Limit: limit}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetTopReceivers returns the nodes the given address pledged the most mana of the given type to in the given time
+// range.
+func (api *GoShimmerAPI) GetTopReceivers(pledger string, manaType mana.Type, start, end int64, limit int) (*jsonmodels.GetManaFlowResponse, error) {
+	return api.GetTopReceiversContext(context.Background(), pledger, manaType, start, end, limit)
+}
+
+// GetTopReceiversContext is GetTopReceivers with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetTopReceiversContext(ctx context.Context, pledger string, manaType mana.Type, start, end int64, limit int) (*jsonmodels.GetManaFlowResponse, error) {
+	res := &jsonmodels.GetManaFlowResponse{}
+	if err := api.do(ctx, http.MethodGet, routeManaFlowReceivers,
+		&jsonmodels.GetManaFlowRequest{Pledger: pledger, ManaType: manaType.String(), Start: start, End: end, Limit: limit}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetManaFlowOfTransaction returns the mana pledged and revoked by the given transaction.
+func (api *GoShimmerAPI) GetManaFlowOfTransaction(transactionID string) (*jsonmodels.GetManaFlowTransactionResponse, error) {
+	return api.GetManaFlowOfTransactionContext(context.Background(), transactionID)
+}
+
+// GetManaFlowOfTransactionContext is GetManaFlowOfTransaction with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetManaFlowOfTransactionContext(ctx context.Context, transactionID string) (*jsonmodels.GetManaFlowTransactionResponse, error) {
+	res := &jsonmodels.GetManaFlowTransactionResponse{}
+	if err := api.do(ctx, http.MethodGet, routeManaFlowTransaction,
+		&jsonmodels.GetManaFlowTransactionRequest{TransactionID: transactionID}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/cockroachdb/errors"
@@ -16,19 +17,42 @@ const (
 
 // AddManualPeers adds the provided list of peers to the manual peering layer.
 func (api *GoShimmerAPI) AddManualPeers(peers []*manualpeering.KnownPeerToAdd) error {
-	if err := api.do(http.MethodPost, routeManualPeers, peers, nil); err != nil {
+	return api.AddManualPeersContext(context.Background(), peers)
+}
+
+// AddManualPeersContext is AddManualPeers with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) AddManualPeersContext(ctx context.Context, peers []*manualpeering.KnownPeerToAdd) error {
+	if err := api.do(ctx, http.MethodPost, routeManualPeers, peers, nil); err != nil {
 		return errors.Wrap(err, "failed to add manual peers via the HTTP API")
 	}
 	return nil
 }
 
+// UpdateManualPeers changes the labels or the enabled state of the provided list of peers in the manual peering layer.
+func (api *GoShimmerAPI) UpdateManualPeers(peers []*manualpeering.KnownPeerToUpdate) error {
+	return api.UpdateManualPeersContext(context.Background(), peers)
+}
+
+// UpdateManualPeersContext is UpdateManualPeers with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) UpdateManualPeersContext(ctx context.Context, peers []*manualpeering.KnownPeerToUpdate) error {
+	if err := api.do(ctx, http.MethodPut, routeManualPeers, peers, nil); err != nil {
+		return errors.Wrap(err, "failed to update manual peers via the HTTP API")
+	}
+	return nil
+}
+
 // RemoveManualPeers remove the provided list of peers from the manual peering layer.
 func (api *GoShimmerAPI) RemoveManualPeers(keys []ed25519.PublicKey) error {
+	return api.RemoveManualPeersContext(context.Background(), keys)
+}
+
+// RemoveManualPeersContext is RemoveManualPeers with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) RemoveManualPeersContext(ctx context.Context, keys []ed25519.PublicKey) error {
 	peersToRemove := make([]*jsonmodels.PeerToRemove, len(keys))
 	for i, key := range keys {
 		peersToRemove[i] = &jsonmodels.PeerToRemove{PublicKey: key}
 	}
-	if err := api.do(http.MethodDelete, routeManualPeers, peersToRemove, nil); err != nil {
+	if err := api.do(ctx, http.MethodDelete, routeManualPeers, peersToRemove, nil); err != nil {
 		return errors.Wrap(err, "failed to remove manual peers via the HTTP API")
 	}
 	return nil
@@ -36,9 +60,15 @@ func (api *GoShimmerAPI) RemoveManualPeers(keys []ed25519.PublicKey) error {
 
 // GetManualPeers gets the list of connected neighbors from the manual peering layer.
 func (api *GoShimmerAPI) GetManualPeers(opts ...manualpeering.GetPeersOption) (
+	peers []*manualpeering.KnownPeer, err error) {
+	return api.GetManualPeersContext(context.Background(), opts...)
+}
+
+// GetManualPeersContext is GetManualPeers with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetManualPeersContext(ctx context.Context, opts ...manualpeering.GetPeersOption) (
 	peers []*manualpeering.KnownPeer, err error) {
 	conf := manualpeering.BuildGetPeersConfig(opts)
-	if err := api.do(http.MethodGet, routeManualPeers, conf, &peers); err != nil {
+	if err := api.do(ctx, http.MethodGet, routeManualPeers, conf, &peers); err != nil {
 		return nil, errors.Wrap(err, "failed to get manual connected peers from the API")
 	}
 	return peers, nil
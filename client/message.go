@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
@@ -14,9 +15,15 @@ const (
 
 // GetMessage is the handler for the /messages/:messageID endpoint.
 func (api *GoShimmerAPI) GetMessage(base58EncodedID string) (*jsonmodels.Message, error) {
+	return api.GetMessageContext(context.Background(), base58EncodedID)
+}
+
+// GetMessageContext is GetMessage with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetMessageContext(ctx context.Context, base58EncodedID string) (*jsonmodels.Message, error) {
 	res := &jsonmodels.Message{}
 
 	if err := api.do(
+		ctx,
 		http.MethodGet,
 		routeMessage+base58EncodedID,
 		nil,
@@ -30,9 +37,15 @@ func (api *GoShimmerAPI) GetMessage(base58EncodedID string) (*jsonmodels.Message
 
 // GetMessageMetadata is the handler for the /messages/:messageID/metadata endpoint.
 func (api *GoShimmerAPI) GetMessageMetadata(base58EncodedID string) (*jsonmodels.MessageMetadata, error) {
+	return api.GetMessageMetadataContext(context.Background(), base58EncodedID)
+}
+
+// GetMessageMetadataContext is GetMessageMetadata with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) GetMessageMetadataContext(ctx context.Context, base58EncodedID string) (*jsonmodels.MessageMetadata, error) {
 	res := &jsonmodels.MessageMetadata{}
 
 	if err := api.do(
+		ctx,
 		http.MethodGet,
 		routeMessage+base58EncodedID+routeMessageMetadata,
 		nil,
@@ -46,8 +59,13 @@ func (api *GoShimmerAPI) GetMessageMetadata(base58EncodedID string) (*jsonmodels
 
 // SendPayload send a message with the given payload.
 func (api *GoShimmerAPI) SendPayload(payload []byte) (string, error) {
+	return api.SendPayloadContext(context.Background(), payload)
+}
+
+// SendPayloadContext is SendPayload with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) SendPayloadContext(ctx context.Context, payload []byte) (string, error) {
 	res := &jsonmodels.PostPayloadResponse{}
-	if err := api.do(http.MethodPost, routeSendPayload,
+	if err := api.do(ctx, http.MethodPost, routeSendPayload,
 		&jsonmodels.PostPayloadRequest{Payload: payload}, res); err != nil {
 		return "", err
 	}
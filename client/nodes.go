@@ -0,0 +1,262 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ErrNoSyncedNode is returned when a transaction can not be submitted because none of the nodes is synced.
+var ErrNoSyncedNode = errors.New("no synced node")
+
+// DefaultHealthCheckInterval is the interval in which the health and the sync state of the nodes are checked.
+const DefaultHealthCheckInterval = 10 * time.Second
+
+// region RetryPolicy //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RetryPolicy defines how often and with which backoff idempotent requests are retried. Every retry is sent to the next
+// healthy node, so a client with several nodes fails over to another node.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a request, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the time to wait before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum time to wait between two attempts.
+	MaxBackoff time.Duration
+	// Multiplier is the factor that the backoff grows with after every retry.
+	Multiplier float64
+}
+
+// DefaultRetryPolicy tries a request up to three times with a backoff of 100ms and 200ms.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+}
+
+// NoRetry sends every request only once.
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// backoff returns the time to wait before the given retry, starting at 1.
+func (r RetryPolicy) backoff(retry int) time.Duration {
+	backoff := float64(r.InitialBackoff)
+	for i := 1; i < retry; i++ {
+		backoff *= r.Multiplier
+	}
+	if r.MaxBackoff > 0 && backoff > float64(r.MaxBackoff) {
+		return r.MaxBackoff
+	}
+
+	return time.Duration(backoff)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region NodeStatus ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NodeStatus is the health of a node of the client as of its last check.
+type NodeStatus struct {
+	// BaseURL is the base URL of the API of the node.
+	BaseURL string
+	// Healthy is false if the last check or the last request failed.
+	Healthy bool
+	// Synced is the sync state that the node reported in its last check.
+	Synced bool
+	// LastCheck is the time of the last check or failed request, it is zero if the node was never checked.
+	LastCheck time.Time
+	// LastError is the error of the last failed check or request.
+	LastError error
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region requestKind //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// requestKind defines to which nodes a request is sent and whether it is retried.
+type requestKind int
+
+const (
+	// readRequest is an idempotent request that is load balanced over the healthy nodes and retried.
+	readRequest requestKind = iota
+	// submitRequest is a transaction submission that is only sent to synced nodes. Submitting the same transaction
+	// twice is harmless, so it is retried as well.
+	submitRequest
+	// writeRequest is a request that is not idempotent, it is sent once to the first healthy node.
+	writeRequest
+)
+
+// idempotentPostRoutes are the routes that use POST to send their arguments, but only read data.
+var idempotentPostRoutes = map[string]bool{
+	routeGetAddresses + "unspentOutputs": true,
+}
+
+// requestKindOf returns the kind of a request to the given route.
+func requestKindOf(method, route string) requestKind {
+	switch {
+	case method == http.MethodGet || method == http.MethodHead:
+		return readRequest
+	case method == http.MethodPost && route == routePostTransactions:
+		return submitRequest
+	case method == http.MethodPost && idempotentPostRoutes[route]:
+		return readRequest
+	default:
+		return writeRequest
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region nodePool /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// nodePool keeps track of the health of the nodes of a client and selects the node of every request.
+type nodePool struct {
+	nodes               []*NodeStatus
+	healthCheckInterval time.Duration
+	// checking contains the base URLs of the nodes that are being checked in the background.
+	checking map[string]bool
+	// next is the number of read requests, which determines the node that the next one starts at.
+	next  int
+	mutex sync.Mutex
+}
+
+func newNodePool(baseURLs ...string) *nodePool {
+	pool := &nodePool{
+		healthCheckInterval: DefaultHealthCheckInterval,
+		checking:            make(map[string]bool),
+	}
+	pool.add(baseURLs...)
+
+	return pool
+}
+
+// add adds the nodes with the given base URLs, nodes that are already known are ignored.
+func (n *nodePool) add(baseURLs ...string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for _, baseURL := range baseURLs {
+		baseURL = strings.TrimSuffix(baseURL, "/")
+		known := false
+		for _, node := range n.nodes {
+			known = known || node.BaseURL == baseURL
+		}
+		if !known {
+			n.nodes = append(n.nodes, &NodeStatus{BaseURL: baseURL, Healthy: true, Synced: true})
+		}
+	}
+}
+
+// size returns the number of nodes.
+func (n *nodePool) size() int {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return len(n.nodes)
+}
+
+// candidates returns the nodes that a request of the given kind may be sent to, in the order in which they are tried.
+// The selection only uses the cached state of the nodes: the nodes whose state is older than the health check interval
+// are checked with the given function in the background, so that a node that is down never delays a request. If no
+// node is healthy, all nodes are returned, as they may have recovered since their last check, but transactions are
+// never sent to nodes that are not synced.
+func (n *nodePool) candidates(kind requestKind, check func(ctx context.Context, baseURL string) (synced bool, err error)) (candidates []string, err error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	// a single node is used without checking it, so that the client behaves like a plain client of that node
+	if len(n.nodes) == 1 {
+		return []string{n.nodes[0].BaseURL}, nil
+	}
+
+	for _, node := range n.nodes {
+		if time.Since(node.LastCheck) > n.healthCheckInterval && !n.checking[node.BaseURL] {
+			n.checking[node.BaseURL] = true
+			go n.checkInBackground(node.BaseURL, check)
+		}
+
+		if node.Healthy && (kind != submitRequest || node.Synced) {
+			candidates = append(candidates, node.BaseURL)
+		}
+	}
+
+	if len(candidates) == 0 {
+		if kind == submitRequest {
+			return nil, errors.Errorf("failed to select a node for the transaction: %w", ErrNoSyncedNode)
+		}
+		for _, node := range n.nodes {
+			candidates = append(candidates, node.BaseURL)
+		}
+	}
+
+	// read requests are distributed round robin over the candidates
+	if kind == readRequest {
+		start := n.next % len(candidates)
+		n.next++
+		candidates = append(candidates[start:], candidates[:start]...)
+	}
+
+	return candidates, nil
+}
+
+// checkInBackground checks the node with the given base URL and stores the result. The check is not bound to the
+// context of the request that triggered it, it is only bounded by the timeout of the check function.
+func (n *nodePool) checkInBackground(baseURL string, check func(ctx context.Context, baseURL string) (synced bool, err error)) {
+	synced, err := check(context.Background(), baseURL)
+	n.update(baseURL, synced, err)
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	delete(n.checking, baseURL)
+}
+
+// update stores the result of a check of the node with the given base URL.
+func (n *nodePool) update(baseURL string, synced bool, err error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for _, node := range n.nodes {
+		if node.BaseURL != baseURL {
+			continue
+		}
+		node.LastCheck = time.Now()
+		node.LastError = err
+		node.Healthy = err == nil
+		if err == nil {
+			node.Synced = synced
+		}
+	}
+}
+
+// markFailed marks the node with the given base URL as unhealthy until its next check.
+func (n *nodePool) markFailed(baseURL string, err error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for _, node := range n.nodes {
+		if node.BaseURL == baseURL {
+			node.Healthy = false
+			node.LastCheck = time.Now()
+			node.LastError = err
+		}
+	}
+}
+
+// status returns a copy of the state of all nodes.
+func (n *nodePool) status() (status []NodeStatus) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	status = make([]NodeStatus, len(n.nodes))
+	for i, node := range n.nodes {
+		status[i] = *node
+	}
+
+	return status
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+)
+
+const testRoute = "test"
+
+// testNode is a node that reports the given sync state and answers the requests to testRoute and to the transaction
+// route with the given status codes, one per request, the last one is repeated.
+type testNode struct {
+	*httptest.Server
+	requests     int32
+	transactions int32
+}
+
+func newTestNode(t *testing.T, synced bool, statusCodes ...int) *testNode {
+	node := &testNode{}
+	node.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(contentType, contentTypeJSON)
+		switch r.URL.Path {
+		case "/" + routeInfo:
+			_ = json.NewEncoder(w).Encode(&jsonmodels.InfoResponse{TangleTime: jsonmodels.TangleTime{Synced: synced}})
+		case "/" + routePostTransactions:
+			atomic.AddInt32(&node.transactions, 1)
+			_ = json.NewEncoder(w).Encode(&jsonmodels.PostTransactionResponse{TransactionID: "tx"})
+		case "/" + testRoute:
+			request := int(atomic.AddInt32(&node.requests, 1))
+			statusCode := statusCodes[len(statusCodes)-1]
+			if request <= len(statusCodes) {
+				statusCode = statusCodes[request-1]
+			}
+			w.WriteHeader(statusCode)
+			_ = json.NewEncoder(w).Encode(&errorresponse{Error: http.StatusText(statusCode)})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(node.Close)
+
+	return node
+}
+
+func (n *testNode) requestCount() int {
+	return int(atomic.LoadInt32(&n.requests))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	assert.Equal(t, 100*time.Millisecond, DefaultRetryPolicy.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, DefaultRetryPolicy.backoff(2))
+	assert.Equal(t, 2*time.Second, DefaultRetryPolicy.backoff(10))
+}
+
+func TestGoShimmerAPI_Failover(t *testing.T) {
+	failing := newTestNode(t, true, http.StatusInternalServerError)
+	healthy := newTestNode(t, true, http.StatusOK)
+	api := NewGoShimmerAPI(failing.URL, WithNodes(healthy.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 2}))
+	api.CheckNodes()
+
+	// the first read request starts at the failing node and fails over to the healthy one
+	require.NoError(t, api.do(context.Background(), http.MethodGet, testRoute, nil, &errorresponse{}))
+	assert.Equal(t, 1, failing.requestCount())
+	assert.Equal(t, 1, healthy.requestCount())
+
+	// the failed node is no candidate until its next check
+	require.NoError(t, api.do(context.Background(), http.MethodGet, testRoute, nil, &errorresponse{}))
+	assert.Equal(t, 1, failing.requestCount())
+	assert.Equal(t, 2, healthy.requestCount())
+	assert.False(t, api.Nodes()[0].Healthy)
+	assert.True(t, errors.Is(api.Nodes()[0].LastError, ErrInternalServerError))
+}
+
+func TestGoShimmerAPI_Retry(t *testing.T) {
+	node := newTestNode(t, true, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK)
+	api := NewGoShimmerAPI(node.URL, WithRetryPolicy(RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 20 * time.Millisecond,
+		Multiplier:     2,
+	}))
+
+	start := time.Now()
+	require.NoError(t, api.do(context.Background(), http.MethodGet, testRoute, nil, &errorresponse{}))
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(60*time.Millisecond))
+	assert.Equal(t, 3, node.requestCount())
+
+	// the error of the last attempt is returned
+	failing := newTestNode(t, true, http.StatusInternalServerError)
+	api = NewGoShimmerAPI(failing.URL, WithRetryPolicy(RetryPolicy{MaxAttempts: 2}))
+	err := api.do(context.Background(), http.MethodGet, testRoute, nil, &errorresponse{})
+	assert.True(t, errors.Is(err, ErrInternalServerError))
+	assert.Equal(t, 2, failing.requestCount())
+
+	// requests that are not idempotent are only sent once
+	failing = newTestNode(t, true, http.StatusInternalServerError)
+	api = NewGoShimmerAPI(failing.URL, WithRetryPolicy(RetryPolicy{MaxAttempts: 3}))
+	assert.Error(t, api.do(context.Background(), http.MethodPost, testRoute, nil, &errorresponse{}))
+	assert.Equal(t, 1, failing.requestCount())
+
+	// the backoff is aborted if the context is done
+	failing = newTestNode(t, true, http.StatusInternalServerError)
+	api = NewGoShimmerAPI(failing.URL, WithRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Hour}))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = api.do(ctx, http.MethodGet, testRoute, nil, &errorresponse{})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, 1, failing.requestCount())
+}
+
+func TestGoShimmerAPI_DefaultRetryPolicy(t *testing.T) {
+	// a client with a single node sends every request once
+	failing := newTestNode(t, true, http.StatusInternalServerError)
+	api := NewGoShimmerAPI(failing.URL)
+	assert.Equal(t, NoRetry, api.retryPolicy)
+	assert.Error(t, api.do(context.Background(), http.MethodGet, testRoute, nil, &errorresponse{}))
+	assert.Equal(t, 1, failing.requestCount())
+
+	// adding the same node again does not make it a client with several nodes
+	assert.Equal(t, NoRetry, NewGoShimmerAPI(failing.URL, WithNodes(failing.URL+"/")).retryPolicy)
+
+	// a client with several nodes fails over to the other nodes
+	assert.Equal(t, DefaultRetryPolicy, NewGoShimmerAPI(failing.URL, WithNodes(newTestNode(t, true, http.StatusOK).URL)).retryPolicy)
+
+	// an explicit retry policy is used for a single node as well
+	assert.Equal(t, DefaultRetryPolicy, NewGoShimmerAPI(failing.URL, WithRetryPolicy(DefaultRetryPolicy)).retryPolicy)
+}
+
+func TestGoShimmerAPI_PostTransactionToSyncedNode(t *testing.T) {
+	unsynced := newTestNode(t, false, http.StatusOK)
+	synced := newTestNode(t, true, http.StatusOK)
+	api := NewGoShimmerAPI(unsynced.URL, WithNodes(synced.URL))
+	api.CheckNodes()
+
+	_, err := api.PostTransaction([]byte{1})
+	require.NoError(t, err)
+	assert.Zero(t, atomic.LoadInt32(&unsynced.transactions))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&synced.transactions))
+
+	// read requests are still sent to the node that is not synced
+	require.NoError(t, api.do(context.Background(), http.MethodGet, testRoute, nil, &errorresponse{}))
+	require.NoError(t, api.do(context.Background(), http.MethodGet, testRoute, nil, &errorresponse{}))
+	assert.Equal(t, 1, unsynced.requestCount())
+	assert.Equal(t, 1, synced.requestCount())
+
+	api = NewGoShimmerAPI(unsynced.URL, WithNodes(newTestNode(t, false, http.StatusOK).URL))
+	api.CheckNodes()
+	_, err = api.PostTransaction([]byte{1})
+	assert.True(t, errors.Is(err, ErrNoSyncedNode))
+}
+
+func TestNodePool_CandidatesDoNotWaitForChecks(t *testing.T) {
+	pool := newNodePool("http://a", "http://b")
+	release := make(chan struct{})
+	var checks int32
+	check := func(ctx context.Context, baseURL string) (bool, error) {
+		atomic.AddInt32(&checks, 1)
+		<-release
+		if baseURL == "http://b" {
+			return false, errors.New("node is down")
+		}
+		return true, nil
+	}
+
+	// the nodes are selected by their cached state while their checks are running
+	candidates, err := pool.candidates(writeRequest, check)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://a", "http://b"}, candidates)
+	_, err = pool.candidates(writeRequest, check)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&checks) == 2 }, time.Second, time.Millisecond)
+
+	close(release)
+	require.Eventually(t, func() bool { return !pool.status()[1].Healthy }, time.Second, time.Millisecond)
+	candidates, err = pool.candidates(writeRequest, check)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://a"}, candidates)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&checks))
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -13,8 +14,13 @@ const (
 
 // ToggleSpammer toggles the node internal spammer.
 func (api *GoShimmerAPI) ToggleSpammer(enable bool, mpm int) (*jsonmodels.SpammerResponse, error) {
+	return api.ToggleSpammerContext(context.Background(), enable, mpm)
+}
+
+// ToggleSpammerContext is ToggleSpammer with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) ToggleSpammerContext(ctx context.Context, enable bool, mpm int) (*jsonmodels.SpammerResponse, error) {
 	res := &jsonmodels.SpammerResponse{}
-	if err := api.do(http.MethodGet, func() string {
+	if err := api.do(ctx, http.MethodGet, func() string {
 		if enable {
 			return fmt.Sprintf("%s?cmd=start&mpm=%d", routeSpammer, mpm)
 		}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/cockroachdb/errors"
@@ -18,9 +19,15 @@ const (
 // PastConeExist checks that all of the messages in the past cone of a message are existing on the node
 // down to the genesis. Returns the number of messages in the past cone as well.
 func (api *GoShimmerAPI) PastConeExist(base58EncodedMessageID string) (*jsonmodels.PastconeResponse, error) {
+	return api.PastConeExistContext(context.Background(), base58EncodedMessageID)
+}
+
+// PastConeExistContext is PastConeExist with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) PastConeExistContext(ctx context.Context, base58EncodedMessageID string) (*jsonmodels.PastconeResponse, error) {
 	res := &jsonmodels.PastconeResponse{}
 
 	if err := api.do(
+		ctx,
 		http.MethodGet,
 		routePastCone,
 		&jsonmodels.PastconeRequest{ID: base58EncodedMessageID},
@@ -37,8 +44,13 @@ func (api *GoShimmerAPI) PastConeExist(base58EncodedMessageID string) (*jsonmode
 
 // Missing returns all the missing messages and their count.
 func (api *GoShimmerAPI) Missing() (*jsonmodels.MissingResponse, error) {
+	return api.MissingContext(context.Background())
+}
+
+// MissingContext is Missing with a context that controls the lifetime of the request.
+func (api *GoShimmerAPI) MissingContext(ctx context.Context) (*jsonmodels.MissingResponse, error) {
 	res := &jsonmodels.MissingResponse{}
-	if err := api.do(http.MethodGet, routeMissing, nil, res); err != nil {
+	if err := api.do(ctx, http.MethodGet, routeMissing, nil, res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -3,6 +3,7 @@ package wallet
 import (
 	"runtime"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/bitmask"
 
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
@@ -15,6 +16,8 @@ type AddressManager struct {
 	seed             *seed.Seed
 	lastAddressIndex uint64
 	spentAddresses   []bitmask.BitMask
+	// watchedAddresses are the addresses of a watch-only wallet, which has no seed to derive them from.
+	watchedAddresses []address.Address
 
 	// internal variables for faster access
 	firstUnspentAddressIndex uint64
@@ -36,19 +39,71 @@ func NewAddressManager(seed *seed.Seed, lastAddressIndex uint64, spentAddresses
 	return
 }
 
-// Address returns the address that belongs to the given index.
+// NewWatchOnlyAddressManager creates an AddressManager for a watch-only wallet that manages the given addresses
+// without knowing the seed they were derived from.
+func NewWatchOnlyAddressManager(addresses []address.Address, lastAddressIndex uint64, spentAddresses []bitmask.BitMask) (addressManager *AddressManager) {
+	defer runtime.KeepAlive(spentAddresses)
+
+	addressManager = &AddressManager{
+		lastAddressIndex: lastAddressIndex,
+		spentAddresses:   spentAddresses,
+		watchedAddresses: addresses,
+	}
+	// a watch-only wallet can not generate new addresses, so it never uses more than the exported ones
+	if len(addresses) != 0 && lastAddressIndex >= uint64(len(addresses)) {
+		addressManager.lastAddressIndex = uint64(len(addresses)) - 1
+	}
+	addressManager.updateFirstUnspentAddressIndex()
+	addressManager.updateLastUnspentAddressIndex()
+
+	return
+}
+
+// Address returns the address that belongs to the given index. A watch-only wallet returns address.AddressEmpty for
+// the indexes beyond its exported addresses, use TryAddress to learn why.
 func (addressManager *AddressManager) Address(addressIndex uint64) address.Address {
+	addr, _ := addressManager.TryAddress(addressIndex)
+
+	return addr
+}
+
+// TryAddress returns the address that belongs to the given index. A watch-only wallet returns an error that wraps
+// ErrWatchOnly for the indexes beyond its exported addresses, as it can not derive them without the seed.
+func (addressManager *AddressManager) TryAddress(addressIndex uint64) (address.Address, error) {
+	if !addressManager.knowsAddress(addressIndex) {
+		return address.AddressEmpty, errors.Errorf("address %d is not known to the watch-only wallet: %w", addressIndex, ErrWatchOnly)
+	}
+
+	return addressManager.address(addressIndex), nil
+}
+
+// address returns the address that belongs to the given index, which needs to be known to the AddressManager.
+func (addressManager *AddressManager) address(addressIndex uint64) address.Address {
 	// update lastUnspentAddressIndex if necessary
 	addressManager.spentAddressIndexes(addressIndex)
 
+	if addressManager.WatchOnly() {
+		return addressManager.watchedAddresses[addressIndex]
+	}
+
 	return addressManager.seed.Address(addressIndex)
 }
 
+// knowsAddress returns true if the AddressManager can return the address that belongs to the given index.
+func (addressManager *AddressManager) knowsAddress(addressIndex uint64) bool {
+	return !addressManager.WatchOnly() || addressIndex < uint64(len(addressManager.watchedAddresses))
+}
+
+// WatchOnly returns true if the AddressManager manages the addresses of a watch-only wallet.
+func (addressManager *AddressManager) WatchOnly() bool {
+	return addressManager.seed == nil
+}
+
 // Addresses returns a list of all addresses of the wallet.
 func (addressManager *AddressManager) Addresses() (addresses []address.Address) {
 	addresses = make([]address.Address, addressManager.lastAddressIndex+1)
 	for i := uint64(0); i <= addressManager.lastAddressIndex; i++ {
-		addresses[i] = addressManager.Address(i)
+		addresses[i] = addressManager.address(i)
 	}
 
 	return
@@ -59,7 +114,7 @@ func (addressManager *AddressManager) UnspentAddresses() (addresses []address.Ad
 	addresses = make([]address.Address, 0)
 	for i := addressManager.firstUnspentAddressIndex; i <= addressManager.lastAddressIndex; i++ {
 		if !addressManager.IsAddressSpent(i) {
-			addresses = append(addresses, addressManager.Address(i))
+			addresses = append(addresses, addressManager.address(i))
 		}
 	}
 
@@ -71,26 +126,57 @@ func (addressManager *AddressManager) SpentAddresses() (addresses []address.Addr
 	addresses = make([]address.Address, 0)
 	for i := uint64(0); i <= addressManager.lastAddressIndex; i++ {
 		if addressManager.IsAddressSpent(i) {
-			addresses = append(addresses, addressManager.Address(i))
+			addresses = append(addresses, addressManager.address(i))
 		}
 	}
 
 	return
 }
 
-// FirstUnspentAddress returns the first unspent address that we know.
+// FirstUnspentAddress returns the first unspent address that we know. A watch-only wallet that has spent all of its
+// addresses returns address.AddressEmpty.
 func (addressManager *AddressManager) FirstUnspentAddress() address.Address {
-	return addressManager.Address(addressManager.firstUnspentAddressIndex)
+	addr, _ := addressManager.TryFirstUnspentAddress()
+
+	return addr
+}
+
+// TryFirstUnspentAddress returns the first unspent address that we know. It returns an error that wraps ErrWatchOnly if
+// a watch-only wallet has spent all of its addresses.
+func (addressManager *AddressManager) TryFirstUnspentAddress() (address.Address, error) {
+	return addressManager.TryAddress(addressManager.firstUnspentAddressIndex)
 }
 
-// LastUnspentAddress returns the last unspent address that we know.
+// LastUnspentAddress returns the last unspent address that we know. A watch-only wallet that has spent all of its
+// addresses returns address.AddressEmpty.
 func (addressManager *AddressManager) LastUnspentAddress() address.Address {
-	return addressManager.Address(addressManager.lastUnspentAddressIndex)
+	addr, _ := addressManager.TryLastUnspentAddress()
+
+	return addr
+}
+
+// TryLastUnspentAddress returns the last unspent address that we know. It returns an error that wraps ErrWatchOnly if
+// a watch-only wallet has spent all of its addresses.
+func (addressManager *AddressManager) TryLastUnspentAddress() (address.Address, error) {
+	if addressManager.WatchOnly() && addressManager.IsAddressSpent(addressManager.lastUnspentAddressIndex) {
+		return address.AddressEmpty, errors.Errorf("all addresses of the watch-only wallet are spent: %w", ErrWatchOnly)
+	}
+
+	return addressManager.TryAddress(addressManager.lastUnspentAddressIndex)
 }
 
-// NewAddress generates and returns a new unused address.
+// NewAddress generates and returns a new unused address. A watch-only wallet returns address.AddressEmpty once it runs
+// out of exported addresses.
 func (addressManager *AddressManager) NewAddress() address.Address {
-	return addressManager.Address(addressManager.lastAddressIndex + 1)
+	addr, _ := addressManager.TryNewAddress()
+
+	return addr
+}
+
+// TryNewAddress generates and returns a new unused address. It returns an error that wraps ErrWatchOnly once a
+// watch-only wallet runs out of exported addresses.
+func (addressManager *AddressManager) TryNewAddress() (address.Address, error) {
+	return addressManager.TryAddress(addressManager.lastAddressIndex + 1)
 }
 
 // MarkAddressSpent marks the given address as spent.
@@ -131,6 +217,11 @@ func (addressManager *AddressManager) spentAddressIndexes(addressIndex uint64) (
 		addressManager.spentAddresses = append(addressManager.spentAddresses, make([]bitmask.BitMask, sliceIndex-spentAddressesCapacity+1)...)
 	}
 
+	// addresses that a watch-only wallet does not know are never generated
+	if !addressManager.knowsAddress(addressIndex) {
+		return
+	}
+
 	// update lastAddressIndex if the index is bigger
 	if addressIndex > addressManager.lastAddressIndex {
 		addressManager.lastAddressIndex = addressIndex
@@ -147,7 +238,8 @@ func (addressManager *AddressManager) spentAddressIndexes(addressIndex uint64) (
 // updateFirstUnspentAddressIndex searches for the first unspent address and updates the firstUnspentAddressIndex.
 func (addressManager *AddressManager) updateFirstUnspentAddressIndex() {
 	for i := addressManager.firstUnspentAddressIndex; true; i++ {
-		if !addressManager.IsAddressSpent(i) {
+		// a watch-only wallet points behind its addresses once all of them are spent
+		if !addressManager.IsAddressSpent(i) || !addressManager.knowsAddress(i) {
 			addressManager.firstUnspentAddressIndex = i
 
 			return
@@ -170,6 +262,8 @@ func (addressManager *AddressManager) updateLastUnspentAddressIndex() {
 		}
 	}
 
-	// or generate a new unspent address
-	addressManager.NewAddress()
+	// or generate a new unspent address, which a watch-only wallet can not do
+	if !addressManager.WatchOnly() {
+		addressManager.address(addressManager.lastAddressIndex + 1)
+	}
 }
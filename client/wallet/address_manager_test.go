@@ -0,0 +1,73 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+)
+
+func TestAddressManager_WatchOnlyRunsOutOfAddresses(t *testing.T) {
+	walletSeed := seed.NewSeed()
+	addressManager := NewWatchOnlyAddressManager([]address.Address{walletSeed.Address(0), walletSeed.Address(1)}, 0, nil)
+
+	newAddress, err := addressManager.TryNewAddress()
+	require.NoError(t, err)
+	assert.Equal(t, walletSeed.Address(1), newAddress)
+
+	// the watch-only wallet can not derive the addresses beyond the exported ones
+	_, err = addressManager.TryNewAddress()
+	assert.True(t, errors.Is(err, ErrWatchOnly))
+	_, err = addressManager.TryAddress(2)
+	assert.True(t, errors.Is(err, ErrWatchOnly))
+	assert.Len(t, addressManager.Addresses(), 2)
+
+	addressManager.MarkAddressSpent(0)
+	remainderAddress, err := addressManager.TryFirstUnspentAddress()
+	require.NoError(t, err)
+	assert.Equal(t, walletSeed.Address(1), remainderAddress)
+
+	addressManager.MarkAddressSpent(1)
+	_, err = addressManager.TryFirstUnspentAddress()
+	assert.True(t, errors.Is(err, ErrWatchOnly))
+	_, err = addressManager.TryLastUnspentAddress()
+	assert.True(t, errors.Is(err, ErrWatchOnly))
+	assert.Empty(t, addressManager.UnspentAddresses())
+
+	// the variants without an error return the empty address instead
+	assert.Equal(t, address.AddressEmpty, addressManager.NewAddress())
+	assert.Equal(t, address.AddressEmpty, addressManager.Address(2))
+	assert.Equal(t, address.AddressEmpty, addressManager.FirstUnspentAddress())
+	assert.Equal(t, address.AddressEmpty, addressManager.LastUnspentAddress())
+}
+
+func TestAddressManager_LastAddressIndexBeyondExportedAddresses(t *testing.T) {
+	walletSeed := seed.NewSeed()
+	addressManager := NewWatchOnlyAddressManager([]address.Address{walletSeed.Address(0)}, 5, nil)
+
+	assert.Equal(t, []address.Address{walletSeed.Address(0)}, addressManager.Addresses())
+	receiveAddress, err := addressManager.TryLastUnspentAddress()
+	require.NoError(t, err)
+	assert.Equal(t, walletSeed.Address(0), receiveAddress)
+}
+
+func TestAddressManager_SeedGeneratesNewAddresses(t *testing.T) {
+	walletSeed := seed.NewSeed()
+	addressManager := NewAddressManager(walletSeed, 0, nil)
+
+	addressManager.MarkAddressSpent(0)
+	receiveAddress, err := addressManager.TryLastUnspentAddress()
+	require.NoError(t, err)
+	assert.Equal(t, walletSeed.Address(1), receiveAddress)
+
+	assert.Equal(t, walletSeed.Address(1), addressManager.LastUnspentAddress())
+
+	newAddress, err := addressManager.TryNewAddress()
+	require.NoError(t, err)
+	assert.Equal(t, walletSeed.Address(2), newAddress)
+	assert.Equal(t, walletSeed.Address(3), addressManager.NewAddress())
+}
@@ -1,9 +1,9 @@
 package wallet
 
 import (
-	"github.com/capossele/asset-registry/pkg/registry"
 	"github.com/cockroachdb/errors"
 
+	"github.com/iotaledger/goshimmer/client/wallet/packages/assetregistry"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
 
@@ -28,33 +28,35 @@ type Asset struct {
 	TransactionID ledgerstate.TransactionID
 }
 
-// ToRegistry creates a ergistry asset from a wallet asset.
-func (a *Asset) ToRegistry() *registry.Asset {
-	return &registry.Asset{
+// ToRegistryEntry creates an unsigned registry entry from a wallet asset.
+func (a *Asset) ToRegistryEntry() *assetregistry.Entry {
+	return &assetregistry.Entry{
 		ID:            a.Color.Base58(),
 		Name:          a.Name,
 		Symbol:        a.Symbol,
+		Precision:     a.Precision,
 		Supply:        a.Supply,
 		TransactionID: a.TransactionID.Base58(),
 	}
 }
 
-// AssetFromRegistryEntry creates a wallet asset from a registry asset.
-func AssetFromRegistryEntry(regAsset *registry.Asset) (*Asset, error) {
-	color, err := ledgerstate.ColorFromBase58EncodedString(regAsset.ID)
+// AssetFromRegistryEntry creates a wallet asset from a registry entry.
+func AssetFromRegistryEntry(entry *assetregistry.Entry) (*Asset, error) {
+	color, err := ledgerstate.ColorFromBase58EncodedString(entry.ID)
 	if err != nil {
-		return nil, errors.Errorf("failed to parse color(ID) of asset from registry response: %w, err")
+		return nil, errors.Errorf("failed to parse color(ID) of asset from registry response: %w", err)
 	}
 	var txID ledgerstate.TransactionID
-	txID, err = ledgerstate.TransactionIDFromBase58(regAsset.TransactionID)
+	txID, err = ledgerstate.TransactionIDFromBase58(entry.TransactionID)
 	if err != nil {
-		return nil, errors.Errorf("failed to parse TransactionID of asset from registry response: %w, err")
+		return nil, errors.Errorf("failed to parse TransactionID of asset from registry response: %w", err)
 	}
 	return &Asset{
 		Color:         color,
-		Name:          regAsset.Name,
-		Symbol:        regAsset.Symbol,
-		Supply:        regAsset.Supply,
+		Name:          entry.Name,
+		Symbol:        entry.Symbol,
+		Precision:     entry.Precision,
+		Supply:        entry.Supply,
 		TransactionID: txID,
 	}, nil
 }
@@ -4,13 +4,12 @@ import (
 	"context"
 	"strconv"
 
-	"github.com/capossele/asset-registry/pkg/registryclient"
-	"github.com/capossele/asset-registry/pkg/registryservice"
 	"github.com/cockroachdb/errors"
-	"github.com/go-resty/resty/v2"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/hive.go/typeutils"
 
+	"github.com/iotaledger/goshimmer/client/wallet/packages/assetregistry"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
 
@@ -22,9 +21,12 @@ const (
 // AssetRegistry represents a registry for colored coins, that stores the relevant metadata in a dictionary.
 type AssetRegistry struct {
 	assets map[ledgerstate.Color]Asset
-	// client communicates with the central registry
-	client  *registryclient.HTTPClient
-	network string
+	// client communicates with the registry server
+	client      *assetregistry.Client
+	registryURL string
+	network     string
+	// connector fetches the minting transactions that the entries of the registry are verified against
+	connector Connector
 }
 
 // NewAssetRegistry is the constructor for the AssetRegistry.
@@ -33,11 +35,11 @@ func NewAssetRegistry(network string, registryURL ...string) *AssetRegistry {
 	if len(registryURL) > 0 {
 		hostURL = registryURL[0]
 	}
-	client := registryclient.NewHTTPClient(resty.New().SetHostURL(hostURL))
 	return &AssetRegistry{
-		make(map[ledgerstate.Color]Asset),
-		client,
-		network,
+		assets:      make(map[ledgerstate.Color]Asset),
+		client:      assetregistry.NewClient(hostURL),
+		registryURL: hostURL,
+		network:     network,
 	}
 }
 
@@ -56,7 +58,7 @@ func ParseAssetRegistry(marshalUtil *marshalutil.MarshalUtil) (assetRegistry *As
 		return
 	}
 	network := typeutils.BytesToString(networkBytes)
-	if !registryservice.Networks[network] {
+	if !assetregistry.Networks[network] {
 		err = errors.Errorf("unsupported asset registry network: %s", network)
 		return
 	}
@@ -147,7 +149,13 @@ func ParseAssetRegistry(marshalUtil *marshalutil.MarshalUtil) (assetRegistry *As
 
 // SetRegistryURL sets the url of the registry api server.
 func (a *AssetRegistry) SetRegistryURL(url string) {
-	a.client = registryclient.NewHTTPClient(resty.New().SetHostURL(url))
+	a.client = assetregistry.NewClient(url)
+	a.registryURL = url
+}
+
+// RegistryURL returns the url of the registry api server.
+func (a *AssetRegistry) RegistryURL() string {
+	return a.registryURL
 }
 
 // Network returns the current network the asset registry connects to.
@@ -155,23 +163,27 @@ func (a *AssetRegistry) Network() string {
 	return a.network
 }
 
-// LoadAsset returns an asset either from local or from central registry.
+// LoadAsset returns an asset either from local or from central registry. Assets of the central registry are only
+// returned if their entry is signed by the minter of the color.
 func (a *AssetRegistry) LoadAsset(id ledgerstate.Color) (*Asset, error) {
 	_, ok := a.assets[id]
 	if !ok {
-		success := a.updateLocalFromCentral(id)
-		if !success {
-			return nil, errors.Errorf("no asset found with assetID (color) %s", id.Base58())
+		if err := a.fetchFromCentral(id); err != nil {
+			return nil, errors.Errorf("no asset found with assetID (color) %s: %w", id.Base58(), err)
 		}
 	}
 	asset := a.assets[id]
 	return &asset, nil
 }
 
-// RegisterAsset registers an asset in the registry, so we can look up names and symbol of colored coins.
-func (a *AssetRegistry) RegisterAsset(color ledgerstate.Color, asset Asset) error {
+// RegisterAsset registers an asset in the registry, so we can look up names and symbol of colored coins. The entry is
+// signed with the given key pair, which needs to own one of the inputs of the minting transaction.
+func (a *AssetRegistry) RegisterAsset(color ledgerstate.Color, asset Asset, keyPair ed25519.KeyPair) error {
 	a.assets[color] = asset
-	return a.client.SaveAsset(context.TODO(), a.network, asset.ToRegistry())
+
+	entry := asset.ToRegistryEntry()
+	entry.Sign(a.network, keyPair)
+	return a.client.SaveEntry(context.TODO(), a.network, entry)
 }
 
 // Name returns the name of the given asset.
@@ -290,15 +302,41 @@ func (a *AssetRegistry) Bytes() []byte {
 }
 
 func (a *AssetRegistry) updateLocalFromCentral(color ledgerstate.Color) (success bool) {
-	loadedAsset, err := a.client.LoadAsset(context.TODO(), a.network, color.Base58())
-	if err == nil {
-		// save it locally
-		var walletAsset *Asset
-		walletAsset, err = AssetFromRegistryEntry(loadedAsset)
-		if err == nil {
-			a.assets[walletAsset.Color] = *walletAsset
-			return true
-		}
+	return a.fetchFromCentral(color) == nil
+}
+
+// fetchFromCentral loads the entry of the given color from the central registry and stores it locally if it is signed
+// by the minter of the color.
+func (a *AssetRegistry) fetchFromCentral(color ledgerstate.Color) (err error) {
+	entry, err := a.client.LoadEntry(context.TODO(), a.network, color.Base58())
+	if err != nil {
+		return err
+	}
+	walletAsset, err := AssetFromRegistryEntry(entry)
+	if err != nil {
+		return err
+	}
+	if walletAsset.Color != color {
+		return errors.Errorf("the registry returned the entry of color %s instead of %s", entry.ID, color.Base58())
 	}
-	return false
+
+	if a.connector == nil {
+		return errors.New("the entry can not be verified without a connection to a node")
+	}
+	historyConnector, ok := a.connector.(HistoryConnector)
+	if !ok {
+		return errors.Errorf("failed to verify the entry of color %s: %w", entry.ID, ErrNoHistoryConnector)
+	}
+	mintingTransaction, err := historyConnector.GetTransactionDetails(walletAsset.TransactionID)
+	if err != nil {
+		return errors.Errorf("failed to load minting transaction %s: %w", entry.TransactionID, err)
+	}
+	if err = entry.VerifyMinting(a.network, mintingTransaction.Inputs, mintingTransaction.Outputs); err != nil {
+		return err
+	}
+
+	// save it locally
+	a.assets[walletAsset.Color] = *walletAsset
+
+	return nil
 }
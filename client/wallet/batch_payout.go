@@ -0,0 +1,279 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/batchpayoutoptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/coinselection"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// region Payment //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Payment is a single payment of a batch payout.
+type Payment struct {
+	// Address is the address that receives the payment.
+	Address ledgerstate.Address
+	// Color is the color of the paid tokens.
+	Color ledgerstate.Color
+	// Amount is the amount of paid tokens.
+	Amount uint64
+	// Reference is an optional reference of the payment, e.g. an invoice number, that is included in the report.
+	Reference string
+}
+
+// String returns a human readable version of the Payment.
+func (p *Payment) String() string {
+	return stringify.Struct("Payment",
+		stringify.StructField("Address", p.Address.Base58()),
+		stringify.StructField("Color", p.Color),
+		stringify.StructField("Amount", p.Amount),
+		stringify.StructField("Reference", p.Reference),
+	)
+}
+
+// PlanPayout splits the given payments into batches that can each be paid by a single transaction. Payments to the
+// same address share an output and therefore the same batch, and each batch pays at most maxRecipients addresses.
+func PlanPayout(payments []*Payment, maxRecipients int) (batches [][]*Payment, err error) {
+	if maxRecipients < 1 || maxRecipients > ledgerstate.MaxOutputCount-1 {
+		return nil, errors.Errorf("the number of recipients per transaction must be between 1 and %d", ledgerstate.MaxOutputCount-1)
+	}
+
+	recipients := make([]string, 0)
+	paymentsByRecipient := make(map[string][]*Payment)
+	for i, payment := range payments {
+		if payment.Address == nil {
+			return nil, errors.Errorf("payment %d has no address", i)
+		}
+		if payment.Amount == 0 {
+			return nil, errors.Errorf("the amount of payment %d to %s needs to be larger than 0", i, payment.Address.Base58())
+		}
+
+		recipient := payment.Address.Base58()
+		if _, exists := paymentsByRecipient[recipient]; !exists {
+			recipients = append(recipients, recipient)
+		}
+		paymentsByRecipient[recipient] = append(paymentsByRecipient[recipient], payment)
+	}
+
+	for start := 0; start < len(recipients); start += maxRecipients {
+		end := start + maxRecipients
+		if end > len(recipients) {
+			end = len(recipients)
+		}
+
+		batch := make([]*Payment, 0)
+		for _, recipient := range recipients[start:end] {
+			batch = append(batch, paymentsByRecipient[recipient]...)
+		}
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region PayoutReport /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PayoutTransaction is a transaction of a batch payout and the payments that it contains.
+type PayoutTransaction struct {
+	// Payments are the payments of the transaction.
+	Payments []*Payment
+	// TransactionID is the ID of the transaction, if it was signed. If the submission of the transaction failed, the
+	// node might still have accepted it, so it needs to be checked before the payments are paid again.
+	TransactionID ledgerstate.TransactionID
+	// Submitted is true if the transaction was submitted to the node.
+	Submitted bool
+	// SubmittedTime is the time when the transaction was submitted.
+	SubmittedTime time.Time
+	// InclusionState is the inclusion state of the transaction when it was last checked.
+	InclusionState ledgerstate.InclusionState
+	// Err is the error that prevented the transaction from being submitted or confirmed.
+	Err error
+}
+
+// Totals returns the paid amounts of the transaction by color.
+func (p *PayoutTransaction) Totals() map[ledgerstate.Color]uint64 {
+	return paymentTotals(p.Payments)
+}
+
+// PayoutReport is the result of a batch payout.
+type PayoutReport struct {
+	// Transactions are the transactions of the payout in the order in which they were submitted.
+	Transactions []*PayoutTransaction
+}
+
+// Confirmed returns true if all transactions of the payout were confirmed.
+func (p *PayoutReport) Confirmed() bool {
+	for _, transaction := range p.Transactions {
+		if !transaction.Submitted || transaction.InclusionState != ledgerstate.Confirmed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Totals returns the amounts by color of the payments in submitted transactions and of the payments that were not
+// submitted.
+func (p *PayoutReport) Totals() (submitted, notSubmitted map[ledgerstate.Color]uint64) {
+	submittedPayments, notSubmittedPayments := make([]*Payment, 0), make([]*Payment, 0)
+	for _, transaction := range p.Transactions {
+		if transaction.Submitted {
+			submittedPayments = append(submittedPayments, transaction.Payments...)
+			continue
+		}
+		notSubmittedPayments = append(notSubmittedPayments, transaction.Payments...)
+	}
+
+	return paymentTotals(submittedPayments), paymentTotals(notSubmittedPayments)
+}
+
+func paymentTotals(payments []*Payment) (totals map[ledgerstate.Color]uint64) {
+	totals = make(map[ledgerstate.Color]uint64)
+	for _, payment := range payments {
+		totals[payment.Color] += payment.Amount
+	}
+
+	return totals
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region BatchPayout //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// BatchPayout pays the given payments with as many transactions as needed. A transaction that can not be funded while
+// previous transactions of the payout are pending is retried once they are confirmed, as it may depend on their
+// remainders. Any other error stops the payout, in particular a failed submission is never retried, as the node might
+// have accepted the transaction anyway. The report contains every transaction of the payout, including the ones that
+// were not submitted because of an error.
+func (wallet *Wallet) BatchPayout(payments []*Payment, options ...batchpayoutoptions.BatchPayoutOption) (report *PayoutReport, err error) {
+	if wallet.WatchOnly() {
+		return nil, errors.Errorf("failed to pay out: %w", ErrWatchOnly)
+	}
+	payoutOptions, err := batchpayoutoptions.Build(options...)
+	if err != nil {
+		return
+	}
+	batches, err := PlanPayout(payments, payoutOptions.MaxRecipientsPerTransaction)
+	if err != nil {
+		return
+	}
+
+	report = &PayoutReport{Transactions: make([]*PayoutTransaction, len(batches))}
+	for i, batch := range batches {
+		report.Transactions[i] = &PayoutTransaction{Payments: batch}
+	}
+
+	var pending []*PayoutTransaction
+	for i, transaction := range report.Transactions {
+		var tx *ledgerstate.Transaction
+		tx, err = wallet.payoutTransaction(transaction.Payments, payoutOptions)
+		if err != nil && len(pending) > 0 && isPayoutFundingError(err) {
+			// the transaction may need the remainders of the pending transactions
+			if err = wallet.trackPayoutTransactions(pending); err == nil {
+				pending = nil
+				tx, err = wallet.payoutTransaction(transaction.Payments, payoutOptions)
+			}
+		}
+		if err != nil {
+			transaction.Err = err
+			if tx != nil {
+				transaction.TransactionID = tx.ID()
+			}
+			return report, errors.Errorf("failed to submit payout transaction %d of %d: %w", i+1, len(report.Transactions), err)
+		}
+
+		transaction.TransactionID = tx.ID()
+		transaction.Submitted = true
+		transaction.SubmittedTime = time.Now()
+		pending = append(pending, transaction)
+	}
+
+	if payoutOptions.WaitForConfirmation {
+		if err = wallet.trackPayoutTransactions(pending); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// isPayoutFundingError returns true if the payout transaction could not be funded with the currently available outputs.
+func isPayoutFundingError(err error) bool {
+	return errors.Is(err, coinselection.ErrInsufficientFunds) || errors.Is(err, ErrTooManyOutputs)
+}
+
+// payoutTransaction prepares, signs and submits a transaction that pays the given payments. If the submission fails,
+// the signed transaction is returned together with the error.
+func (wallet *Wallet) payoutTransaction(payments []*Payment, payoutOptions *batchpayoutoptions.BatchPayoutOptions) (tx *ledgerstate.Transaction, err error) {
+	options := []sendoptions.SendFundsOption{
+		sendoptions.AccessManaPledgeID(payoutOptions.AccessManaPledgeID),
+		sendoptions.ConsensusManaPledgeID(payoutOptions.ConsensusManaPledgeID),
+		sendoptions.CoinSelection(payoutOptions.CoinSelection),
+	}
+	for _, payment := range payments {
+		options = append(options, sendoptions.Destination(address.Address{AddressBytes: payment.Address.Array()}, payment.Amount, payment.Color))
+	}
+	sendOptions, err := sendoptions.Build(options...)
+	if err != nil {
+		return
+	}
+
+	unsignedTx, err := wallet.prepareSendFunds(sendOptions)
+	if err != nil {
+		return
+	}
+	if tx, err = wallet.SignTransaction(unsignedTx); err != nil {
+		return nil, err
+	}
+	if err = wallet.SubmitTransaction(tx); err != nil {
+		return tx, err
+	}
+
+	return tx, nil
+}
+
+// trackPayoutTransactions polls the inclusion states of the given transactions until all of them are confirmed or
+// rejected, or until the confirmation timeout of the wallet is reached.
+func (wallet *Wallet) trackPayoutTransactions(transactions []*PayoutTransaction) (err error) {
+	for timeoutCounter := 0; ; timeoutCounter += wallet.ConfirmationPollInterval {
+		pending := 0
+		for _, transaction := range transactions {
+			if transaction.InclusionState != ledgerstate.Pending {
+				continue
+			}
+			if transaction.InclusionState, err = wallet.connector.GetTransactionInclusionState(transaction.TransactionID); err != nil {
+				return errors.Errorf("failed to retrieve the inclusion state of transaction %s: %w", transaction.TransactionID.Base58(), err)
+			}
+			switch transaction.InclusionState {
+			case ledgerstate.Pending:
+				pending++
+			case ledgerstate.Rejected:
+				transaction.Err = errors.Errorf("transaction %s has been rejected", transaction.TransactionID.Base58())
+			}
+		}
+
+		for _, transaction := range transactions {
+			if transaction.InclusionState == ledgerstate.Rejected {
+				return transaction.Err
+			}
+		}
+		if pending == 0 {
+			return nil
+		}
+		if timeoutCounter > wallet.ConfirmationTimeout {
+			return errors.Errorf("%d payout transactions did not confirm within %d seconds", pending, wallet.ConfirmationTimeout/milliSeconds)
+		}
+
+		time.Sleep(time.Duration(wallet.ConfirmationPollInterval) * time.Millisecond)
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,98 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/batchpayoutoptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/coinselection"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func TestPlanPayout_GroupsByAddress(t *testing.T) {
+	walletSeed := seed.NewSeed()
+	addr0, addr1, addr2 := walletSeed.Address(0).Address(), walletSeed.Address(1).Address(), walletSeed.Address(2).Address()
+	payments := []*Payment{
+		{Address: addr0, Color: ledgerstate.ColorIOTA, Amount: 1, Reference: "a"},
+		{Address: addr1, Color: ledgerstate.ColorIOTA, Amount: 2, Reference: "b"},
+		{Address: addr0, Color: ledgerstate.ColorMint, Amount: 3, Reference: "c"},
+		{Address: addr2, Color: ledgerstate.ColorIOTA, Amount: 4, Reference: "d"},
+	}
+
+	batches, err := PlanPayout(payments, 2)
+	require.NoError(t, err)
+
+	// the payments to addr0 share the first batch, although addr1 comes in between
+	require.Len(t, batches, 2)
+	assert.Equal(t, []*Payment{payments[0], payments[2], payments[1]}, batches[0])
+	assert.Equal(t, []*Payment{payments[3]}, batches[1])
+}
+
+func TestPlanPayout_MaxRecipients(t *testing.T) {
+	walletSeed := seed.NewSeed()
+	payments := make([]*Payment, 0)
+	for i := 0; i < ledgerstate.MaxOutputCount; i++ {
+		payments = append(payments, &Payment{Address: walletSeed.Address(uint64(i)).Address(), Color: ledgerstate.ColorIOTA, Amount: 1})
+	}
+
+	// one output of every transaction is reserved for the remainder
+	batches, err := PlanPayout(payments, ledgerstate.MaxOutputCount-1)
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+	assert.Len(t, batches[0], ledgerstate.MaxOutputCount-1)
+	assert.Len(t, batches[1], 1)
+
+	for _, maxRecipients := range []int{0, ledgerstate.MaxOutputCount} {
+		_, err = PlanPayout(payments, maxRecipients)
+		assert.Error(t, err, "maxRecipients %d", maxRecipients)
+	}
+}
+
+func TestPlanPayout_InvalidPayments(t *testing.T) {
+	addr := seed.NewSeed().Address(0).Address()
+
+	_, err := PlanPayout([]*Payment{
+		{Address: addr, Color: ledgerstate.ColorIOTA, Amount: 1},
+		{Address: addr, Color: ledgerstate.ColorIOTA, Amount: 0},
+	}, 10)
+	assert.Error(t, err)
+
+	_, err = PlanPayout([]*Payment{{Color: ledgerstate.ColorIOTA, Amount: 1}}, 10)
+	assert.Error(t, err)
+}
+
+func TestIsPayoutFundingError(t *testing.T) {
+	assert.True(t, isPayoutFundingError(errors.Errorf("failed to gather initial funds: %w", coinselection.ErrInsufficientFunds)))
+	assert.True(t, isPayoutFundingError(errors.Errorf("consolidate funds and try again: %w", ErrTooManyOutputs)))
+	// a failed submission might have been accepted by the node, so it must not be retried
+	assert.False(t, isPayoutFundingError(errors.New("failed to submit transaction: timeout")))
+}
+
+func TestWallet_BatchPayout_DoesNotRetryFailedSubmission(t *testing.T) {
+	walletSeed := seed.NewSeed()
+	connector := newMockConnector(
+		newMockOutput(walletSeed.Address(0), 0, 100),
+		newMockOutput(walletSeed.Address(0), 1, 100),
+	)
+	// the second transaction times out, although the node might have accepted it
+	connector.sendErrors = []error{nil, errors.New("request timed out")}
+	w := New(Import(walletSeed, 0, nil, NewAssetRegistry("test")), GenericConnector(connector))
+
+	payments := []*Payment{
+		{Address: seed.NewSeed().Address(0).Address(), Color: ledgerstate.ColorIOTA, Amount: 100},
+		{Address: seed.NewSeed().Address(0).Address(), Color: ledgerstate.ColorIOTA, Amount: 100},
+	}
+	report, err := w.BatchPayout(payments, batchpayoutoptions.MaxRecipientsPerTransaction(1))
+	require.Error(t, err)
+
+	assert.Len(t, connector.sentTransactions, 2)
+	require.Len(t, report.Transactions, 2)
+	assert.True(t, report.Transactions[0].Submitted)
+	assert.False(t, report.Transactions[1].Submitted)
+	assert.Equal(t, connector.sentTransactions[1].ID(), report.Transactions[1].TransactionID)
+	assert.Error(t, report.Transactions[1].Err)
+}
@@ -1,6 +1,8 @@
 package wallet
 
 import (
+	"github.com/iotaledger/hive.go/events"
+
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
@@ -16,3 +18,58 @@ type Connector interface {
 	GetTransactionInclusionState(txID ledgerstate.TransactionID) (inc ledgerstate.InclusionState, err error)
 	GetUnspentAliasOutput(address *ledgerstate.AliasAddress) (output *ledgerstate.AliasOutput, err error)
 }
+
+// HistoryConnector is implemented by connectors that can look up the spent outputs of an address and the transactions
+// that created or consumed them. The wallet needs it to record its transaction history and to verify the minting
+// transactions of assets, and uses its connector for this if the connector implements this interface.
+type HistoryConnector interface {
+	// GetAddressOutputs returns all outputs on the given addresses, including the ones that were spent already.
+	GetAddressOutputs(addresses ...address.Address) (outputs OutputsByAddressAndOutputID, err error)
+	// GetOutputConsumers returns the IDs of the transactions that consume the given output.
+	GetOutputConsumers(outputID ledgerstate.OutputID) (consumers []ledgerstate.TransactionID, err error)
+	// GetTransactionDetails returns the timestamp, the consumed outputs and the created outputs of the given transaction.
+	GetTransactionDetails(txID ledgerstate.TransactionID) (details *TransactionDetails, err error)
+}
+
+// Notifier is implemented by connectors that push updates about the addresses and the pending transactions of the
+// wallet, so that the wallet does not need to poll the node while it waits for confirmations. The wallet uses the
+// notifications of its connector if the connector implements this interface.
+type Notifier interface {
+	// SubscribeAddresses subscribes to the confirmed outputs on the given addresses.
+	SubscribeAddresses(addresses ...address.Address)
+	// WatchTransaction subscribes to the inclusion state changes of the given transaction.
+	WatchTransaction(txID ledgerstate.TransactionID)
+	// UnwatchTransaction stops watching the inclusion state of the given transaction.
+	UnwatchTransaction(txID ledgerstate.TransactionID)
+	// Events returns the events that are triggered by the notifications.
+	Events() *NotifierEvents
+	// Close stops the notifications.
+	Close()
+}
+
+// NotifierEvents contains the events that are triggered by a Notifier.
+type NotifierEvents struct {
+	// OutputReceived is triggered when a confirmed output arrives on one of the subscribed addresses.
+	OutputReceived *events.Event
+	// InclusionStateChanged is triggered when the inclusion state of a watched transaction changes.
+	InclusionStateChanged *events.Event
+	// Connected is triggered when the Notifier (re)connects, so notifications might have been missed before.
+	Connected *events.Event
+}
+
+// NewNotifierEvents creates the events of a Notifier.
+func NewNotifierEvents() *NotifierEvents {
+	return &NotifierEvents{
+		OutputReceived:        events.NewEvent(outputEventCaller),
+		InclusionStateChanged: events.NewEvent(inclusionStateEventCaller),
+		Connected:             events.NewEvent(events.VoidCaller),
+	}
+}
+
+func outputEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*Output))(params[0].(*Output))
+}
+
+func inclusionStateEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(ledgerstate.TransactionID, ledgerstate.InclusionState))(params[0].(ledgerstate.TransactionID), params[1].(ledgerstate.InclusionState))
+}
@@ -0,0 +1,150 @@
+package wallet
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/events"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/consolidateoptions"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// DustConsolidationPolicy defines when the wallet consolidates its dust outputs.
+type DustConsolidationPolicy struct {
+	// DustThreshold is the total balance below which a value output counts as dust.
+	DustThreshold uint64
+	// MaxDustOutputs is the number of dust outputs that the wallet keeps, the dust outputs are consolidated as soon as
+	// there are more of them.
+	MaxDustOutputs int
+}
+
+// DefaultDustConsolidationPolicy consolidates the outputs with less than 1000 tokens as soon as there are more than
+// 50 of them.
+var DefaultDustConsolidationPolicy = DustConsolidationPolicy{
+	DustThreshold:  1000,
+	MaxDustOutputs: 50,
+}
+
+// DustOutputs returns the spendable value outputs of the wallet whose total balance is below the dust threshold of the
+// given policy or, if none is given, the policy of the wallet.
+func (wallet *Wallet) DustOutputs(policy ...DustConsolidationPolicy) OutputsByAddressAndOutputID {
+	dustThreshold := DefaultDustConsolidationPolicy.DustThreshold
+	switch {
+	case len(policy) > 0:
+		dustThreshold = policy[0].DustThreshold
+	case wallet.dustConsolidationPolicy != nil:
+		dustThreshold = wallet.dustConsolidationPolicy.DustThreshold
+	}
+
+	_, spendableOutputs := wallet.spendableValueOutputs()
+	dustOutputs := make(OutputsByID)
+	for outputID, output := range spendableOutputs {
+		var total uint64
+		output.Object.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+			total += balance
+			return true
+		})
+		if total < dustThreshold {
+			dustOutputs[outputID] = output
+		}
+	}
+
+	return dustOutputs.OutputsByAddressAndOutputID()
+}
+
+// ConsolidateDust applies the dust consolidation policy of the wallet: if the wallet holds more dust outputs than the
+// policy allows, they are consolidated into as few outputs as possible. It returns no transactions if there is nothing
+// to consolidate. StartDustConsolidation calls it in the background.
+func (wallet *Wallet) ConsolidateDust(options ...consolidateoptions.ConsolidateFundsOption) (txs []*ledgerstate.Transaction, err error) {
+	if wallet.dustConsolidationPolicy == nil {
+		return nil, errors.New("the wallet has no dust consolidation policy")
+	}
+	if wallet.WatchOnly() {
+		return nil, errors.Errorf("failed to consolidate dust: %w", ErrWatchOnly)
+	}
+	consolidateOptions, err := consolidateoptions.Build(options...)
+	if err != nil {
+		return
+	}
+
+	if err = wallet.outputManager.Refresh(); err != nil {
+		return
+	}
+	dustOutputs := wallet.DustOutputs()
+	if dustOutputs.OutputCount() <= wallet.dustConsolidationPolicy.MaxDustOutputs || dustOutputs.OutputCount() < 2 {
+		return nil, nil
+	}
+
+	return wallet.consolidateOutputs(dustOutputs, consolidateOptions)
+}
+
+// StartDustConsolidation starts a background loop that applies the dust consolidation policy of the wallet every
+// interval and whenever the Notifier of the wallet reports a new output. The results of the consolidations that sent
+// transactions or failed are passed to onResult, if it is not nil. The returned function stops the loop and waits for
+// a running consolidation to finish.
+//
+// The wallet is not safe for concurrent use, so while the loop runs, all other calls of the wallet have to be made
+// between PauseDustConsolidation and the resume function that it returns.
+func (wallet *Wallet) StartDustConsolidation(interval time.Duration, onResult func(txs []*ledgerstate.Transaction, err error), options ...consolidateoptions.ConsolidateFundsOption) (stop func(), err error) {
+	if wallet.dustConsolidationPolicy == nil {
+		return nil, errors.New("the wallet has no dust consolidation policy")
+	}
+	if wallet.WatchOnly() {
+		return nil, errors.Errorf("failed to start the dust consolidation: %w", ErrWatchOnly)
+	}
+	if interval <= 0 {
+		return nil, errors.Errorf("invalid dust consolidation interval %s", interval)
+	}
+
+	outputReceived := make(chan struct{}, 1)
+	outputClosure := events.NewClosure(func(*Output) {
+		select {
+		case outputReceived <- struct{}{}:
+		default:
+		}
+	})
+	wallet.events.OutputReceived.Attach(outputClosure)
+
+	shutdown := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-outputReceived:
+			case <-shutdown:
+				return
+			}
+
+			resume := wallet.PauseDustConsolidation()
+			txs, consolidateErr := wallet.ConsolidateDust(options...)
+			resume()
+			if onResult != nil && (len(txs) > 0 || consolidateErr != nil) {
+				onResult(txs, consolidateErr)
+			}
+		}
+	}()
+
+	var stopOnce sync.Once
+	return func() {
+		stopOnce.Do(func() {
+			wallet.events.OutputReceived.Detach(outputClosure)
+			close(shutdown)
+			<-done
+		})
+	}, nil
+}
+
+// PauseDustConsolidation waits for a running background consolidation to finish and keeps the next one from starting
+// until the returned function is called.
+func (wallet *Wallet) PauseDustConsolidation() (resume func()) {
+	wallet.dustConsolidationMutex.Lock()
+
+	return wallet.dustConsolidationMutex.Unlock
+}
@@ -0,0 +1,83 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+type dustConsolidationResult struct {
+	txs []*ledgerstate.Transaction
+	err error
+}
+
+func TestWallet_StartDustConsolidation(t *testing.T) {
+	walletSeed := seed.NewSeed()
+	connector := newMockConnector(
+		newMockOutput(walletSeed.Address(0), 0, 10),
+		newMockOutput(walletSeed.Address(0), 1, 20),
+		newMockOutput(walletSeed.Address(0), 2, 30),
+		newMockOutput(walletSeed.Address(0), 3, 5000),
+	)
+	w := New(
+		Import(walletSeed, 0, nil, NewAssetRegistry("test")),
+		GenericConnector(connector),
+		DustConsolidation(DustConsolidationPolicy{DustThreshold: 1000, MaxDustOutputs: 2}),
+	)
+
+	results := make(chan dustConsolidationResult, 10)
+	stop, err := w.StartDustConsolidation(time.Hour, func(txs []*ledgerstate.Transaction, err error) {
+		results <- dustConsolidationResult{txs: txs, err: err}
+	})
+	require.NoError(t, err)
+	defer stop()
+
+	// a received output triggers the consolidation without waiting for the interval
+	w.Events().OutputReceived.Trigger(newMockOutput(walletSeed.Address(0), 4, 10))
+	select {
+	case result := <-results:
+		require.NoError(t, result.err)
+		require.Len(t, result.txs, 1)
+		assert.Len(t, result.txs[0].Essence().Inputs(), 3)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the dust was not consolidated")
+	}
+
+	// the consolidation does not run while it is paused, and there is no dust left to report
+	resume := w.PauseDustConsolidation()
+	w.Events().OutputReceived.Trigger(newMockOutput(walletSeed.Address(1), 5, 10))
+	assert.Len(t, connector.sentTransactions, 1)
+	resume()
+
+	stop()
+	stop()
+	assert.Empty(t, results)
+}
+
+func TestWallet_StartDustConsolidation_Errors(t *testing.T) {
+	walletSeed := seed.NewSeed()
+	connector := newMockConnector(newMockOutput(walletSeed.Address(0), 0, 10))
+
+	w := New(Import(walletSeed, 0, nil, NewAssetRegistry("test")), GenericConnector(connector))
+	_, err := w.StartDustConsolidation(time.Minute, nil)
+	assert.Error(t, err)
+
+	w = New(Import(walletSeed, 0, nil, NewAssetRegistry("test")), GenericConnector(connector), DustConsolidation(DefaultDustConsolidationPolicy))
+	_, err = w.StartDustConsolidation(0, nil)
+	assert.Error(t, err)
+
+	w = New(
+		ImportWatchOnly([]address.Address{walletSeed.Address(0)}, 0, nil, NewAssetRegistry("test")),
+		GenericConnector(connector),
+		DustConsolidation(DefaultDustConsolidationPolicy),
+	)
+	_, err = w.StartDustConsolidation(time.Minute, nil)
+	assert.True(t, errors.Is(err, ErrWatchOnly))
+}
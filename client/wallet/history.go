@@ -0,0 +1,583 @@
+package wallet
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// HistoryVersion is the version of the marshaled format of a History.
+const HistoryVersion byte = 1
+
+// region TransferDirection ////////////////////////////////////////////////////////////////////////////////////////////
+
+// TransferDirection describes how the funds of a transaction moved with respect to the wallet.
+type TransferDirection uint8
+
+const (
+	// Incoming is the direction of transactions that send funds to the wallet without consuming any of its outputs.
+	Incoming TransferDirection = iota
+	// Outgoing is the direction of transactions that consume outputs of the wallet and send funds to other addresses.
+	Outgoing
+	// Internal is the direction of transactions that only move funds between addresses of the wallet.
+	Internal
+)
+
+// TransferDirectionFromString parses a TransferDirection from its human readable version.
+func TransferDirectionFromString(direction string) (TransferDirection, error) {
+	switch strings.ToLower(direction) {
+	case "incoming", "in":
+		return Incoming, nil
+	case "outgoing", "out":
+		return Outgoing, nil
+	case "internal":
+		return Internal, nil
+	default:
+		return 0, errors.Errorf("unknown transfer direction %q", direction)
+	}
+}
+
+// String returns a human readable version of the TransferDirection.
+func (t TransferDirection) String() string {
+	switch t {
+	case Incoming:
+		return "incoming"
+	case Outgoing:
+		return "outgoing"
+	case Internal:
+		return "internal"
+	default:
+		return "TransferDirection(" + strconv.Itoa(int(t)) + ")"
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TransactionDetails ///////////////////////////////////////////////////////////////////////////////////////////
+
+// TransactionDetails contains the parts of a transaction that the history of the wallet is built from.
+type TransactionDetails struct {
+	// ID is the ID of the transaction.
+	ID ledgerstate.TransactionID
+	// Timestamp is the timestamp of the essence of the transaction.
+	Timestamp time.Time
+	// Inputs are the outputs consumed by the transaction, in the order of its inputs.
+	Inputs ledgerstate.Outputs
+	// Outputs are the outputs created by the transaction.
+	Outputs ledgerstate.Outputs
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region HistoryEntry /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// HistoryEntry is a transaction that moved funds of the wallet.
+type HistoryEntry struct {
+	// TransactionID is the ID of the transaction.
+	TransactionID ledgerstate.TransactionID
+	// Direction is the direction of the transfer.
+	Direction TransferDirection
+	// Amounts are the received amounts of incoming transactions, the amounts sent to other addresses by outgoing
+	// transactions and the moved amounts of internal transactions.
+	Amounts map[ledgerstate.Color]uint64
+	// Counterparties are the addresses that sent the funds of incoming transactions or received the funds of outgoing
+	// transactions.
+	Counterparties []ledgerstate.Address
+	// Addresses are the addresses of the wallet that the transaction consumed outputs from or created outputs on.
+	Addresses []address.Address
+	// InclusionState is the inclusion state of the transaction when the entry was last updated.
+	InclusionState ledgerstate.InclusionState
+	// Timestamp is the timestamp of the transaction.
+	Timestamp time.Time
+	// UpdatedTime is the time when the entry was last updated.
+	UpdatedTime time.Time
+}
+
+// newHistoryEntry creates the HistoryEntry of the given transaction from the outputs that belong to the wallet.
+func newHistoryEntry(transaction *TransactionDetails, ownOutputs map[ledgerstate.OutputID]address.Address) *HistoryEntry {
+	entry := &HistoryEntry{
+		TransactionID: transaction.ID,
+		Amounts:       make(map[ledgerstate.Color]uint64),
+		Timestamp:     transaction.Timestamp,
+	}
+
+	addresses := make(map[address.Address]bool)
+	counterpartyInputs := make(ledgerstate.Outputs, 0)
+	for _, input := range transaction.Inputs {
+		if addr, isOwn := ownOutputs[input.ID()]; isOwn {
+			addresses[addr] = true
+			continue
+		}
+		counterpartyInputs = append(counterpartyInputs, input)
+	}
+	ownInputs := len(addresses) > 0
+
+	received := make(map[ledgerstate.Color]uint64)
+	counterpartyOutputs := make(ledgerstate.Outputs, 0)
+	for _, output := range transaction.Outputs {
+		if addr, isOwn := ownOutputs[output.ID()]; isOwn {
+			addresses[addr] = true
+			addBalances(received, output)
+			continue
+		}
+		counterpartyOutputs = append(counterpartyOutputs, output)
+	}
+
+	switch {
+	case !ownInputs:
+		entry.Direction = Incoming
+		entry.Amounts = received
+		entry.Counterparties = uniqueAddresses(counterpartyInputs)
+	case len(counterpartyOutputs) == 0:
+		entry.Direction = Internal
+		entry.Amounts = received
+	default:
+		entry.Direction = Outgoing
+		for _, output := range counterpartyOutputs {
+			addBalances(entry.Amounts, output)
+		}
+		entry.Counterparties = uniqueAddresses(counterpartyOutputs)
+	}
+
+	entry.Addresses = make([]address.Address, 0, len(addresses))
+	for addr := range addresses {
+		entry.Addresses = append(entry.Addresses, addr)
+	}
+	sort.Slice(entry.Addresses, func(i, j int) bool {
+		return entry.Addresses[i].Index < entry.Addresses[j].Index
+	})
+
+	return entry
+}
+
+// HistoryEntryFromMarshalUtil unmarshals a HistoryEntry using a MarshalUtil (for easier unmarshaling).
+func HistoryEntryFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (entry *HistoryEntry, err error) {
+	entry = &HistoryEntry{}
+	if entry.TransactionID, err = ledgerstate.TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse transaction ID: %w", err)
+	}
+	direction, err := marshalUtil.ReadUint8()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse direction: %w", err)
+	}
+	if entry.Direction = TransferDirection(direction); entry.Direction > Internal {
+		return nil, errors.Errorf("unsupported direction %d", direction)
+	}
+
+	amountCount, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse amount count: %w", err)
+	}
+	entry.Amounts = make(map[ledgerstate.Color]uint64, amountCount)
+	for i := uint16(0); i < amountCount; i++ {
+		color, colorErr := ledgerstate.ColorFromMarshalUtil(marshalUtil)
+		if colorErr != nil {
+			return nil, errors.Errorf("failed to parse color: %w", colorErr)
+		}
+		if entry.Amounts[color], err = marshalUtil.ReadUint64(); err != nil {
+			return nil, errors.Errorf("failed to parse amount: %w", err)
+		}
+	}
+
+	counterpartyCount, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse counterparty count: %w", err)
+	}
+	entry.Counterparties = make([]ledgerstate.Address, counterpartyCount)
+	for i := range entry.Counterparties {
+		if entry.Counterparties[i], err = ledgerstate.AddressFromMarshalUtil(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse counterparty: %w", err)
+		}
+	}
+
+	addressCount, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse address count: %w", err)
+	}
+	entry.Addresses = make([]address.Address, addressCount)
+	for i := range entry.Addresses {
+		if entry.Addresses[i].Index, err = marshalUtil.ReadUint64(); err != nil {
+			return nil, errors.Errorf("failed to parse address index: %w", err)
+		}
+		addressBytes, readErr := marshalUtil.ReadBytes(ledgerstate.AddressLength)
+		if readErr != nil {
+			return nil, errors.Errorf("failed to parse address: %w", readErr)
+		}
+		copy(entry.Addresses[i].AddressBytes[:], addressBytes)
+	}
+
+	if entry.InclusionState, err = ledgerstate.InclusionStateFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse inclusion state: %w", err)
+	}
+	if entry.Timestamp, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse timestamp: %w", err)
+	}
+	if entry.UpdatedTime, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse update time: %w", err)
+	}
+
+	return entry, nil
+}
+
+// Colors returns the colors of the amounts of the entry in a deterministic order.
+func (h *HistoryEntry) Colors() (colors []ledgerstate.Color) {
+	colors = make([]ledgerstate.Color, 0, len(h.Amounts))
+	for color := range h.Amounts {
+		colors = append(colors, color)
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		return bytes.Compare(colors[i][:], colors[j][:]) < 0
+	})
+
+	return colors
+}
+
+// Bytes returns a marshaled version of the HistoryEntry.
+func (h *HistoryEntry) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		Write(h.TransactionID).
+		WriteUint8(uint8(h.Direction)).
+		WriteUint16(uint16(len(h.Amounts)))
+	for _, color := range h.Colors() {
+		marshalUtil.Write(color).WriteUint64(h.Amounts[color])
+	}
+	marshalUtil.WriteUint16(uint16(len(h.Counterparties)))
+	for _, counterparty := range h.Counterparties {
+		marshalUtil.Write(counterparty)
+	}
+	marshalUtil.WriteUint16(uint16(len(h.Addresses)))
+	for _, addr := range h.Addresses {
+		marshalUtil.WriteUint64(addr.Index).WriteBytes(addr.AddressBytes[:])
+	}
+
+	return marshalUtil.
+		Write(h.InclusionState).
+		WriteTime(h.Timestamp).
+		WriteTime(h.UpdatedTime).
+		Bytes()
+}
+
+// String returns a human readable version of the HistoryEntry.
+func (h *HistoryEntry) String() string {
+	counterparties := make([]string, len(h.Counterparties))
+	for i, counterparty := range h.Counterparties {
+		counterparties[i] = counterparty.Base58()
+	}
+	addresses := make([]string, len(h.Addresses))
+	for i, addr := range h.Addresses {
+		addresses[i] = addr.Base58()
+	}
+
+	return stringify.Struct("HistoryEntry",
+		stringify.StructField("TransactionID", h.TransactionID),
+		stringify.StructField("Direction", h.Direction.String()),
+		stringify.StructField("Amounts", ledgerstate.NewColoredBalances(h.Amounts)),
+		stringify.StructField("Counterparties", counterparties),
+		stringify.StructField("Addresses", addresses),
+		stringify.StructField("InclusionState", h.InclusionState),
+		stringify.StructField("Timestamp", h.Timestamp),
+		stringify.StructField("UpdatedTime", h.UpdatedTime),
+	)
+}
+
+// addBalances adds the balances of the given output to the given amounts.
+func addBalances(amounts map[ledgerstate.Color]uint64, output ledgerstate.Output) {
+	output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+		amounts[color] += balance
+		return true
+	})
+}
+
+// uniqueAddresses returns the addresses of the given outputs without duplicates.
+func uniqueAddresses(outputs ledgerstate.Outputs) (addresses []ledgerstate.Address) {
+	seen := make(map[string]bool)
+	for _, output := range outputs {
+		addr := output.Address()
+		if seen[addr.Base58()] {
+			continue
+		}
+		seen[addr.Base58()] = true
+		addresses = append(addresses, addr)
+	}
+
+	return addresses
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region HistoryFilter ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// HistoryFilter selects the entries of a History.
+type HistoryFilter func(entry *HistoryEntry) bool
+
+// FilterDirection selects the entries with the given direction.
+func FilterDirection(direction TransferDirection) HistoryFilter {
+	return func(entry *HistoryEntry) bool {
+		return entry.Direction == direction
+	}
+}
+
+// FilterColor selects the entries that moved funds of the given color.
+func FilterColor(color ledgerstate.Color) HistoryFilter {
+	return func(entry *HistoryEntry) bool {
+		_, exists := entry.Amounts[color]
+		return exists
+	}
+}
+
+// FilterAddress selects the entries that involved the given address, either as an address of the wallet or as a
+// counterparty.
+func FilterAddress(addr ledgerstate.Address) HistoryFilter {
+	return func(entry *HistoryEntry) bool {
+		for _, walletAddress := range entry.Addresses {
+			if walletAddress.Address().Equals(addr) {
+				return true
+			}
+		}
+		for _, counterparty := range entry.Counterparties {
+			if counterparty.Equals(addr) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// FilterInclusionState selects the entries with the given inclusion state.
+func FilterInclusionState(inclusionState ledgerstate.InclusionState) HistoryFilter {
+	return func(entry *HistoryEntry) bool {
+		return entry.InclusionState == inclusionState
+	}
+}
+
+// FilterSince selects the entries with a timestamp at or after the given time.
+func FilterSince(since time.Time) HistoryFilter {
+	return func(entry *HistoryEntry) bool {
+		return !entry.Timestamp.Before(since)
+	}
+}
+
+// FilterUntil selects the entries with a timestamp before the given time.
+func FilterUntil(until time.Time) HistoryFilter {
+	return func(entry *HistoryEntry) bool {
+		return entry.Timestamp.Before(until)
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region History //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// History is the local record of the transactions that moved funds of the wallet. It is built from the outputs on the
+// addresses of the wallet and the transactions that created or consumed them.
+type History struct {
+	entries map[ledgerstate.TransactionID]*HistoryEntry
+	// settledOutputs are the outputs of the wallet whose consuming transaction is confirmed, so that their consumers
+	// do not need to be queried again.
+	settledOutputs map[ledgerstate.OutputID]bool
+	mutex          sync.RWMutex
+}
+
+// NewHistory creates an empty History.
+func NewHistory() *History {
+	return &History{
+		entries:        make(map[ledgerstate.TransactionID]*HistoryEntry),
+		settledOutputs: make(map[ledgerstate.OutputID]bool),
+	}
+}
+
+// HistoryFromBytes unmarshals a History from a sequence of bytes.
+func HistoryFromBytes(historyBytes []byte) (history *History, err error) {
+	marshalUtil := marshalutil.New(historyBytes)
+	version, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse version: %w", err)
+	}
+	if version != HistoryVersion {
+		return nil, errors.Errorf("unsupported history version %d", version)
+	}
+
+	history = NewHistory()
+	entryCount, err := marshalUtil.ReadUint64()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse entry count: %w", err)
+	}
+	for i := uint64(0); i < entryCount; i++ {
+		entry, entryErr := HistoryEntryFromMarshalUtil(marshalUtil)
+		if entryErr != nil {
+			return nil, errors.Errorf("failed to parse entry %d: %w", i, entryErr)
+		}
+		history.entries[entry.TransactionID] = entry
+	}
+
+	settledOutputCount, err := marshalUtil.ReadUint64()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse settled output count: %w", err)
+	}
+	for i := uint64(0); i < settledOutputCount; i++ {
+		outputID, outputIDErr := ledgerstate.OutputIDFromMarshalUtil(marshalUtil)
+		if outputIDErr != nil {
+			return nil, errors.Errorf("failed to parse settled output %d: %w", i, outputIDErr)
+		}
+		history.settledOutputs[outputID] = true
+	}
+	if marshalUtil.ReadOffset() != len(historyBytes) {
+		return nil, errors.New("history contains unexpected trailing bytes")
+	}
+
+	return history, nil
+}
+
+// Entry returns the entry of the given transaction.
+func (h *History) Entry(transactionID ledgerstate.TransactionID) (entry *HistoryEntry, exists bool) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	entry, exists = h.entries[transactionID]
+	return entry, exists
+}
+
+// Entries returns the entries that match all of the given filters, ordered by their timestamp.
+func (h *History) Entries(filters ...HistoryFilter) (entries []*HistoryEntry) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	entries = make([]*HistoryEntry, 0, len(h.entries))
+EntryLoop:
+	for _, entry := range h.entries {
+		for _, filter := range filters {
+			if !filter(entry) {
+				continue EntryLoop
+			}
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Timestamp.Equal(entries[j].Timestamp) {
+			return bytes.Compare(entries[i].TransactionID[:], entries[j].TransactionID[:]) < 0
+		}
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+
+	return entries
+}
+
+// AddressActivity returns the entries that created or consumed outputs on the given address of the wallet.
+func (h *History) AddressActivity(addr address.Address) []*HistoryEntry {
+	return h.Entries(func(entry *HistoryEntry) bool {
+		for _, walletAddress := range entry.Addresses {
+			if walletAddress.AddressBytes == addr.AddressBytes {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Refresh updates the History with the transactions that created or consumed outputs on the given addresses. Entries
+// of transactions that are confirmed or rejected are final and are not queried again. The connector needs to implement
+// HistoryConnector.
+func (h *History) Refresh(connector Connector, addresses ...address.Address) (err error) {
+	if connector == nil {
+		return ErrOffline
+	}
+	historyConnector, ok := connector.(HistoryConnector)
+	if !ok {
+		return errors.Errorf("failed to refresh the history: %w", ErrNoHistoryConnector)
+	}
+
+	outputs, err := historyConnector.GetAddressOutputs(addresses...)
+	if err != nil {
+		return errors.Errorf("failed to retrieve the outputs of the wallet: %w", err)
+	}
+
+	ownOutputs := make(map[ledgerstate.OutputID]address.Address)
+	transactionIDs := make(map[ledgerstate.TransactionID]bool)
+	for addr, outputsByID := range outputs {
+		for outputID := range outputsByID {
+			ownOutputs[outputID] = addr
+			transactionIDs[outputID.TransactionID()] = true
+		}
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	consumersByOutput := make(map[ledgerstate.OutputID][]ledgerstate.TransactionID)
+	for outputID := range ownOutputs {
+		if h.settledOutputs[outputID] {
+			continue
+		}
+		consumers, consumersErr := historyConnector.GetOutputConsumers(outputID)
+		if consumersErr != nil {
+			return errors.Errorf("failed to retrieve the consumers of output %s: %w", outputID.Base58(), consumersErr)
+		}
+		consumersByOutput[outputID] = consumers
+		for _, consumer := range consumers {
+			transactionIDs[consumer] = true
+		}
+	}
+
+	now := time.Now()
+	for transactionID := range transactionIDs {
+		entry, exists := h.entries[transactionID]
+		if exists && entry.InclusionState != ledgerstate.Pending {
+			continue
+		}
+		if !exists {
+			transaction, transactionErr := historyConnector.GetTransactionDetails(transactionID)
+			if transactionErr != nil {
+				return errors.Errorf("failed to retrieve transaction %s: %w", transactionID.Base58(), transactionErr)
+			}
+			entry = newHistoryEntry(transaction, ownOutputs)
+		}
+		if entry.InclusionState, err = connector.GetTransactionInclusionState(transactionID); err != nil {
+			return errors.Errorf("failed to retrieve the inclusion state of transaction %s: %w", transactionID.Base58(), err)
+		}
+		entry.UpdatedTime = now
+		h.entries[transactionID] = entry
+	}
+
+	for outputID, consumers := range consumersByOutput {
+		for _, consumer := range consumers {
+			if entry, exists := h.entries[consumer]; exists && entry.InclusionState == ledgerstate.Confirmed {
+				h.settledOutputs[outputID] = true
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// Bytes returns a marshaled version of the History.
+func (h *History) Bytes() []byte {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	marshalUtil := marshalutil.New().
+		WriteByte(HistoryVersion).
+		WriteUint64(uint64(len(h.entries)))
+	for _, entry := range h.entries {
+		marshalUtil.WriteBytes(entry.Bytes())
+	}
+	marshalUtil.WriteUint64(uint64(len(h.settledOutputs)))
+	for outputID := range h.settledOutputs {
+		marshalUtil.Write(outputID)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
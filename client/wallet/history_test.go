@@ -0,0 +1,144 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// historyScenario funds the wallet from an external address (incoming), moves the funds to another address of the
+// wallet (internal) and sends a part of them to another external address (outgoing).
+type historyScenario struct {
+	walletAddresses      []address.Address
+	sender, recipient    ledgerstate.Address
+	incoming, internal   *TransactionDetails
+	outgoing             *TransactionDetails
+	ownOutputsByOutputID map[ledgerstate.OutputID]address.Address
+}
+
+func newHistoryScenario() *historyScenario {
+	walletSeed := seed.NewSeed()
+	s := &historyScenario{
+		walletAddresses: []address.Address{walletSeed.Address(0), walletSeed.Address(1)},
+		sender:          seed.NewSeed().Address(0).Address(),
+		recipient:       seed.NewSeed().Address(0).Address(),
+	}
+
+	senderFunds := newHistoryOutput(ledgerstate.TransactionID{1}, 0, s.sender, 100)
+	s.incoming = &TransactionDetails{
+		ID:        ledgerstate.TransactionID{2},
+		Timestamp: time.Unix(1, 0),
+		Inputs:    ledgerstate.Outputs{senderFunds},
+		Outputs: ledgerstate.Outputs{
+			newHistoryOutput(ledgerstate.TransactionID{2}, 0, s.walletAddresses[0].Address(), 60),
+			newHistoryOutput(ledgerstate.TransactionID{2}, 1, s.sender, 40),
+		},
+	}
+	s.internal = &TransactionDetails{
+		ID:        ledgerstate.TransactionID{3},
+		Timestamp: time.Unix(2, 0),
+		Inputs:    ledgerstate.Outputs{s.incoming.Outputs[0]},
+		Outputs:   ledgerstate.Outputs{newHistoryOutput(ledgerstate.TransactionID{3}, 0, s.walletAddresses[1].Address(), 60)},
+	}
+	s.outgoing = &TransactionDetails{
+		ID:        ledgerstate.TransactionID{4},
+		Timestamp: time.Unix(3, 0),
+		Inputs:    ledgerstate.Outputs{s.internal.Outputs[0]},
+		Outputs: ledgerstate.Outputs{
+			newHistoryOutput(ledgerstate.TransactionID{4}, 0, s.recipient, 25),
+			newHistoryOutput(ledgerstate.TransactionID{4}, 1, s.walletAddresses[0].Address(), 35),
+		},
+	}
+	s.ownOutputsByOutputID = map[ledgerstate.OutputID]address.Address{
+		s.incoming.Outputs[0].ID(): s.walletAddresses[0],
+		s.internal.Outputs[0].ID(): s.walletAddresses[1],
+		s.outgoing.Outputs[1].ID(): s.walletAddresses[0],
+	}
+
+	return s
+}
+
+func TestNewHistoryEntry(t *testing.T) {
+	s := newHistoryScenario()
+
+	incoming := newHistoryEntry(s.incoming, s.ownOutputsByOutputID)
+	assert.Equal(t, Incoming, incoming.Direction)
+	assert.Equal(t, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 60}, incoming.Amounts)
+	assert.Equal(t, []ledgerstate.Address{s.sender}, incoming.Counterparties)
+	assert.Equal(t, []address.Address{s.walletAddresses[0]}, incoming.Addresses)
+	assert.Equal(t, s.incoming.Timestamp, incoming.Timestamp)
+
+	internal := newHistoryEntry(s.internal, s.ownOutputsByOutputID)
+	assert.Equal(t, Internal, internal.Direction)
+	assert.Equal(t, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 60}, internal.Amounts)
+	assert.Empty(t, internal.Counterparties)
+	assert.Equal(t, s.walletAddresses, internal.Addresses)
+
+	// the remainder that returns to the wallet is not part of the sent amount
+	outgoing := newHistoryEntry(s.outgoing, s.ownOutputsByOutputID)
+	assert.Equal(t, Outgoing, outgoing.Direction)
+	assert.Equal(t, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 25}, outgoing.Amounts)
+	assert.Equal(t, []ledgerstate.Address{s.recipient}, outgoing.Counterparties)
+	assert.Equal(t, s.walletAddresses, outgoing.Addresses)
+}
+
+func TestHistory_Refresh(t *testing.T) {
+	s := newHistoryScenario()
+	connector := newMockHistoryConnector()
+	connector.addTransaction(s.incoming, ledgerstate.Confirmed, s.walletAddresses...)
+	connector.addTransaction(s.internal, ledgerstate.Confirmed, s.walletAddresses...)
+	connector.addTransaction(s.outgoing, ledgerstate.Pending, s.walletAddresses...)
+
+	history := NewHistory()
+	require.NoError(t, history.Refresh(connector, s.walletAddresses...))
+
+	entries := history.Entries()
+	require.Len(t, entries, 3)
+	expectedDirections := map[ledgerstate.TransactionID]TransferDirection{
+		s.incoming.ID: Incoming,
+		s.internal.ID: Internal,
+		s.outgoing.ID: Outgoing,
+	}
+	for _, entry := range entries {
+		assert.Equal(t, expectedDirections[entry.TransactionID], entry.Direction)
+		assert.False(t, entry.UpdatedTime.IsZero())
+	}
+	outgoing, exists := history.Entry(s.outgoing.ID)
+	require.True(t, exists)
+	assert.Equal(t, ledgerstate.Pending, outgoing.InclusionState)
+	assert.Len(t, history.Entries(FilterDirection(Incoming)), 1)
+	assert.Len(t, history.AddressActivity(s.walletAddresses[1]), 2)
+
+	// the outputs consumed by confirmed transactions and the confirmed transactions are not queried again
+	connector.consumerLookups, connector.transactionLookups = 0, 0
+	connector.inclusionStates[s.outgoing.ID] = ledgerstate.Confirmed
+	require.NoError(t, history.Refresh(connector, s.walletAddresses...))
+	assert.Equal(t, 2, connector.consumerLookups)
+	assert.Zero(t, connector.transactionLookups)
+	outgoing, _ = history.Entry(s.outgoing.ID)
+	assert.Equal(t, ledgerstate.Confirmed, outgoing.InclusionState)
+
+	connector.consumerLookups = 0
+	require.NoError(t, history.Refresh(connector, s.walletAddresses...))
+	assert.Equal(t, 1, connector.consumerLookups)
+}
+
+func TestHistory_Refresh_NoHistoryConnector(t *testing.T) {
+	err := NewHistory().Refresh(newMockConnector(), seed.NewSeed().Address(0))
+	assert.True(t, errors.Is(err, ErrNoHistoryConnector))
+	assert.True(t, errors.Is(NewHistory().Refresh(nil), ErrOffline))
+}
+
+func newHistoryOutput(transactionID ledgerstate.TransactionID, index uint16, addr ledgerstate.Address, amount uint64) ledgerstate.Output {
+	output := ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: amount}), addr)
+	output.SetID(ledgerstate.NewOutputID(transactionID, index))
+
+	return output
+}
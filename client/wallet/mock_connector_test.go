@@ -0,0 +1,233 @@
+package wallet
+
+import (
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+// mockConnector is a Connector that holds a fixed set of confirmed outputs and records the sent transactions.
+type mockConnector struct {
+	outputs          map[address.Address]map[ledgerstate.OutputID]*Output
+	sendErrors       []error
+	sentTransactions []*ledgerstate.Transaction
+	inclusionStates  map[ledgerstate.TransactionID]ledgerstate.InclusionState
+}
+
+func newMockConnector(outputs ...*Output) *mockConnector {
+	connector := &mockConnector{
+		outputs:         make(map[address.Address]map[ledgerstate.OutputID]*Output),
+		inclusionStates: make(map[ledgerstate.TransactionID]ledgerstate.InclusionState),
+	}
+	for _, output := range outputs {
+		if _, exists := connector.outputs[output.Address]; !exists {
+			connector.outputs[output.Address] = make(map[ledgerstate.OutputID]*Output)
+		}
+		connector.outputs[output.Address][output.Object.ID()] = output
+	}
+
+	return connector
+}
+
+// newMockOutput creates a confirmed output with the given amount of IOTA on the given address.
+func newMockOutput(addr address.Address, index uint16, amount uint64) *Output {
+	output := ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: amount}), addr.Address())
+	output.SetID(ledgerstate.NewOutputID(ledgerstate.TransactionID{1}, index))
+
+	return &Output{
+		Address:        addr,
+		Object:         output,
+		InclusionState: InclusionState{Liked: true, Confirmed: true},
+	}
+}
+
+func (m *mockConnector) UnspentOutputs(addresses ...address.Address) (outputs OutputsByAddressAndOutputID, err error) {
+	outputs = make(OutputsByAddressAndOutputID)
+	for _, addr := range addresses {
+		for outputID, output := range m.outputs[addr] {
+			if _, exists := outputs[addr]; !exists {
+				outputs[addr] = make(map[ledgerstate.OutputID]*Output)
+			}
+			// hand out copies, so that the wallet can not modify the state of the connector
+			outputCopy := *output
+			outputs[addr][outputID] = &outputCopy
+		}
+	}
+
+	return outputs, nil
+}
+
+func (m *mockConnector) SendTransaction(tx *ledgerstate.Transaction) (err error) {
+	m.sentTransactions = append(m.sentTransactions, tx)
+	if len(m.sendErrors) >= len(m.sentTransactions) {
+		err = m.sendErrors[len(m.sentTransactions)-1]
+	}
+	if err == nil {
+		m.inclusionStates[tx.ID()] = ledgerstate.Confirmed
+	}
+
+	return err
+}
+
+func (m *mockConnector) RequestFaucetFunds(address.Address, int) error { return nil }
+
+func (m *mockConnector) GetAllowedPledgeIDs() (map[mana.Type][]string, error) {
+	return map[mana.Type][]string{mana.AccessMana: {""}, mana.ConsensusMana: {""}}, nil
+}
+
+func (m *mockConnector) GetTransactionInclusionState(txID ledgerstate.TransactionID) (ledgerstate.InclusionState, error) {
+	return m.inclusionStates[txID], nil
+}
+
+func (m *mockConnector) GetUnspentAliasOutput(*ledgerstate.AliasAddress) (*ledgerstate.AliasOutput, error) {
+	return nil, nil
+}
+
+// mockHistoryConnector is a mockConnector that also provides the transactions that created or consumed the outputs of
+// the wallet. It counts the lookups, so that tests can check which ones are repeated.
+type mockHistoryConnector struct {
+	*mockConnector
+	addressOutputs     map[address.Address]map[ledgerstate.OutputID]*Output
+	consumers          map[ledgerstate.OutputID][]ledgerstate.TransactionID
+	transactions       map[ledgerstate.TransactionID]*TransactionDetails
+	consumerLookups    int
+	transactionLookups int
+}
+
+func newMockHistoryConnector() *mockHistoryConnector {
+	return &mockHistoryConnector{
+		mockConnector:  newMockConnector(),
+		addressOutputs: make(map[address.Address]map[ledgerstate.OutputID]*Output),
+		consumers:      make(map[ledgerstate.OutputID][]ledgerstate.TransactionID),
+		transactions:   make(map[ledgerstate.TransactionID]*TransactionDetails),
+	}
+}
+
+// addTransaction records the given transaction with the given inclusion state. Its outputs on the given wallet
+// addresses are returned by GetAddressOutputs and its inputs are consumed by it.
+func (m *mockHistoryConnector) addTransaction(transaction *TransactionDetails, inclusionState ledgerstate.InclusionState, walletAddresses ...address.Address) {
+	m.transactions[transaction.ID] = transaction
+	m.inclusionStates[transaction.ID] = inclusionState
+	for _, input := range transaction.Inputs {
+		m.consumers[input.ID()] = append(m.consumers[input.ID()], transaction.ID)
+	}
+	for _, output := range transaction.Outputs {
+		for _, addr := range walletAddresses {
+			if output.Address().Array() != addr.AddressBytes {
+				continue
+			}
+			if _, exists := m.addressOutputs[addr]; !exists {
+				m.addressOutputs[addr] = make(map[ledgerstate.OutputID]*Output)
+			}
+			m.addressOutputs[addr][output.ID()] = &Output{Address: addr, Object: output}
+		}
+	}
+}
+
+func (m *mockHistoryConnector) GetAddressOutputs(addresses ...address.Address) (OutputsByAddressAndOutputID, error) {
+	outputs := make(OutputsByAddressAndOutputID)
+	for _, addr := range addresses {
+		if addressOutputs, exists := m.addressOutputs[addr]; exists {
+			outputs[addr] = addressOutputs
+		}
+	}
+
+	return outputs, nil
+}
+
+func (m *mockHistoryConnector) GetOutputConsumers(outputID ledgerstate.OutputID) ([]ledgerstate.TransactionID, error) {
+	m.consumerLookups++
+	return m.consumers[outputID], nil
+}
+
+func (m *mockHistoryConnector) GetTransactionDetails(txID ledgerstate.TransactionID) (*TransactionDetails, error) {
+	m.transactionLookups++
+	transaction, exists := m.transactions[txID]
+	if !exists {
+		return nil, errors.Errorf("transaction %s does not exist", txID.Base58())
+	}
+
+	return transaction, nil
+}
+
+// mockFaucetConnector is a mockConnector that can be accessed concurrently. Its faucet requests are reported on the
+// faucetRequests channel, and the test adds the outputs of the faucet with addOutput.
+type mockFaucetConnector struct {
+	*mockConnector
+	faucetRequests chan address.Address
+	mutex          sync.Mutex
+}
+
+func newMockFaucetConnector(outputs ...*Output) *mockFaucetConnector {
+	return &mockFaucetConnector{
+		mockConnector:  newMockConnector(outputs...),
+		faucetRequests: make(chan address.Address, 10),
+	}
+}
+
+// addOutput adds the given confirmed output to the connector.
+func (m *mockFaucetConnector) addOutput(output *Output) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, exists := m.outputs[output.Address]; !exists {
+		m.outputs[output.Address] = make(map[ledgerstate.OutputID]*Output)
+	}
+	m.outputs[output.Address][output.Object.ID()] = output
+}
+
+func (m *mockFaucetConnector) UnspentOutputs(addresses ...address.Address) (outputs OutputsByAddressAndOutputID, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.mockConnector.UnspentOutputs(addresses...)
+}
+
+func (m *mockFaucetConnector) RequestFaucetFunds(addr address.Address, _ int) error {
+	m.faucetRequests <- addr
+
+	return nil
+}
+
+// mockNotifier is a Notifier whose notifications are triggered by the test. The subscriptions and the watched and
+// unwatched transactions are reported on the channels of the same name.
+type mockNotifier struct {
+	events     *NotifierEvents
+	subscribed chan struct{}
+	watched    chan ledgerstate.TransactionID
+	unwatched  chan ledgerstate.TransactionID
+}
+
+func newMockNotifier() *mockNotifier {
+	return &mockNotifier{
+		events:     NewNotifierEvents(),
+		subscribed: make(chan struct{}, 1),
+		watched:    make(chan ledgerstate.TransactionID, 10),
+		unwatched:  make(chan ledgerstate.TransactionID, 10),
+	}
+}
+
+func (m *mockNotifier) SubscribeAddresses(...address.Address) {
+	select {
+	case m.subscribed <- struct{}{}:
+	default:
+	}
+}
+
+func (m *mockNotifier) WatchTransaction(txID ledgerstate.TransactionID) {
+	m.watched <- txID
+}
+
+func (m *mockNotifier) UnwatchTransaction(txID ledgerstate.TransactionID) {
+	m.unwatched <- txID
+}
+
+func (m *mockNotifier) Events() *NotifierEvents {
+	return m.events
+}
+
+func (m *mockNotifier) Close() {}
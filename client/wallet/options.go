@@ -1,10 +1,12 @@
 package wallet
 
 import (
-	"github.com/capossele/asset-registry/pkg/registryservice"
 	"github.com/iotaledger/hive.go/bitmask"
 
 	"github.com/iotaledger/goshimmer/client"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/assetregistry"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/coinselection"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
 )
 
@@ -26,6 +28,30 @@ func Import(seed *seed.Seed, lastAddressIndex uint64, spentAddresses []bitmask.B
 	}
 }
 
+// ImportWatchOnly restores a watch-only wallet, that can prepare transactions for the given addresses but not sign
+// them.
+func ImportWatchOnly(addresses []address.Address, lastAddressIndex uint64, spentAddresses []bitmask.BitMask, assetRegistry *AssetRegistry) Option {
+	return func(wallet *Wallet) {
+		wallet.addressManager = NewWatchOnlyAddressManager(addresses, lastAddressIndex, spentAddresses)
+		wallet.assetRegistry = assetRegistry
+	}
+}
+
+// ImportHistory restores the transaction history that was previously recorded by the wallet.
+func ImportHistory(history *History) Option {
+	return func(wallet *Wallet) {
+		wallet.history = history
+	}
+}
+
+// Offline configures the wallet to not connect to a node, so that it can sign transactions on a machine without
+// network access.
+func Offline(enabled bool) Option {
+	return func(wallet *Wallet) {
+		wallet.offline = enabled
+	}
+}
+
 // ReusableAddress configures the wallet to run in "single address" mode where all the funds are always managed on a
 // single reusable address.
 func ReusableAddress(enabled bool) Option {
@@ -34,6 +60,21 @@ func ReusableAddress(enabled bool) Option {
 	}
 }
 
+// CoinSelection configures the strategy that the wallet uses to select the outputs that fund a transfer.
+func CoinSelection(strategy coinselection.Strategy) Option {
+	return func(wallet *Wallet) {
+		wallet.coinSelection = strategy
+	}
+}
+
+// DustConsolidation configures the policy that ConsolidateDust and StartDustConsolidation use to consolidate the dust
+// outputs of the wallet.
+func DustConsolidation(policy DustConsolidationPolicy) Option {
+	return func(wallet *Wallet) {
+		wallet.dustConsolidationPolicy = &policy
+	}
+}
+
 // FaucetPowDifficulty configures the wallet with the faucet's target PoW difficulty
 func FaucetPowDifficulty(powTarget int) Option {
 	return func(wallet *Wallet) {
@@ -66,12 +107,35 @@ func ConfirmationTimeout(timeout int) Option {
 // AssetRegistryNetwork defines which network we intend to use for asset lookups.
 func AssetRegistryNetwork(network string) Option {
 	return func(wallet *Wallet) {
-		if registryservice.Networks[network] {
+		if assetregistry.Networks[network] {
 			wallet.assetRegistry = NewAssetRegistry(network)
 		}
 	}
 }
 
+// AssetRegistryURL defines the url of the asset registry server that is used for asset lookups.
+func AssetRegistryURL(url string) Option {
+	return func(wallet *Wallet) {
+		wallet.assetRegistryURL = url
+	}
+}
+
+// Notifications configures the wallet to wait for the notifications of the given Notifier instead of polling the node
+// for confirmations. A connector that implements the Notifier interface is used as the Notifier by default.
+func Notifications(notifier Notifier) Option {
+	return func(wallet *Wallet) {
+		wallet.notifier = notifier
+	}
+}
+
+// TxStream configures the wallet to receive its notifications from the txstream server at the given address
+// (host:port) of a node.
+func TxStream(txStreamAddress string) Option {
+	return func(wallet *Wallet) {
+		wallet.notifier = NewTxStreamNotifier(txStreamAddress)
+	}
+}
+
 // GenericConnector allows us to provide a generic connector to the wallet. It can be used to mock the behavior of a
 // real connector in tests or to provide new connection methods for nodes.
 func GenericConnector(connector Connector) Option {
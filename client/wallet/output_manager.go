@@ -12,13 +12,16 @@ type OutputManager struct {
 	unspentOutputs OutputsByAddressAndOutputID
 }
 
-// NewUnspentOutputManager creates a new UnspentOutputManager.
+// NewUnspentOutputManager creates a new UnspentOutputManager. The connector is nil if the wallet is offline.
 func NewUnspentOutputManager(addressManager *AddressManager, connector Connector) (outputManager *OutputManager) {
 	outputManager = &OutputManager{
 		addressManager: addressManager,
 		connector:      connector,
 		unspentOutputs: NewAddressToOutputs(),
 	}
+	if connector == nil {
+		return
+	}
 
 	if err := outputManager.Refresh(true); err != nil {
 		panic(err)
@@ -29,6 +32,10 @@ func NewUnspentOutputManager(addressManager *AddressManager, connector Connector
 
 // Refresh checks for unspent outputs on the addresses provided by address manager and updates the internal state.
 func (o *OutputManager) Refresh(includeSpentAddresses ...bool) error {
+	if o.connector == nil {
+		return ErrOffline
+	}
+
 	// go through the list of all addresses in the wallet
 	addressesToRefresh := o.addressManager.Addresses()
 
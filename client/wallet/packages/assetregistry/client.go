@@ -0,0 +1,99 @@
+package assetregistry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-resty/resty/v2"
+)
+
+// Client is a client for the API of an asset registry.
+type Client struct {
+	client *resty.Client
+}
+
+// NewClient creates a Client for the registry with the given URL.
+func NewClient(url string) *Client {
+	return &Client{client: resty.New().SetHostURL(url)}
+}
+
+// SaveEntry stores the entry in the registry of the given network.
+func (c *Client) SaveEntry(ctx context.Context, network string, entry *Entry) error {
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetBody(entry).
+		SetError(&ErrorResponse{}).
+		Post(assetsEndpoint(network))
+	if err != nil {
+		return errors.Errorf("failed to save entry of color %s: %w", entry.ID, err)
+	}
+	if resp.IsSuccess() {
+		return nil
+	}
+
+	return responseError(resp, "failed to save entry of color "+entry.ID)
+}
+
+// LoadEntry returns the entry of the given color from the registry of the given network.
+func (c *Client) LoadEntry(ctx context.Context, network, id string) (*Entry, error) {
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetError(&ErrorResponse{}).
+		Get(assetsEndpoint(network) + "/" + id)
+	if err != nil {
+		return nil, errors.Errorf("failed to load entry of color %s: %w", id, err)
+	}
+	if !resp.IsSuccess() {
+		return nil, responseError(resp, "failed to load entry of color "+id)
+	}
+
+	entry := &Entry{}
+	if err = json.Unmarshal(resp.Body(), entry); err != nil {
+		return nil, errors.Errorf("failed to parse entry of color %s: %w", id, err)
+	}
+
+	return entry, nil
+}
+
+// LoadEntries returns all entries of the registry of the given network.
+func (c *Client) LoadEntries(ctx context.Context, network string) ([]*Entry, error) {
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetError(&ErrorResponse{}).
+		Get(assetsEndpoint(network))
+	if err != nil {
+		return nil, errors.Errorf("failed to load entries: %w", err)
+	}
+	if !resp.IsSuccess() {
+		return nil, responseError(resp, "failed to load entries")
+	}
+
+	entries := make([]*Entry, 0)
+	if err = json.Unmarshal(resp.Body(), &entries); err != nil {
+		return nil, errors.Errorf("failed to parse entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+func assetsEndpoint(network string) string {
+	return RegistriesEndpoint + "/" + network + AssetsEndpoint
+}
+
+// responseError turns a failed response into an error that wraps the matching error of the package, if there is one.
+func responseError(resp *resty.Response, message string) error {
+	switch resp.StatusCode() {
+	case http.StatusNotFound:
+		return errors.Errorf("%s: %w", message, ErrNotFound)
+	case http.StatusConflict:
+		return errors.Errorf("%s: %w", message, ErrAlreadyRegistered)
+	}
+
+	if errorResponse, ok := resp.Error().(*ErrorResponse); ok && errorResponse.Error != "" {
+		return errors.Errorf("%s: %s", message, errorResponse.Error)
+	}
+
+	return errors.Errorf("%s: %s", message, resp.Status())
+}
@@ -0,0 +1,199 @@
+// Package assetregistry contains the signed entries of the asset registry and a client for the registry API.
+package assetregistry
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+	"github.com/iotaledger/hive.go/typeutils"
+	"github.com/mr-tron/base58"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+const (
+	// RegistriesEndpoint is the API endpoint that contains the registries of the different networks.
+	RegistriesEndpoint = "/registries"
+
+	// AssetsEndpoint is the API endpoint of a registry that contains its entries.
+	AssetsEndpoint = "/assets"
+)
+
+// Networks contains the networks that an asset registry keeps entries for by default.
+var Networks = map[string]bool{
+	"pollen":   true,
+	"nectar":   true,
+	"internal": true,
+	"test":     true,
+}
+
+var (
+	// ErrUnsigned is returned when an entry has no signature.
+	ErrUnsigned = errors.New("entry is not signed")
+
+	// ErrInvalidSignature is returned when the signature of an entry does not match its content.
+	ErrInvalidSignature = errors.New("invalid entry signature")
+
+	// ErrNotMinter is returned when an entry is not signed by the key that minted its color.
+	ErrNotMinter = errors.New("entry is not signed by the minter of the color")
+
+	// ErrAlreadyRegistered is returned when a color is already registered by a different key.
+	ErrAlreadyRegistered = errors.New("color is already registered by a different key")
+
+	// ErrNotFound is returned when a color is not registered.
+	ErrNotFound = errors.New("color is not registered")
+)
+
+// region Entry ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Entry is the metadata of a colored coin in the asset registry. It is signed by a key that owned the funds consumed by
+// the minting transaction, so that nobody but the creator of an asset can register its name and symbol.
+type Entry struct {
+	// ID is the base58 encoded color of the asset.
+	ID string `json:"ID"`
+	// Name is the name of the asset.
+	Name string `json:"name"`
+	// Symbol is the currency symbol of the asset.
+	Symbol string `json:"symbol"`
+	// Precision is the number of decimal places that wallets show for the asset.
+	Precision int `json:"precision"`
+	// Supply is the amount of tokens that were minted.
+	Supply uint64 `json:"supply"`
+	// TransactionID is the base58 encoded ID of the transaction that minted the asset.
+	TransactionID string `json:"transactionID"`
+	// PublicKey is the base58 encoded public key that signed the entry.
+	PublicKey string `json:"publicKey,omitempty"`
+	// Signature is the base58 encoded signature of the entry.
+	Signature string `json:"signature,omitempty"`
+}
+
+// SigningMessage returns the bytes that are signed by the minter. They contain the network, so that an entry can not be
+// replayed on the registry of another network.
+func (e *Entry) SigningMessage(network string) []byte {
+	marshalUtil := marshalutil.New()
+	for _, field := range []string{network, e.ID, e.Name, e.Symbol, e.TransactionID} {
+		fieldBytes := typeutils.StringToBytes(field)
+		marshalUtil.WriteUint32(uint32(len(fieldBytes)))
+		marshalUtil.WriteBytes(fieldBytes)
+	}
+	marshalUtil.WriteUint32(uint32(e.Precision))
+	marshalUtil.WriteUint64(e.Supply)
+
+	return marshalUtil.Bytes()
+}
+
+// Sign signs the entry for the given network with the given key pair.
+func (e *Entry) Sign(network string, keyPair ed25519.KeyPair) {
+	e.PublicKey = keyPair.PublicKey.String()
+	e.Signature = keyPair.PrivateKey.Sign(e.SigningMessage(network)).String()
+}
+
+// VerifySignature checks the signature of the entry for the given network and returns the key that signed it.
+func (e *Entry) VerifySignature(network string) (publicKey ed25519.PublicKey, err error) {
+	if e.PublicKey == "" || e.Signature == "" {
+		return ed25519.PublicKey{}, errors.Errorf("failed to verify entry of color %s: %w", e.ID, ErrUnsigned)
+	}
+
+	publicKeyBytes, err := base58.Decode(e.PublicKey)
+	if err != nil {
+		return ed25519.PublicKey{}, errors.Errorf("failed to decode public key %s: %w", e.PublicKey, err)
+	}
+	if publicKey, _, err = ed25519.PublicKeyFromBytes(publicKeyBytes); err != nil {
+		return ed25519.PublicKey{}, errors.Errorf("failed to parse public key %s: %w", e.PublicKey, err)
+	}
+	signatureBytes, err := base58.Decode(e.Signature)
+	if err != nil {
+		return ed25519.PublicKey{}, errors.Errorf("failed to decode signature %s: %w", e.Signature, err)
+	}
+	signature, _, err := ed25519.SignatureFromBytes(signatureBytes)
+	if err != nil {
+		return ed25519.PublicKey{}, errors.Errorf("failed to parse signature %s: %w", e.Signature, err)
+	}
+
+	if !publicKey.VerifySignature(e.SigningMessage(network), signature) {
+		return ed25519.PublicKey{}, errors.Errorf("failed to verify entry of color %s: %w", e.ID, ErrInvalidSignature)
+	}
+
+	return publicKey, nil
+}
+
+// VerifyMinting checks that the entry is signed for the given network by the owner of one of the inputs of its
+// minting transaction, and that the transaction minted the color and the supply of the entry. The inputs are the
+// outputs consumed by the minting transaction and the outputs are the outputs that it created.
+func (e *Entry) VerifyMinting(network string, inputs, outputs ledgerstate.Outputs) (err error) {
+	publicKey, err := e.VerifySignature(network)
+	if err != nil {
+		return err
+	}
+
+	color, err := ledgerstate.ColorFromBase58EncodedString(e.ID)
+	if err != nil {
+		return errors.Errorf("failed to parse color %s: %w", e.ID, err)
+	}
+	transactionID, err := ledgerstate.TransactionIDFromBase58(e.TransactionID)
+	if err != nil {
+		return errors.Errorf("failed to parse transaction ID %s: %w", e.TransactionID, err)
+	}
+
+	signerAddress := ledgerstate.NewED25519Address(publicKey)
+	signerOwnsInput := false
+	for _, input := range inputs {
+		if input.Address().Equals(signerAddress) {
+			signerOwnsInput = true
+			break
+		}
+	}
+	if !signerOwnsInput {
+		return errors.Errorf("%s does not own an input of transaction %s: %w", signerAddress.Base58(), e.TransactionID, ErrNotMinter)
+	}
+
+	for _, output := range outputs {
+		if output.ID().TransactionID() != transactionID || ledgerstate.Color(blake2b.Sum256(output.ID().Bytes())) != color {
+			continue
+		}
+
+		// the node either returns the output as it was issued or with the minted color already applied
+		minted, mintExists := output.Balances().Get(ledgerstate.ColorMint)
+		if !mintExists {
+			minted, _ = output.Balances().Get(color)
+		}
+		if minted != e.Supply {
+			return errors.Errorf("transaction %s minted %d tokens of color %s, but the entry claims %d", e.TransactionID, minted, e.ID, e.Supply)
+		}
+
+		return nil
+	}
+
+	return errors.Errorf("transaction %s did not mint color %s: %w", e.TransactionID, e.ID, ErrNotMinter)
+}
+
+// String returns a human readable version of the Entry.
+func (e *Entry) String() string {
+	return stringify.Struct("Entry",
+		stringify.StructField("ID", e.ID),
+		stringify.StructField("Name", e.Name),
+		stringify.StructField("Symbol", e.Symbol),
+		stringify.StructField("Precision", e.Precision),
+		stringify.StructField("Supply", e.Supply),
+		stringify.StructField("TransactionID", e.TransactionID),
+		stringify.StructField("PublicKey", e.PublicKey),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ErrorResponse ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ErrorResponse is the body of a failed registry API request.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewErrorResponse creates an ErrorResponse from the given error.
+func NewErrorResponse(err error) *ErrorResponse {
+	return &ErrorResponse{Error: err.Error()}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,146 @@
+package assetregistry
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+const testNetwork = "test"
+
+func TestEntry_VerifySignature(t *testing.T) {
+	keyPair := ed25519.GenerateKeyPair()
+	entry := &Entry{ID: ledgerstate.Color{1}.Base58(), Name: "Test Token", Symbol: "TT", Supply: 1000}
+
+	_, err := entry.VerifySignature(testNetwork)
+	assert.True(t, errors.Is(err, ErrUnsigned))
+
+	entry.Sign(testNetwork, keyPair)
+	publicKey, err := entry.VerifySignature(testNetwork)
+	require.NoError(t, err)
+	assert.Equal(t, keyPair.PublicKey, publicKey)
+
+	// the entry can not be replayed on the registry of another network
+	_, err = entry.VerifySignature("nectar")
+	assert.True(t, errors.Is(err, ErrInvalidSignature))
+
+	// the content can not be changed after signing
+	entry.Name = "Other Token"
+	_, err = entry.VerifySignature(testNetwork)
+	assert.True(t, errors.Is(err, ErrInvalidSignature))
+
+	// the signature can not be replaced by a signature of another key
+	entry.Name = "Test Token"
+	entry.PublicKey = ed25519.GenerateKeyPair().PublicKey.String()
+	_, err = entry.VerifySignature(testNetwork)
+	assert.True(t, errors.Is(err, ErrInvalidSignature))
+
+	entry.Signature = "invalid"
+	_, err = entry.VerifySignature(testNetwork)
+	assert.Error(t, err)
+}
+
+func TestEntry_VerifyMinting(t *testing.T) {
+	minter := ed25519.GenerateKeyPair()
+	inputs, outputs, transactionID, color := newMintingTransaction(minter.PublicKey, 1000)
+
+	testCases := []struct {
+		name    string
+		network string
+		signer  ed25519.KeyPair
+		modify  func(entry *Entry)
+		valid   bool
+		err     error
+	}{
+		{
+			name:    "valid entry",
+			network: testNetwork,
+			signer:  minter,
+			valid:   true,
+		},
+		{
+			name:    "signed for another network",
+			network: "nectar",
+			signer:  minter,
+			err:     ErrInvalidSignature,
+		},
+		{
+			name:    "signer does not own an input",
+			network: testNetwork,
+			signer:  ed25519.GenerateKeyPair(),
+			err:     ErrNotMinter,
+		},
+		{
+			name:    "transaction did not mint the color",
+			network: testNetwork,
+			signer:  minter,
+			modify:  func(entry *Entry) { entry.ID = ledgerstate.Color{1}.Base58() },
+			err:     ErrNotMinter,
+		},
+		{
+			name:    "color was minted by another transaction",
+			network: testNetwork,
+			signer:  minter,
+			modify:  func(entry *Entry) { entry.TransactionID = ledgerstate.TransactionID{2}.Base58() },
+			err:     ErrNotMinter,
+		},
+		{
+			name:    "supply does not match",
+			network: testNetwork,
+			signer:  minter,
+			modify:  func(entry *Entry) { entry.Supply = 1001 },
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			entry := &Entry{ID: color.Base58(), Name: "Test Token", Symbol: "TT", Supply: 1000, TransactionID: transactionID.Base58()}
+			if testCase.modify != nil {
+				testCase.modify(entry)
+			}
+			entry.Sign(testNetwork, testCase.signer)
+
+			err := entry.VerifyMinting(testCase.network, inputs, outputs)
+			if testCase.valid {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			if testCase.err != nil {
+				assert.True(t, errors.Is(err, testCase.err), "unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestEntry_VerifyMinting_ColorApplied(t *testing.T) {
+	minter := ed25519.GenerateKeyPair()
+	inputs, outputs, transactionID, color := newMintingTransaction(minter.PublicKey, 1000)
+
+	// the node might return the minted output with its color already applied
+	coloredOutputs := ledgerstate.Outputs{outputs[0].UpdateMintingColor()}
+	entry := &Entry{ID: color.Base58(), Supply: 1000, TransactionID: transactionID.Base58()}
+	entry.Sign(testNetwork, minter)
+	assert.NoError(t, entry.VerifyMinting(testNetwork, inputs, coloredOutputs))
+}
+
+// newMintingTransaction returns the consumed and created outputs of a transaction that mints the given supply with the
+// funds of the given key.
+func newMintingTransaction(publicKey ed25519.PublicKey, supply uint64) (inputs, outputs ledgerstate.Outputs, transactionID ledgerstate.TransactionID, color ledgerstate.Color) {
+	input := ledgerstate.NewSigLockedSingleOutput(supply, ledgerstate.NewED25519Address(publicKey))
+	input.SetID(ledgerstate.NewOutputID(ledgerstate.TransactionID{3}, 0))
+
+	transactionID = ledgerstate.TransactionID{1}
+	minted := ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{
+		ledgerstate.ColorMint: supply,
+	}), ledgerstate.NewED25519Address(ed25519.GenerateKeyPair().PublicKey))
+	minted.SetID(ledgerstate.NewOutputID(transactionID, 0))
+
+	return ledgerstate.Outputs{input}, ledgerstate.Outputs{minted}, transactionID, blake2b.Sum256(minted.ID().Bytes())
+}
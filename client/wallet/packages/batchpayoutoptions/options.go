@@ -0,0 +1,82 @@
+package batchpayoutoptions
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/coinselection"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// BatchPayoutOption is the type for the optional parameters for the BatchPayout call.
+type BatchPayoutOption func(*BatchPayoutOptions) error
+
+// AccessManaPledgeID is an option for BatchPayout call that defines the nodeID to pledge access mana to.
+func AccessManaPledgeID(nodeID string) BatchPayoutOption {
+	return func(options *BatchPayoutOptions) error {
+		options.AccessManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// ConsensusManaPledgeID is an option for BatchPayout call that defines the nodeID to pledge consensus mana to.
+func ConsensusManaPledgeID(nodeID string) BatchPayoutOption {
+	return func(options *BatchPayoutOptions) error {
+		options.ConsensusManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// WaitForConfirmation defines if the call should wait for the confirmation of all transactions before it returns.
+func WaitForConfirmation(wait bool) BatchPayoutOption {
+	return func(options *BatchPayoutOptions) error {
+		options.WaitForConfirmation = wait
+		return nil
+	}
+}
+
+// CoinSelection is an option for BatchPayout call that defines the strategy that selects the outputs that fund the
+// transactions, instead of the strategy of the wallet.
+func CoinSelection(strategy coinselection.Strategy) BatchPayoutOption {
+	return func(options *BatchPayoutOptions) error {
+		options.CoinSelection = strategy
+		return nil
+	}
+}
+
+// MaxRecipientsPerTransaction defines how many recipients are paid by a single transaction. It can not exceed the
+// maximum number of outputs of a transaction minus the output for the remainder.
+func MaxRecipientsPerTransaction(maxRecipients int) BatchPayoutOption {
+	return func(options *BatchPayoutOptions) error {
+		if maxRecipients < 1 || maxRecipients > ledgerstate.MaxOutputCount-1 {
+			return errors.Errorf("the number of recipients per transaction must be between 1 and %d", ledgerstate.MaxOutputCount-1)
+		}
+		options.MaxRecipientsPerTransaction = maxRecipients
+		return nil
+	}
+}
+
+// BatchPayoutOptions is a struct that is used to aggregate the optional parameters provided in the BatchPayout call.
+type BatchPayoutOptions struct {
+	AccessManaPledgeID          string
+	ConsensusManaPledgeID       string
+	WaitForConfirmation         bool
+	CoinSelection               coinselection.Strategy
+	MaxRecipientsPerTransaction int
+}
+
+// Build is a utility function that constructs the BatchPayoutOptions.
+func Build(options ...BatchPayoutOption) (result *BatchPayoutOptions, err error) {
+	// create options to collect the arguments provided
+	result = &BatchPayoutOptions{
+		MaxRecipientsPerTransaction: ledgerstate.MaxOutputCount - 1,
+	}
+
+	// apply arguments to our options
+	for _, option := range options {
+		if err = option(result); err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,356 @@
+// Package coinselection implements the strategies that the wallet uses to select the outputs that fund a transfer.
+package coinselection
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// minimizeChangeMaxTries bounds the number of combinations that MinimizeChange tries before it falls back to
+// LargestFirst.
+const minimizeChangeMaxTries = 100000
+
+var (
+	// ErrInsufficientFunds is returned when the candidates do not hold enough funds for the transfer.
+	ErrInsufficientFunds = errors.New("insufficient funds")
+
+	// ErrTooManyInputs is returned together with the selected outputs when the transfer can only be funded with more
+	// inputs than allowed.
+	ErrTooManyInputs = errors.New("too many inputs")
+)
+
+// region Strategy /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Strategy selects the outputs that fund a transfer from the spendable outputs of a wallet.
+type Strategy interface {
+	// Select returns the candidates that are consumed to fund the required balances. If the required balances can only
+	// be funded with more than maxInputs outputs, the selected outputs are returned together with ErrTooManyInputs.
+	Select(candidates ledgerstate.Outputs, required map[ledgerstate.Color]uint64, maxInputs int) (selected ledgerstate.Outputs, err error)
+
+	// String returns the name of the Strategy.
+	String() string
+}
+
+var (
+	// InputOrder selects the candidates in the order in which they are given, which is the order of the addresses
+	// of the wallet.
+	InputOrder Strategy = &orderedStrategy{name: "input-order"}
+
+	// LargestFirst selects the candidates with the largest amounts first, which minimizes the number of inputs.
+	LargestFirst Strategy = &orderedStrategy{name: "largest-first", less: func(a, b uint64) bool { return a > b }}
+
+	// SmallestFirst selects the candidates with the smallest amounts first, which reduces the number of small outputs
+	// over time.
+	SmallestFirst Strategy = &orderedStrategy{name: "smallest-first", less: func(a, b uint64) bool { return a < b }}
+
+	// MinimizeChange selects the combination of candidates that exceeds the required balances the least, so that the
+	// remainder is as small as possible or not needed at all.
+	MinimizeChange Strategy = &minimizeChange{}
+
+	// Random selects the candidates in a random order, so that the selection does not reveal which outputs belong to
+	// the same wallet.
+	Random Strategy = &random{}
+
+	// ColorAware prefers candidates that only hold colors of the transfer, so that unrelated colored balances are not
+	// moved to the remainder, and selects the largest of them first.
+	ColorAware Strategy = &colorAware{}
+)
+
+// Strategies contains all strategies by their name.
+var Strategies = map[string]Strategy{
+	InputOrder.String():     InputOrder,
+	LargestFirst.String():   LargestFirst,
+	SmallestFirst.String():  SmallestFirst,
+	MinimizeChange.String(): MinimizeChange,
+	Random.String():         Random,
+	ColorAware.String():     ColorAware,
+}
+
+// FromString returns the Strategy with the given name.
+func FromString(name string) (Strategy, error) {
+	strategy, exists := Strategies[strings.ToLower(name)]
+	if !exists {
+		return nil, errors.Errorf("unknown coin selection strategy %q, expected one of %s", name, strings.Join(Names(), ", "))
+	}
+
+	return strategy, nil
+}
+
+// Names returns the names of all strategies in alphabetical order.
+func Names() (names []string) {
+	for name := range Strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region orderedStrategy //////////////////////////////////////////////////////////////////////////////////////////////
+
+// orderedStrategy selects the candidates ordered by the amount that they contribute to the transfer.
+type orderedStrategy struct {
+	name string
+	less func(a, b uint64) bool
+}
+
+func (o *orderedStrategy) Select(candidates ledgerstate.Outputs, required map[ledgerstate.Color]uint64, maxInputs int) (ledgerstate.Outputs, error) {
+	ordered := contributing(candidates, required)
+	if o.less != nil {
+		sortByContribution(ordered, required, o.less)
+	}
+
+	return selectInOrder(ordered, required, maxInputs)
+}
+
+func (o *orderedStrategy) String() string {
+	return o.name
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region minimizeChange ///////////////////////////////////////////////////////////////////////////////////////////////
+
+type minimizeChange struct{}
+
+func (m *minimizeChange) Select(candidates ledgerstate.Outputs, required map[ledgerstate.Color]uint64, maxInputs int) (ledgerstate.Outputs, error) {
+	ordered := contributing(candidates, required)
+	sortByContribution(ordered, required, func(a, b uint64) bool { return a > b })
+
+	// the remaining funds of the candidates after each position bound the search
+	remaining := make([]map[ledgerstate.Color]uint64, len(ordered)+1)
+	remaining[len(ordered)] = make(map[ledgerstate.Color]uint64)
+	for i := len(ordered) - 1; i >= 0; i-- {
+		remaining[i] = copyBalances(remaining[i+1])
+		addBalances(remaining[i], ordered[i])
+	}
+	if !enough(remaining[0], required) {
+		return selectInOrder(ordered, required, maxInputs)
+	}
+
+	var (
+		best       []int
+		bestChange uint64
+		tries      int
+		current    []int
+	)
+	collected := make(map[ledgerstate.Color]uint64)
+	var search func(position int)
+	search = func(position int) {
+		tries++
+		if tries > minimizeChangeMaxTries || (best != nil && bestChange == 0) {
+			return
+		}
+		if enough(collected, required) {
+			if change := changeOf(collected, required); best == nil || change < bestChange || (change == bestChange && len(current) < len(best)) {
+				best = append([]int(nil), current...)
+				bestChange = change
+			}
+			return
+		}
+		if position == len(ordered) || len(current) == maxInputs {
+			return
+		}
+		merged := copyBalances(collected)
+		for color, balance := range remaining[position] {
+			merged[color] += balance
+		}
+		if !enough(merged, required) {
+			return
+		}
+
+		current = append(current, position)
+		addBalances(collected, ordered[position])
+		search(position + 1)
+		subtractBalances(collected, ordered[position])
+		current = current[:len(current)-1]
+
+		search(position + 1)
+	}
+	search(0)
+
+	if best == nil {
+		return selectInOrder(ordered, required, maxInputs)
+	}
+
+	selected := make(ledgerstate.Outputs, len(best))
+	for i, position := range best {
+		selected[i] = ordered[position]
+	}
+
+	return selected, nil
+}
+
+func (m *minimizeChange) String() string {
+	return "minimize-change"
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region random ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type random struct{}
+
+func (r *random) Select(candidates ledgerstate.Outputs, required map[ledgerstate.Color]uint64, maxInputs int) (ledgerstate.Outputs, error) {
+	shuffled := contributing(candidates, required)
+	for i := len(shuffled) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return nil, errors.Errorf("failed to shuffle candidates: %w", err)
+		}
+		shuffled[i], shuffled[j.Int64()] = shuffled[j.Int64()], shuffled[i]
+	}
+
+	return selectInOrder(shuffled, required, maxInputs)
+}
+
+func (r *random) String() string {
+	return "random"
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region colorAware ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+type colorAware struct{}
+
+func (c *colorAware) Select(candidates ledgerstate.Outputs, required map[ledgerstate.Color]uint64, maxInputs int) (ledgerstate.Outputs, error) {
+	ordered := contributing(candidates, required)
+	sortByContribution(ordered, required, func(a, b uint64) bool { return a > b })
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return foreignColors(ordered[i], required) < foreignColors(ordered[j], required)
+	})
+
+	return selectInOrder(ordered, required, maxInputs)
+}
+
+func (c *colorAware) String() string {
+	return "color-aware"
+}
+
+// foreignColors returns the number of colors of the output that are not required by the transfer.
+func foreignColors(output ledgerstate.Output, required map[ledgerstate.Color]uint64) (count int) {
+	output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+		if _, isRequired := required[color]; !isRequired {
+			count++
+		}
+		return true
+	})
+
+	return count
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility functions ////////////////////////////////////////////////////////////////////////////////////////////
+
+// contributing returns a copy of the candidates that hold at least one of the required colors.
+func contributing(candidates ledgerstate.Outputs, required map[ledgerstate.Color]uint64) (result ledgerstate.Outputs) {
+	result = make(ledgerstate.Outputs, 0, len(candidates))
+	for _, candidate := range candidates {
+		if contribution(candidate, required) > 0 {
+			result = append(result, candidate)
+		}
+	}
+
+	return result
+}
+
+// contribution returns the sum of the balances of the output in the required colors.
+func contribution(output ledgerstate.Output, required map[ledgerstate.Color]uint64) (amount uint64) {
+	output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+		if _, isRequired := required[color]; isRequired {
+			amount += balance
+		}
+		return true
+	})
+
+	return amount
+}
+
+// sortByContribution sorts the outputs by their contribution and their ID, so that the order is deterministic.
+func sortByContribution(outputs ledgerstate.Outputs, required map[ledgerstate.Color]uint64, less func(a, b uint64) bool) {
+	sort.SliceStable(outputs, func(i, j int) bool {
+		contributionI, contributionJ := contribution(outputs[i], required), contribution(outputs[j], required)
+		if contributionI == contributionJ {
+			return bytes.Compare(outputs[i].ID().Bytes(), outputs[j].ID().Bytes()) < 0
+		}
+		return less(contributionI, contributionJ)
+	})
+}
+
+// selectInOrder selects the outputs in the given order until the required balances are collected.
+func selectInOrder(ordered ledgerstate.Outputs, required map[ledgerstate.Color]uint64, maxInputs int) (selected ledgerstate.Outputs, err error) {
+	collected := make(map[ledgerstate.Color]uint64)
+	for _, output := range ordered {
+		if enough(collected, required) {
+			break
+		}
+		selected = append(selected, output)
+		addBalances(collected, output)
+	}
+
+	if !enough(collected, required) {
+		return nil, errors.Errorf("failed to collect %s, there are only %s available: %w",
+			ledgerstate.NewColoredBalances(required), ledgerstate.NewColoredBalances(collected), ErrInsufficientFunds)
+	}
+	if len(selected) > maxInputs {
+		return selected, errors.Errorf("the transfer needs %d inputs, but only %d are allowed: %w", len(selected), maxInputs, ErrTooManyInputs)
+	}
+
+	return selected, nil
+}
+
+// enough returns true if the collected balances cover the required balances.
+func enough(collected, required map[ledgerstate.Color]uint64) bool {
+	for color, balance := range required {
+		if collected[color] < balance {
+			return false
+		}
+	}
+
+	return true
+}
+
+// changeOf returns the amount of the collected balances that exceeds the required balances.
+func changeOf(collected, required map[ledgerstate.Color]uint64) (change uint64) {
+	for color, balance := range collected {
+		change += balance - required[color]
+	}
+
+	return change
+}
+
+func copyBalances(balances map[ledgerstate.Color]uint64) map[ledgerstate.Color]uint64 {
+	copied := make(map[ledgerstate.Color]uint64, len(balances))
+	for color, balance := range balances {
+		copied[color] = balance
+	}
+
+	return copied
+}
+
+func addBalances(balances map[ledgerstate.Color]uint64, output ledgerstate.Output) {
+	output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+		balances[color] += balance
+		return true
+	})
+}
+
+func subtractBalances(balances map[ledgerstate.Color]uint64, output ledgerstate.Output) {
+	output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+		balances[color] -= balance
+		return true
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
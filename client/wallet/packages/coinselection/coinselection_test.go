@@ -0,0 +1,239 @@
+package coinselection
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+var colorA = ledgerstate.Color{1}
+
+func TestStrategies(t *testing.T) {
+	// the outputs are referenced by their index in the candidates of a test case
+	small := []map[ledgerstate.Color]uint64{
+		{ledgerstate.ColorIOTA: 30},
+		{ledgerstate.ColorIOTA: 50},
+		{ledgerstate.ColorIOTA: 20},
+		{colorA: 100},
+	}
+
+	testCases := []struct {
+		name       string
+		strategy   Strategy
+		candidates []map[ledgerstate.Color]uint64
+		required   map[ledgerstate.Color]uint64
+		maxInputs  int
+		expected   []int
+		err        error
+	}{
+		{
+			name:       "input order keeps the order of the candidates",
+			strategy:   InputOrder,
+			candidates: small,
+			required:   map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 60},
+			maxInputs:  10,
+			expected:   []int{0, 1},
+		},
+		{
+			name:       "largest first",
+			strategy:   LargestFirst,
+			candidates: small,
+			required:   map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 60},
+			maxInputs:  10,
+			expected:   []int{1, 0},
+		},
+		{
+			name:       "smallest first",
+			strategy:   SmallestFirst,
+			candidates: small,
+			required:   map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 60},
+			maxInputs:  10,
+			expected:   []int{2, 0, 1},
+		},
+		{
+			name:       "minimize change finds an exact match",
+			strategy:   MinimizeChange,
+			candidates: small,
+			required:   map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 70},
+			maxInputs:  10,
+			expected:   []int{1, 2},
+		},
+		{
+			name:     "minimize change prefers an exact match over fewer inputs",
+			strategy: MinimizeChange,
+			candidates: []map[ledgerstate.Color]uint64{
+				{ledgerstate.ColorIOTA: 90},
+				{ledgerstate.ColorIOTA: 60},
+				{ledgerstate.ColorIOTA: 40},
+			},
+			required:  map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 100},
+			maxInputs: 10,
+			expected:  []int{1, 2},
+		},
+		{
+			name:     "minimize change prefers fewer inputs for the same change",
+			strategy: MinimizeChange,
+			candidates: []map[ledgerstate.Color]uint64{
+				{ledgerstate.ColorIOTA: 25},
+				{ledgerstate.ColorIOTA: 25},
+				{ledgerstate.ColorIOTA: 50},
+			},
+			required:  map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 50},
+			maxInputs: 10,
+			expected:  []int{2},
+		},
+		{
+			name:     "color aware prefers outputs without foreign colors",
+			strategy: ColorAware,
+			candidates: []map[ledgerstate.Color]uint64{
+				{ledgerstate.ColorIOTA: 100, colorA: 5},
+				{ledgerstate.ColorIOTA: 40},
+				{ledgerstate.ColorIOTA: 50},
+			},
+			required:  map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 60},
+			maxInputs: 10,
+			expected:  []int{2, 1},
+		},
+		{
+			name:     "color aware falls back to outputs with foreign colors",
+			strategy: ColorAware,
+			candidates: []map[ledgerstate.Color]uint64{
+				{ledgerstate.ColorIOTA: 100, colorA: 5},
+				{ledgerstate.ColorIOTA: 40},
+				{ledgerstate.ColorIOTA: 50},
+			},
+			required:  map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 120},
+			maxInputs: 10,
+			expected:  []int{2, 1, 0},
+		},
+		{
+			name:       "colored transfer only selects outputs holding the color",
+			strategy:   LargestFirst,
+			candidates: small,
+			required:   map[ledgerstate.Color]uint64{colorA: 10},
+			maxInputs:  10,
+			expected:   []int{3},
+		},
+		{
+			name:       "too many inputs returns the selection",
+			strategy:   LargestFirst,
+			candidates: small,
+			required:   map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 60},
+			maxInputs:  1,
+			expected:   []int{1, 0},
+			err:        ErrTooManyInputs,
+		},
+		{
+			name:       "minimize change falls back to largest first if no combination is small enough",
+			strategy:   MinimizeChange,
+			candidates: small,
+			required:   map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 60},
+			maxInputs:  1,
+			expected:   []int{1, 0},
+			err:        ErrTooManyInputs,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			candidates := newOutputs(testCase.candidates...)
+			selected, err := testCase.strategy.Select(candidates, testCase.required, testCase.maxInputs)
+			if testCase.err != nil {
+				assert.True(t, errors.Is(err, testCase.err), "unexpected error %v", err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			expected := make(ledgerstate.Outputs, len(testCase.expected))
+			for i, index := range testCase.expected {
+				expected[i] = candidates[index]
+			}
+			assert.Equal(t, expected, selected)
+		})
+	}
+}
+
+func TestMinimizeChange_ExactMatchAmongManyCandidates(t *testing.T) {
+	balances := []map[ledgerstate.Color]uint64{{ledgerstate.ColorIOTA: 3}}
+	for i := 0; i < 200; i++ {
+		balances = append(balances, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 7})
+	}
+	candidates := newOutputs(balances...)
+
+	// the search stops at the first combination without change
+	selected, err := MinimizeChange.Select(candidates, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 38}, 10)
+	require.NoError(t, err)
+	collected := make(map[ledgerstate.Color]uint64)
+	for _, output := range selected {
+		addBalances(collected, output)
+	}
+	assert.Equal(t, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 38}, collected)
+	assert.Len(t, selected, 6)
+}
+
+func TestStrategies_InsufficientFunds(t *testing.T) {
+	candidates := newOutputs(
+		map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 30},
+		map[ledgerstate.Color]uint64{colorA: 100},
+	)
+
+	for _, name := range Names() {
+		strategy, err := FromString(name)
+		require.NoError(t, err)
+
+		_, err = strategy.Select(candidates, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 31}, 10)
+		assert.True(t, errors.Is(err, ErrInsufficientFunds), "strategy %s", name)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	candidates := newOutputs(
+		map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 30},
+		map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 50},
+		map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 20},
+		map[ledgerstate.Color]uint64{colorA: 100},
+	)
+
+	// the whole balance can only be collected by selecting every output that holds IOTA
+	selected, err := Random.Select(candidates, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 100}, 10)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, candidates[:3], selected)
+
+	selected, err = Random.Select(candidates, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 10}, 10)
+	require.NoError(t, err)
+	assert.Len(t, selected, 1)
+	assert.Contains(t, candidates[:3], selected[0])
+}
+
+func TestFromString(t *testing.T) {
+	for name, strategy := range Strategies {
+		parsed, err := FromString(name)
+		require.NoError(t, err)
+		assert.Equal(t, strategy, parsed)
+	}
+
+	parsed, err := FromString("Largest-First")
+	require.NoError(t, err)
+	assert.Equal(t, LargestFirst, parsed)
+
+	_, err = FromString("unknown")
+	assert.Error(t, err)
+	assert.Len(t, Names(), 6)
+}
+
+// newOutputs creates outputs with the given balances and distinct IDs.
+func newOutputs(balances ...map[ledgerstate.Color]uint64) (outputs ledgerstate.Outputs) {
+	address := ledgerstate.NewED25519Address(ed25519.PublicKey{})
+	for i, balance := range balances {
+		output := ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(balance), address)
+		output.SetID(ledgerstate.NewOutputID(ledgerstate.TransactionID{1, byte(i / ledgerstate.MaxOutputCount)}, uint16(i%ledgerstate.MaxOutputCount)))
+		outputs = append(outputs, output)
+	}
+
+	return outputs
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/cockroachdb/errors"
 
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/coinselection"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/constants"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
@@ -124,6 +125,15 @@ func Fallback(addy ledgerstate.Address, deadline time.Time) SendFundsOption {
 	}
 }
 
+// CoinSelection is an option for SendFunds call that defines the strategy that selects the outputs that fund the
+// transfer, instead of the strategy of the wallet.
+func CoinSelection(strategy coinselection.Strategy) SendFundsOption {
+	return func(options *SendFundsOptions) error {
+		options.CoinSelection = strategy
+		return nil
+	}
+}
+
 // SendFundsOptions is a struct that is used to aggregate the optional parameters provided in the SendFunds call.
 type SendFundsOptions struct {
 	Destinations          map[address.Address]map[ledgerstate.Color]uint64
@@ -134,6 +144,7 @@ type SendFundsOptions struct {
 	AccessManaPledgeID    string
 	ConsensusManaPledgeID string
 	WaitForConfirmation   bool
+	CoinSelection         coinselection.Strategy
 }
 
 // RequiredFunds derives how much funds are needed based on the Destinations to fund the transfer.
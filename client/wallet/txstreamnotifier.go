@@ -0,0 +1,213 @@
+package wallet
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/txstream"
+	txstreamclient "github.com/iotaledger/goshimmer/packages/txstream/client"
+)
+
+const (
+	// txStreamClientID is the id that the wallet uses to identify itself at the txstream server of the node.
+	txStreamClientID = "wallet"
+	// txStreamDialTimeout is the timeout for connecting to the txstream server of the node.
+	txStreamDialTimeout = 5 * time.Second
+	// txStreamRefreshInterval is the interval in which the inclusion states of the watched transactions are requested
+	// again. The node only pushes the inclusion states of transactions that have outputs on the subscribed addresses
+	// and ignores requests for transactions that it has not booked yet.
+	txStreamRefreshInterval = 2 * time.Second
+)
+
+// txStreamRequestAddress is the address that is sent along with the inclusion state requests. The node only echoes it
+// back, so it does not need to belong to the wallet.
+var txStreamRequestAddress = ledgerstate.NewED25519Address(ed25519.PublicKey{})
+
+// TxStreamNotifier is a Notifier that receives the notifications from the txstream server of a node. It reconnects
+// automatically and restores its subscriptions when the connection to the node is lost.
+type TxStreamNotifier struct {
+	client       *txstreamclient.Client
+	events       *NotifierEvents
+	addresses    map[[ledgerstate.AddressLength]byte]address.Address
+	transactions map[ledgerstate.TransactionID]*ledgerstate.InclusionState
+	mutex        sync.RWMutex
+	shutdown     chan struct{}
+	closeOnce    sync.Once
+}
+
+// NewTxStreamNotifier connects to the txstream server at the given address (host:port) of a node.
+func NewTxStreamNotifier(txStreamAddress string) *TxStreamNotifier {
+	return newTxStreamNotifier(func() (string, net.Conn, error) {
+		conn, err := net.DialTimeout("tcp", txStreamAddress, txStreamDialTimeout)
+		return txStreamAddress, conn, err
+	}, txStreamRefreshInterval)
+}
+
+// newTxStreamNotifier creates a TxStreamNotifier that connects with the given dial function and requests the inclusion
+// states of the watched transactions again in the given interval.
+func newTxStreamNotifier(dial txstreamclient.DialFunc, refreshInterval time.Duration) *TxStreamNotifier {
+	notifier := &TxStreamNotifier{
+		events:       NewNotifierEvents(),
+		addresses:    make(map[[ledgerstate.AddressLength]byte]address.Address),
+		transactions: make(map[ledgerstate.TransactionID]*ledgerstate.InclusionState),
+		shutdown:     make(chan struct{}),
+	}
+
+	notifier.client = txstreamclient.New(txStreamClientID, logger.NewNopLogger(), dial)
+	notifier.client.Events.TransactionReceived.Attach(events.NewClosure(notifier.onTransactionReceived))
+	notifier.client.Events.InclusionStateReceived.Attach(events.NewClosure(notifier.onInclusionStateReceived))
+	notifier.client.Events.Connected.Attach(events.NewClosure(notifier.onConnected))
+
+	go notifier.refreshLoop(refreshInterval)
+
+	return notifier
+}
+
+// SubscribeAddresses subscribes to the confirmed outputs on the given addresses. The node sends the unspent outputs
+// that already exist on an address when it is subscribed for the first time.
+func (notifier *TxStreamNotifier) SubscribeAddresses(addresses ...address.Address) {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	for _, addr := range addresses {
+		if _, exists := notifier.addresses[addr.AddressBytes]; exists {
+			continue
+		}
+		notifier.addresses[addr.AddressBytes] = addr
+
+		// the client blocks while it is not connected, so we never call it from the caller's goroutine
+		go notifier.client.Subscribe(addr.Address())
+	}
+}
+
+// WatchTransaction subscribes to the inclusion state changes of the given transaction. The current inclusion state is
+// reported as the first change.
+func (notifier *TxStreamNotifier) WatchTransaction(txID ledgerstate.TransactionID) {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	if _, exists := notifier.transactions[txID]; exists {
+		return
+	}
+	// the inclusion state is unknown until the node reports it
+	notifier.transactions[txID] = nil
+
+	go notifier.client.RequestTxInclusionState(txStreamRequestAddress, txID)
+}
+
+// UnwatchTransaction stops watching the inclusion state of the given transaction.
+func (notifier *TxStreamNotifier) UnwatchTransaction(txID ledgerstate.TransactionID) {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	delete(notifier.transactions, txID)
+}
+
+// Events returns the events that are triggered by the notifications.
+func (notifier *TxStreamNotifier) Events() *NotifierEvents {
+	return notifier.events
+}
+
+// Close closes the connection to the node.
+func (notifier *TxStreamNotifier) Close() {
+	notifier.closeOnce.Do(func() {
+		close(notifier.shutdown)
+		notifier.client.Close()
+	})
+}
+
+// onTransactionReceived reports the outputs of a confirmed transaction on the subscribed addresses.
+func (notifier *TxStreamNotifier) onTransactionReceived(msg *txstream.MsgTransaction) {
+	notifier.updateInclusionState(msg.Tx.ID(), ledgerstate.Confirmed)
+
+	notifier.mutex.RLock()
+	addr, subscribed := notifier.addresses[msg.Address.Array()]
+	notifier.mutex.RUnlock()
+	if !subscribed {
+		return
+	}
+
+	for _, output := range msg.Tx.Essence().Outputs() {
+		if output.Address().Array() != addr.AddressBytes {
+			continue
+		}
+
+		notifier.events.OutputReceived.Trigger(&Output{
+			Address: addr,
+			Object:  output.UpdateMintingColor(),
+			InclusionState: InclusionState{
+				Liked:     true,
+				Confirmed: true,
+			},
+			Metadata: OutputMetadata{
+				Timestamp: msg.Tx.Essence().Timestamp(),
+			},
+		})
+	}
+}
+
+func (notifier *TxStreamNotifier) onInclusionStateReceived(msg *txstream.MsgTxInclusionState) {
+	notifier.updateInclusionState(msg.TxID, msg.State)
+}
+
+// onConnected reports the reconnect and requests the inclusion states that might have changed while the notifier was
+// not connected. The client restores the subscriptions of the addresses by itself.
+func (notifier *TxStreamNotifier) onConnected() {
+	go func() {
+		notifier.events.Connected.Trigger()
+		notifier.requestInclusionStates()
+	}()
+}
+
+// updateInclusionState triggers the InclusionStateChanged event if the inclusion state of a watched transaction has
+// changed. Transactions that are confirmed or rejected are not watched anymore.
+func (notifier *TxStreamNotifier) updateInclusionState(txID ledgerstate.TransactionID, state ledgerstate.InclusionState) {
+	notifier.mutex.Lock()
+	previousState, watched := notifier.transactions[txID]
+	if !watched || (previousState != nil && *previousState == state) {
+		notifier.mutex.Unlock()
+		return
+	}
+	if state == ledgerstate.Pending {
+		notifier.transactions[txID] = &state
+	} else {
+		delete(notifier.transactions, txID)
+	}
+	notifier.mutex.Unlock()
+
+	notifier.events.InclusionStateChanged.Trigger(txID, state)
+}
+
+func (notifier *TxStreamNotifier) refreshLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-notifier.shutdown:
+			return
+		case <-ticker.C:
+			notifier.requestInclusionStates()
+		}
+	}
+}
+
+func (notifier *TxStreamNotifier) requestInclusionStates() {
+	notifier.mutex.RLock()
+	txIDs := make([]ledgerstate.TransactionID, 0, len(notifier.transactions))
+	for txID := range notifier.transactions {
+		txIDs = append(txIDs, txID)
+	}
+	notifier.mutex.RUnlock()
+
+	for _, txID := range txIDs {
+		notifier.client.RequestTxInclusionState(txStreamRequestAddress, txID)
+	}
+}
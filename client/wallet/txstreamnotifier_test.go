@@ -0,0 +1,131 @@
+package wallet
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate/utxoutil"
+	"github.com/iotaledger/goshimmer/packages/txstream/server"
+	"github.com/iotaledger/goshimmer/packages/txstream/utxodbledger"
+)
+
+// inclusionStateChange is an InclusionStateChanged event of a Notifier.
+type inclusionStateChange struct {
+	txID  ledgerstate.TransactionID
+	state ledgerstate.InclusionState
+}
+
+// startTxStreamNotifier returns a TxStreamNotifier that is connected to a txstream server of the returned ledger.
+func startTxStreamNotifier(t *testing.T, refreshInterval time.Duration) (*utxodbledger.UtxoDBLedger, *TxStreamNotifier) {
+	ledger := utxodbledger.New(logger.NewNopLogger())
+
+	done := make(chan struct{})
+	t.Cleanup(func() { close(done) })
+
+	notifier := newTxStreamNotifier(func() (string, net.Conn, error) {
+		conn1, conn2 := net.Pipe()
+		go server.Run(conn2, logger.NewNopLogger(), ledger, done)
+		return "pipe", conn1, nil
+	}, refreshInterval)
+	t.Cleanup(notifier.Close)
+
+	return ledger, notifier
+}
+
+// recordInclusionStateChanges returns a channel that receives the InclusionStateChanged events of the notifier.
+func recordInclusionStateChanges(t *testing.T, notifier Notifier) chan inclusionStateChange {
+	changes := make(chan inclusionStateChange, 10)
+	closure := events.NewClosure(func(txID ledgerstate.TransactionID, state ledgerstate.InclusionState) {
+		changes <- inclusionStateChange{txID: txID, state: state}
+	})
+	notifier.Events().InclusionStateChanged.Attach(closure)
+	t.Cleanup(func() { notifier.Events().InclusionStateChanged.Detach(closure) })
+
+	return changes
+}
+
+func TestTxStreamNotifier_UpdateInclusionState(t *testing.T) {
+	_, notifier := startTxStreamNotifier(t, time.Hour)
+	changes := recordInclusionStateChanges(t, notifier)
+
+	// the ledger does not know the transactions, so the node does not answer the inclusion state requests
+	watchedTxID := ledgerstate.TransactionID{1}
+	notifier.WatchTransaction(watchedTxID)
+
+	// only changes of the inclusion states of watched transactions are reported
+	notifier.updateInclusionState(watchedTxID, ledgerstate.Pending)
+	notifier.updateInclusionState(watchedTxID, ledgerstate.Pending)
+	notifier.updateInclusionState(ledgerstate.TransactionID{2}, ledgerstate.Confirmed)
+	notifier.updateInclusionState(watchedTxID, ledgerstate.Confirmed)
+
+	// the transaction is not watched anymore once it is confirmed
+	notifier.updateInclusionState(watchedTxID, ledgerstate.Confirmed)
+	notifier.updateInclusionState(watchedTxID, ledgerstate.Rejected)
+
+	require.Len(t, changes, 2)
+	assert.Equal(t, inclusionStateChange{txID: watchedTxID, state: ledgerstate.Pending}, <-changes)
+	assert.Equal(t, inclusionStateChange{txID: watchedTxID, state: ledgerstate.Confirmed}, <-changes)
+
+	// an unwatched transaction is not reported either
+	rejectedTxID := ledgerstate.TransactionID{3}
+	notifier.WatchTransaction(rejectedTxID)
+	notifier.UnwatchTransaction(rejectedTxID)
+	notifier.updateInclusionState(rejectedTxID, ledgerstate.Rejected)
+	assert.Empty(t, changes)
+}
+
+func TestTxStreamNotifier_RefreshLoop(t *testing.T) {
+	ledger, notifier := startTxStreamNotifier(t, 10*time.Millisecond)
+	changes := recordInclusionStateChanges(t, notifier)
+
+	keyPair, addr := ledger.NewKeyPairByIndex(1)
+	require.NoError(t, ledger.RequestFunds(addr))
+	_, targetAddress := ledger.NewKeyPairByIndex(2)
+	builder := utxoutil.NewBuilder(ledger.GetAddressOutputs(addr)...)
+	require.NoError(t, builder.AddSigLockedIOTAOutput(targetAddress, 100))
+	require.NoError(t, builder.AddRemainderOutputIfNeeded(addr, nil))
+	tx, err := builder.BuildWithED25519(keyPair)
+	require.NoError(t, err)
+
+	// the node ignores the first request, since it does not know the transaction yet
+	notifier.WatchTransaction(tx.ID())
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, changes)
+
+	// no address of the transaction is subscribed, so only the refresh loop learns about the confirmation
+	require.NoError(t, ledger.PostTransaction(tx))
+	select {
+	case change := <-changes:
+		assert.Equal(t, inclusionStateChange{txID: tx.ID(), state: ledgerstate.Confirmed}, change)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the inclusion state was not requested again")
+	}
+}
+
+func TestTxStreamNotifier_OutputReceived(t *testing.T) {
+	ledger, notifier := startTxStreamNotifier(t, time.Hour)
+
+	outputs := make(chan *Output, 10)
+	notifier.Events().OutputReceived.Attach(events.NewClosure(func(output *Output) { outputs <- output }))
+
+	walletAddress := seed.NewSeed().Address(0)
+	notifier.SubscribeAddresses(walletAddress)
+	require.NoError(t, ledger.RequestFunds(walletAddress.Address()))
+
+	select {
+	case output := <-outputs:
+		assert.Equal(t, walletAddress, output.Address)
+		assert.True(t, output.InclusionState.Confirmed)
+		assert.Equal(t, walletAddress.Address().Array(), output.Object.Address().Array())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the output was not reported")
+	}
+}
@@ -0,0 +1,163 @@
+package wallet
+
+import (
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// UnsignedTransactionVersion is the version of the marshaled format of an UnsignedTransaction.
+const UnsignedTransactionVersion byte = 1
+
+// UnsignedTransaction is a transaction that was prepared by a wallet, but not signed yet. Besides the essence, it
+// contains the outputs consumed by the transaction together with the wallet addresses that own them, so that it can be
+// verified and signed by a wallet that holds the seed but has no connection to the network.
+type UnsignedTransaction struct {
+	// Essence is the essence of the transaction that needs to be signed.
+	Essence *ledgerstate.TransactionEssence
+	// ConsumedOutputs are the outputs referenced by the inputs of the essence, in the same order.
+	ConsumedOutputs []*ConsumedOutput
+}
+
+// ConsumedOutput is an output that is consumed by an UnsignedTransaction.
+type ConsumedOutput struct {
+	// Address is the wallet address that needs to sign for the output.
+	Address address.Address
+	// Output is the consumed output.
+	Output ledgerstate.Output
+}
+
+// NewUnsignedTransaction creates an UnsignedTransaction of the given essence consuming the given outputs.
+func NewUnsignedTransaction(essence *ledgerstate.TransactionEssence, consumedOutputsByID OutputsByID) (unsignedTransaction *UnsignedTransaction, err error) {
+	unsignedTransaction = &UnsignedTransaction{
+		Essence:         essence,
+		ConsumedOutputs: make([]*ConsumedOutput, len(essence.Inputs())),
+	}
+	for i, input := range essence.Inputs() {
+		output, exists := consumedOutputsByID[input.(*ledgerstate.UTXOInput).ReferencedOutputID()]
+		if !exists {
+			return nil, errors.Errorf("consumed output of input %d is missing", i)
+		}
+		unsignedTransaction.ConsumedOutputs[i] = &ConsumedOutput{Address: output.Address, Output: output.Object}
+	}
+
+	return unsignedTransaction, nil
+}
+
+// UnsignedTransactionFromBytes unmarshals an UnsignedTransaction from a sequence of bytes.
+func UnsignedTransactionFromBytes(bytes []byte) (unsignedTransaction *UnsignedTransaction, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	version, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse version: %w", err)
+	}
+	if version != UnsignedTransactionVersion {
+		return nil, errors.Errorf("unsupported unsigned transaction version %d", version)
+	}
+
+	unsignedTransaction = &UnsignedTransaction{}
+	if unsignedTransaction.Essence, err = ledgerstate.TransactionEssenceFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse transaction essence: %w", err)
+	}
+	unsignedTransaction.ConsumedOutputs = make([]*ConsumedOutput, len(unsignedTransaction.Essence.Inputs()))
+	for i := range unsignedTransaction.ConsumedOutputs {
+		consumedOutput := &ConsumedOutput{}
+		if consumedOutput.Address.Index, err = marshalUtil.ReadUint64(); err != nil {
+			return nil, errors.Errorf("failed to parse address index of consumed output %d: %w", i, err)
+		}
+		ledgerstateAddress, err := ledgerstate.AddressFromMarshalUtil(marshalUtil)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse address of consumed output %d: %w", i, err)
+		}
+		consumedOutput.Address.AddressBytes = ledgerstateAddress.Array()
+		outputID, err := ledgerstate.OutputIDFromMarshalUtil(marshalUtil)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse ID of consumed output %d: %w", i, err)
+		}
+		if consumedOutput.Output, err = ledgerstate.OutputFromMarshalUtil(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse consumed output %d: %w", i, err)
+		}
+		consumedOutput.Output.SetID(outputID)
+		unsignedTransaction.ConsumedOutputs[i] = consumedOutput
+	}
+	if marshalUtil.ReadOffset() != len(bytes) {
+		return nil, errors.New("unsigned transaction contains unexpected trailing bytes")
+	}
+
+	if err = unsignedTransaction.Verify(); err != nil {
+		return nil, err
+	}
+
+	return unsignedTransaction, nil
+}
+
+// Verify checks that the consumed outputs match the inputs of the essence and that the transaction does not create or
+// destroy funds.
+func (u *UnsignedTransaction) Verify() error {
+	inputs := u.Essence.Inputs()
+	if len(inputs) != len(u.ConsumedOutputs) {
+		return errors.Errorf("the transaction has %d inputs but %d consumed outputs", len(inputs), len(u.ConsumedOutputs))
+	}
+	for i, input := range inputs {
+		utxoInput, ok := input.(*ledgerstate.UTXOInput)
+		if !ok {
+			return errors.Errorf("input %d is not a UTXO input", i)
+		}
+		if utxoInput.ReferencedOutputID() != u.ConsumedOutputs[i].Output.ID() {
+			return errors.Errorf("consumed output %d does not match input %s", i, utxoInput.ReferencedOutputID().Base58())
+		}
+	}
+	if !ledgerstate.TransactionBalancesValid(u.Outputs(), u.Essence.Outputs()) {
+		return errors.New("the balances of the consumed and created outputs do not match")
+	}
+
+	return nil
+}
+
+// Outputs returns the consumed outputs in the order of the inputs.
+func (u *UnsignedTransaction) Outputs() (outputs ledgerstate.Outputs) {
+	outputs = make(ledgerstate.Outputs, len(u.ConsumedOutputs))
+	for i, consumedOutput := range u.ConsumedOutputs {
+		outputs[i] = consumedOutput.Output
+	}
+
+	return outputs
+}
+
+// Bytes returns a marshaled version of the UnsignedTransaction.
+func (u *UnsignedTransaction) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		WriteByte(UnsignedTransactionVersion).
+		Write(u.Essence)
+	for _, consumedOutput := range u.ConsumedOutputs {
+		marshalUtil.
+			WriteUint64(consumedOutput.Address.Index).
+			WriteBytes(consumedOutput.Address.AddressBytes[:]).
+			Write(consumedOutput.Output.ID()).
+			Write(consumedOutput.Output)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// String returns a human readable version of the UnsignedTransaction.
+func (u *UnsignedTransaction) String() string {
+	return stringify.Struct("UnsignedTransaction",
+		stringify.StructField("Essence", u.Essence),
+		stringify.StructField("ConsumedOutputs", u.ConsumedOutputs),
+	)
+}
+
+// String returns a human readable version of the ConsumedOutput.
+func (c *ConsumedOutput) String() string {
+	return stringify.Struct("ConsumedOutput",
+		stringify.StructField("Address", c.Address.Base58()),
+		stringify.StructField("AddressIndex", strconv.FormatUint(c.Address.Index, 10)),
+		stringify.StructField("Output", c.Output),
+	)
+}
@@ -1,18 +1,23 @@
 package wallet
 
 import (
+	"bytes"
 	"reflect"
+	"sync"
 	"time"
 	"unsafe"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/bitmask"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/marshalutil"
 	"golang.org/x/crypto/blake2b"
 
+	"github.com/iotaledger/goshimmer/client"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/claimconditionaloptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/coinselection"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/consolidateoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/createnftoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/delegateoptions"
@@ -25,6 +30,7 @@ import (
 	"github.com/iotaledger/goshimmer/client/wallet/packages/sweepnftownedoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/transfernftoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/withdrawfromnftoptions"
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
 )
@@ -44,16 +50,46 @@ const (
 // ErrTooManyOutputs is an error returned when the number of outputs/inputs exceeds the protocol wide constant
 var ErrTooManyOutputs = errors.New("number of outputs is more, than supported for a single transaction")
 
+// ErrWatchOnly is an error returned when an operation needs the seed of the wallet, but the wallet is watch-only.
+var ErrWatchOnly = errors.New("the wallet is watch-only")
+
+// ErrAssetNotRegistered is an error returned when an asset was created, but its entry could not be stored in the asset
+// registry.
+var ErrAssetNotRegistered = errors.New("the asset was not registered")
+
+// ErrOffline is an error returned when an operation needs a connection to a node, but the wallet is offline.
+var ErrOffline = errors.New("the wallet is offline")
+
+// ErrNoHistoryConnector is an error returned when an operation needs to look up past transactions, but the connector of
+// the wallet does not implement HistoryConnector.
+var ErrNoHistoryConnector = errors.New("the connector does not provide the transaction history")
+
+// watchOnlyStatePrefix marks the exported state of a watch-only wallet.
+var watchOnlyStatePrefix = []byte("WATCH-ONLY-WALLET")
+
 // Wallet is a wallet that can handle aliases and extendedlockedoutputs.
 type Wallet struct {
 	addressManager *AddressManager
 	assetRegistry  *AssetRegistry
-	outputManager  *OutputManager
-	connector      Connector
+	// if set, the asset registry uses the registry server at this url.
+	assetRegistryURL string
+	history          *History
+	outputManager    *OutputManager
+	connector        Connector
+	coinSelection    coinselection.Strategy
+	// if a policy is set, ConsolidateDust consolidates the dust outputs of the wallet according to it.
+	dustConsolidationPolicy *DustConsolidationPolicy
+	// dustConsolidationMutex keeps the background dust consolidation from running alongside the other calls.
+	dustConsolidationMutex *sync.Mutex
 
 	faucetPowDifficulty int
 	// if this option is enabled the wallet will use a single reusable address instead of changing addresses.
-	reusableAddress          bool
+	reusableAddress bool
+	// if this option is enabled the wallet does not connect to a node and can only sign transactions.
+	offline bool
+	// if a notifier is set, the wallet waits for its notifications instead of polling the node for confirmations.
+	notifier                 Notifier
+	events                   *NotifierEvents
 	ConfirmationPollInterval int // in milliseconds
 	ConfirmationTimeout      int // in ms
 }
@@ -62,7 +98,10 @@ type Wallet struct {
 // in as an optional parameter.
 func New(options ...Option) (wallet *Wallet) {
 	// create wallet
-	wallet = &Wallet{}
+	wallet = &Wallet{
+		dustConsolidationMutex: &sync.Mutex{},
+		events:                 NewNotifierEvents(),
+	}
 
 	// configure wallet
 	for _, option := range options {
@@ -86,19 +125,57 @@ func New(options ...Option) (wallet *Wallet) {
 	if wallet.assetRegistry == nil {
 		wallet.assetRegistry = NewAssetRegistry(DefaultAssetRegistryNetwork)
 	}
+	if wallet.assetRegistryURL != "" {
+		wallet.assetRegistry.SetRegistryURL(wallet.assetRegistryURL)
+	}
+
+	// select the outputs in the order of the addresses if no coin selection strategy was provided in the options.
+	if wallet.coinSelection == nil {
+		wallet.coinSelection = coinselection.InputOrder
+	}
+
+	// initialize an empty history if none was provided in the options.
+	if wallet.history == nil {
+		wallet.history = NewHistory()
+	}
 
 	// initialize wallet with default connector (server) if none was provided
-	if wallet.connector == nil {
+	if wallet.connector == nil && !wallet.offline {
 		panic("you need to provide a connector for your wallet")
 	}
 
+	// the asset registry verifies the entries of the registry server against the minting transactions
+	wallet.assetRegistry.connector = wallet.connector
+
 	// initialize output manager
 	wallet.outputManager = NewUnspentOutputManager(wallet.addressManager, wallet.connector)
+	if wallet.offline {
+		return
+	}
 	err := wallet.outputManager.Refresh(true)
 	if err != nil {
 		panic(err)
 	}
 
+	// use the notifications of the connector if it can push them and no other notifier was provided
+	if wallet.notifier == nil {
+		if notifier, ok := wallet.connector.(Notifier); ok {
+			wallet.notifier = notifier
+		}
+	}
+	if wallet.notifier != nil {
+		wallet.notifier.Events().OutputReceived.Attach(events.NewClosure(func(output *Output) {
+			wallet.events.OutputReceived.Trigger(output)
+		}))
+		wallet.notifier.Events().InclusionStateChanged.Attach(events.NewClosure(func(txID ledgerstate.TransactionID, state ledgerstate.InclusionState) {
+			wallet.events.InclusionStateChanged.Trigger(txID, state)
+		}))
+		wallet.notifier.Events().Connected.Attach(events.NewClosure(func() {
+			wallet.events.Connected.Trigger()
+		}))
+		wallet.subscribeAddresses()
+	}
+
 	return
 }
 
@@ -113,10 +190,45 @@ func (wallet *Wallet) SendFunds(options ...sendoptions.SendFundsOption) (tx *led
 		return
 	}
 
+	unsignedTx, err := wallet.prepareSendFunds(sendOptions)
+	if err != nil {
+		return
+	}
+	if tx, err = wallet.SignTransaction(unsignedTx); err != nil {
+		return nil, err
+	}
+	if err = wallet.SubmitTransaction(tx, sendOptions.WaitForConfirmation); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region PrepareSendFunds /////////////////////////////////////////////////////////////////////////////////////////////
+
+// PrepareSendFunds prepares the transaction of SendFunds without signing it, so that it can be signed by a wallet that
+// holds the seed. The consumed outputs are only marked as spent once the signed transaction is submitted with
+// SubmitTransaction.
+func (wallet *Wallet) PrepareSendFunds(options ...sendoptions.SendFundsOption) (unsignedTx *UnsignedTransaction, err error) {
+	sendOptions, err := sendoptions.Build(options...)
+	if err != nil {
+		return
+	}
+
+	return wallet.prepareSendFunds(sendOptions)
+}
+
+func (wallet *Wallet) prepareSendFunds(sendOptions *sendoptions.SendFundsOptions) (unsignedTx *UnsignedTransaction, err error) {
+	if wallet.offline {
+		return nil, errors.Errorf("failed to prepare transaction: %w", ErrOffline)
+	}
+
 	// how much funds will we need to fund this transfer?
 	requiredFunds := sendOptions.RequiredFunds()
 	// collect that many outputs for funding
-	consumedOutputs, err := wallet.collectOutputsForFunding(requiredFunds)
+	consumedOutputs, err := wallet.collectOutputsForFunding(requiredFunds, sendOptions.CoinSelection)
 	if err != nil {
 		if errors.Is(err, ErrTooManyOutputs) {
 			err = errors.Errorf("consolidate funds and try again: %w", err)
@@ -134,15 +246,48 @@ func (wallet *Wallet) SendFunds(options ...sendoptions.SendFundsOption) (tx *led
 	inputs := wallet.buildInputs(consumedOutputs)
 	// aggregate all the funds we consume from inputs
 	totalConsumedFunds := consumedOutputs.TotalFundsInOutputs()
-	remainderAddress := wallet.chooseRemainderAddress(consumedOutputs, sendOptions.RemainderAddress)
+	remainderAddress, err := wallet.chooseRemainderAddress(consumedOutputs, sendOptions.RemainderAddress)
+	if err != nil {
+		return nil, err
+	}
 	outputs := wallet.buildOutputs(sendOptions, totalConsumedFunds, remainderAddress)
 
 	txEssence := ledgerstate.NewTransactionEssence(0, time.Now(), aPledgeID, cPledgeID, inputs, outputs)
-	outputsByID := consumedOutputs.OutputsByID()
+	if unsignedTx, err = NewUnsignedTransaction(txEssence, consumedOutputs.OutputsByID()); err != nil {
+		return nil, err
+	}
+	if err = unsignedTx.Verify(); err != nil {
+		return nil, errors.Errorf("prepared transaction is invalid: %w", err)
+	}
 
-	unlockBlocks, inputsAsOutputsInOrder := wallet.buildUnlockBlocks(inputs, outputsByID, txEssence)
+	return unsignedTx, nil
+}
 
-	tx = ledgerstate.NewTransaction(txEssence, unlockBlocks)
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SignTransaction //////////////////////////////////////////////////////////////////////////////////////////////
+
+// SignTransaction verifies the given UnsignedTransaction and signs it with the keys of the consumed addresses, which
+// need to belong to the seed of this wallet. It does not need a connection to a node.
+func (wallet *Wallet) SignTransaction(unsignedTx *UnsignedTransaction) (tx *ledgerstate.Transaction, err error) {
+	if wallet.WatchOnly() {
+		return nil, errors.Errorf("failed to sign transaction: %w", ErrWatchOnly)
+	}
+	if err = unsignedTx.Verify(); err != nil {
+		return nil, errors.Errorf("failed to sign invalid transaction: %w", err)
+	}
+
+	outputsByID := make(OutputsByID)
+	for _, consumedOutput := range unsignedTx.ConsumedOutputs {
+		if wallet.Seed().Address(consumedOutput.Address.Index) != consumedOutput.Address {
+			return nil, errors.Errorf("address %s with index %d does not belong to the seed of the wallet", consumedOutput.Address.Base58(), consumedOutput.Address.Index)
+		}
+		outputsByID[consumedOutput.Output.ID()] = &Output{Address: consumedOutput.Address, Object: consumedOutput.Output}
+	}
+
+	unlockBlocks, inputsAsOutputsInOrder := wallet.buildUnlockBlocks(unsignedTx.Essence.Inputs(), outputsByID, unsignedTx.Essence)
+
+	tx = ledgerstate.NewTransaction(unsignedTx.Essence, unlockBlocks)
 
 	// check syntactical validity by marshaling an unmarshaling
 	tx, _, err = ledgerstate.TransactionFromBytes(tx.Bytes())
@@ -159,17 +304,29 @@ func (wallet *Wallet) SendFunds(options ...sendoptions.SendFundsOption) (tx *led
 		return nil, errors.Errorf("created transaction is invalid: %s", tx.String())
 	}
 
-	wallet.markOutputsAndAddressesSpent(consumedOutputs)
+	return tx, nil
+}
 
-	err = wallet.connector.SendTransaction(tx)
-	if err != nil {
-		return nil, err
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SubmitTransaction ////////////////////////////////////////////////////////////////////////////////////////////
+
+// SubmitTransaction sends a signed transaction to the node of the wallet. The outputs of the wallet that are consumed
+// by the transaction are marked as spent, even if the submission fails, as the node might have accepted it anyway.
+func (wallet *Wallet) SubmitTransaction(tx *ledgerstate.Transaction, waitForConfirmation ...bool) (err error) {
+	if wallet.offline {
+		return errors.Errorf("failed to submit transaction: %w", ErrOffline)
 	}
-	if sendOptions.WaitForConfirmation {
+
+	wallet.markOutputsAndAddressesSpent(wallet.consumedOutputs(tx))
+	if err = wallet.connector.SendTransaction(tx); err != nil {
+		return err
+	}
+	if len(waitForConfirmation) > 0 && waitForConfirmation[0] {
 		err = wallet.WaitForTxConfirmation(tx.ID())
 	}
 
-	return tx, err
+	return err
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -192,7 +349,7 @@ func (wallet *Wallet) ConsolidateFunds(options ...consolidateoptions.Consolidate
 		return
 	}
 	// collect outputs
-	allOutputs, err := wallet.collectOutputsForFunding(confirmedAvailableBalance)
+	allOutputs, err := wallet.collectOutputsForFunding(confirmedAvailableBalance, coinselection.InputOrder)
 	if err != nil && !errors.Is(err, ErrTooManyOutputs) {
 		return
 	}
@@ -200,6 +357,12 @@ func (wallet *Wallet) ConsolidateFunds(options ...consolidateoptions.Consolidate
 		err = errors.Errorf("can't consolidate funds, there is only one value output in wallet")
 		return
 	}
+
+	return wallet.consolidateOutputs(allOutputs, consolidateOptions)
+}
+
+// consolidateOutputs consolidates the given outputs into one output per chunk of at most MaxInputCount outputs.
+func (wallet *Wallet) consolidateOutputs(allOutputs OutputsByAddressAndOutputID, consolidateOptions *consolidateoptions.ConsolidateFundsOptions) (txs []*ledgerstate.Transaction, err error) {
 	consumedOutputsSlice := allOutputs.SplitIntoChunksOfMaxInputCount()
 
 	for _, consumedOutputs := range consumedOutputsSlice {
@@ -207,7 +370,11 @@ func (wallet *Wallet) ConsolidateFunds(options ...consolidateoptions.Consolidate
 		inputs := wallet.buildInputs(consumedOutputs)
 		// aggregate all the funds we consume from inputs
 		totalConsumedFunds := consumedOutputs.TotalFundsInOutputs()
-		toAddress := wallet.chooseToAddress(consumedOutputs, address.AddressEmpty) // no optional toAddress from options
+		toAddress, aErr := wallet.chooseToAddress(consumedOutputs, address.AddressEmpty) // no optional toAddress from options
+		if aErr != nil {
+			err = aErr
+			return
+		}
 
 		outputs := ledgerstate.NewOutputs(ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(totalConsumedFunds), toAddress.Address()))
 
@@ -286,7 +453,10 @@ func (wallet *Wallet) ClaimConditionalFunds(options ...claimconditionaloptions.C
 	inputs := wallet.buildInputs(consumedOutputs)
 	// aggregate all the funds we consume from inputs
 	totalConsumedFunds := consumedOutputs.TotalFundsInOutputs()
-	toAddress := wallet.chooseToAddress(consumedOutputs, address.AddressEmpty) // no optional toAddress from options
+	toAddress, err := wallet.chooseToAddress(consumedOutputs, address.AddressEmpty) // no optional toAddress from options
+	if err != nil {
+		return
+	}
 	outputs := ledgerstate.NewOutputs(ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(totalConsumedFunds), toAddress.Address()))
 
 	// determine pledgeIDs
@@ -355,7 +525,10 @@ func (wallet *Wallet) CreateAsset(asset Asset, waitForConfirmation ...bool) (ass
 		}
 		return
 	}
-	receiveAddress := wallet.chooseToAddress(consumedOutputs, address.AddressEmpty)
+	receiveAddress, err := wallet.chooseToAddress(consumedOutputs, address.AddressEmpty)
+	if err != nil {
+		return
+	}
 
 	var wait bool
 	if len(waitForConfirmation) > 0 {
@@ -389,12 +562,69 @@ func (wallet *Wallet) CreateAsset(asset Asset, waitForConfirmation ...bool) (ass
 	if assetColor != ledgerstate.ColorIOTA {
 		asset.Color = assetColor
 		asset.TransactionID = tx.ID()
-		wallet.assetRegistry.RegisterAsset(assetColor, asset)
+
+		signers := make([]ledgerstate.Address, 0)
+		for _, unlockBlock := range tx.UnlockBlocks() {
+			if signatureUnlockBlock, ok := unlockBlock.(*ledgerstate.SignatureUnlockBlock); ok {
+				if signature, ok := signatureUnlockBlock.Signature().(*ledgerstate.ED25519Signature); ok {
+					signers = append(signers, ledgerstate.NewED25519Address(signature.PublicKey))
+				}
+			}
+		}
+		if err = wallet.registerAsset(asset, signers); err != nil {
+			err = errors.Errorf("failed to register asset %s: %v: %w", assetColor.Base58(), err, ErrAssetNotRegistered)
+		}
 	}
 
 	return
 }
 
+// RegisterAsset signs the registry entry of an asset of the local asset registry with the key that owned an input of
+// its minting transaction and stores it in the registry server. It can be used to retry a failed registration of
+// CreateAsset.
+func (wallet *Wallet) RegisterAsset(color ledgerstate.Color) (err error) {
+	asset, exists := wallet.assetRegistry.assets[color]
+	if !exists {
+		return errors.Errorf("asset %s was not created by this wallet", color.Base58())
+	}
+	if wallet.connector == nil {
+		return errors.Errorf("failed to register asset %s: %w", color.Base58(), ErrOffline)
+	}
+
+	historyConnector, ok := wallet.connector.(HistoryConnector)
+	if !ok {
+		return errors.Errorf("failed to register asset %s: %w", color.Base58(), ErrNoHistoryConnector)
+	}
+	mintingTransaction, err := historyConnector.GetTransactionDetails(asset.TransactionID)
+	if err != nil {
+		return errors.Errorf("failed to load minting transaction %s: %w", asset.TransactionID.Base58(), err)
+	}
+	signers := make([]ledgerstate.Address, 0, len(mintingTransaction.Inputs))
+	for _, input := range mintingTransaction.Inputs {
+		signers = append(signers, input.Address())
+	}
+
+	return wallet.registerAsset(asset, signers)
+}
+
+// registerAsset signs the registry entry of the asset with the key of the first of the given addresses that belongs to
+// the wallet.
+func (wallet *Wallet) registerAsset(asset Asset, signers []ledgerstate.Address) (err error) {
+	if wallet.WatchOnly() {
+		return errors.Errorf("failed to sign the registry entry: %w", ErrWatchOnly)
+	}
+
+	for _, walletAddress := range wallet.addressManager.Addresses() {
+		for _, signer := range signers {
+			if walletAddress.Address().Equals(signer) {
+				return wallet.assetRegistry.RegisterAsset(asset.Color, asset, *wallet.Seed().KeyPair(walletAddress.Index))
+			}
+		}
+	}
+
+	return errors.Errorf("the wallet does not own an input of minting transaction %s", asset.TransactionID.Base58())
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region DelegateFunds ////////////////////////////////////////////////////////////////////////////////////////////////
@@ -428,7 +658,15 @@ func (wallet *Wallet) DelegateFunds(options ...delegateoptions.DelegateFundsOpti
 	inputs := wallet.buildInputs(consumedOutputs)
 	// aggregate all the funds we consume from inputs
 	totalConsumedFunds := consumedOutputs.TotalFundsInOutputs()
-	remainderAddress := wallet.chooseRemainderAddress(consumedOutputs, delegateOptions.RemainderAddress)
+	remainderAddress, err := wallet.chooseRemainderAddress(consumedOutputs, delegateOptions.RemainderAddress)
+	if err != nil {
+		return
+	}
+	// we are the governance controllers, so we can claim back the delegated funds
+	governingAddress, err := wallet.TryReceiveAddress()
+	if err != nil {
+		return
+	}
 
 	unsortedOutputs := ledgerstate.Outputs{}
 	for addr, balanceMap := range delegateOptions.Destinations {
@@ -437,8 +675,7 @@ func (wallet *Wallet) DelegateFunds(options ...delegateoptions.DelegateFundsOpti
 		if err != nil {
 			return
 		}
-		// we are the governance controllers, so we can claim back the delegated funds
-		delegationOutput.SetGoverningAddress(wallet.ReceiveAddress().Address())
+		delegationOutput.SetGoverningAddress(governingAddress.Address())
 		// is there a delegation timelock?
 		if !delegateOptions.DelegateUntil.IsZero() {
 			delegationOutput = delegationOutput.WithDelegationAndTimelock(delegateOptions.DelegateUntil)
@@ -521,7 +758,11 @@ func (wallet *Wallet) ReclaimDelegatedFunds(options ...reclaimoptions.ReclaimFun
 	}
 	if reclaimOptions.ToAddress == nil {
 		// if no optional address is provided, send to receive address of the wallet
-		reclaimOptions.ToAddress = wallet.ReceiveAddress().Address()
+		receiveAddress, aErr := wallet.TryReceiveAddress()
+		if aErr != nil {
+			return nil, aErr
+		}
+		reclaimOptions.ToAddress = receiveAddress.Address()
 	}
 
 	tx, err = wallet.DestroyNFT(
@@ -558,7 +799,10 @@ func (wallet *Wallet) CreateNFT(options ...createnftoptions.CreateNFTOption) (tx
 		return nil, nil, err
 	}
 	// determine which address should receive the nft
-	nftWalletAddress := wallet.chooseToAddress(consumedOutputs, address.AddressEmpty)
+	nftWalletAddress, err := wallet.chooseToAddress(consumedOutputs, address.AddressEmpty)
+	if err != nil {
+		return nil, nil, err
+	}
 	// build inputs from consumed outputs
 	inputs := wallet.buildInputs(consumedOutputs)
 	// aggregate all the funds we consume from inputs
@@ -585,8 +829,11 @@ func (wallet *Wallet) CreateNFT(options ...createnftoptions.CreateNFTOption) (tx
 	remainderBalances := ledgerstate.NewColoredBalances(totalConsumedFunds)
 	// only add remainder output if there is a remainder balance
 	if remainderBalances.Size() != 0 {
-		unsortedOutputs = append(unsortedOutputs, ledgerstate.NewSigLockedColoredOutput(
-			remainderBalances, wallet.chooseRemainderAddress(consumedOutputs, address.AddressEmpty).Address()))
+		remainderAddress, aErr := wallet.chooseRemainderAddress(consumedOutputs, address.AddressEmpty)
+		if aErr != nil {
+			return nil, nil, aErr
+		}
+		unsortedOutputs = append(unsortedOutputs, ledgerstate.NewSigLockedColoredOutput(remainderBalances, remainderAddress.Address()))
 	}
 	// create tx essence
 	outputs := ledgerstate.NewOutputs(unsortedOutputs...)
@@ -800,7 +1047,10 @@ func (wallet *Wallet) DestroyNFT(options ...destroynftoptions.DestroyNFTOption)
 		// we only consume the to-be-destroyed alias
 		walletAlias.Address: {walletAlias.Object.ID(): walletAlias},
 	}
-	remainderAddy := wallet.chooseRemainderAddress(consumedOutputs, address.AddressEmpty)
+	remainderAddy, err := wallet.chooseRemainderAddress(consumedOutputs, address.AddressEmpty)
+	if err != nil {
+		return
+	}
 	remainderOutput := ledgerstate.NewSigLockedColoredOutput(alias.Balances(), remainderAddy.Address())
 
 	inputs := ledgerstate.Inputs{alias.Input()}
@@ -904,7 +1154,10 @@ func (wallet *Wallet) WithdrawFundsFromNFT(options ...withdrawfromnftoptions.Wit
 	} else {
 		optionsToAddress = address.Address{AddressBytes: withdrawOptions.ToAddress.Array()}
 	}
-	remainderAddress := wallet.chooseRemainderAddress(consumedOutputs, optionsToAddress)
+	remainderAddress, err := wallet.chooseRemainderAddress(consumedOutputs, optionsToAddress)
+	if err != nil {
+		return
+	}
 
 	remainderOutput := ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(withdrawBalances), remainderAddress.Address())
 
@@ -1023,8 +1276,11 @@ func (wallet *Wallet) DepositFundsToNFT(options ...deposittonftoptions.DepositFu
 	remainderBalances := ledgerstate.NewColoredBalances(totalConsumed)
 	// only add remainder output if there is a remainder balance
 	if remainderBalances.Size() != 0 {
-		unsortedOutputs = append(unsortedOutputs, ledgerstate.NewSigLockedColoredOutput(
-			remainderBalances, wallet.chooseRemainderAddress(consumedOutputs, address.AddressEmpty).Address()))
+		remainderAddress, aErr := wallet.chooseRemainderAddress(consumedOutputs, address.AddressEmpty)
+		if aErr != nil {
+			return nil, aErr
+		}
+		unsortedOutputs = append(unsortedOutputs, ledgerstate.NewSigLockedColoredOutput(remainderBalances, remainderAddress.Address()))
 	}
 
 	// create tx essence
@@ -1144,7 +1400,10 @@ func (wallet Wallet) SweepNFTOwnedFunds(options ...sweepnftownedoptions.SweepNFT
 		// we only consume the to-be-destroyed alias from the wallet
 		walletAlias.Address: {walletAlias.Object.ID(): walletAlias},
 	}
-	toAddress := wallet.chooseToAddress(consumedOutputs, optionsToAddress)
+	toAddress, err := wallet.chooseToAddress(consumedOutputs, optionsToAddress)
+	if err != nil {
+		return
+	}
 
 	unsortedInputs := toBeConsumed.Inputs()
 	unsortedOutputs := ledgerstate.Outputs{nextAlias, ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(totalConsumed), toAddress.Address())}
@@ -1276,7 +1535,10 @@ func (wallet *Wallet) SweepNFTOwnedNFTs(options ...sweepnftownednftsoptions.Swee
 		// we only consume the to-be-destroyed alias from the wallet
 		walletAlias.Address: {walletAlias.Object.ID(): walletAlias},
 	}
-	toAddress := wallet.chooseToAddress(consumedOutputs, optionsToAddress)
+	toAddress, err := wallet.chooseToAddress(consumedOutputs, optionsToAddress)
+	if err != nil {
+		return
+	}
 	// nextAlias is the nft we control
 	nextAlias := alias.NewAliasOutputNext(false)
 	// transition nft owned aliases
@@ -1376,6 +1638,26 @@ func (wallet *Wallet) ServerStatus() (status ServerStatus, err error) {
 	return wallet.connector.(*WebConnector).ServerStatus()
 }
 
+// Nodes returns the health of the nodes that the wallet is connected to, if it uses a WebConnector.
+func (wallet *Wallet) Nodes() []client.NodeStatus {
+	webConnector, ok := wallet.connector.(*WebConnector)
+	if !ok {
+		return nil
+	}
+
+	return webConnector.Nodes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Delegations //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Delegations retrieves the delegations to the given delegation address. If receiver is empty, the delegations to the
+// connected node are retrieved.
+func (wallet *Wallet) Delegations(receiver string) (*jsonmodels.GetDelegationsResponse, error) {
+	return wallet.connector.(*WebConnector).Delegations(receiver)
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region AllowedPledgeNodeIDs /////////////////////////////////////////////////////////////////////////////////////////
@@ -1396,31 +1678,79 @@ func (wallet *Wallet) AssetRegistry() *AssetRegistry {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region History //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// History returns the local transaction history of the wallet.
+func (wallet *Wallet) History() *History {
+	return wallet.history
+}
+
+// RefreshHistory updates the transaction history with the transactions that created or consumed outputs on the
+// addresses of the wallet. It returns ErrNoHistoryConnector if the connector does not implement HistoryConnector.
+func (wallet *Wallet) RefreshHistory() (err error) {
+	if wallet.offline {
+		return ErrOffline
+	}
+
+	return wallet.history.Refresh(wallet.connector, wallet.addressManager.Addresses()...)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region ReceiveAddress ///////////////////////////////////////////////////////////////////////////////////////////////
 
-// ReceiveAddress returns the last receive address of the wallet.
+// ReceiveAddress returns the last receive address of the wallet. A watch-only wallet that has spent all of its
+// addresses returns address.AddressEmpty, use TryReceiveAddress to get the error instead.
 func (wallet *Wallet) ReceiveAddress() address.Address {
 	return wallet.addressManager.LastUnspentAddress()
 }
 
+// TryReceiveAddress returns the last receive address of the wallet. It returns an error that wraps ErrWatchOnly if a
+// watch-only wallet has spent all of its addresses.
+func (wallet *Wallet) TryReceiveAddress() (address.Address, error) {
+	return wallet.addressManager.TryLastUnspentAddress()
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region NewReceiveAddress ////////////////////////////////////////////////////////////////////////////////////////////
 
-// NewReceiveAddress generates and returns a new unused receive address.
+// NewReceiveAddress generates and returns a new unused receive address. A watch-only wallet returns
+// address.AddressEmpty once it runs out of exported addresses, use TryNewReceiveAddress to get the error instead.
 func (wallet *Wallet) NewReceiveAddress() address.Address {
-	return wallet.addressManager.NewAddress()
+	newAddress, _ := wallet.TryNewReceiveAddress()
+
+	return newAddress
+}
+
+// TryNewReceiveAddress generates and returns a new unused receive address. It returns an error that wraps ErrWatchOnly
+// once a watch-only wallet runs out of exported addresses.
+func (wallet *Wallet) TryNewReceiveAddress() (address.Address, error) {
+	newAddress, err := wallet.addressManager.TryNewAddress()
+	if err != nil {
+		return address.AddressEmpty, err
+	}
+	wallet.subscribeAddresses()
+
+	return newAddress, nil
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region RemainderAddress /////////////////////////////////////////////////////////////////////////////////////////////
 
-// RemainderAddress returns the address that is used for the remainder of funds.
+// RemainderAddress returns the address that is used for the remainder of funds. A watch-only wallet that has spent all
+// of its addresses returns address.AddressEmpty, use TryRemainderAddress to get the error instead.
 func (wallet *Wallet) RemainderAddress() address.Address {
 	return wallet.addressManager.FirstUnspentAddress()
 }
 
+// TryRemainderAddress returns the address that is used for the remainder of funds. It returns an error that wraps
+// ErrWatchOnly if a watch-only wallet has spent all of its addresses.
+func (wallet *Wallet) TryRemainderAddress() (address.Address, error) {
+	return wallet.addressManager.TryFirstUnspentAddress()
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region UnspentOutputs ///////////////////////////////////////////////////////////////////////////////////////////////
@@ -1454,8 +1784,12 @@ func (wallet *Wallet) UnspentAliasOutputs(includePending bool) map[address.Addre
 
 // RequestFaucetFunds requests some funds from the faucet for testing purposes.
 func (wallet *Wallet) RequestFaucetFunds(waitForConfirmation ...bool) (err error) {
+	receiveAddress, err := wallet.TryReceiveAddress()
+	if err != nil {
+		return
+	}
 	if len(waitForConfirmation) == 0 || !waitForConfirmation[0] {
-		err = wallet.connector.RequestFaucetFunds(wallet.ReceiveAddress(), wallet.faucetPowDifficulty)
+		err = wallet.connector.RequestFaucetFunds(receiveAddress, wallet.faucetPowDifficulty)
 
 		return
 	}
@@ -1468,10 +1802,13 @@ func (wallet *Wallet) RequestFaucetFunds(waitForConfirmation ...bool) (err error
 		return
 	}
 
-	err = wallet.connector.RequestFaucetFunds(wallet.ReceiveAddress(), wallet.faucetPowDifficulty)
+	err = wallet.connector.RequestFaucetFunds(receiveAddress, wallet.faucetPowDifficulty)
 	if err != nil {
 		return
 	}
+	if wallet.notifier != nil {
+		return wallet.waitForBalanceConfirmationNotification(confirmedBalance)
+	}
 	err = wallet.waitForBalanceConfirmation(confirmedBalance)
 	return
 }
@@ -1902,11 +2239,17 @@ func (wallet *Wallet) DelegatedAliasBalance(refresh ...bool) (
 
 // region Seed /////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// Seed returns the seed of this wallet that is used to generate all of the wallets addresses and private keys.
+// Seed returns the seed of this wallet that is used to generate all of the wallets addresses and private keys. It is
+// nil if the wallet is watch-only.
 func (wallet *Wallet) Seed() *seed.Seed {
 	return wallet.addressManager.seed
 }
 
+// WatchOnly returns true if the wallet only knows its addresses but not the seed, so that it can not sign.
+func (wallet *Wallet) WatchOnly() bool {
+	return wallet.addressManager.WatchOnly()
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region AddressManager ///////////////////////////////////////////////////////////////////////////////////////////////
@@ -1920,8 +2263,13 @@ func (wallet *Wallet) AddressManager() *AddressManager {
 
 // region ExportState //////////////////////////////////////////////////////////////////////////////////////////////////
 
-// ExportState exports the current state of the wallet to a marshaled version.
+// ExportState exports the current state of the wallet to a marshaled version. Watch-only wallets export their
+// addresses instead of the seed, see ExportWatchOnlyState.
 func (wallet *Wallet) ExportState() []byte {
+	if wallet.WatchOnly() {
+		return wallet.ExportWatchOnlyState(0)
+	}
+
 	marshalUtil := marshalutil.New()
 	marshalUtil.WriteBytes(wallet.Seed().Bytes())
 	marshalUtil.WriteUint64(wallet.AddressManager().lastAddressIndex)
@@ -1931,12 +2279,90 @@ func (wallet *Wallet) ExportState() []byte {
 	return marshalUtil.Bytes()
 }
 
+// ExportWatchOnlyState exports the state of the wallet without the seed, so that it can be imported by a watch-only
+// wallet. Besides the addresses used so far, it contains the given number of additional addresses that the watch-only
+// wallet can use as remainder addresses.
+func (wallet *Wallet) ExportWatchOnlyState(additionalAddresses uint64) []byte {
+	addressCount := wallet.addressManager.lastAddressIndex + 1 + additionalAddresses
+	if wallet.WatchOnly() {
+		addressCount = uint64(len(wallet.addressManager.watchedAddresses))
+	}
+
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteBytes(watchOnlyStatePrefix)
+	marshalUtil.WriteUint64(addressCount)
+	for i := uint64(0); i < addressCount; i++ {
+		var addr address.Address
+		if wallet.WatchOnly() {
+			addr = wallet.addressManager.watchedAddresses[i]
+		} else {
+			addr = wallet.Seed().Address(i)
+		}
+		marshalUtil.WriteBytes(addr.AddressBytes[:])
+	}
+	marshalUtil.WriteUint64(wallet.AddressManager().lastAddressIndex)
+	marshalUtil.WriteBytes(wallet.assetRegistry.Bytes())
+	marshalUtil.WriteBytes(*(*[]byte)(unsafe.Pointer(&wallet.addressManager.spentAddresses)))
+
+	return marshalUtil.Bytes()
+}
+
+// IsWatchOnlyState returns true if the given state was exported by ExportWatchOnlyState.
+func IsWatchOnlyState(stateBytes []byte) bool {
+	return bytes.HasPrefix(stateBytes, watchOnlyStatePrefix)
+}
+
+// ParseWatchOnlyState parses a state that was exported by ExportWatchOnlyState, so that it can be imported with
+// ImportWatchOnly.
+func ParseWatchOnlyState(stateBytes []byte) (addresses []address.Address, lastAddressIndex uint64, spentAddresses []bitmask.BitMask, assetRegistry *AssetRegistry, err error) {
+	if !IsWatchOnlyState(stateBytes) {
+		return nil, 0, nil, nil, errors.New("the state was not exported by a watch-only wallet")
+	}
+
+	marshalUtil := marshalutil.New(stateBytes[len(watchOnlyStatePrefix):])
+	addressCount, err := marshalUtil.ReadUint64()
+	if err != nil {
+		return nil, 0, nil, nil, errors.Errorf("failed to parse address count: %w", err)
+	}
+	if addressCount == 0 || addressCount > uint64(len(stateBytes)/ledgerstate.AddressLength) {
+		return nil, 0, nil, nil, errors.Errorf("invalid address count %d", addressCount)
+	}
+	addresses = make([]address.Address, addressCount)
+	for i := range addresses {
+		addressBytes, readErr := marshalUtil.ReadBytes(ledgerstate.AddressLength)
+		if readErr != nil {
+			return nil, 0, nil, nil, errors.Errorf("failed to parse address %d: %w", i, readErr)
+		}
+		addresses[i].Index = uint64(i)
+		copy(addresses[i].AddressBytes[:], addressBytes)
+	}
+	if lastAddressIndex, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, 0, nil, nil, errors.Errorf("failed to parse last address index: %w", err)
+	}
+	if lastAddressIndex >= addressCount {
+		return nil, 0, nil, nil, errors.Errorf("last address index %d exceeds the %d addresses", lastAddressIndex, addressCount)
+	}
+	if assetRegistry, _, err = ParseAssetRegistry(marshalUtil); err != nil {
+		return nil, 0, nil, nil, errors.Errorf("failed to parse asset registry: %w", err)
+	}
+
+	spentAddressesBytes := marshalUtil.ReadRemainingBytes()
+	spentAddresses = *(*[]bitmask.BitMask)(unsafe.Pointer(&spentAddressesBytes))
+
+	return addresses, lastAddressIndex, spentAddresses, assetRegistry, nil
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region WaitForTxConfirmation ////////////////////////////////////////////////////////////////////////////////////////
 
-// WaitForTxConfirmation waits for the given tx to confirm. If the transaction is rejected, an error is returned.
+// WaitForTxConfirmation waits for the given tx to confirm. If the transaction is rejected, an error is returned. The
+// wallet waits for the notifications of its Notifier if it has one and polls the node otherwise.
 func (wallet *Wallet) WaitForTxConfirmation(txID ledgerstate.TransactionID) (err error) {
+	if wallet.notifier != nil {
+		return wallet.waitForTxConfirmationNotification(txID)
+	}
+
 	timeoutCounter := 0
 	for {
 		time.Sleep(time.Duration(wallet.ConfirmationPollInterval) * time.Millisecond)
@@ -1959,8 +2385,108 @@ func (wallet *Wallet) WaitForTxConfirmation(txID ledgerstate.TransactionID) (err
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region Events ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Events returns the events that are triggered by the notifications of the wallet's Notifier. OutputReceived reports
+// the confirmed outputs on the unspent addresses of the wallet and InclusionStateChanged the inclusion states of the
+// transactions that the wallet waits for. No events are triggered if the wallet does not have a Notifier.
+func (wallet *Wallet) Events() *NotifierEvents {
+	return wallet.events
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Close ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Close stops the notifications of the wallet's Notifier.
+func (wallet *Wallet) Close() {
+	if wallet.notifier != nil {
+		wallet.notifier.Close()
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region Internal Methods /////////////////////////////////////////////////////////////////////////////////////////////
 
+// subscribeAddresses subscribes the Notifier to the unspent addresses of the wallet.
+func (wallet *Wallet) subscribeAddresses() {
+	if wallet.notifier == nil {
+		return
+	}
+
+	wallet.notifier.SubscribeAddresses(wallet.addressManager.UnspentAddresses()...)
+}
+
+// waitForTxConfirmationNotification waits until the Notifier reports that the given tx is confirmed or rejected.
+func (wallet *Wallet) waitForTxConfirmationNotification(txID ledgerstate.TransactionID) (err error) {
+	finalState := make(chan ledgerstate.InclusionState, 1)
+	closure := events.NewClosure(func(changedTxID ledgerstate.TransactionID, state ledgerstate.InclusionState) {
+		if changedTxID != txID || state == ledgerstate.Pending {
+			return
+		}
+		select {
+		case finalState <- state:
+		default:
+		}
+	})
+	wallet.notifier.Events().InclusionStateChanged.Attach(closure)
+	defer wallet.notifier.Events().InclusionStateChanged.Detach(closure)
+
+	wallet.subscribeAddresses()
+	wallet.notifier.WatchTransaction(txID)
+	defer wallet.notifier.UnwatchTransaction(txID)
+
+	select {
+	case state := <-finalState:
+		if state == ledgerstate.Rejected {
+			return errors.Errorf("transaction %s has been rejected", txID.Base58())
+		}
+		return nil
+	case <-time.After(time.Duration(wallet.ConfirmationTimeout) * time.Millisecond):
+		return errors.Errorf("transaction %s did not confirm within %d seconds", txID.Base58(), wallet.ConfirmationTimeout/milliSeconds)
+	}
+}
+
+// waitForBalanceConfirmationNotification waits until the balance of the wallet changes compared to the provided
+// argument. It checks the balance whenever the Notifier reports a new output or a reconnect instead of polling.
+func (wallet *Wallet) waitForBalanceConfirmationNotification(prevConfirmedBalance map[ledgerstate.Color]uint64) (err error) {
+	updated := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case updated <- struct{}{}:
+		default:
+		}
+	}
+	outputClosure := events.NewClosure(func(*Output) { notify() })
+	wallet.notifier.Events().OutputReceived.Attach(outputClosure)
+	defer wallet.notifier.Events().OutputReceived.Detach(outputClosure)
+	connectedClosure := events.NewClosure(notify)
+	wallet.notifier.Events().Connected.Attach(connectedClosure)
+	defer wallet.notifier.Events().Connected.Detach(connectedClosure)
+
+	wallet.subscribeAddresses()
+	// the output might have arrived before we started to listen
+	notify()
+
+	timeout := time.After(time.Duration(wallet.ConfirmationTimeout) * time.Millisecond)
+	for {
+		select {
+		case <-updated:
+		case <-timeout:
+			return errors.Errorf("confirmed balance did not change within timeout limit (%d)", wallet.ConfirmationTimeout/milliSeconds)
+		}
+
+		newConfirmedBalance, _, balanceErr := wallet.Balance()
+		if balanceErr != nil {
+			return balanceErr
+		}
+		if !reflect.DeepEqual(prevConfirmedBalance, newConfirmedBalance) {
+			return nil
+		}
+	}
+}
+
 // waitForBalanceConfirmation waits until the balance of the wallet changes compared to the provided argument.
 // (a transaction modifying the wallet balance got confirmed)
 func (wallet *Wallet) waitForBalanceConfirmation(prevConfirmedBalance map[ledgerstate.Color]uint64) (err error) {
@@ -2090,19 +2616,45 @@ func (wallet *Wallet) findStateControlledAliasOutputByAliasID(id *ledgerstate.Al
 	return nil, err
 }
 
-// collectOutputsForFunding tries to collect unspent outputs to fund fundingBalance
-func (wallet *Wallet) collectOutputsForFunding(fundingBalance map[ledgerstate.Color]uint64) (OutputsByAddressAndOutputID, error) {
+// collectOutputsForFunding tries to collect unspent outputs to fund fundingBalance. The outputs are selected with the
+// given coin selection strategy or the strategy of the wallet.
+func (wallet *Wallet) collectOutputsForFunding(fundingBalance map[ledgerstate.Color]uint64, strategy ...coinselection.Strategy) (OutputsByAddressAndOutputID, error) {
 	if fundingBalance == nil {
 		return nil, errors.Errorf("can't collect fund: empty fundingBalance provided")
 	}
 
+	selection := wallet.coinSelection
+	if len(strategy) > 0 && strategy[0] != nil {
+		selection = strategy[0]
+	}
+
 	_ = wallet.outputManager.Refresh()
+	candidates, candidatesByID := wallet.spendableValueOutputs()
+
+	selected, err := selection.Select(candidates, fundingBalance, ledgerstate.MaxInputCount)
+	if err != nil && !errors.Is(err, coinselection.ErrTooManyInputs) {
+		return nil, errors.Errorf("failed to gather initial funds: %w", err)
+	}
+
+	outputsToConsume := make(OutputsByID)
+	for _, output := range selected {
+		outputsToConsume[output.ID()] = candidatesByID[output.ID()]
+	}
+	if err != nil {
+		return outputsToConsume.OutputsByAddressAndOutputID(), errors.Errorf("failed to collect outputs: %w", ErrTooManyOutputs)
+	}
+
+	return outputsToConsume.OutputsByAddressAndOutputID(), nil
+}
+
+// spendableValueOutputs returns the confirmed value outputs that the wallet can unlock now, in the order of their
+// addresses.
+func (wallet *Wallet) spendableValueOutputs() (outputs ledgerstate.Outputs, outputsByID OutputsByID) {
 	addresses := wallet.addressManager.Addresses()
 	unspentOutputs := wallet.outputManager.UnspentValueOutputs(false, addresses...)
 
-	collected := make(map[ledgerstate.Color]uint64)
-	outputsToConsume := NewAddressToOutputs()
-	numOfCollectedOutputs := 0
+	outputs = make(ledgerstate.Outputs, 0)
+	outputsByID = make(OutputsByID)
 	now := time.Now()
 	for _, addy := range addresses {
 		for outputID, output := range unspentOutputs[addy] {
@@ -2117,47 +2669,12 @@ func (wallet *Wallet) collectOutputsForFunding(fundingBalance map[ledgerstate.Co
 					continue
 				}
 			}
-			contributingOutput := false
-			output.Object.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
-				_, has := fundingBalance[color]
-				if has {
-					collected[color] += balance
-					contributingOutput = true
-				}
-				return true
-			})
-			if contributingOutput {
-				// store the output in the outputs to use for the transfer
-				if _, addressEntryExists := outputsToConsume[addy]; !addressEntryExists {
-					outputsToConsume[addy] = make(map[ledgerstate.OutputID]*Output)
-				}
-				outputsToConsume[addy][outputID] = output
-				numOfCollectedOutputs++
-				if enoughCollected(collected, fundingBalance) && numOfCollectedOutputs <= ledgerstate.MaxInputCount {
-					return outputsToConsume, nil
-				}
-			}
+			outputs = append(outputs, output.Object)
+			outputsByID[outputID] = output
 		}
 	}
 
-	if enoughCollected(collected, fundingBalance) && numOfCollectedOutputs > ledgerstate.MaxOutputCount {
-		return outputsToConsume, errors.Errorf("failed to collect outputs: %w", ErrTooManyOutputs)
-	}
-
-	return nil, errors.Errorf("failed to gather initial funds \n %s, there are only \n %s funds available",
-		ledgerstate.NewColoredBalances(fundingBalance).String(),
-		ledgerstate.NewColoredBalances(collected).String(),
-	)
-}
-
-// enoughCollected checks if collected has at least target funds
-func enoughCollected(collected, target map[ledgerstate.Color]uint64) bool {
-	for color, balance := range target {
-		if collected[color] < balance {
-			return false
-		}
-	}
-	return true
+	return outputs, outputsByID
 }
 
 // buildInputs builds a list of deterministically sorted inputs from the provided OutputsByAddressAndOutputID mapping.
@@ -2269,48 +2786,78 @@ func (wallet *Wallet) markOutputsAndAddressesSpent(consumedOutputs OutputsByAddr
 	}
 }
 
-// chooseRemainderAddress chooses an appropriate remainder address based on the wallet configuration and where we are spending from.
-func (wallet *Wallet) chooseRemainderAddress(consumedOutputs OutputsByAddressAndOutputID, optionsRemainder address.Address) (remainder address.Address) {
-	if optionsRemainder == address.AddressEmpty {
-		if wallet.reusableAddress {
-			return wallet.RemainderAddress()
+// consumedOutputs returns the unspent outputs of the wallet that are consumed by the given transaction.
+func (wallet *Wallet) consumedOutputs(tx *ledgerstate.Transaction) (consumedOutputs OutputsByAddressAndOutputID) {
+	referencedOutputIDs := make(map[ledgerstate.OutputID]bool)
+	for _, input := range tx.Essence().Inputs() {
+		if utxoInput, ok := input.(*ledgerstate.UTXOInput); ok {
+			referencedOutputIDs[utxoInput.ReferencedOutputID()] = true
 		}
-		_, spendFromRemainderAddress := consumedOutputs[wallet.RemainderAddress()]
-		_, spendFromReceiveAddress := consumedOutputs[wallet.ReceiveAddress()]
-		if spendFromRemainderAddress && spendFromReceiveAddress {
-			// we are about to spend from both
-			return wallet.NewReceiveAddress()
-		}
-		if spendFromRemainderAddress && !spendFromReceiveAddress {
-			// we are about to spend from remainder, but not from receive
-			return wallet.ReceiveAddress()
+	}
+
+	consumedOutputs = make(OutputsByAddressAndOutputID)
+	for addr, outputs := range wallet.outputManager.UnspentOutputs(true) {
+		for outputID, output := range outputs {
+			if !referencedOutputIDs[outputID] {
+				continue
+			}
+			if _, addressExists := consumedOutputs[addr]; !addressExists {
+				consumedOutputs[addr] = make(map[ledgerstate.OutputID]*Output)
+			}
+			consumedOutputs[addr][outputID] = output
 		}
-		// we are not spending from remainder
-		return wallet.RemainderAddress()
 	}
-	return optionsRemainder
+
+	return consumedOutputs
+}
+
+// chooseRemainderAddress chooses an appropriate remainder address based on the wallet configuration and where we are spending from.
+func (wallet *Wallet) chooseRemainderAddress(consumedOutputs OutputsByAddressAndOutputID, optionsRemainder address.Address) (remainder address.Address, err error) {
+	if optionsRemainder != address.AddressEmpty {
+		return optionsRemainder, nil
+	}
+
+	return wallet.chooseUnspentAddress(consumedOutputs)
 }
 
 // chooseToAddress chooses an appropriate toAddress based on the wallet configuration and where we are spending from.
-func (wallet *Wallet) chooseToAddress(consumedOutputs OutputsByAddressAndOutputID, optionsToAddress address.Address) (toAddress address.Address) {
-	if optionsToAddress == address.AddressEmpty {
-		if wallet.reusableAddress {
-			return wallet.ReceiveAddress()
-		}
-		_, spendFromRemainderAddress := consumedOutputs[wallet.RemainderAddress()]
-		_, spendFromReceiveAddress := consumedOutputs[wallet.ReceiveAddress()]
-		if spendFromRemainderAddress && spendFromReceiveAddress {
-			// we are about to spend from both
-			return wallet.NewReceiveAddress()
-		}
-		if spendFromRemainderAddress && !spendFromReceiveAddress {
-			// we are about to spend from remainder, but not from receive
-			return wallet.ReceiveAddress()
-		}
-		// we are not spending from remainder
-		return wallet.RemainderAddress()
+func (wallet *Wallet) chooseToAddress(consumedOutputs OutputsByAddressAndOutputID, optionsToAddress address.Address) (toAddress address.Address, err error) {
+	if optionsToAddress != address.AddressEmpty {
+		return optionsToAddress, nil
+	}
+	if wallet.reusableAddress {
+		return wallet.TryReceiveAddress()
+	}
+
+	return wallet.chooseUnspentAddress(consumedOutputs)
+}
+
+// chooseUnspentAddress chooses an address of the wallet that is not spent by the given consumed outputs.
+func (wallet *Wallet) chooseUnspentAddress(consumedOutputs OutputsByAddressAndOutputID) (addr address.Address, err error) {
+	remainderAddress, err := wallet.TryRemainderAddress()
+	if err != nil {
+		return address.AddressEmpty, err
+	}
+	if wallet.reusableAddress {
+		return remainderAddress, nil
+	}
+	receiveAddress, err := wallet.TryReceiveAddress()
+	if err != nil {
+		return address.AddressEmpty, err
+	}
+
+	_, spendFromRemainderAddress := consumedOutputs[remainderAddress]
+	_, spendFromReceiveAddress := consumedOutputs[receiveAddress]
+	if spendFromRemainderAddress && spendFromReceiveAddress {
+		// we are about to spend from both
+		return wallet.TryNewReceiveAddress()
+	}
+	if spendFromRemainderAddress && !spendFromReceiveAddress {
+		// we are about to spend from remainder, but not from receive
+		return receiveAddress, nil
 	}
-	return optionsToAddress
+	// we are not spending from remainder
+	return remainderAddress, nil
 }
 
 // checkBalancesAndUnlocks checks if tx balances are okay and unlock blocks are valid.
@@ -0,0 +1,161 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func TestWallet_PrepareSendFunds_DoesNotMarkOutputsSpent(t *testing.T) {
+	walletSeed := seed.NewSeed()
+	connector := newMockConnector(newMockOutput(walletSeed.Address(0), 0, 100))
+	w := New(Import(walletSeed, 0, nil, NewAssetRegistry("test")), GenericConnector(connector))
+	require.NoError(t, w.Refresh())
+
+	unsignedTx, err := w.PrepareSendFunds(sendoptions.Destination(seed.NewSeed().Address(0), 60))
+	require.NoError(t, err)
+
+	// an unsigned transaction that is never submitted does not lock the funds of the wallet
+	assert.Len(t, w.UnspentOutputs(), 1)
+	assert.False(t, w.AddressManager().IsAddressSpent(0))
+
+	tx, err := w.SignTransaction(unsignedTx)
+	require.NoError(t, err)
+	assert.Len(t, w.UnspentOutputs(), 1)
+
+	require.NoError(t, w.SubmitTransaction(tx))
+	assert.Empty(t, w.UnspentOutputs())
+	assert.True(t, w.AddressManager().IsAddressSpent(0))
+}
+
+func TestWallet_WatchOnlyRemainderAddress(t *testing.T) {
+	walletSeed := seed.NewSeed()
+	connector := newMockConnector(newMockOutput(walletSeed.Address(0), 0, 100))
+	w := New(ImportWatchOnly([]address.Address{walletSeed.Address(0)}, 0, nil, NewAssetRegistry("test")), GenericConnector(connector))
+	require.NoError(t, w.Refresh())
+
+	// the only exported address is spent by the transaction, so there is no address left for the remainder
+	_, err := w.PrepareSendFunds(sendoptions.Destination(seed.NewSeed().Address(0), 60))
+	assert.True(t, errors.Is(err, ErrWatchOnly))
+}
+
+func TestWallet_WaitForTxConfirmation_Notifications(t *testing.T) {
+	testCases := []struct {
+		name   string
+		states []ledgerstate.InclusionState
+		err    string
+	}{
+		{name: "confirmed", states: []ledgerstate.InclusionState{ledgerstate.Pending, ledgerstate.Confirmed}},
+		{name: "rejected", states: []ledgerstate.InclusionState{ledgerstate.Pending, ledgerstate.Rejected}, err: "has been rejected"},
+		{name: "timeout", states: []ledgerstate.InclusionState{ledgerstate.Pending}, err: "did not confirm"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			notifier := newMockNotifier()
+			w := New(Import(seed.NewSeed(), 0, nil, NewAssetRegistry("test")), GenericConnector(newMockConnector()), Notifications(notifier))
+			w.ConfirmationTimeout = 200
+
+			txID := ledgerstate.TransactionID{1}
+			go func() {
+				<-notifier.watched
+				// the final states of other transactions are ignored
+				notifier.Events().InclusionStateChanged.Trigger(ledgerstate.TransactionID{2}, ledgerstate.Confirmed)
+				for _, state := range tc.states {
+					notifier.Events().InclusionStateChanged.Trigger(txID, state)
+				}
+			}()
+
+			err := w.WaitForTxConfirmation(txID)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+			}
+			assert.Equal(t, txID, <-notifier.unwatched)
+		})
+	}
+}
+
+func TestWallet_RequestFaucetFunds_Notifications(t *testing.T) {
+	testCases := []struct {
+		name         string
+		addOutput    bool
+		notification func(notifier *mockNotifier, output *Output)
+		err          string
+	}{
+		{
+			name:      "output received",
+			addOutput: true,
+			notification: func(notifier *mockNotifier, output *Output) {
+				notifier.Events().OutputReceived.Trigger(output)
+			},
+		},
+		{
+			name:      "reconnected",
+			addOutput: true,
+			notification: func(notifier *mockNotifier, _ *Output) {
+				notifier.Events().Connected.Trigger()
+			},
+		},
+		{
+			// the wallet does not poll the node while it waits for notifications
+			name:         "no notification",
+			addOutput:    true,
+			notification: func(*mockNotifier, *Output) {},
+			err:          "did not change",
+		},
+		{
+			name: "notification without a new output",
+			notification: func(notifier *mockNotifier, output *Output) {
+				notifier.Events().OutputReceived.Trigger(output)
+			},
+			err: "did not change",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			walletSeed := seed.NewSeed()
+			connector := newMockFaucetConnector(newMockOutput(walletSeed.Address(0), 0, 100))
+			notifier := newMockNotifier()
+			w := New(Import(walletSeed, 0, nil, NewAssetRegistry("test")), GenericConnector(connector), Notifications(notifier))
+			w.ConfirmationTimeout = 500
+			<-notifier.subscribed
+
+			go func() {
+				faucetAddress := <-connector.faucetRequests
+				// the wallet subscribes its addresses once it listens to the notifications, and checks its balance once
+				<-notifier.subscribed
+				time.Sleep(100 * time.Millisecond)
+
+				output := newMockOutput(faucetAddress, 1, 1000)
+				if tc.addOutput {
+					connector.addOutput(output)
+				}
+				tc.notification(notifier, output)
+			}()
+
+			err := w.RequestFaucetFunds(true)
+			if tc.err == "" {
+				require.NoError(t, err)
+				confirmedBalance, _, balanceErr := w.Balance(false)
+				require.NoError(t, balanceErr)
+				assert.Equal(t, uint64(1100), confirmedBalance[ledgerstate.ColorIOTA])
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
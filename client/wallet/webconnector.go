@@ -1,16 +1,19 @@
 package wallet
 
 import (
+	"time"
+
 	"github.com/cockroachdb/errors"
 
 	"github.com/iotaledger/goshimmer/client"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
 )
 
 // WebConnector implements a connector that uses the web API to connect to a node to implement the required functions
-// for the wallet.
+// for the wallet. It can connect to several nodes by passing client.WithNodes to its constructor.
 type WebConnector struct {
 	client *client.GoShimmerAPI
 }
@@ -22,6 +25,19 @@ func NewWebConnector(baseURL string, setters ...client.Option) *WebConnector {
 	}
 }
 
+// NewWebConnectorFromAPI creates a WebConnector that uses the given client, so that the wallet can share it, and the
+// health of its nodes, with other users of the client.
+func NewWebConnectorFromAPI(api *client.GoShimmerAPI) *WebConnector {
+	return &WebConnector{
+		client: api,
+	}
+}
+
+// Nodes returns the health of the nodes that the connector uses.
+func (webConnector *WebConnector) Nodes() []client.NodeStatus {
+	return webConnector.client.Nodes()
+}
+
 // ServerStatus retrieves the connected server status with Info api.
 func (webConnector *WebConnector) ServerStatus() (status ServerStatus, err error) {
 	response, err := webConnector.client.Info()
@@ -38,6 +54,11 @@ func (webConnector *WebConnector) ServerStatus() (status ServerStatus, err error
 	return
 }
 
+// Delegations retrieves the delegations to the given delegation address from the delegation registry of the node.
+func (webConnector *WebConnector) Delegations(receiver string) (*jsonmodels.GetDelegationsResponse, error) {
+	return webConnector.client.GetDelegations(receiver)
+}
+
 // RequestFaucetFunds request some funds from the faucet for test purposes.
 func (webConnector *WebConnector) RequestFaucetFunds(addr address.Address, powTarget int) (err error) {
 	_, err = webConnector.client.SendFaucetRequest(addr.Address().Base58(), powTarget)
@@ -175,6 +196,89 @@ func (webConnector WebConnector) GetUnspentAliasOutput(addr *ledgerstate.AliasAd
 	return nil, errors.Errorf("couldn't find unspent alias output for alias addr %s", addr.Base58())
 }
 
+// GetAddressOutputs returns all outputs on the given addresses, including the ones that were spent already. The
+// inclusion state and the metadata of the returned outputs are not set.
+func (webConnector WebConnector) GetAddressOutputs(addresses ...address.Address) (outputs OutputsByAddressAndOutputID, err error) {
+	outputs = make(OutputsByAddressAndOutputID)
+	for _, addr := range addresses {
+		response, err := webConnector.client.GetAddressOutputs(addr.Address().Base58())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, output := range response.Outputs {
+			lOutput, err := output.ToLedgerstateOutput()
+			if err != nil {
+				return nil, err
+			}
+			if _, addressExists := outputs[addr]; !addressExists {
+				outputs[addr] = make(map[ledgerstate.OutputID]*Output)
+			}
+			outputs[addr][lOutput.ID()] = &Output{
+				Address: addr,
+				Object:  lOutput,
+			}
+		}
+	}
+
+	return
+}
+
+// GetOutputConsumers returns the IDs of the transactions that consume the given output.
+func (webConnector WebConnector) GetOutputConsumers(outputID ledgerstate.OutputID) (consumers []ledgerstate.TransactionID, err error) {
+	response, err := webConnector.client.GetOutputConsumers(outputID.Base58())
+	if err != nil {
+		return
+	}
+
+	consumers = make([]ledgerstate.TransactionID, len(response.Consumers))
+	for i, consumer := range response.Consumers {
+		if consumers[i], err = ledgerstate.TransactionIDFromBase58(consumer.TransactionID); err != nil {
+			return nil, err
+		}
+	}
+
+	return
+}
+
+// GetTransactionDetails returns the timestamp, the consumed outputs and the created outputs of the given transaction.
+func (webConnector WebConnector) GetTransactionDetails(txID ledgerstate.TransactionID) (details *TransactionDetails, err error) {
+	transaction, err := webConnector.client.GetTransaction(txID.Base58())
+	if err != nil {
+		return
+	}
+
+	details = &TransactionDetails{
+		ID:        txID,
+		Timestamp: time.Unix(transaction.Timestamp, 0),
+		Inputs:    make(ledgerstate.Outputs, 0, len(transaction.Inputs)),
+		Outputs:   make(ledgerstate.Outputs, 0, len(transaction.Outputs)),
+	}
+	for _, input := range transaction.Inputs {
+		if input.ReferencedOutputID == nil {
+			return nil, errors.Errorf("input of transaction %s has an unsupported type %s", txID.Base58(), input.Type)
+		}
+		consumedOutput, err := webConnector.client.GetOutput(input.ReferencedOutputID.Base58)
+		if err != nil {
+			return nil, err
+		}
+		lOutput, err := consumedOutput.ToLedgerstateOutput()
+		if err != nil {
+			return nil, err
+		}
+		details.Inputs = append(details.Inputs, lOutput)
+	}
+	for _, output := range transaction.Outputs {
+		lOutput, err := output.ToLedgerstateOutput()
+		if err != nil {
+			return nil, err
+		}
+		details.Outputs = append(details.Outputs, lOutput)
+	}
+
+	return
+}
+
 // colorFromString is an internal utility method that parses the given string into a Color.
 func colorFromString(colorStr string) (color ledgerstate.Color) {
 	if colorStr == "IOTA" {
@@ -34,17 +34,43 @@ type ConsensusMechanism struct {
 	Storage                  *Storage
 	likedThresholdExecutor   *timedexecutor.TimedExecutor
 	locallyFinalizedExecutor *timedexecutor.TimedExecutor
+	tracer                   *Tracer
 }
 
 // NewConsensusMechanism is the constructor for the FCoB consensus mechanism.
-func NewConsensusMechanism() *ConsensusMechanism {
-	return &ConsensusMechanism{
+func NewConsensusMechanism(opts ...Option) *ConsensusMechanism {
+	f := &ConsensusMechanism{
 		Events: &ConsensusMechanismEvents{
 			Error: events.NewEvent(events.ErrorCaller),
 			Vote:  events.NewEvent(voteEventHandler),
 		},
 		likedThresholdExecutor:   timedexecutor.New(1),
 		locallyFinalizedExecutor: timedexecutor.New(1),
+		tracer:                   NewTracer(DefaultTraceRetention, DefaultMaxTraces),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// Option is a function that configures the ConsensusMechanism.
+type Option func(*ConsensusMechanism)
+
+// TraceRetention sets how long the opinion traces of the transactions are kept after their last update. A retention
+// of 0 disables the traces.
+func TraceRetention(retention time.Duration) Option {
+	return func(f *ConsensusMechanism) {
+		f.tracer.retention = retention
+	}
+}
+
+// MaxTraces sets the number of transactions whose opinion traces are kept, the least recently updated traces are
+// evicted first. A limit of 0 disables the traces.
+func MaxTraces(maxTraces int) Option {
+	return func(f *ConsensusMechanism) {
+		f.tracer.maxTraces = maxTraces
 	}
 }
 
@@ -91,6 +117,7 @@ func (f *ConsensusMechanism) SetTransactionLiked(transactionID ledgerstate.Trans
 	f.Storage.Opinion(transactionID).Consume(func(opinion *Opinion) {
 		modified = opinion.SetLiked(liked)
 		opinion.SetLevelOfKnowledge(Three)
+		f.tracer.step(transactionID, RuleBranchDecided, liked, Three)
 	})
 
 	return modified
@@ -125,6 +152,10 @@ func (f *ConsensusMechanism) EvaluateTimestamp(messageID tangle.MessageID) {
 		LoK:       Two,
 	})
 
+	f.tangle.Utils.ComputeIfTransaction(messageID, func(transactionID ledgerstate.TransactionID) {
+		f.tracer.step(transactionID, RuleTimestamp, true, Two, messageID)
+	})
+
 	f.setEligibility(messageID)
 
 	f.setTimestampOpinionDone(messageID)
@@ -143,6 +174,7 @@ func (f *ConsensusMechanism) ProcessVote(ev *vote.OpinionEvent) {
 			return
 		}
 
+		f.traceVote(transactionID, ev, RuleFPCFinalized, Two)
 		f.Storage.Opinion(transactionID).Consume(func(opinion *Opinion) {
 			opinion.SetLiked(ev.Opinion == voter.Like)
 			opinion.SetLevelOfKnowledge(Two)
@@ -155,6 +187,33 @@ func (f *ConsensusMechanism) ProcessVote(ev *vote.OpinionEvent) {
 	}
 }
 
+// ProcessFailedVote allows an external voter to hand in a vote that failed to finalize. The opinion is kept, but the
+// vote is added to the trace of the transaction.
+func (f *ConsensusMechanism) ProcessFailedVote(ev *vote.OpinionEvent) {
+	if ev.Ctx.Type == vote.ConflictType {
+		transactionID, err := ledgerstate.TransactionIDFromBase58(ev.ID)
+		if err != nil {
+			f.Events.Error.Trigger(err)
+			return
+		}
+
+		f.traceVote(transactionID, ev, RuleFPCFailed, One)
+	}
+}
+
+// TransactionTrace returns the trace of the rules that formed the opinion of the given transaction. Traces are only
+// kept for a bounded time after their last update.
+func (f *ConsensusMechanism) TransactionTrace(transactionID ledgerstate.TransactionID) (trace *Trace, exists bool) {
+	if trace, exists = f.tracer.Trace(transactionID); !exists {
+		return nil, false
+	}
+
+	opinionEssence := f.Storage.OpinionEssence(transactionID)
+	trace.Liked, trace.LevelOfKnowledge = opinionEssence.liked, opinionEssence.levelOfKnowledge
+
+	return trace, true
+}
+
 // TransactionOpinionEssence returns the opinion essence of a given transactionID.
 func (f *ConsensusMechanism) TransactionOpinionEssence(transactionID ledgerstate.TransactionID) (opinion OpinionEssence) {
 	opinion = f.Storage.OpinionEssence(transactionID)
@@ -200,6 +259,9 @@ func (f *ConsensusMechanism) onTransactionBooked(transactionID ledgerstate.Trans
 	f.tangle.LedgerState.TransactionMetadata(transactionID).Consume(func(transactionMetadata *ledgerstate.TransactionMetadata) {
 		timestamp = transactionMetadata.SolidificationTime()
 	})
+	f.tracer.record(transactionID, func(trace *Trace) {
+		trace.ArrivalTime = timestamp
+	})
 
 	// filters both rejected and invalid branch
 	branchInclusionState := f.tangle.LedgerState.BranchInclusionState(f.tangle.LedgerState.BranchID(transactionID))
@@ -210,6 +272,7 @@ func (f *ConsensusMechanism) onTransactionBooked(transactionID ledgerstate.Trans
 			levelOfKnowledge: Two,
 		}
 		f.Storage.opinionStorage.Store(newOpinion).Release()
+		f.tracer.step(transactionID, RuleRejectedBranch, false, Two)
 		f.onPayloadOpinionFormed(messageID, newOpinion.liked)
 		return
 	}
@@ -218,6 +281,8 @@ func (f *ConsensusMechanism) onTransactionBooked(transactionID ledgerstate.Trans
 		newOpinion.OpinionEssence = deriveOpinion(timestamp, f.OpinionsEssence(transactionID, f.tangle.LedgerState.ConflictSet(transactionID)))
 
 		f.Storage.opinionStorage.Store(newOpinion).Release()
+		f.traceConflicts(transactionID)
+		f.tracer.step(transactionID, derivationRule(newOpinion.LevelOfKnowledge()), newOpinion.liked, newOpinion.LevelOfKnowledge())
 
 		switch newOpinion.LevelOfKnowledge() {
 		case Pending:
@@ -259,21 +324,25 @@ func (f *ConsensusMechanism) onTransactionBooked(transactionID ledgerstate.Trans
 				// and no other conflicts arrived within LikedThreshold seconds,
 				// start voting with local like
 				conflictSet := ConflictSet(f.OpinionsEssence(transactionID, f.tangle.LedgerState.ConflictSet(transactionID)))
+				f.traceConflicts(transactionID)
 				if conflictSet.finalizedAsDisliked(opinion.OpinionEssence) {
 					opinion.SetLiked(true)
 					opinion.SetLevelOfKnowledge(One)
+					f.tracer.step(transactionID, RuleLikedThreshold, true, One)
 					// trigger voting for this transactionID
 					f.Events.Vote.Trigger(transactionID.Base58(), voter.Like)
 					return
 				}
 				opinion.SetLevelOfKnowledge(One)
 				opinion.SetLiked(false)
+				f.tracer.step(transactionID, RuleLikedThreshold, false, One)
 				// trigger voting for this transactionID
 				f.Events.Vote.Trigger(transactionID.Base58(), voter.Dislike)
 				return
 			}
 			opinion.SetLevelOfKnowledge(One)
 			opinion.SetLiked(true)
+			f.tracer.step(transactionID, RuleLikedThreshold, true, One)
 		}) {
 			panic(fmt.Sprintf("could not load opinion of transaction %s", transactionID))
 		}
@@ -286,11 +355,13 @@ func (f *ConsensusMechanism) onTransactionBooked(transactionID ledgerstate.Trans
 
 					opinion.SetLiked(true)
 					if f.tangle.LedgerState.TransactionConflicting(transactionID) {
+						f.tracer.step(transactionID, RuleLocallyFinalizedThreshold, true, opinion.LevelOfKnowledge())
 						// trigger voting for this transactionID
 						f.Events.Vote.Trigger(transactionID.Base58(), voter.Like)
 						return
 					}
 					opinion.SetLevelOfKnowledge(Two)
+					f.tracer.step(transactionID, RuleLocallyFinalizedThreshold, true, Two)
 					// trigger OpinionPayloadFormed
 					messageIDs := f.tangle.Storage.AttachmentMessageIDs(transactionID)
 					for _, messageID := range messageIDs {
@@ -354,6 +425,33 @@ func (f *ConsensusMechanism) setEligibility(messageID tangle.MessageID) {
 	})
 }
 
+// traceConflicts records the arrival times of the transactions that conflict with the given transaction in its trace.
+func (f *ConsensusMechanism) traceConflicts(transactionID ledgerstate.TransactionID) {
+	conflicts := make([]ConflictArrival, 0)
+	for conflictID := range f.tangle.LedgerState.ConflictSet(transactionID) {
+		if conflictID == transactionID {
+			continue
+		}
+		conflicts = append(conflicts, ConflictArrival{
+			TransactionID: conflictID,
+			ArrivalTime:   f.Storage.OpinionEssence(conflictID).timestamp,
+		})
+	}
+
+	f.tracer.record(transactionID, func(trace *Trace) {
+		trace.Conflicts = conflicts
+	})
+}
+
+// traceVote records the rounds and opinions of the given FPC vote in the trace of the given transaction.
+func (f *ConsensusMechanism) traceVote(transactionID ledgerstate.TransactionID, ev *vote.OpinionEvent, rule Rule, levelOfKnowledge LevelOfKnowledge) {
+	f.tracer.record(transactionID, func(trace *Trace) {
+		trace.FPCRounds = ev.Ctx.Rounds
+		trace.FPCOpinions = append([]voter.Opinion(nil), ev.Ctx.Opinions...)
+	})
+	f.tracer.step(transactionID, rule, ev.Opinion == voter.Like, levelOfKnowledge)
+}
+
 // OpinionFormedTime returns the time when the opinion for the given message was formed.
 func (f *ConsensusMechanism) OpinionFormedTime(messageID tangle.MessageID) (t time.Time) {
 	f.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *MessageMetadata) {
@@ -459,6 +557,18 @@ func voteEventHandler(handler interface{}, params ...interface{}) {
 
 // region utility functions ////////////////////////////////////////////////////////////////////////////////////////////
 
+// derivationRule returns the Rule that derived an opinion with the given LevelOfKnowledge from the conflict set.
+func derivationRule(levelOfKnowledge LevelOfKnowledge) Rule {
+	switch levelOfKnowledge {
+	case Pending:
+		return RuleConflictPending
+	case One:
+		return RuleConflictAnchor
+	default:
+		return RuleConflictDecidedLike
+	}
+}
+
 // deriveOpinion returns the initial opinion based on the given targetTime and conflictSet.
 func deriveOpinion(targetTime time.Time, conflictSet ConflictSet) (opinion OpinionEssence) {
 	if conflictSet.hasDecidedLike() {
@@ -343,6 +343,25 @@ func TestOpinionFormer(t *testing.T) {
 
 	wg.Wait()
 
+	conflicts := map[ledgerstate.TransactionID]ledgerstate.TransactionID{tx1.ID(): tx2.ID(), tx2.ID(): tx1.ID()}
+	for transactionID, conflictID := range conflicts {
+		trace, exists := consensusProvider.TransactionTrace(transactionID)
+		require.True(t, exists)
+		require.Len(t, trace.Conflicts, 1)
+		assert.Equal(t, conflictID, trace.Conflicts[0].TransactionID)
+		assert.False(t, trace.ArrivalTime.IsZero())
+		assert.False(t, trace.Liked)
+		assert.Equal(t, Two, trace.LevelOfKnowledge)
+
+		rules := make(map[Rule]TraceStep)
+		for _, step := range trace.Steps {
+			rules[step.Rule] = step
+		}
+		assert.Contains(t, rules, RuleTimestamp)
+		assert.Contains(t, rules, RuleFPCFinalized)
+		assert.False(t, rules[RuleFPCFinalized].Liked)
+	}
+
 	t.Log("Waiting shutdown..")
 }
 
@@ -0,0 +1,219 @@
+package fcob
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+const (
+	// DefaultTraceRetention is the default time that the opinion trace of a transaction is kept after its last update.
+	DefaultTraceRetention = time.Hour
+	// DefaultMaxTraces is the default number of transactions whose opinion traces are kept.
+	DefaultMaxTraces = 10000
+)
+
+// region Rule /////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Rule is a rule of FCoB that formed or changed the opinion about a transaction.
+type Rule uint8
+
+const (
+	// RuleRejectedBranch disliked the transaction as it was booked into a rejected or invalid branch.
+	RuleRejectedBranch Rule = iota
+	// RuleConflictDecidedLike disliked the transaction as a conflicting transaction was already liked with LoK Two.
+	RuleConflictDecidedLike
+	// RuleConflictAnchor disliked the transaction as a conflicting transaction arrived before it.
+	RuleConflictAnchor
+	// RuleConflictPending kept the opinion pending as the opinions about the conflicting transactions are pending.
+	RuleConflictPending
+	// RuleLikedThreshold formed the opinion after the first half of the quarantine time.
+	RuleLikedThreshold
+	// RuleLocallyFinalizedThreshold formed the opinion after the second half of the quarantine time.
+	RuleLocallyFinalizedThreshold
+	// RuleTimestamp formed the opinion about the timestamp of an attachment of the transaction.
+	RuleTimestamp
+	// RuleFPCFinalized set the opinion to the result of a finalized FPC vote.
+	RuleFPCFinalized
+	// RuleFPCFailed recorded a FPC vote that was not finalized in the maximum number of rounds.
+	RuleFPCFailed
+	// RuleBranchDecided set the opinion as the branch of the transaction was confirmed or rejected.
+	RuleBranchDecided
+)
+
+// String returns a human readable version of the Rule.
+func (r Rule) String() string {
+	switch r {
+	case RuleRejectedBranch:
+		return "RejectedBranch"
+	case RuleConflictDecidedLike:
+		return "ConflictDecidedLike"
+	case RuleConflictAnchor:
+		return "ConflictAnchor"
+	case RuleConflictPending:
+		return "ConflictPending"
+	case RuleLikedThreshold:
+		return "LikedThreshold"
+	case RuleLocallyFinalizedThreshold:
+		return "LocallyFinalizedThreshold"
+	case RuleTimestamp:
+		return "Timestamp"
+	case RuleFPCFinalized:
+		return "FPCFinalized"
+	case RuleFPCFailed:
+		return "FPCFailed"
+	case RuleBranchDecided:
+		return "BranchDecided"
+	default:
+		return "Rule(Unknown)"
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Trace ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Trace records how the opinion about a transaction was formed.
+type Trace struct {
+	// TransactionID is the ID of the traced transaction.
+	TransactionID ledgerstate.TransactionID
+	// ArrivalTime is the time when the transaction became solid.
+	ArrivalTime time.Time
+	// Conflicts are the arrival times of the conflicting transactions, as known when the opinion was formed.
+	Conflicts []ConflictArrival
+	// Steps are the rules that fired, in the order in which they fired.
+	Steps []TraceStep
+	// FPCRounds is the number of FPC rounds that the vote about the transaction took.
+	FPCRounds int
+	// FPCOpinions are the opinions that were formed in the FPC rounds, starting with the initial opinion.
+	FPCOpinions []opinion.Opinion
+	// Liked is the current opinion about the transaction.
+	Liked bool
+	// LevelOfKnowledge is the level of knowledge of the current opinion.
+	LevelOfKnowledge LevelOfKnowledge
+
+	lastUpdated time.Time
+}
+
+// ConflictArrival is the arrival time of a conflicting transaction.
+type ConflictArrival struct {
+	TransactionID ledgerstate.TransactionID
+	ArrivalTime   time.Time
+}
+
+// TraceStep is a rule that fired for a transaction and the opinion that it formed.
+type TraceStep struct {
+	Time             time.Time
+	Rule             Rule
+	Liked            bool
+	LevelOfKnowledge LevelOfKnowledge
+	// MessageID is the attachment that the rule evaluated, if the rule evaluates attachments.
+	MessageID tangle.MessageID
+}
+
+// clone returns a copy of the Trace that can be handed out.
+func (t *Trace) clone() *Trace {
+	cloned := *t
+	cloned.Conflicts = append([]ConflictArrival(nil), t.Conflicts...)
+	cloned.Steps = append([]TraceStep(nil), t.Steps...)
+	cloned.FPCOpinions = append([]opinion.Opinion(nil), t.FPCOpinions...)
+
+	return &cloned
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Tracer ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Tracer keeps the opinion traces of the transactions in memory for a bounded time after their last update. The number
+// of traces is bounded as well: if it is reached, the least recently updated trace is evicted, so that a burst of
+// conflicts can not grow the memory without limit.
+type Tracer struct {
+	retention time.Duration
+	maxTraces int
+	traces    map[ledgerstate.TransactionID]*list.Element
+	// recentlyUpdated contains the traces ordered by their last update, the most recently updated one first.
+	recentlyUpdated *list.List
+	mutex           sync.Mutex
+}
+
+// NewTracer creates a Tracer that keeps at most maxTraces traces for the given retention.
+func NewTracer(retention time.Duration, maxTraces int) *Tracer {
+	return &Tracer{
+		retention:       retention,
+		maxTraces:       maxTraces,
+		traces:          make(map[ledgerstate.TransactionID]*list.Element),
+		recentlyUpdated: list.New(),
+	}
+}
+
+// Trace returns a copy of the trace of the given transaction.
+func (t *Tracer) Trace(transactionID ledgerstate.TransactionID) (trace *Trace, exists bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	element, exists := t.traces[transactionID]
+	if !exists || time.Since(element.Value.(*Trace).lastUpdated) > t.retention {
+		return nil, false
+	}
+
+	return element.Value.(*Trace).clone(), true
+}
+
+// record updates the trace of the given transaction with the given function and creates it if it does not exist yet.
+func (t *Tracer) record(transactionID ledgerstate.TransactionID, update func(trace *Trace)) {
+	if t.retention <= 0 || t.maxTraces <= 0 {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := time.Now()
+	t.prune(now)
+
+	element, exists := t.traces[transactionID]
+	if !exists {
+		if len(t.traces) >= t.maxTraces {
+			t.remove(t.recentlyUpdated.Back())
+		}
+		element = t.recentlyUpdated.PushFront(&Trace{TransactionID: transactionID})
+		t.traces[transactionID] = element
+	}
+	t.recentlyUpdated.MoveToFront(element)
+
+	trace := element.Value.(*Trace)
+	update(trace)
+	trace.lastUpdated = now
+}
+
+// step appends a step with the given rule and opinion to the trace of the given transaction.
+func (t *Tracer) step(transactionID ledgerstate.TransactionID, rule Rule, liked bool, levelOfKnowledge LevelOfKnowledge, messageID ...tangle.MessageID) {
+	t.record(transactionID, func(trace *Trace) {
+		step := TraceStep{Time: time.Now(), Rule: rule, Liked: liked, LevelOfKnowledge: levelOfKnowledge}
+		if len(messageID) > 0 {
+			step.MessageID = messageID[0]
+		}
+		trace.Steps = append(trace.Steps, step)
+	})
+}
+
+// prune removes the traces that were not updated within the retention. It needs to be called with the lock of the
+// Tracer.
+func (t *Tracer) prune(now time.Time) {
+	for element := t.recentlyUpdated.Back(); element != nil && now.Sub(element.Value.(*Trace).lastUpdated) > t.retention; element = t.recentlyUpdated.Back() {
+		t.remove(element)
+	}
+}
+
+// remove removes the trace of the given element. It needs to be called with the lock of the Tracer.
+func (t *Tracer) remove(element *list.Element) {
+	t.recentlyUpdated.Remove(element)
+	delete(t.traces, element.Value.(*Trace).TransactionID)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
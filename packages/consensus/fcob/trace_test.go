@@ -0,0 +1,68 @@
+package fcob
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func TestTracer(t *testing.T) {
+	tracer := NewTracer(50*time.Millisecond, DefaultMaxTraces)
+	transactionID := ledgerstate.TransactionID{1}
+
+	tracer.step(transactionID, RuleLikedThreshold, true, One)
+	tracer.step(transactionID, RuleLocallyFinalizedThreshold, true, Two)
+
+	trace, exists := tracer.Trace(transactionID)
+	require.True(t, exists)
+	require.Len(t, trace.Steps, 2)
+	assert.Equal(t, RuleLikedThreshold, trace.Steps[0].Rule)
+	assert.Equal(t, Two, trace.Steps[1].LevelOfKnowledge)
+
+	// the returned trace is a copy
+	trace.Steps[0].Rule = RuleFPCFailed
+	trace, _ = tracer.Trace(transactionID)
+	assert.Equal(t, RuleLikedThreshold, trace.Steps[0].Rule)
+
+	// traces expire after the retention and are pruned by later updates
+	time.Sleep(60 * time.Millisecond)
+	_, exists = tracer.Trace(transactionID)
+	assert.False(t, exists)
+	tracer.step(ledgerstate.TransactionID{2}, RuleTimestamp, true, Two)
+	assert.Len(t, tracer.traces, 1)
+
+	// a retention of 0 disables the traces
+	disabled := NewTracer(0, DefaultMaxTraces)
+	disabled.step(transactionID, RuleTimestamp, true, Two)
+	_, exists = disabled.Trace(transactionID)
+	assert.False(t, exists)
+}
+
+func TestTracer_MaxTraces(t *testing.T) {
+	tracer := NewTracer(time.Hour, 2)
+	tracer.step(ledgerstate.TransactionID{1}, RuleLikedThreshold, true, One)
+	tracer.step(ledgerstate.TransactionID{2}, RuleLikedThreshold, true, One)
+	tracer.step(ledgerstate.TransactionID{1}, RuleLocallyFinalizedThreshold, true, Two)
+
+	// the least recently updated trace is evicted
+	tracer.step(ledgerstate.TransactionID{3}, RuleLikedThreshold, true, One)
+	assert.Len(t, tracer.traces, 2)
+	assert.Equal(t, 2, tracer.recentlyUpdated.Len())
+	_, exists := tracer.Trace(ledgerstate.TransactionID{2})
+	assert.False(t, exists)
+	trace, exists := tracer.Trace(ledgerstate.TransactionID{1})
+	require.True(t, exists)
+	assert.Len(t, trace.Steps, 2)
+	_, exists = tracer.Trace(ledgerstate.TransactionID{3})
+	assert.True(t, exists)
+
+	// a limit of 0 disables the traces
+	disabled := NewTracer(time.Hour, 0)
+	disabled.step(ledgerstate.TransactionID{1}, RuleTimestamp, true, Two)
+	_, exists = disabled.Trace(ledgerstate.TransactionID{1})
+	assert.False(t, exists)
+}
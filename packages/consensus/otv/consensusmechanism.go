@@ -0,0 +1,231 @@
+package otv
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+// region ConsensusMechanism ///////////////////////////////////////////////////////////////////////////////////////////
+
+// ConsensusMechanism represents the "on tangle voting" consensus that can be used as a ConsensusMechanism in the Tangle.
+// It does not vote: of every conflict set it likes the Branch with the highest approval weight, so the opinions of the
+// nodes converge to the heaviest Branches by the messages they issue. Branches of equal weight are decided in favor of
+// the transaction that was solid first, and the liked Branch is only replaced by a strictly heavier one.
+type ConsensusMechanism struct {
+	Events *ConsensusMechanismEvents
+
+	tangle        *tangle.Tangle
+	evaluateMutex sync.Mutex
+}
+
+// NewConsensusMechanism is the constructor for the OTV consensus mechanism.
+func NewConsensusMechanism() *ConsensusMechanism {
+	return &ConsensusMechanism{
+		Events: &ConsensusMechanismEvents{
+			Error:              events.NewEvent(events.ErrorCaller),
+			BranchLikeSwitched: events.NewEvent(branchIDEventHandler),
+		},
+	}
+}
+
+// Init initializes the ConsensusMechanism by making the Tangle object available that is using it.
+func (o *ConsensusMechanism) Init(tangle *tangle.Tangle) {
+	o.tangle = tangle
+}
+
+// Setup sets up the behavior of the ConsensusMechanism by making it attach to the relevant events in the Tangle.
+func (o *ConsensusMechanism) Setup() {
+	o.tangle.LedgerState.BranchDAG.Events.BranchConfirmed.Attach(events.NewClosure(func(branchDAGEvent *ledgerstate.BranchDAGEvent) {
+		defer branchDAGEvent.Release()
+		o.SetTransactionLiked(branchDAGEvent.Branch.ID().TransactionID(), true)
+	}))
+	o.tangle.LedgerState.BranchDAG.Events.BranchRejected.Attach(events.NewClosure(func(branchDAGEvent *ledgerstate.BranchDAGEvent) {
+		defer branchDAGEvent.Release()
+		o.SetTransactionLiked(branchDAGEvent.Branch.ID().TransactionID(), false)
+	}))
+
+	o.tangle.Booker.Events.MessageBooked.Attach(events.NewClosure(o.Evaluate))
+	o.tangle.ApprovalWeightManager.Events.MessageProcessed.Attach(events.NewClosure(o.evaluateMessageBranch))
+}
+
+// TransactionLiked returns a boolean value indicating whether the given Transaction is liked.
+func (o *ConsensusMechanism) TransactionLiked(transactionID ledgerstate.TransactionID) (liked bool) {
+	o.tangle.LedgerState.BranchDAG.Branch(o.tangle.LedgerState.BranchID(transactionID)).Consume(func(branch ledgerstate.Branch) {
+		liked = branch.MonotonicallyLiked()
+	})
+
+	return
+}
+
+// SetTransactionLiked sets the transaction like status.
+func (o *ConsensusMechanism) SetTransactionLiked(transactionID ledgerstate.TransactionID, liked bool) (modified bool) {
+	branchID := o.tangle.LedgerState.BranchID(transactionID)
+	if branchID.TransactionID() != transactionID {
+		return false
+	}
+
+	modified, err := o.tangle.LedgerState.BranchDAG.SetBranchLiked(branchID, liked)
+	if err != nil {
+		o.Events.Error.Trigger(err)
+	}
+
+	return modified
+}
+
+// Shutdown shuts down the ConsensusMechanism and persists its state.
+func (o *ConsensusMechanism) Shutdown() {}
+
+// Evaluate forms the opinion of the given message: its timestamp is always considered honest and the Branch of its
+// transaction is liked if it is the heaviest of its conflict sets.
+func (o *ConsensusMechanism) Evaluate(messageID tangle.MessageID) {
+	o.tangle.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *tangle.MessageMetadata) {
+		messageMetadata.SetEligible(o.parentsEligible(messageID))
+	})
+
+	o.tangle.Utils.ComputeIfTransaction(messageID, func(transactionID ledgerstate.TransactionID) {
+		if o.tangle.LedgerState.TransactionConflicting(transactionID) {
+			o.EvaluateBranch(o.tangle.LedgerState.BranchID(transactionID))
+		}
+	})
+
+	o.tangle.ConsensusManager.Events.MessageOpinionFormed.Trigger(messageID)
+}
+
+// EvaluateBranch likes the heaviest Branch of every conflict set of the ConflictBranches that the given Branch consists
+// of.
+func (o *ConsensusMechanism) EvaluateBranch(branchID ledgerstate.BranchID) {
+	conflictBranchIDs, err := o.tangle.LedgerState.BranchDAG.ResolveConflictBranchIDs(ledgerstate.NewBranchIDs(branchID))
+	if err != nil {
+		o.Events.Error.Trigger(err)
+		return
+	}
+
+	o.evaluateMutex.Lock()
+	defer o.evaluateMutex.Unlock()
+
+	for conflictBranchID := range conflictBranchIDs {
+		o.tangle.LedgerState.BranchDAG.Branch(conflictBranchID).Consume(func(branch ledgerstate.Branch) {
+			conflictBranch, ok := branch.(*ledgerstate.ConflictBranch)
+			if !ok {
+				return
+			}
+			for conflictID := range conflictBranch.Conflicts() {
+				o.evaluateConflict(conflictID)
+			}
+		})
+	}
+}
+
+// evaluateConflict likes the heaviest member of the given conflict set, unless the liked member is equally heavy.
+func (o *ConsensusMechanism) evaluateConflict(conflictID ledgerstate.ConflictID) {
+	var (
+		likedBranchID    ledgerstate.BranchID
+		likedWeight      = -1.0
+		heaviestBranchID ledgerstate.BranchID
+		heaviestWeight   = -1.0
+		heaviestTime     time.Time
+		decided          bool
+	)
+	o.tangle.LedgerState.BranchDAG.ConflictMembers(conflictID).Consume(func(conflictMember *ledgerstate.ConflictMember) {
+		memberID := conflictMember.BranchID()
+		if o.tangle.LedgerState.BranchInclusionState(memberID) != ledgerstate.Pending {
+			decided = true
+			return
+		}
+
+		weight := o.weightOfBranch(memberID)
+		o.tangle.LedgerState.BranchDAG.Branch(memberID).Consume(func(branch ledgerstate.Branch) {
+			if branch.Liked() {
+				likedBranchID, likedWeight = memberID, weight
+			}
+		})
+
+		solidificationTime := o.solidificationTime(memberID.TransactionID())
+		if weight > heaviestWeight || (weight == heaviestWeight && solidificationTime.Before(heaviestTime)) {
+			heaviestBranchID, heaviestWeight, heaviestTime = memberID, weight, solidificationTime
+		}
+	})
+	if decided || heaviestWeight < 0 || heaviestBranchID == likedBranchID || likedWeight >= heaviestWeight {
+		return
+	}
+
+	if _, err := o.tangle.LedgerState.BranchDAG.SetBranchLiked(heaviestBranchID, true); err != nil {
+		o.Events.Error.Trigger(err)
+		return
+	}
+	if likedBranchID != ledgerstate.UndefinedBranchID {
+		o.Events.BranchLikeSwitched.Trigger(likedBranchID, heaviestBranchID)
+	}
+}
+
+// evaluateMessageBranch re-evaluates the conflict sets of the Branch of the given message after its approval weight was
+// applied.
+func (o *ConsensusMechanism) evaluateMessageBranch(messageID tangle.MessageID) {
+	branchID, err := o.tangle.Booker.MessageBranchID(messageID)
+	if err != nil {
+		o.Events.Error.Trigger(err)
+		return
+	}
+	if branchID == ledgerstate.MasterBranchID {
+		return
+	}
+
+	o.EvaluateBranch(branchID)
+}
+
+// weightOfBranch returns the approval weight of the given ConflictBranch, which is 0 if nobody supported it yet.
+func (o *ConsensusMechanism) weightOfBranch(branchID ledgerstate.BranchID) (weight float64) {
+	if weight = o.tangle.ApprovalWeightManager.WeightOfBranch(branchID); weight == math.MaxFloat64 {
+		return 0
+	}
+
+	return weight
+}
+
+// solidificationTime returns the time when the given transaction became solid.
+func (o *ConsensusMechanism) solidificationTime(transactionID ledgerstate.TransactionID) (solidificationTime time.Time) {
+	o.tangle.LedgerState.TransactionMetadata(transactionID).Consume(func(transactionMetadata *ledgerstate.TransactionMetadata) {
+		solidificationTime = transactionMetadata.SolidificationTime()
+	})
+
+	return
+}
+
+// parentsEligible checks if all parents of the given message are eligible.
+func (o *ConsensusMechanism) parentsEligible(messageID tangle.MessageID) (eligible bool) {
+	o.tangle.Storage.Message(messageID).Consume(func(message *tangle.Message) {
+		eligible = true
+		message.ForEachParent(func(parent tangle.Parent) {
+			eligible = eligible && o.tangle.ConsensusManager.MessageEligible(parent.ID)
+		})
+	})
+
+	return
+}
+
+var _ tangle.ConsensusMechanism = &ConsensusMechanism{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ConsensusMechanismEvents /////////////////////////////////////////////////////////////////////////////////////
+
+// ConsensusMechanismEvents represents events triggered by the ConsensusMechanism.
+type ConsensusMechanismEvents struct {
+	// Error gets called when OTV faces an error.
+	Error *events.Event
+
+	// BranchLikeSwitched gets called when a heavier Branch replaced the liked Branch of a conflict set.
+	BranchLikeSwitched *events.Event
+}
+
+func branchIDEventHandler(handler interface{}, params ...interface{}) {
+	handler.(func(previousBranchID, newBranchID ledgerstate.BranchID))(params[0].(ledgerstate.BranchID), params[1].(ledgerstate.BranchID))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
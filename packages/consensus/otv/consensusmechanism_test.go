@@ -0,0 +1,92 @@
+package otv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+func TestConsensusMechanism(t *testing.T) {
+	nodes := make(map[string]*identity.Identity)
+	for _, node := range []string{"A", "B", "C", "D", "E"} {
+		nodes[node] = identity.GenerateIdentity()
+	}
+
+	var weightProvider *tangle.CManaWeightProvider
+	manaRetrieverMock := func() map[identity.ID]float64 {
+		for _, node := range nodes {
+			weightProvider.Update(time.Now(), node.ID())
+		}
+		return map[identity.ID]float64{
+			nodes["A"].ID(): 30,
+			nodes["B"].ID(): 15,
+			nodes["C"].ID(): 25,
+			nodes["D"].ID(): 20,
+			nodes["E"].ID(): 10,
+		}
+	}
+	weightProvider = tangle.NewCManaWeightProvider(manaRetrieverMock, time.Now)
+
+	consensusMechanism := NewConsensusMechanism()
+	testTangle := tangle.New(
+		tangle.Consensus(consensusMechanism),
+		tangle.ApprovalWeights(weightProvider),
+		tangle.SchedulerConfig(tangle.SchedulerParams{
+			Rate:                        time.Millisecond,
+			AccessManaRetrieveFunc:      func(identity.ID) float64 { return 1 },
+			TotalAccessManaRetrieveFunc: func() float64 { return 1 },
+		}),
+		tangle.CacheTimeProvider(database.NewCacheTimeProvider(0)),
+	)
+	defer testTangle.Shutdown()
+	testTangle.Setup()
+
+	var switched []ledgerstate.BranchID
+	consensusMechanism.Events.BranchLikeSwitched.Attach(events.NewClosure(func(previousBranchID, newBranchID ledgerstate.BranchID) {
+		switched = append(switched, previousBranchID, newBranchID)
+	}))
+	consensusMechanism.Events.Error.Attach(events.NewClosure(func(err error) {
+		t.Error(err)
+	}))
+
+	testFramework := tangle.NewMessageTestFramework(testTangle, tangle.WithGenesisOutput("G", 500))
+	issue := func(alias string, options ...tangle.MessageOption) {
+		testFramework.CreateMessage(alias, options...)
+		testFramework.IssueMessages(alias).WaitApprovalWeightProcessed()
+	}
+
+	issue("Message1", tangle.WithStrongParents("Genesis"), tangle.WithIssuer(nodes["A"].PublicKey()))
+	issue("Message2", tangle.WithStrongParents("Message1"), tangle.WithIssuer(nodes["A"].PublicKey()), tangle.WithInputs("G"), tangle.WithOutput("B", 500))
+	assert.True(t, consensusMechanism.TransactionLiked(testFramework.TransactionID("Message2")))
+
+	// the double spend is disliked, as the first transaction is heavier
+	issue("Message3", tangle.WithStrongParents("Message1"), tangle.WithIssuer(nodes["E"].PublicKey()), tangle.WithInputs("G"), tangle.WithOutput("C", 500))
+	assert.True(t, consensusMechanism.TransactionLiked(testFramework.TransactionID("Message2")))
+	assert.False(t, consensusMechanism.TransactionLiked(testFramework.TransactionID("Message3")))
+
+	// the weight of the double spend grows to 0.25, which is still lighter than 0.30
+	issue("Message4", tangle.WithStrongParents("Message3"), tangle.WithIssuer(nodes["B"].PublicKey()))
+	assert.True(t, consensusMechanism.TransactionLiked(testFramework.TransactionID("Message2")))
+	assert.Empty(t, switched)
+
+	// once it is heavier, the node switches its opinion
+	issue("Message5", tangle.WithStrongParents("Message4"), tangle.WithIssuer(nodes["C"].PublicKey()))
+	assert.False(t, consensusMechanism.TransactionLiked(testFramework.TransactionID("Message2")))
+	assert.True(t, consensusMechanism.TransactionLiked(testFramework.TransactionID("Message3")))
+	assert.Equal(t, []ledgerstate.BranchID{testFramework.BranchID("Message2"), testFramework.BranchID("Message3")}, switched)
+
+	// an equally heavy branch does not replace the liked one
+	issue("Message6", tangle.WithStrongParents("Message2"), tangle.WithIssuer(nodes["D"].PublicKey()))
+	assert.True(t, consensusMechanism.TransactionLiked(testFramework.TransactionID("Message3")))
+	assert.Len(t, switched, 2)
+
+	// all messages have honest timestamps
+	assert.True(t, testFramework.MessageMetadata("Message6").IsEligible())
+}
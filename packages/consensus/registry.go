@@ -0,0 +1,77 @@
+package consensus
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+var (
+	// ErrUnknownConsensusMechanism is returned when no consensus mechanism with the requested name is registered.
+	ErrUnknownConsensusMechanism = errors.New("unknown consensus mechanism")
+	// ErrConsensusMechanismRegistered is returned when a consensus mechanism with the same name is already registered.
+	ErrConsensusMechanismRegistered = errors.New("consensus mechanism already registered")
+)
+
+// Factory creates a new instance of a consensus mechanism.
+type Factory func() tangle.ConsensusMechanism
+
+// Registry contains the consensus mechanisms that a node can use, so that the used one can be selected by name.
+type Registry struct {
+	factories      map[string]Factory
+	factoriesMutex sync.RWMutex
+}
+
+// NewRegistry creates a new empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: make(map[string]Factory),
+	}
+}
+
+// Register adds the consensus mechanism created by the given factory under the given name.
+func (r *Registry) Register(name string, factory Factory) error {
+	r.factoriesMutex.Lock()
+	defer r.factoriesMutex.Unlock()
+
+	if _, exists := r.factories[name]; exists {
+		return errors.Errorf("failed to register %s: %w", name, ErrConsensusMechanismRegistered)
+	}
+	r.factories[name] = factory
+
+	return nil
+}
+
+// New creates the consensus mechanism with the given name.
+func (r *Registry) New(name string) (tangle.ConsensusMechanism, error) {
+	r.factoriesMutex.RLock()
+	defer r.factoriesMutex.RUnlock()
+
+	factory, exists := r.factories[name]
+	if !exists {
+		return nil, errors.Errorf("%s is not one of %v: %w", name, r.names(), ErrUnknownConsensusMechanism)
+	}
+
+	return factory(), nil
+}
+
+// Names returns the sorted names of all registered consensus mechanisms.
+func (r *Registry) Names() []string {
+	r.factoriesMutex.RLock()
+	defer r.factoriesMutex.RUnlock()
+
+	return r.names()
+}
+
+func (r *Registry) names() (names []string) {
+	names = make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
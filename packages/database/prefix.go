@@ -24,4 +24,16 @@ const (
 
 	// PrefixEpochs defines the storage prefix for the epochs package.
 	PrefixEpochs
+
+	// PrefixManualPeering defines the storage prefix for the manualpeering package.
+	PrefixManualPeering
+
+	// PrefixAnalysis defines the storage prefix for the history of the analysis server.
+	PrefixAnalysis
+
+	// PrefixDelegation defines the storage prefix for the delegation registry of the manarefresher plugin.
+	PrefixDelegation
+
+	// PrefixStatement defines the storage prefix for the statements received by the consensus plugin.
+	PrefixStatement
 )
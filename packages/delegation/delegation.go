@@ -0,0 +1,127 @@
+package delegation
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/marshalutil"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// Status represents the lifecycle state of a delegation.
+type Status uint8
+
+const (
+	// Active is the status of a delegation whose funds are delegated to the receiver.
+	Active Status = iota
+	// Expiring is the status of a delegation whose timelock expires within the expiry window of the registry.
+	Expiring
+	// Expired is the status of a delegation whose timelock has passed, so the delegator can reclaim the funds.
+	Expired
+	// Reclaimed is the status of a delegation whose funds were taken back by the delegator.
+	Reclaimed
+)
+
+// String returns a human readable representation of the Status.
+func (s Status) String() string {
+	switch s {
+	case Active:
+		return "active"
+	case Expiring:
+		return "expiring"
+	case Expired:
+		return "expired"
+	case Reclaimed:
+		return "reclaimed"
+	default:
+		return "unknown"
+	}
+}
+
+// Delegation is the registry entry of a delegation alias output.
+type Delegation struct {
+	// AliasAddress is the address of the delegation alias.
+	AliasAddress *ledgerstate.AliasAddress
+	// OutputID is the ID of the latest known output of the delegation alias.
+	OutputID ledgerstate.OutputID
+	// Receiver is the state address of the alias, i.e. the delegation address of the node the funds are delegated to.
+	Receiver ledgerstate.Address
+	// Delegator is the governing address of the alias that is able to reclaim the funds.
+	Delegator ledgerstate.Address
+	// Balances are the delegated funds.
+	Balances *ledgerstate.ColoredBalances
+	// Timelock is the time until the funds are locked in the delegation, zero if there is no timelock.
+	Timelock time.Time
+	// Since is the time the funds were delegated.
+	Since time.Time
+	// Updated is the time of the latest output of the delegation alias.
+	Updated time.Time
+	// Closed is the time the funds were reclaimed, zero if the delegation was not reclaimed.
+	Closed time.Time
+	// Status is the lifecycle state of the delegation.
+	Status Status
+}
+
+// Amount returns the total amount of delegated funds, which is also the consensus mana it contributes to the receiver.
+func (d *Delegation) Amount() (amount uint64) {
+	d.Balances.ForEach(func(_ ledgerstate.Color, balance uint64) bool {
+		amount += balance
+		return true
+	})
+	return amount
+}
+
+// Clone returns a copy of the Delegation.
+func (d *Delegation) Clone() *Delegation {
+	c := *d
+	return &c
+}
+
+// Bytes returns a marshaled version of the Delegation.
+func (d *Delegation) Bytes() []byte {
+	return marshalutil.New().
+		Write(d.AliasAddress).
+		Write(d.OutputID).
+		Write(d.Receiver).
+		Write(d.Delegator).
+		Write(d.Balances).
+		WriteTime(d.Timelock).
+		WriteTime(d.Since).
+		WriteTime(d.Updated).
+		WriteTime(d.Closed).
+		WriteUint8(uint8(d.Status)).
+		Bytes()
+}
+
+// FromBytes unmarshals a Delegation from a sequence of bytes.
+func FromBytes(bytes []byte) (delegation *Delegation, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	delegation = &Delegation{}
+	if delegation.AliasAddress, err = ledgerstate.AliasAddressFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Wrap(err, "failed to parse alias address")
+	}
+	if delegation.OutputID, err = ledgerstate.OutputIDFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Wrap(err, "failed to parse output ID")
+	}
+	if delegation.Receiver, err = ledgerstate.AddressFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Wrap(err, "failed to parse receiver address")
+	}
+	if delegation.Delegator, err = ledgerstate.AddressFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Wrap(err, "failed to parse delegator address")
+	}
+	if delegation.Balances, err = ledgerstate.ColoredBalancesFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Wrap(err, "failed to parse balances")
+	}
+	for _, t := range []*time.Time{&delegation.Timelock, &delegation.Since, &delegation.Updated, &delegation.Closed} {
+		if *t, err = marshalUtil.ReadTime(); err != nil {
+			return nil, errors.Wrap(err, "failed to parse time")
+		}
+	}
+	status, err := marshalUtil.ReadUint8()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse status")
+	}
+	delegation.Status = Status(status)
+	return delegation, nil
+}
@@ -0,0 +1,30 @@
+package delegation
+
+import (
+	"github.com/iotaledger/hive.go/events"
+)
+
+// Events contains the events of the delegation Registry.
+type Events struct {
+	// Created is triggered when funds are delegated to a receiver.
+	Created *events.Event
+	// Expiring is triggered when the timelock of a delegation enters the expiry window of the registry.
+	Expiring *events.Event
+	// Expired is triggered when the timelock of a delegation has passed.
+	Expired *events.Event
+	// Reclaimed is triggered when the delegator took back the delegated funds.
+	Reclaimed *events.Event
+}
+
+func newEvents() *Events {
+	return &Events{
+		Created:   events.NewEvent(delegationEventCaller),
+		Expiring:  events.NewEvent(delegationEventCaller),
+		Expired:   events.NewEvent(delegationEventCaller),
+		Reclaimed: events.NewEvent(delegationEventCaller),
+	}
+}
+
+func delegationEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(delegation *Delegation))(params[0].(*Delegation))
+}
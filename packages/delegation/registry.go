@@ -0,0 +1,296 @@
+package delegation
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/kvstore"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// DefaultExpiryWindow is the default time before the timelock of a delegation when it is reported as expiring.
+const DefaultExpiryWindow = time.Hour
+
+// Registry is an index of the delegation alias outputs, grouped by the receiving delegation address. It tracks the
+// lifecycle of every delegation from the moment the funds are delegated until they are reclaimed by the delegator.
+type Registry struct {
+	// Events contains the lifecycle events of the tracked delegations.
+	Events *Events
+
+	store        kvstore.KVStore
+	expiryWindow time.Duration
+
+	delegations map[[ledgerstate.AddressLength]byte]*Delegation
+	outputs     map[ledgerstate.OutputID]*Delegation
+	mutex       sync.RWMutex
+}
+
+// Option is a function setting a registry option.
+type Option func(r *Registry)
+
+// WithExpiryWindow sets the time before the timelock of a delegation when it is reported as expiring.
+func WithExpiryWindow(window time.Duration) Option {
+	return func(r *Registry) {
+		r.expiryWindow = window
+	}
+}
+
+// NewRegistry creates a new Registry that persists its entries in the given store and loads the previously persisted
+// entries.
+func NewRegistry(store kvstore.KVStore, opts ...Option) (*Registry, error) {
+	r := &Registry{
+		Events:       newEvents(),
+		store:        store,
+		expiryWindow: DefaultExpiryWindow,
+		delegations:  make(map[[ledgerstate.AddressLength]byte]*Delegation),
+		outputs:      make(map[ledgerstate.OutputID]*Delegation),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	var parseErr error
+	if err := store.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		delegation, err := FromBytes(value)
+		if err != nil {
+			parseErr = errors.Wrapf(err, "failed to parse persisted delegation with key %x", key)
+			return false
+		}
+		r.index(delegation)
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate over persisted delegations")
+	}
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	return r, nil
+}
+
+// ProcessTransaction updates the registry with the delegation outputs created and consumed by the given confirmed
+// transaction.
+func (r *Registry) ProcessTransaction(transaction *ledgerstate.Transaction) error {
+	r.mutex.Lock()
+	timestamp := transaction.Essence().Timestamp()
+
+	// the delegations consumed by the transaction are reclaimed, unless the transaction creates the next output of the
+	// delegation alias
+	consumed := make(map[[ledgerstate.AddressLength]byte]*Delegation)
+	for _, input := range transaction.Essence().Inputs() {
+		utxoInput, ok := input.(*ledgerstate.UTXOInput)
+		if !ok {
+			continue
+		}
+		if delegation, exists := r.outputs[utxoInput.ReferencedOutputID()]; exists {
+			consumed[delegation.AliasAddress.Array()] = delegation
+		}
+	}
+
+	var triggers []func()
+	var changed []*Delegation
+	for _, output := range transaction.Essence().Outputs() {
+		alias, ok := output.(*ledgerstate.AliasOutput)
+		if !ok || !alias.IsDelegated() {
+			continue
+		}
+		key := alias.GetAliasAddress().Array()
+		delete(consumed, key)
+
+		delegation, exists := r.delegations[key]
+		isNew := !exists || delegation.Status == Reclaimed || !delegation.Receiver.Equals(alias.GetStateAddress())
+		if isNew {
+			if exists {
+				delete(r.outputs, delegation.OutputID)
+			}
+			delegation = &Delegation{AliasAddress: alias.GetAliasAddress(), Since: timestamp}
+			r.delegations[key] = delegation
+		} else {
+			delete(r.outputs, delegation.OutputID)
+		}
+		if !delegation.Timelock.Equal(alias.DelegationTimelock()) {
+			// a changed timelock restarts the expiry tracking
+			delegation.Status = Active
+		}
+		delegation.OutputID = alias.ID()
+		delegation.Receiver = alias.GetStateAddress()
+		delegation.Delegator = alias.GetGoverningAddress()
+		delegation.Balances = alias.Balances()
+		delegation.Timelock = alias.DelegationTimelock()
+		delegation.Updated = timestamp
+		r.outputs[delegation.OutputID] = delegation
+		changed = append(changed, delegation)
+
+		if isNew {
+			created := delegation.Clone()
+			triggers = append(triggers, func() { r.Events.Created.Trigger(created) })
+		}
+	}
+
+	for _, delegation := range consumed {
+		delete(r.outputs, delegation.OutputID)
+		delegation.Status = Reclaimed
+		delegation.Closed = timestamp
+		changed = append(changed, delegation)
+
+		reclaimed := delegation.Clone()
+		triggers = append(triggers, func() { r.Events.Reclaimed.Trigger(reclaimed) })
+	}
+
+	err := r.persist(changed)
+	r.mutex.Unlock()
+
+	for _, trigger := range triggers {
+		trigger()
+	}
+	return err
+}
+
+// CheckTimelocks updates the status of the delegations whose timelock is about to expire or has expired at the given
+// time.
+func (r *Registry) CheckTimelocks(now time.Time) error {
+	r.mutex.Lock()
+	var triggers []func()
+	var changed []*Delegation
+	for _, delegation := range r.delegations {
+		if delegation.Timelock.IsZero() || delegation.Status == Expired || delegation.Status == Reclaimed {
+			continue
+		}
+		switch {
+		case !now.Before(delegation.Timelock):
+			delegation.Status = Expired
+			expired := delegation.Clone()
+			triggers = append(triggers, func() { r.Events.Expired.Trigger(expired) })
+		case delegation.Status == Active && delegation.Timelock.Sub(now) <= r.expiryWindow:
+			delegation.Status = Expiring
+			expiring := delegation.Clone()
+			triggers = append(triggers, func() { r.Events.Expiring.Trigger(expiring) })
+		default:
+			continue
+		}
+		changed = append(changed, delegation)
+	}
+	err := r.persist(changed)
+	r.mutex.Unlock()
+
+	for _, trigger := range triggers {
+		trigger()
+	}
+	return err
+}
+
+// Prune removes the delegations that were reclaimed before the given time.
+func (r *Registry) Prune(before time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for key, delegation := range r.delegations {
+		if delegation.Status != Reclaimed || !delegation.Closed.Before(before) {
+			continue
+		}
+		if err := r.store.Delete(key[:]); err != nil && !errors.Is(err, kvstore.ErrKeyNotFound) {
+			return errors.Wrapf(err, "failed to delete delegation %s", delegation.AliasAddress.Base58())
+		}
+		delete(r.delegations, key)
+	}
+	return nil
+}
+
+// Delegation returns the delegation of the given alias.
+func (r *Registry) Delegation(aliasAddress *ledgerstate.AliasAddress) (*Delegation, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	delegation, exists := r.delegations[aliasAddress.Array()]
+	if !exists {
+		return nil, false
+	}
+	return delegation.Clone(), true
+}
+
+// Delegations returns the delegations to the given receiver, ordered by the time the funds were delegated. If receiver
+// is nil, the delegations to all receivers are returned.
+func (r *Registry) Delegations(receiver ledgerstate.Address) []*Delegation {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	delegations := make([]*Delegation, 0)
+	for _, delegation := range r.delegations {
+		if receiver != nil && !delegation.Receiver.Equals(receiver) {
+			continue
+		}
+		delegations = append(delegations, delegation.Clone())
+	}
+	sort.Slice(delegations, func(i, j int) bool {
+		return delegations[i].Since.Before(delegations[j].Since)
+	})
+	return delegations
+}
+
+// ReceiverSummary aggregates the delegations of a single receiver.
+type ReceiverSummary struct {
+	// Receiver is the delegation address of the receiver.
+	Receiver ledgerstate.Address
+	// Delegations is the number of delegations that were not reclaimed.
+	Delegations int
+	// Amount is the total amount of funds of the delegations that were not reclaimed.
+	Amount uint64
+}
+
+// Receivers returns the summaries of all receivers with funds delegated to them, ordered by the delegated amount.
+func (r *Registry) Receivers() []*ReceiverSummary {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	summaries := make(map[string]*ReceiverSummary)
+	for _, delegation := range r.delegations {
+		if delegation.Status == Reclaimed {
+			continue
+		}
+		key := delegation.Receiver.Base58()
+		if _, exists := summaries[key]; !exists {
+			summaries[key] = &ReceiverSummary{Receiver: delegation.Receiver}
+		}
+		summaries[key].Delegations++
+		summaries[key].Amount += delegation.Amount()
+	}
+
+	result := make([]*ReceiverSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Amount != result[j].Amount {
+			return result[i].Amount > result[j].Amount
+		}
+		return result[i].Receiver.Base58() < result[j].Receiver.Base58()
+	})
+	return result
+}
+
+func (r *Registry) index(delegation *Delegation) {
+	r.delegations[delegation.AliasAddress.Array()] = delegation
+	if delegation.Status != Reclaimed {
+		r.outputs[delegation.OutputID] = delegation
+	}
+}
+
+func (r *Registry) persist(delegations []*Delegation) error {
+	if len(delegations) == 0 {
+		return nil
+	}
+	batch := r.store.Batched()
+	for _, delegation := range delegations {
+		key := delegation.AliasAddress.Array()
+		if err := batch.Set(key[:], delegation.Bytes()); err != nil {
+			batch.Cancel()
+			return errors.Wrapf(err, "failed to persist delegation %s", delegation.AliasAddress.Base58())
+		}
+	}
+	if err := batch.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit delegations")
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package delegation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func newTestAddress(t *testing.T) ledgerstate.Address {
+	publicKey, _, err := ed25519.GenerateKey()
+	require.NoError(t, err)
+	return ledgerstate.NewED25519Address(publicKey)
+}
+
+func newTestTransaction(timestamp time.Time, inputs []ledgerstate.OutputID, outputs ...ledgerstate.Output) *ledgerstate.Transaction {
+	txInputs := make(ledgerstate.Inputs, len(inputs))
+	unlockBlocks := make(ledgerstate.UnlockBlocks, len(inputs))
+	for i, outputID := range inputs {
+		txInputs[i] = ledgerstate.NewUTXOInput(outputID)
+		unlockBlocks[i] = ledgerstate.NewReferenceUnlockBlock(0)
+	}
+	essence := ledgerstate.NewTransactionEssence(0, timestamp, identity.ID{}, identity.ID{}, ledgerstate.NewInputs(txInputs...), ledgerstate.NewOutputs(outputs...))
+	return ledgerstate.NewTransaction(essence, unlockBlocks)
+}
+
+func TestRegistry_Lifecycle(t *testing.T) {
+	store := mapdb.NewMapDB()
+	registry, err := NewRegistry(store, WithExpiryWindow(time.Hour))
+	require.NoError(t, err)
+
+	var created, expiring, expired, reclaimed []*Delegation
+	registry.Events.Created.Attach(eventCollector(&created))
+	registry.Events.Expiring.Attach(eventCollector(&expiring))
+	registry.Events.Expired.Attach(eventCollector(&expired))
+	registry.Events.Reclaimed.Attach(eventCollector(&reclaimed))
+
+	receiver := newTestAddress(t)
+	delegator := newTestAddress(t)
+	start := time.Unix(1600000000, 0)
+	timelock := start.Add(3 * time.Hour)
+
+	// delegate funds
+	origin, err := ledgerstate.NewAliasOutputMint(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 1000}, receiver)
+	require.NoError(t, err)
+	origin.SetGoverningAddress(delegator)
+	delegateTx := newTestTransaction(start, nil, origin.WithDelegationAndTimelock(timelock))
+	require.NoError(t, registry.ProcessTransaction(delegateTx))
+	require.Len(t, created, 1)
+	aliasAddress := created[0].AliasAddress
+	assert.EqualValues(t, 1000, created[0].Amount())
+	assert.True(t, created[0].Delegator.Equals(delegator))
+
+	// refreshing the delegation only moves the output
+	delegated := delegateTx.Essence().Outputs()[0].(*ledgerstate.AliasOutput)
+	refreshTx := newTestTransaction(start.Add(time.Hour), []ledgerstate.OutputID{delegated.ID()}, delegated.NewAliasOutputNext(false))
+	require.NoError(t, registry.ProcessTransaction(refreshTx))
+	assert.Len(t, created, 1)
+	assert.Empty(t, reclaimed)
+	delegation, exists := registry.Delegation(aliasAddress)
+	require.True(t, exists)
+	assert.Equal(t, refreshTx.Essence().Outputs()[0].ID(), delegation.OutputID)
+	assert.Equal(t, start, delegation.Since)
+
+	require.NoError(t, registry.CheckTimelocks(start.Add(90*time.Minute)))
+	assert.Empty(t, expiring)
+	require.NoError(t, registry.CheckTimelocks(start.Add(150*time.Minute)))
+	require.Len(t, expiring, 1)
+	require.NoError(t, registry.CheckTimelocks(start.Add(160*time.Minute)))
+	assert.Len(t, expiring, 1)
+	require.NoError(t, registry.CheckTimelocks(timelock))
+	require.Len(t, expired, 1)
+
+	summaries := registry.Receivers()
+	require.Len(t, summaries, 1)
+	assert.EqualValues(t, 1000, summaries[0].Amount)
+	assert.Len(t, registry.Delegations(receiver), 1)
+	assert.Empty(t, registry.Delegations(delegator))
+
+	// the registry is restored from the store
+	restored, err := NewRegistry(store)
+	require.NoError(t, err)
+	delegation, exists = restored.Delegation(aliasAddress)
+	require.True(t, exists)
+	assert.Equal(t, Expired, delegation.Status)
+	assert.True(t, delegation.Timelock.Equal(timelock))
+
+	// reclaiming the funds closes the delegation
+	refreshed := refreshTx.Essence().Outputs()[0].(*ledgerstate.AliasOutput)
+	reclaimOutput := ledgerstate.NewSigLockedColoredOutput(refreshed.Balances(), delegator)
+	reclaimTx := newTestTransaction(timelock.Add(time.Minute), []ledgerstate.OutputID{refreshed.ID()}, reclaimOutput)
+	require.NoError(t, restored.ProcessTransaction(reclaimTx))
+	delegation, exists = restored.Delegation(aliasAddress)
+	require.True(t, exists)
+	assert.Equal(t, Reclaimed, delegation.Status)
+	assert.Empty(t, restored.Receivers())
+
+	require.NoError(t, restored.Prune(timelock.Add(time.Hour)))
+	_, exists = restored.Delegation(aliasAddress)
+	assert.False(t, exists)
+}
+
+func eventCollector(collected *[]*Delegation) *events.Closure {
+	return events.NewClosure(func(delegation *Delegation) {
+		*collected = append(*collected, delegation)
+	})
+}
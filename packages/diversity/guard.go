@@ -0,0 +1,386 @@
+// Package diversity implements optional constraints on the neighbors chosen by the autopeering to make eclipse attacks
+// harder: a limit on the number of neighbors that share a subnet, a slot that is reserved for a neighbor with high
+// consensus mana and the periodic rotation of the oldest neighbor.
+package diversity
+
+import (
+	"math"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/identity"
+)
+
+const (
+	// DefaultIPv4PrefixLength is the length of the prefix that defines the subnet of an IPv4 address.
+	DefaultIPv4PrefixLength = 16
+	// DefaultIPv6PrefixLength is the length of the prefix that defines the subnet of an IPv6 address.
+	DefaultIPv6PrefixLength = 48
+
+	defaultHighManaRefreshInterval = time.Minute
+)
+
+// RejectionReason is the reason why a candidate was rejected as a neighbor.
+type RejectionReason string
+
+const (
+	// RejectedSubnetLimit means that the subnet of the candidate already contains the maximum number of neighbors.
+	RejectedSubnetLimit RejectionReason = "subnetLimit"
+	// RejectedHighManaSlot means that the last free slot is reserved for a neighbor with high consensus mana.
+	RejectedHighManaSlot RejectionReason = "highManaSlot"
+)
+
+// HighManaFunc returns the IDs of the nodes that belong to the high consensus mana set.
+type HighManaFunc func() ([]identity.ID, error)
+
+// Guard validates neighbor candidates against the diversity constraints and keeps track of the current neighborhood.
+// All constraints are disabled unless they are enabled by the corresponding Option.
+type Guard struct {
+	maxPerSubnet            int
+	ipv4PrefixLength        int
+	ipv6PrefixLength        int
+	neighborhoodSize        int
+	highManaFunc            HighManaFunc
+	highManaRefreshInterval time.Duration
+	rotationInterval        time.Duration
+	timeFunc                func() time.Time
+
+	neighbors       map[identity.ID]*neighbor
+	highMana        map[identity.ID]struct{}
+	highManaUpdated time.Time
+	highManaErr     error
+	rejections      map[RejectionReason]uint64
+	rejected        map[identity.ID]RejectionReason
+	rotations       uint64
+	mutex           sync.Mutex
+}
+
+type neighbor struct {
+	peer   *peer.Peer
+	subnet string
+	since  time.Time
+}
+
+// Option defines a single option for the NewGuard function.
+type Option func(g *Guard)
+
+// WithMaxPerSubnet returns an Option that limits the number of neighbors per /16 IPv4 or /48 IPv6 subnet.
+func WithMaxPerSubnet(max int) Option {
+	return func(g *Guard) {
+		g.maxPerSubnet = max
+	}
+}
+
+// WithPrefixLengths returns an Option that changes the prefix lengths which define the subnet of an address.
+func WithPrefixLengths(ipv4, ipv6 int) Option {
+	return func(g *Guard) {
+		g.ipv4PrefixLength = ipv4
+		g.ipv6PrefixLength = ipv6
+	}
+}
+
+// WithHighManaNeighbor returns an Option that reserves the last of the size slots of the neighborhood for a node of the
+// high consensus mana set returned by the given function. The set is cached for the given refresh interval.
+func WithHighManaNeighbor(size int, highManaFunc HighManaFunc, refreshInterval time.Duration) Option {
+	return func(g *Guard) {
+		g.neighborhoodSize = size
+		g.highManaFunc = highManaFunc
+		if refreshInterval > 0 {
+			g.highManaRefreshInterval = refreshInterval
+		}
+	}
+}
+
+// WithRotationInterval returns an Option that enables the rotation of neighbors that are older than the interval.
+func WithRotationInterval(interval time.Duration) Option {
+	return func(g *Guard) {
+		g.rotationInterval = interval
+	}
+}
+
+// WithTimeFunc returns an Option that replaces the clock of the Guard.
+func WithTimeFunc(timeFunc func() time.Time) Option {
+	return func(g *Guard) {
+		g.timeFunc = timeFunc
+	}
+}
+
+// NewGuard creates a new Guard with the given options.
+func NewGuard(opts ...Option) *Guard {
+	g := &Guard{
+		ipv4PrefixLength:        DefaultIPv4PrefixLength,
+		ipv6PrefixLength:        DefaultIPv6PrefixLength,
+		highManaRefreshInterval: defaultHighManaRefreshInterval,
+		timeFunc:                time.Now,
+		neighbors:               make(map[identity.ID]*neighbor),
+		rejections:              make(map[RejectionReason]uint64),
+		rejected:                make(map[identity.ID]RejectionReason),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Subnet returns the subnet of the given IP in CIDR notation, according to the prefix lengths of the Guard.
+func (g *Guard) Subnet(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return (&net.IPNet{IP: ip4.Mask(net.CIDRMask(g.ipv4PrefixLength, 32)), Mask: net.CIDRMask(g.ipv4PrefixLength, 32)}).String()
+	}
+	return (&net.IPNet{IP: ip.Mask(net.CIDRMask(g.ipv6PrefixLength, 128)), Mask: net.CIDRMask(g.ipv6PrefixLength, 128)}).String()
+}
+
+// IsValid checks whether the given candidate can be added to the neighborhood without violating the constraints.
+// It implements the selection.Validator interface.
+func (g *Guard) IsValid(p *peer.Peer) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if _, exists := g.neighbors[p.ID()]; exists {
+		return true
+	}
+
+	if g.maxPerSubnet > 0 {
+		subnet := g.Subnet(p.IP())
+		count := 0
+		for _, n := range g.neighbors {
+			if n.subnet == subnet {
+				count++
+			}
+		}
+		if count >= g.maxPerSubnet {
+			g.reject(p.ID(), RejectedSubnetLimit)
+			return false
+		}
+	}
+
+	// the last free slot is reserved for a high mana node, if the set cannot be determined the constraint is skipped
+	if g.highManaFunc != nil && len(g.neighbors)+1 >= g.neighborhoodSize {
+		highMana, err := g.highManaSet()
+		if err == nil && len(highMana) > 0 && g.highManaNeighborCount(highMana) == 0 {
+			if _, isHighMana := highMana[p.ID()]; !isHighMana {
+				g.reject(p.ID(), RejectedHighManaSlot)
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// AddNeighbor adds the given peer to the tracked neighborhood.
+func (g *Guard) AddNeighbor(p *peer.Peer) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if _, exists := g.neighbors[p.ID()]; exists {
+		return
+	}
+	g.neighbors[p.ID()] = &neighbor{peer: p, subnet: g.Subnet(p.IP()), since: g.timeFunc()}
+	g.resetRejected()
+}
+
+// RemoveNeighbor removes the peer with the given ID from the tracked neighborhood.
+func (g *Guard) RemoveNeighbor(id identity.ID) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if _, exists := g.neighbors[id]; !exists {
+		return
+	}
+	delete(g.neighbors, id)
+	g.resetRejected()
+}
+
+// RotationCandidate returns the neighbor that should be dropped to rotate the neighborhood. Neighbors in an
+// over-represented subnet are dropped first, then the oldest neighbor that is not the only high mana neighbor. It returns
+// false if the rotation is disabled or no neighbor is older than the rotation interval.
+func (g *Guard) RotationCandidate() (id identity.ID, ok bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.rotationInterval <= 0 {
+		return identity.ID{}, false
+	}
+
+	subnetCounts := g.subnetCounts()
+	var highMana map[identity.ID]struct{}
+	if g.highManaFunc != nil {
+		highMana, _ = g.highManaSet()
+	}
+	highManaCount := g.highManaNeighborCount(highMana)
+
+	now := g.timeFunc()
+	var candidate *neighbor
+	candidateOverLimit := false
+	for _, n := range g.neighbors {
+		if now.Sub(n.since) < g.rotationInterval {
+			continue
+		}
+		if _, isHighMana := highMana[n.peer.ID()]; isHighMana && highManaCount == 1 {
+			continue
+		}
+		overLimit := g.maxPerSubnet > 0 && subnetCounts[n.subnet] > g.maxPerSubnet
+		if candidate == nil || (overLimit && !candidateOverLimit) ||
+			(overLimit == candidateOverLimit && n.since.Before(candidate.since)) {
+			candidate = n
+			candidateOverLimit = overLimit
+		}
+	}
+	if candidate == nil {
+		return identity.ID{}, false
+	}
+	return candidate.peer.ID(), true
+}
+
+// RecordRotation increases the number of rotated neighbors reported by the Diagnostics.
+func (g *Guard) RecordRotation() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.rotations++
+}
+
+// Diagnostics returns a report about the diversity of the current neighborhood.
+func (g *Guard) Diagnostics() *Diagnostics {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	subnetCounts := g.subnetCounts()
+	diagnostics := &Diagnostics{
+		NeighborCount:   len(g.neighbors),
+		DistinctSubnets: len(subnetCounts),
+		MaxPerSubnet:    g.maxPerSubnet,
+		Subnets:         make([]SubnetCount, 0, len(subnetCounts)),
+		DiversityScore:  diversityScore(subnetCounts, len(g.neighbors)),
+		Rejections:      make(map[RejectionReason]uint64, len(g.rejections)),
+		Rotations:       g.rotations,
+	}
+	for subnet, count := range subnetCounts {
+		diagnostics.Subnets = append(diagnostics.Subnets, SubnetCount{Subnet: subnet, Count: count})
+		if count > diagnostics.LargestSubnet {
+			diagnostics.LargestSubnet = count
+		}
+	}
+	sort.Slice(diagnostics.Subnets, func(i, j int) bool {
+		if diagnostics.Subnets[i].Count != diagnostics.Subnets[j].Count {
+			return diagnostics.Subnets[i].Count > diagnostics.Subnets[j].Count
+		}
+		return diagnostics.Subnets[i].Subnet < diagnostics.Subnets[j].Subnet
+	})
+	for reason, count := range g.rejections {
+		diagnostics.Rejections[reason] = count
+	}
+
+	if g.highManaFunc != nil {
+		diagnostics.HighManaRequired = true
+		highMana, err := g.highManaSet()
+		if err != nil {
+			diagnostics.HighManaError = err.Error()
+		}
+		diagnostics.HighManaNeighbors = g.highManaNeighborCount(highMana)
+	}
+
+	if g.rotationInterval > 0 {
+		diagnostics.RotationInterval = g.rotationInterval
+		now := g.timeFunc()
+		for _, n := range g.neighbors {
+			if age := now.Sub(n.since); age > diagnostics.OldestNeighborAge {
+				diagnostics.OldestNeighborAge = age
+			}
+		}
+	}
+
+	return diagnostics
+}
+
+// reject counts the rejection of the candidate, unless it was already rejected for the same reason since the
+// neighborhood last changed. The selection validates the same candidates in every round, so counting every call would
+// overstate the rejections. The mutex must be held by the caller.
+func (g *Guard) reject(id identity.ID, reason RejectionReason) {
+	if previousReason, rejected := g.rejected[id]; rejected && previousReason == reason {
+		return
+	}
+	g.rejected[id] = reason
+	g.rejections[reason]++
+}
+
+// resetRejected starts a new round of rejections, as the changed neighborhood may turn the rejected candidates valid.
+// The mutex must be held by the caller.
+func (g *Guard) resetRejected() {
+	g.rejected = make(map[identity.ID]RejectionReason)
+}
+
+// highManaSet returns the cached high mana set and refreshes it if it expired. The mutex must be held by the caller.
+func (g *Guard) highManaSet() (map[identity.ID]struct{}, error) {
+	now := g.timeFunc()
+	if g.highMana != nil && now.Sub(g.highManaUpdated) < g.highManaRefreshInterval {
+		return g.highMana, g.highManaErr
+	}
+
+	ids, err := g.highManaFunc()
+	g.highManaUpdated = now
+	g.highManaErr = err
+	g.highMana = make(map[identity.ID]struct{}, len(ids))
+	for _, id := range ids {
+		g.highMana[id] = struct{}{}
+	}
+	return g.highMana, g.highManaErr
+}
+
+func (g *Guard) highManaNeighborCount(highMana map[identity.ID]struct{}) (count int) {
+	for id := range g.neighbors {
+		if _, isHighMana := highMana[id]; isHighMana {
+			count++
+		}
+	}
+	return count
+}
+
+func (g *Guard) subnetCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, n := range g.neighbors {
+		counts[n.subnet]++
+	}
+	return counts
+}
+
+// diversityScore returns the normalized Shannon entropy of the distribution of the neighbors over the subnets, i.e. 1 if
+// every neighbor is in a different subnet and 0 if all of them share the same subnet.
+func diversityScore(subnetCounts map[string]int, total int) float64 {
+	if total <= 1 {
+		return 1
+	}
+	var entropy float64
+	for _, count := range subnetCounts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log(p)
+	}
+	return entropy / math.Log(float64(total))
+}
+
+// Diagnostics reports how diverse the current neighborhood is.
+type Diagnostics struct {
+	NeighborCount     int
+	DistinctSubnets   int
+	LargestSubnet     int
+	MaxPerSubnet      int
+	Subnets           []SubnetCount
+	DiversityScore    float64
+	HighManaRequired  bool
+	HighManaNeighbors int
+	HighManaError     string
+	RotationInterval  time.Duration
+	OldestNeighborAge time.Duration
+	Rotations         uint64
+	Rejections        map[RejectionReason]uint64
+}
+
+// SubnetCount contains the number of neighbors in a subnet.
+type SubnetCount struct {
+	Subnet string
+	Count  int
+}
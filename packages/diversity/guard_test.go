@@ -0,0 +1,109 @@
+package diversity
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPeer(t *testing.T, ip string) *peer.Peer {
+	publicKey, _, err := ed25519.GenerateKey()
+	require.NoError(t, err)
+	services := service.New()
+	services.Update(service.PeeringKey, "udp", 14626)
+	return peer.NewPeer(identity.New(publicKey), net.ParseIP(ip), services)
+}
+
+func TestGuard_Subnet(t *testing.T) {
+	g := NewGuard()
+	assert.Equal(t, "10.1.0.0/16", g.Subnet(net.ParseIP("10.1.2.3")))
+	assert.Equal(t, "2001:db8:1::/48", g.Subnet(net.ParseIP("2001:db8:1:2::1")))
+}
+
+func TestGuard_SubnetLimit(t *testing.T) {
+	g := NewGuard(WithMaxPerSubnet(2))
+
+	g.AddNeighbor(newTestPeer(t, "10.1.0.1"))
+	g.AddNeighbor(newTestPeer(t, "10.1.0.2"))
+	assert.False(t, g.IsValid(newTestPeer(t, "10.1.200.3")))
+	assert.True(t, g.IsValid(newTestPeer(t, "10.2.0.1")))
+
+	diagnostics := g.Diagnostics()
+	assert.Equal(t, 2, diagnostics.NeighborCount)
+	assert.Equal(t, 1, diagnostics.DistinctSubnets)
+	assert.Equal(t, 2, diagnostics.LargestSubnet)
+	assert.Equal(t, 0.0, diagnostics.DiversityScore)
+	assert.EqualValues(t, 1, diagnostics.Rejections[RejectedSubnetLimit])
+}
+
+func TestGuard_RejectionsCountedOncePerCandidate(t *testing.T) {
+	g := NewGuard(WithMaxPerSubnet(1))
+	g.AddNeighbor(newTestPeer(t, "10.1.0.1"))
+
+	// the selection validates the same candidates in every round
+	candidate := newTestPeer(t, "10.1.0.2")
+	for i := 0; i < 3; i++ {
+		assert.False(t, g.IsValid(candidate))
+		assert.False(t, g.IsValid(newTestPeer(t, "10.1.0.3")))
+	}
+	assert.EqualValues(t, 4, g.Diagnostics().Rejections[RejectedSubnetLimit])
+
+	// removing an unknown neighbor does not change the neighborhood
+	g.RemoveNeighbor(candidate.ID())
+	assert.False(t, g.IsValid(candidate))
+	assert.EqualValues(t, 4, g.Diagnostics().Rejections[RejectedSubnetLimit])
+
+	// a changed neighborhood starts a new round
+	g.AddNeighbor(newTestPeer(t, "10.2.0.1"))
+	assert.False(t, g.IsValid(candidate))
+	assert.EqualValues(t, 5, g.Diagnostics().Rejections[RejectedSubnetLimit])
+}
+
+func TestGuard_HighManaSlot(t *testing.T) {
+	highMana := newTestPeer(t, "10.3.0.1")
+	g := NewGuard(WithHighManaNeighbor(3, func() ([]identity.ID, error) {
+		return []identity.ID{highMana.ID()}, nil
+	}, time.Minute))
+
+	g.AddNeighbor(newTestPeer(t, "10.1.0.1"))
+	assert.True(t, g.IsValid(newTestPeer(t, "10.2.0.1")))
+	g.AddNeighbor(newTestPeer(t, "10.2.0.1"))
+
+	// the last slot is reserved
+	assert.False(t, g.IsValid(newTestPeer(t, "10.4.0.1")))
+	assert.True(t, g.IsValid(highMana))
+	g.AddNeighbor(highMana)
+
+	diagnostics := g.Diagnostics()
+	assert.Equal(t, 1, diagnostics.HighManaNeighbors)
+	assert.Equal(t, 1.0, diagnostics.DiversityScore)
+}
+
+func TestGuard_RotationCandidate(t *testing.T) {
+	now := time.Now()
+	g := NewGuard(WithRotationInterval(time.Hour), WithTimeFunc(func() time.Time { return now }))
+
+	oldest := newTestPeer(t, "10.1.0.1")
+	g.AddNeighbor(oldest)
+	now = now.Add(30 * time.Minute)
+	g.AddNeighbor(newTestPeer(t, "10.2.0.1"))
+
+	_, ok := g.RotationCandidate()
+	assert.False(t, ok)
+
+	now = now.Add(2 * time.Hour)
+	id, ok := g.RotationCandidate()
+	require.True(t, ok)
+	assert.Equal(t, oldest.ID(), id)
+
+	g.RemoveNeighbor(id)
+	g.RecordRotation()
+	assert.EqualValues(t, 1, g.Diagnostics().Rotations)
+}
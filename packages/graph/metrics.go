@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"sort"
+)
+
+// ConnectedComponents returns the IDs of the nodes of every connected component, the largest component first.
+func (g *Graph) ConnectedComponents() [][]string {
+	names := g.names()
+	visited := make([]bool, len(g.nodes))
+	var components [][]string
+	for start := range g.nodes {
+		if visited[start] {
+			continue
+		}
+		visited[start] = true
+		var component []string
+		stack := []nodeID{nodeID(start)}
+		for len(stack) > 0 {
+			id := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			component = append(component, names[id])
+			for _, adjID := range g.nodes[id].Adj {
+				if !visited[adjID] {
+					visited[adjID] = true
+					stack = append(stack, adjID)
+				}
+			}
+		}
+		sort.Strings(component)
+		components = append(components, component)
+	}
+	sort.SliceStable(components, func(i, j int) bool {
+		return len(components[i]) > len(components[j])
+	})
+	return components
+}
+
+// DegreeDistribution returns the number of nodes for every degree that occurs in the graph.
+func (g *Graph) DegreeDistribution() map[int]int {
+	distribution := make(map[int]int)
+	for i := range g.nodes {
+		distribution[len(g.nodes[i].Adj)]++
+	}
+	return distribution
+}
+
+// MinCut returns the minimum number of edges that need to be removed to disconnect the graph.
+// It uses the Stoer-Wagner algorithm and runs in O(n^3), a graph with less than two nodes has a min cut of 0.
+func (g *Graph) MinCut() int {
+	numNodes := len(g.nodes)
+	if numNodes < 2 {
+		return 0
+	}
+
+	weights := make([][]int, numNodes)
+	for i := range weights {
+		weights[i] = make([]int, numNodes)
+	}
+	for i := range g.nodes {
+		for _, adjID := range g.nodes[i].Adj {
+			weights[i][adjID] = 1
+		}
+	}
+
+	active := make([]int, numNodes)
+	for i := range active {
+		active[i] = i
+	}
+
+	minCut := -1
+	connectivity := make([]int, numNodes)
+	added := make([]bool, numNodes)
+	for len(active) > 1 {
+		// maximum adjacency search over the remaining (merged) nodes
+		for _, v := range active {
+			connectivity[v] = 0
+			added[v] = false
+		}
+		prev, last := -1, -1
+		for range active {
+			selected := -1
+			for _, v := range active {
+				if !added[v] && (selected == -1 || connectivity[v] > connectivity[selected]) {
+					selected = v
+				}
+			}
+			added[selected] = true
+			prev, last = last, selected
+			for _, v := range active {
+				if !added[v] {
+					connectivity[v] += weights[selected][v]
+				}
+			}
+		}
+
+		// the cut of the phase separates the last added node from the rest
+		if minCut == -1 || connectivity[last] < minCut {
+			minCut = connectivity[last]
+		}
+
+		// merge the last added node into the one added before it
+		for _, v := range active {
+			weights[prev][v] += weights[last][v]
+			weights[v][prev] = weights[prev][v]
+		}
+		for i, v := range active {
+			if v == last {
+				active = append(active[:i], active[i+1:]...)
+				break
+			}
+		}
+	}
+
+	return minCut
+}
+
+// names returns the IDs of the nodes indexed by their internal ID.
+func (g *Graph) names() []string {
+	names := make([]string, len(g.nodes))
+	for name, id := range g.symbolTable {
+		if int(id) < len(names) {
+			names[id] = name
+		}
+	}
+	return names
+}
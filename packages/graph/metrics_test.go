@@ -0,0 +1,47 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph_ConnectedComponents(t *testing.T) {
+	g := New([]string{"A", "B", "C", "D", "E"})
+	g.AddEdge("A", "B")
+	g.AddEdge("B", "C")
+	g.AddEdge("D", "E")
+
+	assert.Equal(t, [][]string{{"A", "B", "C"}, {"D", "E"}}, g.ConnectedComponents())
+	assert.Equal(t, map[int]int{1: 4, 2: 1}, g.DegreeDistribution())
+	assert.Equal(t, 0, g.MinCut())
+}
+
+func TestGraph_MinCut(t *testing.T) {
+	// two triangles connected by a single bridge
+	g := New([]string{"A", "B", "C", "D", "E", "F"})
+	g.AddEdge("A", "B")
+	g.AddEdge("B", "C")
+	g.AddEdge("C", "A")
+	g.AddEdge("D", "E")
+	g.AddEdge("E", "F")
+	g.AddEdge("F", "D")
+	g.AddEdge("C", "D")
+	assert.Equal(t, 1, g.MinCut())
+
+	// a second bridge makes the graph 2-edge-connected
+	g.AddEdge("A", "F")
+	assert.Equal(t, 2, g.MinCut())
+
+	// a complete graph of four nodes needs three edges to be removed
+	g = New([]string{"A", "B", "C", "D"})
+	for _, a := range []string{"A", "B", "C", "D"} {
+		for _, b := range []string{"A", "B", "C", "D"} {
+			if a < b {
+				g.AddEdge(a, b)
+			}
+		}
+	}
+	assert.Equal(t, 3, g.MinCut())
+	assert.Equal(t, 1, g.Diameter())
+}
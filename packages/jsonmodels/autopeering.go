@@ -20,3 +20,27 @@ type PeerService struct {
 	ID      string `json:"id"`      // ID of the service
 	Address string `json:"address"` // network address of the service
 }
+
+// GetNeighborhoodDiversityResponse contains information about the diversity of the autopeering neighborhood.
+type GetNeighborhoodDiversityResponse struct {
+	NeighborCount     int               `json:"neighborCount"`
+	DistinctSubnets   int               `json:"distinctSubnets"`
+	LargestSubnet     int               `json:"largestSubnet"`
+	MaxPerSubnet      int               `json:"maxPerSubnet"`
+	Subnets           []SubnetCount     `json:"subnets"`
+	DiversityScore    float64           `json:"diversityScore"`
+	HighManaRequired  bool              `json:"highManaRequired"`
+	HighManaNeighbors int               `json:"highManaNeighbors"`
+	HighManaError     string            `json:"highManaError,omitempty"`
+	RotationInterval  string            `json:"rotationInterval,omitempty"`
+	OldestNeighborAge string            `json:"oldestNeighborAge,omitempty"`
+	Rotations         uint64            `json:"rotations"`
+	Rejections        map[string]uint64 `json:"rejections"`
+	Error             string            `json:"error,omitempty"`
+}
+
+// SubnetCount contains the number of neighbors within a subnet.
+type SubnetCount struct {
+	Subnet string `json:"subnet"`
+	Count  int    `json:"count"`
+}
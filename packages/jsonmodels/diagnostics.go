@@ -0,0 +1,597 @@
+package jsonmodels
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// DiagnosticsFormatCSV is the format of the diagnostic endpoints that returns a CSV table with a header row.
+	DiagnosticsFormatCSV = "csv"
+	// DiagnosticsFormatJSONLines is the format of the diagnostic endpoints that returns one JSON object per line.
+	DiagnosticsFormatJSONLines = "jsonl"
+	// DiagnosticsFormatParameter is the query parameter that selects the format of the diagnostic endpoints.
+	DiagnosticsFormatParameter = "format"
+)
+
+// DiagnosticRow is a row of a diagnostic table that can be converted from and to a CSV row.
+type DiagnosticRow interface {
+	// CSVRow returns the columns of the row in the order of the table description.
+	CSVRow() []string
+	// ParseCSVRow sets the fields of the row from a CSV row with the given header.
+	ParseCSVRow(header, row []string) error
+}
+
+// region DiagnosticMessage ////////////////////////////////////////////////////////////////////////////////////////////
+
+// DiagnosticMessagesTableDescription holds the description of the diagnostic messages.
+var DiagnosticMessagesTableDescription = []string{
+	"ID",
+	"IssuerID",
+	"IssuerPublicKey",
+	"IssuanceTime",
+	"ArrivalTime",
+	"SolidTime",
+	"ScheduledTime",
+	"BookedTime",
+	"OpinionFormedTime",
+	"FinalizedTime",
+	"StrongParents",
+	"WeakParents",
+	"StrongApprovers",
+	"WeakApprovers",
+	"BranchID",
+	"InclusionState",
+	"Scheduled",
+	"Booked",
+	"Eligible",
+	"Invalid",
+	"Finalized",
+	"Rank",
+	"IsPastMarker",
+	"PastMarkers",
+	"PMHI",
+	"PMLI",
+	"FutureMarkers",
+	"FMHI",
+	"FMLI",
+	"PayloadType",
+	"TransactionID",
+	"PayloadOpinionFormed",
+	"TimestampOpinionFormed",
+	"MessageOpinionFormed",
+	"MessageOpinionTriggered",
+	"TimestampOpinion",
+	"TimestampLoK",
+}
+
+// DiagnosticMessage represents the JSON model of a row of the message diagnostics.
+type DiagnosticMessage struct {
+	ID                string    `json:"id"`
+	IssuerID          string    `json:"issuerID"`
+	IssuerPublicKey   string    `json:"issuerPublicKey"`
+	IssuanceTime      time.Time `json:"issuanceTime"`
+	ArrivalTime       time.Time `json:"arrivalTime"`
+	SolidTime         time.Time `json:"solidTime"`
+	ScheduledTime     time.Time `json:"scheduledTime"`
+	BookedTime        time.Time `json:"bookedTime"`
+	OpinionFormedTime time.Time `json:"opinionFormedTime"`
+	FinalizedTime     time.Time `json:"finalizedTime"`
+	StrongParents     []string  `json:"strongParents"`
+	WeakParents       []string  `json:"weakParents"`
+	StrongApprovers   []string  `json:"strongApprovers"`
+	WeakApprovers     []string  `json:"weakApprovers"`
+	BranchID          string    `json:"branchID"`
+	InclusionState    string    `json:"inclusionState"`
+	Scheduled         bool      `json:"scheduled"`
+	Booked            bool      `json:"booked"`
+	Eligible          bool      `json:"eligible"`
+	Invalid           bool      `json:"invalid"`
+	Finalized         bool      `json:"finalized"`
+	Rank              uint64    `json:"rank"`
+	IsPastMarker      bool      `json:"isPastMarker"`
+	PastMarkers       string    `json:"pastMarkers"`
+	PMHI              uint64    `json:"pastMarkersHighestIndex"`
+	PMLI              uint64    `json:"pastMarkersLowestIndex"`
+	FutureMarkers     string    `json:"futureMarkers"`
+	FMHI              uint64    `json:"futureMarkersHighestIndex"`
+	FMLI              uint64    `json:"futureMarkersLowestIndex"`
+	PayloadType       string    `json:"payloadType"`
+	TransactionID     string    `json:"transactionID,omitempty"`
+	// consensus information
+	PayloadOpinionFormed    bool   `json:"payloadOpinionFormed"`
+	TimestampOpinionFormed  bool   `json:"timestampOpinionFormed"`
+	MessageOpinionFormed    bool   `json:"messageOpinionFormed"`
+	MessageOpinionTriggered bool   `json:"messageOpinionTriggered"`
+	TimestampOpinion        string `json:"timestampOpinion"`
+	TimestampLoK            string `json:"timestampLoK"`
+}
+
+// CSVRow returns the columns of the message in the order of the DiagnosticMessagesTableDescription.
+func (d *DiagnosticMessage) CSVRow() []string {
+	return []string{
+		d.ID,
+		d.IssuerID,
+		d.IssuerPublicKey,
+		formatDiagnosticTime(d.IssuanceTime),
+		formatDiagnosticTime(d.ArrivalTime),
+		formatDiagnosticTime(d.SolidTime),
+		formatDiagnosticTime(d.ScheduledTime),
+		formatDiagnosticTime(d.BookedTime),
+		formatDiagnosticTime(d.OpinionFormedTime),
+		formatDiagnosticTime(d.FinalizedTime),
+		formatDiagnosticList(d.StrongParents),
+		formatDiagnosticList(d.WeakParents),
+		formatDiagnosticList(d.StrongApprovers),
+		formatDiagnosticList(d.WeakApprovers),
+		d.BranchID,
+		d.InclusionState,
+		fmt.Sprint(d.Scheduled),
+		fmt.Sprint(d.Booked),
+		fmt.Sprint(d.Eligible),
+		fmt.Sprint(d.Invalid),
+		fmt.Sprint(d.Finalized),
+		fmt.Sprint(d.Rank),
+		fmt.Sprint(d.IsPastMarker),
+		d.PastMarkers,
+		fmt.Sprint(d.PMHI),
+		fmt.Sprint(d.PMLI),
+		d.FutureMarkers,
+		fmt.Sprint(d.FMHI),
+		fmt.Sprint(d.FMLI),
+		d.PayloadType,
+		d.TransactionID,
+		fmt.Sprint(d.PayloadOpinionFormed),
+		fmt.Sprint(d.TimestampOpinionFormed),
+		fmt.Sprint(d.MessageOpinionFormed),
+		fmt.Sprint(d.MessageOpinionTriggered),
+		d.TimestampOpinion,
+		d.TimestampLoK,
+	}
+}
+
+// ParseCSVRow sets the fields of the message from a CSV row with the given header.
+func (d *DiagnosticMessage) ParseCSVRow(header, row []string) error {
+	r, err := newDiagnosticCSVRecord(header, row)
+	if err != nil {
+		return err
+	}
+
+	d.ID = r.string("ID")
+	d.IssuerID = r.string("IssuerID")
+	d.IssuerPublicKey = r.string("IssuerPublicKey")
+	d.IssuanceTime = r.time("IssuanceTime")
+	d.ArrivalTime = r.time("ArrivalTime")
+	d.SolidTime = r.time("SolidTime")
+	d.ScheduledTime = r.time("ScheduledTime")
+	d.BookedTime = r.time("BookedTime")
+	d.OpinionFormedTime = r.time("OpinionFormedTime")
+	d.FinalizedTime = r.time("FinalizedTime")
+	d.StrongParents = r.list("StrongParents")
+	d.WeakParents = r.list("WeakParents")
+	d.StrongApprovers = r.list("StrongApprovers")
+	d.WeakApprovers = r.list("WeakApprovers")
+	d.BranchID = r.string("BranchID")
+	d.InclusionState = r.string("InclusionState")
+	d.Scheduled = r.bool("Scheduled")
+	d.Booked = r.bool("Booked")
+	d.Eligible = r.bool("Eligible")
+	d.Invalid = r.bool("Invalid")
+	d.Finalized = r.bool("Finalized")
+	d.Rank = r.uint64("Rank")
+	d.IsPastMarker = r.bool("IsPastMarker")
+	d.PastMarkers = r.string("PastMarkers")
+	d.PMHI = r.uint64("PMHI")
+	d.PMLI = r.uint64("PMLI")
+	d.FutureMarkers = r.string("FutureMarkers")
+	d.FMHI = r.uint64("FMHI")
+	d.FMLI = r.uint64("FMLI")
+	d.PayloadType = r.string("PayloadType")
+	d.TransactionID = r.string("TransactionID")
+	d.PayloadOpinionFormed = r.bool("PayloadOpinionFormed")
+	d.TimestampOpinionFormed = r.bool("TimestampOpinionFormed")
+	d.MessageOpinionFormed = r.bool("MessageOpinionFormed")
+	d.MessageOpinionTriggered = r.bool("MessageOpinionTriggered")
+	d.TimestampOpinion = r.string("TimestampOpinion")
+	d.TimestampLoK = r.string("TimestampLoK")
+
+	return r.err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region DiagnosticTip ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DiagnosticTipsTableDescription holds the description of the diagnostic tips.
+var DiagnosticTipsTableDescription = append([]string{"tipType"}, DiagnosticMessagesTableDescription...)
+
+// DiagnosticTip represents the JSON model of a row of the tips diagnostics.
+type DiagnosticTip struct {
+	TipType string `json:"tipType"`
+	DiagnosticMessage
+}
+
+// CSVRow returns the columns of the tip in the order of the DiagnosticTipsTableDescription.
+func (d *DiagnosticTip) CSVRow() []string {
+	return append([]string{d.TipType}, d.DiagnosticMessage.CSVRow()...)
+}
+
+// ParseCSVRow sets the fields of the tip from a CSV row with the given header.
+func (d *DiagnosticTip) ParseCSVRow(header, row []string) error {
+	r, err := newDiagnosticCSVRecord(header, row)
+	if err != nil {
+		return err
+	}
+	d.TipType = r.string("tipType")
+
+	return d.DiagnosticMessage.ParseCSVRow(header, row)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region DiagnosticBranch /////////////////////////////////////////////////////////////////////////////////////////////
+
+// DiagnosticBranchesTableDescription holds the description of the diagnostic branches.
+var DiagnosticBranchesTableDescription = []string{
+	"ID",
+	"ConflictSet",
+	"IssuanceTime",
+	"SolidTime",
+	"OpinionFormedTime",
+	"Liked",
+	"MonotonicallyLiked",
+	"InclusionState",
+	"Finalized",
+	"LazyBooked",
+	"TransactionLiked",
+}
+
+// DiagnosticBranch represents the JSON model of a row of the branch diagnostics.
+type DiagnosticBranch struct {
+	ID                 string    `json:"id"`
+	ConflictSet        []string  `json:"conflictSet"`
+	IssuanceTime       time.Time `json:"issuanceTime"`
+	SolidTime          time.Time `json:"solidTime"`
+	OpinionFormedTime  time.Time `json:"opinionFormedTime"`
+	Liked              bool      `json:"liked"`
+	MonotonicallyLiked bool      `json:"monotonicallyLiked"`
+	InclusionState     string    `json:"inclusionState"`
+	Finalized          bool      `json:"finalized"`
+	LazyBooked         bool      `json:"lazyBooked"`
+	TransactionLiked   bool      `json:"transactionLiked"`
+}
+
+// CSVRow returns the columns of the branch in the order of the DiagnosticBranchesTableDescription.
+func (d *DiagnosticBranch) CSVRow() []string {
+	return []string{
+		d.ID,
+		formatDiagnosticList(d.ConflictSet),
+		formatDiagnosticTime(d.IssuanceTime),
+		formatDiagnosticTime(d.SolidTime),
+		formatDiagnosticTime(d.OpinionFormedTime),
+		fmt.Sprint(d.Liked),
+		fmt.Sprint(d.MonotonicallyLiked),
+		d.InclusionState,
+		fmt.Sprint(d.Finalized),
+		fmt.Sprint(d.LazyBooked),
+		fmt.Sprint(d.TransactionLiked),
+	}
+}
+
+// ParseCSVRow sets the fields of the branch from a CSV row with the given header.
+func (d *DiagnosticBranch) ParseCSVRow(header, row []string) error {
+	r, err := newDiagnosticCSVRecord(header, row)
+	if err != nil {
+		return err
+	}
+
+	d.ID = r.string("ID")
+	d.ConflictSet = r.list("ConflictSet")
+	d.IssuanceTime = r.time("IssuanceTime")
+	d.SolidTime = r.time("SolidTime")
+	d.OpinionFormedTime = r.time("OpinionFormedTime")
+	d.Liked = r.bool("Liked")
+	d.MonotonicallyLiked = r.bool("MonotonicallyLiked")
+	d.InclusionState = r.string("InclusionState")
+	d.Finalized = r.bool("Finalized")
+	d.LazyBooked = r.bool("LazyBooked")
+	d.TransactionLiked = r.bool("TransactionLiked")
+
+	return r.err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region DiagnosticTransaction ////////////////////////////////////////////////////////////////////////////////////////
+
+// DiagnosticUTXODAGTableDescription holds the description of the diagnostic UTXODAG.
+var DiagnosticUTXODAGTableDescription = []string{
+	"ID",
+	"IssuanceTime",
+	"SolidTime",
+	"OpinionFormedTime",
+	"AccessManaPledgeID",
+	"ConsensusManaPledgeID",
+	"Inputs",
+	"Outputs",
+	"Attachments",
+	"BranchID",
+	"BranchLiked",
+	"BranchMonotonicallyLiked",
+	"Conflicting",
+	"InclusionState",
+	"Finalized",
+	"LazyBooked",
+	"Liked",
+	"LoK",
+	"FCOB1Time",
+	"FCOB2Time",
+}
+
+// DiagnosticTransaction represents the JSON model of a row of the UTXODAG diagnostics.
+type DiagnosticTransaction struct {
+	// transaction essence
+	ID                    string    `json:"id"`
+	IssuanceTime          time.Time `json:"issuanceTime"`
+	SolidTime             time.Time `json:"solidTime"`
+	OpinionFormedTime     time.Time `json:"opinionFormedTime"`
+	AccessManaPledgeID    string    `json:"accessManaPledgeID"`
+	ConsensusManaPledgeID string    `json:"consensusManaPledgeID"`
+	Inputs                []string  `json:"inputs"`
+	Outputs               []string  `json:"outputs"`
+	// attachments
+	Attachments []string `json:"attachments"`
+	// transaction metadata
+	BranchID                 string    `json:"branchID"`
+	BranchLiked              bool      `json:"branchLiked"`
+	BranchMonotonicallyLiked bool      `json:"branchMonotonicallyLiked"`
+	Conflicting              bool      `json:"conflicting"`
+	InclusionState           string    `json:"inclusionState"`
+	Finalized                bool      `json:"finalized"`
+	LazyBooked               bool      `json:"lazyBooked"`
+	Liked                    bool      `json:"liked"`
+	LoK                      string    `json:"lok"`
+	FCOBTime1                time.Time `json:"fcob1Time"`
+	FCOBTime2                time.Time `json:"fcob2Time"`
+}
+
+// CSVRow returns the columns of the transaction in the order of the DiagnosticUTXODAGTableDescription.
+func (d *DiagnosticTransaction) CSVRow() []string {
+	return []string{
+		d.ID,
+		formatDiagnosticTime(d.IssuanceTime),
+		formatDiagnosticTime(d.SolidTime),
+		formatDiagnosticTime(d.OpinionFormedTime),
+		d.AccessManaPledgeID,
+		d.ConsensusManaPledgeID,
+		formatDiagnosticList(d.Inputs),
+		formatDiagnosticList(d.Outputs),
+		formatDiagnosticList(d.Attachments),
+		d.BranchID,
+		fmt.Sprint(d.BranchLiked),
+		fmt.Sprint(d.BranchMonotonicallyLiked),
+		fmt.Sprint(d.Conflicting),
+		d.InclusionState,
+		fmt.Sprint(d.Finalized),
+		fmt.Sprint(d.LazyBooked),
+		fmt.Sprint(d.Liked),
+		d.LoK,
+		formatDiagnosticTime(d.FCOBTime1),
+		formatDiagnosticTime(d.FCOBTime2),
+	}
+}
+
+// ParseCSVRow sets the fields of the transaction from a CSV row with the given header.
+func (d *DiagnosticTransaction) ParseCSVRow(header, row []string) error {
+	r, err := newDiagnosticCSVRecord(header, row)
+	if err != nil {
+		return err
+	}
+
+	d.ID = r.string("ID")
+	d.IssuanceTime = r.time("IssuanceTime")
+	d.SolidTime = r.time("SolidTime")
+	d.OpinionFormedTime = r.time("OpinionFormedTime")
+	d.AccessManaPledgeID = r.string("AccessManaPledgeID")
+	d.ConsensusManaPledgeID = r.string("ConsensusManaPledgeID")
+	d.Inputs = r.list("Inputs")
+	d.Outputs = r.list("Outputs")
+	d.Attachments = r.list("Attachments")
+	d.BranchID = r.string("BranchID")
+	d.BranchLiked = r.bool("BranchLiked")
+	d.BranchMonotonicallyLiked = r.bool("BranchMonotonicallyLiked")
+	d.Conflicting = r.bool("Conflicting")
+	d.InclusionState = r.string("InclusionState")
+	d.Finalized = r.bool("Finalized")
+	d.LazyBooked = r.bool("LazyBooked")
+	d.Liked = r.bool("Liked")
+	d.LoK = r.string("LoK")
+	d.FCOBTime1 = r.time("FCOB1Time")
+	d.FCOBTime2 = r.time("FCOB2Time")
+
+	return r.err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region DiagnosticDRNGMessage ////////////////////////////////////////////////////////////////////////////////////////
+
+// DiagnosticDRNGMessagesTableDescription holds the description of the diagnostic dRNG messages.
+var DiagnosticDRNGMessagesTableDescription = []string{
+	"ID",
+	"IssuerID",
+	"IssuerPublicKey",
+	"IssuanceTime",
+	"ArrivalTime",
+	"SolidTime",
+	"ScheduledTime",
+	"BookedTime",
+	"OpinionFormedTime",
+	"dRNGPayloadType",
+	"InstanceID",
+	"Round",
+	"PreviousSignature",
+	"Signature",
+	"DistributedPK",
+}
+
+// DiagnosticDRNGMessage represents the JSON model of a row of the dRNG diagnostics.
+type DiagnosticDRNGMessage struct {
+	ID                string    `json:"id"`
+	IssuerID          string    `json:"issuerID"`
+	IssuerPublicKey   string    `json:"issuerPublicKey"`
+	IssuanceTime      time.Time `json:"issuanceTime"`
+	ArrivalTime       time.Time `json:"arrivalTime"`
+	SolidTime         time.Time `json:"solidTime"`
+	ScheduledTime     time.Time `json:"scheduledTime"`
+	BookedTime        time.Time `json:"bookedTime"`
+	OpinionFormedTime time.Time `json:"opinionFormedTime"`
+	PayloadType       string    `json:"payloadType"`
+	InstanceID        uint32    `json:"instanceID"`
+	Round             uint64    `json:"round"`
+	PreviousSignature string    `json:"previousSignature"`
+	Signature         string    `json:"signature"`
+	DistributedPK     string    `json:"distributedPK"`
+}
+
+// CSVRow returns the columns of the dRNG message in the order of the DiagnosticDRNGMessagesTableDescription.
+func (d *DiagnosticDRNGMessage) CSVRow() []string {
+	return []string{
+		d.ID,
+		d.IssuerID,
+		d.IssuerPublicKey,
+		formatDiagnosticTime(d.IssuanceTime),
+		formatDiagnosticTime(d.ArrivalTime),
+		formatDiagnosticTime(d.SolidTime),
+		formatDiagnosticTime(d.ScheduledTime),
+		formatDiagnosticTime(d.BookedTime),
+		formatDiagnosticTime(d.OpinionFormedTime),
+		d.PayloadType,
+		fmt.Sprint(d.InstanceID),
+		fmt.Sprint(d.Round),
+		d.PreviousSignature,
+		d.Signature,
+		d.DistributedPK,
+	}
+}
+
+// ParseCSVRow sets the fields of the dRNG message from a CSV row with the given header.
+func (d *DiagnosticDRNGMessage) ParseCSVRow(header, row []string) error {
+	r, err := newDiagnosticCSVRecord(header, row)
+	if err != nil {
+		return err
+	}
+
+	d.ID = r.string("ID")
+	d.IssuerID = r.string("IssuerID")
+	d.IssuerPublicKey = r.string("IssuerPublicKey")
+	d.IssuanceTime = r.time("IssuanceTime")
+	d.ArrivalTime = r.time("ArrivalTime")
+	d.SolidTime = r.time("SolidTime")
+	d.ScheduledTime = r.time("ScheduledTime")
+	d.BookedTime = r.time("BookedTime")
+	d.OpinionFormedTime = r.time("OpinionFormedTime")
+	d.PayloadType = r.string("dRNGPayloadType")
+	d.InstanceID = uint32(r.uint64("InstanceID"))
+	d.Round = r.uint64("Round")
+	d.PreviousSignature = r.string("PreviousSignature")
+	d.Signature = r.string("Signature")
+	d.DistributedPK = r.string("DistributedPK")
+
+	return r.err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region diagnosticCSVRecord //////////////////////////////////////////////////////////////////////////////////////////
+
+// zeroDiagnosticTime is the value that the zero time is written as, so that it can be restored when a row is parsed.
+var zeroDiagnosticTime = fmt.Sprint(time.Time{}.UnixNano())
+
+// formatDiagnosticTime returns the time as nanoseconds since the unix epoch.
+func formatDiagnosticTime(t time.Time) string {
+	return fmt.Sprint(t.UnixNano())
+}
+
+// formatDiagnosticList returns the elements of the list separated by semicolons.
+func formatDiagnosticList(list []string) string {
+	return strings.Join(list, ";")
+}
+
+// diagnosticCSVRecord gives access to the columns of a CSV row by the names in its header. Columns that are missing,
+// e.g. because the row was written by an older node, are left empty. The first error that occurs while parsing a column
+// is kept, so that a row can be parsed without checking every column.
+type diagnosticCSVRecord struct {
+	columns map[string]string
+	err     error
+}
+
+func newDiagnosticCSVRecord(header, row []string) (*diagnosticCSVRecord, error) {
+	if len(header) != len(row) {
+		return nil, errors.Errorf("row has %d columns, but the header has %d", len(row), len(header))
+	}
+
+	record := &diagnosticCSVRecord{columns: make(map[string]string, len(header))}
+	for i, column := range header {
+		record.columns[column] = row[i]
+	}
+
+	return record, nil
+}
+
+func (r *diagnosticCSVRecord) string(column string) string {
+	return r.columns[column]
+}
+
+func (r *diagnosticCSVRecord) list(column string) []string {
+	if r.columns[column] == "" {
+		return nil
+	}
+
+	return strings.Split(r.columns[column], ";")
+}
+
+func (r *diagnosticCSVRecord) bool(column string) bool {
+	if r.columns[column] == "" {
+		return false
+	}
+
+	value, err := strconv.ParseBool(r.columns[column])
+	r.keepError(column, err)
+
+	return value
+}
+
+func (r *diagnosticCSVRecord) uint64(column string) uint64 {
+	if r.columns[column] == "" {
+		return 0
+	}
+
+	value, err := strconv.ParseUint(r.columns[column], 10, 64)
+	r.keepError(column, err)
+
+	return value
+}
+
+func (r *diagnosticCSVRecord) time(column string) time.Time {
+	if r.columns[column] == "" || r.columns[column] == zeroDiagnosticTime {
+		return time.Time{}
+	}
+
+	nanoseconds, err := strconv.ParseInt(r.columns[column], 10, 64)
+	r.keepError(column, err)
+
+	return time.Unix(0, nanoseconds)
+}
+
+func (r *diagnosticCSVRecord) keepError(column string, err error) {
+	if err != nil && r.err == nil {
+		r.err = errors.Errorf("failed to parse column %s: %w", column, err)
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,300 @@
+package jsonmodels
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDiagnosticTime = time.Unix(0, 1625000000123456789)
+
+func testDiagnosticMessage() DiagnosticMessage {
+	return DiagnosticMessage{
+		ID:                      "message",
+		IssuerID:                "issuer",
+		IssuerPublicKey:         "publicKey",
+		IssuanceTime:            testDiagnosticTime,
+		ArrivalTime:             testDiagnosticTime.Add(time.Second),
+		SolidTime:               testDiagnosticTime.Add(2 * time.Second),
+		ScheduledTime:           testDiagnosticTime.Add(3 * time.Second),
+		BookedTime:              testDiagnosticTime.Add(4 * time.Second),
+		OpinionFormedTime:       testDiagnosticTime.Add(5 * time.Second),
+		FinalizedTime:           testDiagnosticTime.Add(6 * time.Second),
+		StrongParents:           []string{"parent1", "parent2"},
+		WeakParents:             []string{"parent3"},
+		StrongApprovers:         []string{"approver1", "approver2"},
+		WeakApprovers:           []string{"approver3"},
+		BranchID:                "branch",
+		InclusionState:          "Confirmed",
+		Scheduled:               true,
+		Booked:                  true,
+		Eligible:                true,
+		Invalid:                 false,
+		Finalized:               true,
+		Rank:                    42,
+		IsPastMarker:            true,
+		PastMarkers:             "1:2",
+		PMHI:                    2,
+		PMLI:                    1,
+		FutureMarkers:           "1:3",
+		FMHI:                    3,
+		FMLI:                    3,
+		PayloadType:             "TransactionType(1337)",
+		TransactionID:           "transaction",
+		PayloadOpinionFormed:    true,
+		TimestampOpinionFormed:  true,
+		MessageOpinionFormed:    true,
+		MessageOpinionTriggered: false,
+		TimestampOpinion:        "Like",
+		TimestampLoK:            "Two",
+	}
+}
+
+func TestDiagnosticRow_RoundTrip(t *testing.T) {
+	testCases := []struct {
+		name   string
+		header []string
+		row    DiagnosticRow
+		parsed DiagnosticRow
+	}{
+		{
+			name:   "message",
+			header: DiagnosticMessagesTableDescription,
+			row:    func() *DiagnosticMessage { m := testDiagnosticMessage(); return &m }(),
+			parsed: &DiagnosticMessage{},
+		},
+		{
+			name:   "message with zero times and empty lists",
+			header: DiagnosticMessagesTableDescription,
+			row:    &DiagnosticMessage{ID: "message", IssuanceTime: testDiagnosticTime, Rank: 1},
+			parsed: &DiagnosticMessage{},
+		},
+		{
+			name:   "tip",
+			header: DiagnosticTipsTableDescription,
+			row:    &DiagnosticTip{TipType: "StrongTip", DiagnosticMessage: testDiagnosticMessage()},
+			parsed: &DiagnosticTip{},
+		},
+		{
+			name:   "tip with zero times and empty lists",
+			header: DiagnosticTipsTableDescription,
+			row:    &DiagnosticTip{TipType: "WeakTip", DiagnosticMessage: DiagnosticMessage{ID: "message"}},
+			parsed: &DiagnosticTip{},
+		},
+		{
+			name:   "branch",
+			header: DiagnosticBranchesTableDescription,
+			row: &DiagnosticBranch{
+				ID:                 "branch",
+				ConflictSet:        []string{"conflict1", "conflict2"},
+				IssuanceTime:       testDiagnosticTime,
+				SolidTime:          testDiagnosticTime.Add(time.Second),
+				OpinionFormedTime:  testDiagnosticTime.Add(2 * time.Second),
+				Liked:              true,
+				MonotonicallyLiked: true,
+				InclusionState:     "Pending",
+				Finalized:          false,
+				LazyBooked:         true,
+				TransactionLiked:   true,
+			},
+			parsed: &DiagnosticBranch{},
+		},
+		{
+			name:   "branch with zero times and empty lists",
+			header: DiagnosticBranchesTableDescription,
+			row:    &DiagnosticBranch{ID: "branch", InclusionState: "Rejected"},
+			parsed: &DiagnosticBranch{},
+		},
+		{
+			name:   "transaction",
+			header: DiagnosticUTXODAGTableDescription,
+			row: &DiagnosticTransaction{
+				ID:                       "transaction",
+				IssuanceTime:             testDiagnosticTime,
+				SolidTime:                testDiagnosticTime.Add(time.Second),
+				OpinionFormedTime:        testDiagnosticTime.Add(2 * time.Second),
+				AccessManaPledgeID:       "accessPledge",
+				ConsensusManaPledgeID:    "consensusPledge",
+				Inputs:                   []string{"input1", "input2"},
+				Outputs:                  []string{"output1"},
+				Attachments:              []string{"message1", "message2"},
+				BranchID:                 "branch",
+				BranchLiked:              true,
+				BranchMonotonicallyLiked: true,
+				Conflicting:              true,
+				InclusionState:           "Confirmed",
+				Finalized:                true,
+				LazyBooked:               false,
+				Liked:                    true,
+				LoK:                      "Three",
+				FCOBTime1:                testDiagnosticTime.Add(3 * time.Second),
+				FCOBTime2:                testDiagnosticTime.Add(4 * time.Second),
+			},
+			parsed: &DiagnosticTransaction{},
+		},
+		{
+			name:   "transaction with zero times and empty lists",
+			header: DiagnosticUTXODAGTableDescription,
+			row:    &DiagnosticTransaction{ID: "transaction", SolidTime: testDiagnosticTime},
+			parsed: &DiagnosticTransaction{},
+		},
+		{
+			name:   "dRNG message",
+			header: DiagnosticDRNGMessagesTableDescription,
+			row: &DiagnosticDRNGMessage{
+				ID:                "message",
+				IssuerID:          "issuer",
+				IssuerPublicKey:   "publicKey",
+				IssuanceTime:      testDiagnosticTime,
+				ArrivalTime:       testDiagnosticTime.Add(time.Second),
+				SolidTime:         testDiagnosticTime.Add(2 * time.Second),
+				ScheduledTime:     testDiagnosticTime.Add(3 * time.Second),
+				BookedTime:        testDiagnosticTime.Add(4 * time.Second),
+				OpinionFormedTime: testDiagnosticTime.Add(5 * time.Second),
+				PayloadType:       "CollectiveBeaconType",
+				InstanceID:        1,
+				Round:             1234,
+				PreviousSignature: "previousSignature",
+				Signature:         "signature",
+				DistributedPK:     "distributedPK",
+			},
+			parsed: &DiagnosticDRNGMessage{},
+		},
+		{
+			name:   "dRNG message with zero times",
+			header: DiagnosticDRNGMessagesTableDescription,
+			row:    &DiagnosticDRNGMessage{ID: "message", Round: 1},
+			parsed: &DiagnosticDRNGMessage{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			row := tc.row.CSVRow()
+			require.Len(t, row, len(tc.header))
+
+			require.NoError(t, tc.parsed.ParseCSVRow(tc.header, row))
+			assert.Equal(t, tc.row, tc.parsed)
+		})
+	}
+}
+
+func TestDiagnosticRow_ParseCSVRow_OlderHeader(t *testing.T) {
+	message := testDiagnosticMessage()
+
+	testCases := []struct {
+		name     string
+		header   []string
+		row      []string
+		parsed   DiagnosticRow
+		expected DiagnosticRow
+	}{
+		{
+			name:   "message without the consensus columns",
+			header: DiagnosticMessagesTableDescription[:31],
+			row:    message.CSVRow()[:31],
+			parsed: &DiagnosticMessage{},
+			expected: func() *DiagnosticMessage {
+				expected := testDiagnosticMessage()
+				expected.PayloadOpinionFormed = false
+				expected.TimestampOpinionFormed = false
+				expected.MessageOpinionFormed = false
+				expected.MessageOpinionTriggered = false
+				expected.TimestampOpinion = ""
+				expected.TimestampLoK = ""
+				return &expected
+			}(),
+		},
+		{
+			name:     "tip without the tip type",
+			header:   DiagnosticMessagesTableDescription,
+			row:      message.CSVRow(),
+			parsed:   &DiagnosticTip{},
+			expected: &DiagnosticTip{DiagnosticMessage: testDiagnosticMessage()},
+		},
+		{
+			name:     "branch in a different column order",
+			header:   []string{"Liked", "ID", "ConflictSet"},
+			row:      []string{"true", "branch", "conflict1;conflict2"},
+			parsed:   &DiagnosticBranch{},
+			expected: &DiagnosticBranch{ID: "branch", ConflictSet: []string{"conflict1", "conflict2"}, Liked: true},
+		},
+		{
+			name:     "transaction without the FCoB columns",
+			header:   DiagnosticUTXODAGTableDescription[:18],
+			row:      (&DiagnosticTransaction{ID: "transaction", Liked: true, LoK: "One"}).CSVRow()[:18],
+			parsed:   &DiagnosticTransaction{},
+			expected: &DiagnosticTransaction{ID: "transaction", Liked: true, LoK: "One"},
+		},
+		{
+			name:     "dRNG message with only the ID",
+			header:   []string{"ID"},
+			row:      []string{"message"},
+			parsed:   &DiagnosticDRNGMessage{},
+			expected: &DiagnosticDRNGMessage{ID: "message"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, tc.parsed.ParseCSVRow(tc.header, tc.row))
+			assert.Equal(t, tc.expected, tc.parsed)
+		})
+	}
+}
+
+func TestDiagnosticRow_ParseCSVRow_Invalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		header []string
+		row    []string
+		parsed DiagnosticRow
+		err    string
+	}{
+		{
+			name:   "row shorter than the header",
+			header: DiagnosticBranchesTableDescription,
+			row:    []string{"branch"},
+			parsed: &DiagnosticBranch{},
+			err:    "row has 1 columns, but the header has 11",
+		},
+		{
+			name:   "invalid bool",
+			header: []string{"ID", "Booked"},
+			row:    []string{"message", "maybe"},
+			parsed: &DiagnosticMessage{},
+			err:    "failed to parse column Booked",
+		},
+		{
+			name:   "invalid uint",
+			header: []string{"ID", "Round"},
+			row:    []string{"message", "-1"},
+			parsed: &DiagnosticDRNGMessage{},
+			err:    "failed to parse column Round",
+		},
+		{
+			name:   "invalid time",
+			header: []string{"ID", "SolidTime"},
+			row:    []string{"transaction", "yesterday"},
+			parsed: &DiagnosticTransaction{},
+			err:    "failed to parse column SolidTime",
+		},
+		{
+			name:   "first error is kept",
+			header: []string{"tipType", "IssuanceTime", "Rank"},
+			row:    []string{"StrongTip", "now", "first"},
+			parsed: &DiagnosticTip{},
+			err:    "failed to parse column IssuanceTime",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.parsed.ParseCSVRow(tc.header, tc.row)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/consensus/fcob"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
 )
 
 // region Address //////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -825,6 +826,77 @@ func NewTransactionConsensusMetadata(transactionID ledgerstate.TransactionID, op
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region TransactionConsensusTrace ////////////////////////////////////////////////////////////////////////////////////
+
+// TransactionConsensusTrace represents the JSON model of the trace of the rules that formed the opinion of a transaction.
+type TransactionConsensusTrace struct {
+	TransactionID string               `json:"transactionID"`
+	ArrivalTime   int64                `json:"arrivalTime"`
+	Conflicts     []ConflictArrival    `json:"conflicts"`
+	Steps         []ConsensusTraceStep `json:"steps"`
+	FPCRounds     int                  `json:"fpcRounds"`
+	FPCOpinions   []string             `json:"fpcOpinions"`
+	Liked         bool                 `json:"liked"`
+	LoK           string               `json:"lok"`
+}
+
+// ConflictArrival represents the JSON model of the arrival time of a conflicting transaction.
+type ConflictArrival struct {
+	TransactionID string `json:"transactionID"`
+	ArrivalTime   int64  `json:"arrivalTime"`
+}
+
+// ConsensusTraceStep represents the JSON model of a rule that formed the opinion of a transaction.
+type ConsensusTraceStep struct {
+	Time      int64  `json:"time"`
+	Rule      string `json:"rule"`
+	Liked     bool   `json:"liked"`
+	LoK       string `json:"lok"`
+	MessageID string `json:"messageID,omitempty"`
+}
+
+// NewTransactionConsensusTrace returns the TransactionConsensusTrace from the given fcob.Trace.
+func NewTransactionConsensusTrace(trace *fcob.Trace) *TransactionConsensusTrace {
+	conflicts := make([]ConflictArrival, len(trace.Conflicts))
+	for i, conflict := range trace.Conflicts {
+		conflicts[i] = ConflictArrival{
+			TransactionID: conflict.TransactionID.Base58(),
+			ArrivalTime:   conflict.ArrivalTime.UnixNano(),
+		}
+	}
+
+	steps := make([]ConsensusTraceStep, len(trace.Steps))
+	for i, step := range trace.Steps {
+		steps[i] = ConsensusTraceStep{
+			Time:  step.Time.UnixNano(),
+			Rule:  step.Rule.String(),
+			Liked: step.Liked,
+			LoK:   step.LevelOfKnowledge.String(),
+		}
+		if step.MessageID != tangle.EmptyMessageID {
+			steps[i].MessageID = step.MessageID.Base58()
+		}
+	}
+
+	fpcOpinions := make([]string, len(trace.FPCOpinions))
+	for i, fpcOpinion := range trace.FPCOpinions {
+		fpcOpinions[i] = fpcOpinion.String()
+	}
+
+	return &TransactionConsensusTrace{
+		TransactionID: trace.TransactionID.Base58(),
+		ArrivalTime:   trace.ArrivalTime.UnixNano(),
+		Conflicts:     conflicts,
+		Steps:         steps,
+		FPCRounds:     trace.FPCRounds,
+		FPCOpinions:   fpcOpinions,
+		Liked:         trace.Liked,
+		LoK:           trace.LevelOfKnowledge.String(),
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region utils ////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // getStringBalances translates colored balances to map[string]uint64
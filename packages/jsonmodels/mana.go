@@ -1,6 +1,10 @@
 package jsonmodels
 
-import "github.com/iotaledger/goshimmer/packages/mana"
+import (
+	"github.com/iotaledger/goshimmer/packages/delegation"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
 
 // GetManaRequest is the request for get mana.
 type GetManaRequest struct {
@@ -72,8 +76,11 @@ type OnlineNodeStr struct {
 
 // PastConsensusVectorMetadataResponse is the response.
 type PastConsensusVectorMetadataResponse struct {
-	Metadata *mana.ConsensusBasePastManaVectorMetadata `json:"metadata,omitempty"`
-	Error    string                                    `json:"error,omitempty"`
+	Metadata           *mana.ConsensusBasePastManaVectorMetadata `json:"metadata,omitempty"`
+	OldestTimestamp    int64                                     `json:"oldestTimestamp"`
+	Checkpoints        int                                       `json:"checkpoints"`
+	CheckpointInterval string                                    `json:"checkpointInterval"`
+	Error              string                                    `json:"error,omitempty"`
 }
 
 // PastConsensusManaVectorRequest is the request.
@@ -88,6 +95,22 @@ type PastConsensusManaVectorResponse struct {
 	TimeStamp int64          `json:"timestamp"`
 }
 
+// GetPastManaRequest is the request for the mana of a node at a time in the past.
+type GetPastManaRequest struct {
+	NodeID    string `json:"nodeID"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// GetPastManaResponse defines the response for the mana of a node at a time in the past.
+type GetPastManaResponse struct {
+	Error       string  `json:"error,omitempty"`
+	ShortNodeID string  `json:"shortNodeID"`
+	NodeID      string  `json:"nodeID"`
+	Access      float64 `json:"access"`
+	Consensus   float64 `json:"consensus"`
+	Timestamp   int64   `json:"timestamp"`
+}
+
 // PendingRequest is the pending mana request.
 type PendingRequest struct {
 	OutputID string `json:"outputID"`
@@ -129,3 +152,134 @@ type AllowedPledge struct {
 	IsFilterEnabled bool     `json:"isFilterEnabled"`
 	Allowed         []string `json:"allowed,omitempty"`
 }
+
+// GetDelegationsRequest is the request object of mana/delegations.
+type GetDelegationsRequest struct {
+	Receiver string `json:"receiver"`
+}
+
+// GetDelegationsResponse is the response object of mana/delegations.
+type GetDelegationsResponse struct {
+	Receiver      string        `json:"receiver"`
+	Delegations   []*Delegation `json:"delegations"`
+	TotalAmount   uint64        `json:"totalAmount"`
+	ConsensusMana float64       `json:"consensusMana"`
+	PendingMana   float64       `json:"pendingMana"`
+	Error         string        `json:"error,omitempty"`
+}
+
+// Delegation represents a delegation alias output of the delegation registry.
+type Delegation struct {
+	AliasID       string            `json:"aliasID"`
+	OutputID      string            `json:"outputID"`
+	Receiver      string            `json:"receiver"`
+	Delegator     string            `json:"delegator"`
+	Balances      map[string]uint64 `json:"balances"`
+	Amount        uint64            `json:"amount"`
+	Timelock      int64             `json:"timelock,omitempty"`
+	Since         int64             `json:"since"`
+	Updated       int64             `json:"updated"`
+	Closed        int64             `json:"closed,omitempty"`
+	Status        string            `json:"status"`
+	ConsensusMana float64           `json:"consensusMana"`
+	PendingMana   float64           `json:"pendingMana"`
+}
+
+// NewDelegation returns a Delegation from the given delegation registry entry. The consensus mana contribution of a
+// delegation is its amount as long as the funds are not reclaimed, the pending mana is the access mana that the next
+// refresh of the delegation pledges to the receiver.
+func NewDelegation(d *delegation.Delegation, pendingMana float64) *Delegation {
+	balances := make(map[string]uint64)
+	d.Balances.ForEach(func(color ledgerstate.Color, balance uint64) bool {
+		balances[color.Base58()] = balance
+		return true
+	})
+	res := &Delegation{
+		AliasID:   d.AliasAddress.Base58(),
+		OutputID:  d.OutputID.Base58(),
+		Receiver:  d.Receiver.Base58(),
+		Delegator: d.Delegator.Base58(),
+		Balances:  balances,
+		Amount:    d.Amount(),
+		Since:     d.Since.Unix(),
+		Updated:   d.Updated.Unix(),
+		Status:    d.Status.String(),
+	}
+	if !d.Timelock.IsZero() {
+		res.Timelock = d.Timelock.Unix()
+	}
+	if !d.Closed.IsZero() {
+		res.Closed = d.Closed.Unix()
+	}
+	if d.Status != delegation.Reclaimed {
+		res.ConsensusMana = float64(res.Amount)
+		res.PendingMana = pendingMana
+	}
+	return res
+}
+
+// GetDelegationReceiversResponse is the response object of mana/delegations/receivers.
+type GetDelegationReceiversResponse struct {
+	Receivers []*DelegationReceiver `json:"receivers"`
+	Error     string                `json:"error,omitempty"`
+}
+
+// DelegationReceiver summarizes the delegations to a single delegation address.
+type DelegationReceiver struct {
+	Receiver    string `json:"receiver"`
+	Delegations int    `json:"delegations"`
+	Amount      uint64 `json:"amount"`
+}
+
+// GetManaFlowRequest is the request object of the mana/flow endpoints.
+type GetManaFlowRequest struct {
+	NodeID   string `json:"nodeID,omitempty"`
+	Pledger  string `json:"pledger,omitempty"`
+	ManaType string `json:"manaType,omitempty"`
+	Start    int64  `json:"start,omitempty"`
+	End      int64  `json:"end,omitempty"`
+	Limit    int    `json:"limit,omitempty"`
+}
+
+// GetManaFlowResponse is the response object of the mana/flow endpoints.
+type GetManaFlowResponse struct {
+	ManaType     string      `json:"manaType"`
+	Start        int64       `json:"start"`
+	End          int64       `json:"end"`
+	CoveredSince int64       `json:"coveredSince"`
+	Flows        []*ManaFlow `json:"flows"`
+	Error        string      `json:"error,omitempty"`
+}
+
+// ManaFlow is the mana pledged and revoked between a pledger address and a node.
+type ManaFlow struct {
+	Pledger      string  `json:"pledger"`
+	ShortNodeID  string  `json:"shortNodeID"`
+	NodeID       string  `json:"nodeID"`
+	Pledged      float64 `json:"pledged"`
+	Revoked      float64 `json:"revoked"`
+	Transactions int     `json:"transactions"`
+}
+
+// GetManaFlowTransactionRequest is the request object of mana/flow/transaction.
+type GetManaFlowTransactionRequest struct {
+	TransactionID string `json:"transactionID"`
+}
+
+// GetManaFlowTransactionResponse is the response object of mana/flow/transaction.
+type GetManaFlowTransactionResponse struct {
+	TransactionID string            `json:"transactionID"`
+	Records       []*ManaFlowRecord `json:"records"`
+	Error         string            `json:"error,omitempty"`
+}
+
+// ManaFlowRecord is a single pledge or revocation of mana by a transaction.
+type ManaFlowRecord struct {
+	Pledger     string  `json:"pledger"`
+	ShortNodeID string  `json:"shortNodeID"`
+	NodeID      string  `json:"nodeID"`
+	ManaType    string  `json:"manaType"`
+	Amount      float64 `json:"amount"`
+	Time        int64   `json:"time"`
+	Revoked     bool    `json:"revoked"`
+}
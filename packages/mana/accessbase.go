@@ -1,7 +1,6 @@
 package mana
 
 import (
-	"math"
 	"time"
 )
 
@@ -10,6 +9,9 @@ type AccessBaseMana struct {
 	BaseMana2          float64
 	EffectiveBaseMana2 float64
 	LastUpdated        time.Time
+
+	// model is the model of the mana formulas, the default model is used if it is nil.
+	model Model
 }
 
 func (a *AccessBaseMana) update(t time.Time) error {
@@ -34,7 +36,7 @@ func (a *AccessBaseMana) updateBM2(n time.Duration) {
 		a.BaseMana2 = 0
 		return
 	}
-	a.BaseMana2 *= math.Pow(math.E, -Decay*n.Seconds())
+	a.BaseMana2 = a.currentModel().DecayBaseMana(a.BaseMana2, a.LastUpdated, a.LastUpdated.Add(n))
 }
 
 func (a *AccessBaseMana) updateEBM2(n time.Duration) {
@@ -48,14 +50,7 @@ func (a *AccessBaseMana) updateEBM2(n time.Duration) {
 		return
 	}
 
-	if emaCoeff2 != Decay {
-		a.EffectiveBaseMana2 = math.Pow(math.E, -emaCoeff2*n.Seconds())*a.EffectiveBaseMana2 +
-			(math.Pow(math.E, -Decay*n.Seconds())-math.Pow(math.E, -emaCoeff2*n.Seconds()))/
-				(emaCoeff2-Decay)*emaCoeff2/math.Pow(math.E, -Decay*n.Seconds())*a.BaseMana2
-	} else {
-		a.EffectiveBaseMana2 = math.Pow(math.E, -Decay*n.Seconds())*a.EffectiveBaseMana2 +
-			Decay*n.Seconds()*a.BaseMana2
-	}
+	a.EffectiveBaseMana2 = a.currentModel().UpdateEffectiveMana(a.EffectiveBaseMana2, a.BaseMana2, a.LastUpdated, a.LastUpdated.Add(n))
 }
 
 func (a *AccessBaseMana) revoke(float64) error {
@@ -64,7 +59,21 @@ func (a *AccessBaseMana) revoke(float64) error {
 
 func (a *AccessBaseMana) pledge(tx *TxInfo) (pledged float64) {
 	t := tx.TimeStamp
+	model := a.currentModel()
+	// pending mana awarded, need to see how long funds sat
+	for _, input := range tx.InputInfos {
+		pledged += model.PendingMana(input.Amount, input.TimeStamp, t)
+	}
+	if !t.After(a.LastUpdated) {
+		// past update, the pledged BM2 already decayed until `bm.LastUpdated`
+		pledged = model.DecayBaseMana(pledged, t, a.LastUpdated)
+	}
+	a.add(pledged, t)
+	return
+}
 
+// add adds the BM2 that was pledged at `t`. For a past update, the amount has to be decayed until `bm.LastUpdated`.
+func (a *AccessBaseMana) add(amount float64, t time.Time) {
 	if t.After(a.LastUpdated) {
 		// regular update
 		n := t.Sub(a.LastUpdated)
@@ -72,32 +81,22 @@ func (a *AccessBaseMana) pledge(tx *TxInfo) (pledged float64) {
 		a.updateBM2(n)
 		a.updateEBM2(n)
 		a.LastUpdated = t
-		// pending mana awarded, need to see how long funds sat
-		for _, input := range tx.InputInfos {
-			bm2Add := input.Amount * (1 - math.Pow(math.E, -Decay*(t.Sub(input.TimeStamp).Seconds())))
-			a.BaseMana2 += bm2Add
-			pledged += bm2Add
-		}
-	} else {
-		// past update
-		n := a.LastUpdated.Sub(t)
-		// update  BM2 at `t`
-		oldMana2 := a.BaseMana2
-		for _, input := range tx.InputInfos {
-			bm2Add := input.Amount * (1 - math.Pow(math.E, -Decay*(t.Sub(input.TimeStamp).Seconds()))) *
-				math.Pow(math.E, -Decay*n.Seconds())
-			a.BaseMana2 += bm2Add
-			pledged += bm2Add
-		}
-		// update EBM2 to `bm.LastUpdated`
-		if emaCoeff2 != Decay {
-			a.EffectiveBaseMana2 += (a.BaseMana2 - oldMana2) * emaCoeff2 * (math.Pow(math.E, -Decay*n.Seconds()) -
-				math.Pow(math.E, -emaCoeff2*n.Seconds())) / (emaCoeff2 - Decay) / math.Pow(math.E, -Decay*n.Seconds())
-		} else {
-			a.EffectiveBaseMana2 += (a.BaseMana2 - oldMana2) * Decay * n.Seconds()
-		}
+		a.BaseMana2 = a.currentModel().AddBaseMana(a.BaseMana2, amount)
+		return
 	}
-	return
+
+	// past update
+	a.BaseMana2 = a.currentModel().AddBaseMana(a.BaseMana2, amount)
+	// update EBM2 to `bm.LastUpdated`
+	a.EffectiveBaseMana2 += a.currentModel().UpdateEffectiveMana(0, amount, t, a.LastUpdated)
+}
+
+// currentModel returns the model of the mana formulas of the entry.
+func (a *AccessBaseMana) currentModel() Model {
+	if a.model == nil {
+		return DefaultModel()
+	}
+	return a.model
 }
 
 // BaseValue returns the base mana value (BM2).
@@ -14,9 +14,19 @@ import (
 // AccessBaseManaVector represents a base mana vector.
 type AccessBaseManaVector struct {
 	vector map[identity.ID]*AccessBaseMana
+	// model is the model of the mana formulas of the entries, the default model is used if it is nil.
+	model Model
 	sync.RWMutex
 }
 
+// Model returns the model of the mana formulas used by the vector.
+func (a *AccessBaseManaVector) Model() Model {
+	if a.model == nil {
+		return DefaultModel()
+	}
+	return a.model
+}
+
 // Type returns the type of this mana vector.
 func (a *AccessBaseManaVector) Type() Type {
 	return AccessMana
@@ -48,6 +58,7 @@ func (a *AccessBaseManaVector) LoadSnapshot(snapshot map[identity.ID]SnapshotNod
 			BaseMana2:          record.AccessMana.Value,
 			EffectiveBaseMana2: record.AccessMana.Value,
 			LastUpdated:        record.AccessMana.Timestamp,
+			model:              a.model,
 		}
 		// trigger events
 		Events().Pledged.Trigger(&PledgedEvent{
@@ -70,7 +81,7 @@ func (a *AccessBaseManaVector) Book(txInfo *TxInfo) {
 		pledgeNodeID := txInfo.PledgeID[a.Type()]
 		if _, exist := a.vector[pledgeNodeID]; !exist {
 			// first time we see this node
-			a.vector[pledgeNodeID] = &AccessBaseMana{}
+			a.vector[pledgeNodeID] = &AccessBaseMana{model: a.model}
 		}
 		// save it for proper event trigger
 		oldMana := *a.vector[pledgeNodeID]
@@ -229,7 +240,11 @@ func (a *AccessBaseManaVector) GetHighestManaNodesFraction(p float64) (res []Nod
 func (a *AccessBaseManaVector) SetMana(nodeID identity.ID, bm BaseMana) {
 	a.Lock()
 	defer a.Unlock()
-	a.vector[nodeID] = bm.(*AccessBaseMana)
+	accessBaseMana := bm.(*AccessBaseMana)
+	if a.model != nil {
+		accessBaseMana.model = a.model
+	}
+	a.vector[nodeID] = accessBaseMana
 }
 
 // ForEach iterates over the vector and calls the provided callback.
@@ -282,6 +297,7 @@ func (a *AccessBaseManaVector) FromPersistable(p *PersistableBaseMana) (err erro
 		BaseMana2:          p.BaseValues[0],
 		EffectiveBaseMana2: p.EffectiveValues[0],
 		LastUpdated:        p.LastUpdated,
+		model:              a.model,
 	}
 	return
 }
@@ -43,12 +43,33 @@ type BaseManaVector interface {
 	RemoveZeroNodes()
 }
 
+// VectorOption is a function setting an option of a base mana vector.
+type VectorOption func(options *vectorOptions)
+
+type vectorOptions struct {
+	model Model
+}
+
+// WithModel sets the model of the mana formulas of an access base mana vector. Consensus base mana is not decayed, so
+// the option has no effect on consensus base mana vectors.
+func WithModel(model Model) VectorOption {
+	return func(options *vectorOptions) {
+		options.model = model
+	}
+}
+
 // NewBaseManaVector creates and returns a new base mana vector for the specified type.
-func NewBaseManaVector(vectorType Type) (BaseManaVector, error) {
+func NewBaseManaVector(vectorType Type, opts ...VectorOption) (BaseManaVector, error) {
+	options := &vectorOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	switch vectorType {
 	case AccessMana:
 		return &AccessBaseManaVector{
 			vector: make(map[identity.ID]*AccessBaseMana),
+			model:  options.model,
 		}, nil
 	case ConsensusMana:
 		return &ConsensusBaseManaVector{
@@ -19,4 +19,8 @@ var (
 	ErrInvalidTargetManaType = errors.New("invalid target mana type")
 	// ErrUnknownManaEvent is returned if mana event type could not be identified.
 	ErrUnknownManaEvent = errors.New("unknown mana event")
+	// ErrHistoryNotAvailable is returned if the mana history does not reach back to the requested time.
+	ErrHistoryNotAvailable = errors.New("mana history not available for the requested time")
+	// ErrQueryNotAllowed is returned when the node is not synced and mana debug mode is disabled.
+	ErrQueryNotAllowed = errors.New("mana query not allowed, node is not synced, debug mode disabled")
 )
@@ -0,0 +1,426 @@
+package mana
+
+import (
+	"encoding/binary"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+)
+
+const (
+	historyPrefixCheckpoint byte = iota
+	historyPrefixEvent
+)
+
+// eventBucketDuration is the time span covered by a bucket of the event log. Events are stored by bucket so that the
+// replay between two checkpoints only iterates over the buckets in between.
+const eventBucketDuration = time.Hour
+
+// HistoryMetadata describes which part of the history of a mana type is available.
+type HistoryMetadata struct {
+	ManaType           Type
+	OldestCheckpoint   time.Time
+	LatestCheckpoint   time.Time
+	Checkpoints        int
+	CheckpointInterval time.Duration
+}
+
+// History stores periodic checkpoints of the access and consensus base mana vectors together with the log of the
+// pledge and revoke events. The mana at any time after the oldest checkpoint is reconstructed by replaying the logged
+// events on top of the closest checkpoint before that time.
+//
+// The oldest checkpoint of every mana type is the base of its history. Later checkpoints are only derived from the base
+// and the event log, so events that are logged late (i.e. with a timestamp before existing checkpoints) invalidate
+// the checkpoints after them, which are then recreated by CreateCheckpoints.
+type History struct {
+	store              kvstore.KVStore
+	checkpointInterval time.Duration
+	mutex              sync.RWMutex
+}
+
+// NewHistory creates a new History that persists its data in the given store and creates a checkpoint every interval.
+func NewHistory(store kvstore.KVStore, checkpointInterval time.Duration) *History {
+	return &History{
+		store:              store,
+		checkpointInterval: checkpointInterval,
+	}
+}
+
+// Initialize stores the given vector as the base of the history of its mana type at time t, unless a history exists.
+func (h *History) Initialize(vector BaseManaVector, t time.Time) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	checkpoints, err := h.checkpointTimes(vector.Type())
+	if err != nil || len(checkpoints) > 0 {
+		return err
+	}
+	return h.storeCheckpoint(vector, t)
+}
+
+// LogEvent adds a pledge or revoke event of the access or consensus mana to the event log. Other events are ignored.
+func (h *History) LogEvent(ev Event) error {
+	if ev.Type() != EventTypePledge && ev.Type() != EventTypeRevoke {
+		return nil
+	}
+	persistableEvent := ev.ToPersistable()
+	if persistableEvent.ManaType != AccessMana && persistableEvent.ManaType != ConsensusMana {
+		return nil
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err := h.store.Set(eventKey(persistableEvent), persistableEvent.Bytes()); err != nil {
+		return errors.Errorf("failed to log %s event: %w", persistableEvent.ManaType, err)
+	}
+
+	checkpoints, err := h.checkpointTimes(persistableEvent.ManaType)
+	if err != nil || len(checkpoints) == 0 || persistableEvent.Time.After(checkpoints[len(checkpoints)-1]) {
+		return err
+	}
+
+	// the event is not part of the checkpoints after it and needs to be added to the base if it is older
+	if !persistableEvent.Time.After(checkpoints[0]) {
+		vector, loadErr := h.loadCheckpoint(persistableEvent.ManaType, checkpoints[0])
+		if loadErr != nil {
+			return loadErr
+		}
+		if err = replayEvent(vector, ev); err != nil {
+			return err
+		}
+		if err = h.storeCheckpoint(vector, checkpoints[0]); err != nil {
+			return err
+		}
+	}
+	for _, checkpoint := range checkpoints[1:] {
+		if checkpoint.Before(persistableEvent.Time) {
+			continue
+		}
+		if err = h.store.Delete(checkpointKey(persistableEvent.ManaType, checkpoint)); err != nil {
+			return errors.Errorf("failed to delete invalidated %s checkpoint: %w", persistableEvent.ManaType, err)
+		}
+	}
+	return nil
+}
+
+// CreateCheckpoints creates the missing checkpoints up to the given time for every mana type with an initialized
+// history. The time should lag behind the current time, so that the events before it are already logged.
+func (h *History) CreateCheckpoints(until time.Time) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for _, manaType := range []Type{AccessMana, ConsensusMana} {
+		checkpoints, err := h.checkpointTimes(manaType)
+		if err != nil {
+			return err
+		}
+		if len(checkpoints) == 0 {
+			continue
+		}
+		latest := checkpoints[len(checkpoints)-1]
+		if latest.Add(h.checkpointInterval).After(until) {
+			continue
+		}
+
+		vector, err := h.loadCheckpoint(manaType, latest)
+		if err != nil {
+			return err
+		}
+		events, err := h.events(manaType, nil, latest, until)
+		if err != nil {
+			return err
+		}
+		for next := latest.Add(h.checkpointInterval); !next.After(until); next = next.Add(h.checkpointInterval) {
+			for len(events) > 0 && !events[0].Timestamp().After(next) {
+				if err = replayEvent(vector, events[0]); err != nil {
+					return err
+				}
+				events = events[1:]
+			}
+			if err = h.storeCheckpoint(vector, next); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ManaVectorAt reconstructs the base mana vector of the given type at time t.
+func (h *History) ManaVectorAt(manaType Type, t time.Time) (BaseManaVector, error) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	checkpoints, err := h.checkpointTimes(manaType)
+	if err != nil {
+		return nil, err
+	}
+	index := sort.Search(len(checkpoints), func(i int) bool { return checkpoints[i].After(t) }) - 1
+	if index < 0 {
+		return nil, errors.Errorf("no %s checkpoint before %s: %w", manaType, t, ErrHistoryNotAvailable)
+	}
+
+	vector, err := h.loadCheckpoint(manaType, checkpoints[index])
+	if err != nil {
+		return nil, err
+	}
+	events, err := h.events(manaType, nil, checkpoints[index], t)
+	if err != nil {
+		return nil, err
+	}
+	for _, ev := range events {
+		if err = replayEvent(vector, ev); err != nil {
+			return nil, err
+		}
+	}
+	return vector, nil
+}
+
+// ManaAt returns the mana of the given type that the node had at time t.
+func (h *History) ManaAt(manaType Type, nodeID identity.ID, t time.Time) (float64, error) {
+	vector, err := h.ManaVectorAt(manaType, t)
+	if err != nil {
+		return 0, err
+	}
+	value, _, err := vector.GetMana(nodeID, t)
+	if errors.Is(err, ErrNodeNotFoundInBaseManaVector) {
+		return 0, nil
+	}
+	return value, err
+}
+
+// Events returns the logged events of the given type and nodes within [start, end] in chronological order. If no
+// node is given, the events of all nodes are returned.
+func (h *History) Events(manaType Type, nodeIDs []identity.ID, start, end time.Time) (EventSlice, error) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	// events are returned after the given time, so move it to include events at start
+	return h.events(manaType, nodeIDs, start.Add(-time.Nanosecond), end)
+}
+
+// Metadata returns which part of the history of the given mana type is available.
+func (h *History) Metadata(manaType Type) (*HistoryMetadata, error) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	checkpoints, err := h.checkpointTimes(manaType)
+	if err != nil {
+		return nil, err
+	}
+	metadata := &HistoryMetadata{
+		ManaType:           manaType,
+		Checkpoints:        len(checkpoints),
+		CheckpointInterval: h.checkpointInterval,
+	}
+	if len(checkpoints) > 0 {
+		metadata.OldestCheckpoint = checkpoints[0]
+		metadata.LatestCheckpoint = checkpoints[len(checkpoints)-1]
+	}
+	return metadata, nil
+}
+
+// Prune removes the checkpoints and events that are not needed to reconstruct the mana after the given time. The
+// latest checkpoint before that time becomes the new base of the history.
+func (h *History) Prune(before time.Time) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for _, manaType := range []Type{AccessMana, ConsensusMana} {
+		checkpoints, err := h.checkpointTimes(manaType)
+		if err != nil {
+			return err
+		}
+		index := sort.Search(len(checkpoints), func(i int) bool { return checkpoints[i].After(before) }) - 1
+		if index <= 0 {
+			continue
+		}
+		base := checkpoints[index]
+
+		batch := h.store.Batched()
+		for _, checkpoint := range checkpoints[:index] {
+			if err = batch.Delete(checkpointKey(manaType, checkpoint)); err != nil {
+				batch.Cancel()
+				return errors.Errorf("failed to prune %s checkpoint: %w", manaType, err)
+			}
+		}
+		var deleteErr error
+		if err = h.store.IterateKeys([]byte{historyPrefixEvent, byte(manaType)}, func(key kvstore.Key) bool {
+			if eventTime(key).After(base) {
+				return true
+			}
+			deleteErr = batch.Delete(key)
+			return deleteErr == nil
+		}); err != nil || deleteErr != nil {
+			batch.Cancel()
+			return errors.Errorf("failed to prune %s events: %w", manaType, errors.CombineErrors(err, deleteErr))
+		}
+		if err = batch.Commit(); err != nil {
+			return errors.Errorf("failed to prune %s history: %w", manaType, err)
+		}
+	}
+	return nil
+}
+
+// checkpointTimes returns the times of the stored checkpoints of the given mana type in ascending order.
+func (h *History) checkpointTimes(manaType Type) (times []time.Time, err error) {
+	if err = h.store.IterateKeys([]byte{historyPrefixCheckpoint, byte(manaType)}, func(key kvstore.Key) bool {
+		times = append(times, decodeTime(key[2:]))
+		return true
+	}); err != nil {
+		return nil, errors.Errorf("failed to read %s checkpoints: %w", manaType, err)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times, nil
+}
+
+func (h *History) storeCheckpoint(vector BaseManaVector, t time.Time) error {
+	persistables := vector.ToPersistables()
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteUint32(uint32(len(persistables)))
+	for _, persistable := range persistables {
+		marshalUtil.WriteBytes(persistable.Bytes())
+	}
+	if err := h.store.Set(checkpointKey(vector.Type(), t), marshalUtil.Bytes()); err != nil {
+		return errors.Errorf("failed to store %s checkpoint: %w", vector.Type(), err)
+	}
+	return nil
+}
+
+func (h *History) loadCheckpoint(manaType Type, t time.Time, opts ...VectorOption) (BaseManaVector, error) {
+	value, err := h.store.Get(checkpointKey(manaType, t))
+	if err != nil {
+		return nil, errors.Errorf("failed to load %s checkpoint: %w", manaType, err)
+	}
+	vector, err := NewBaseManaVector(manaType, opts...)
+	if err != nil {
+		return nil, err
+	}
+	marshalUtil := marshalutil.New(value)
+	count, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse %s checkpoint: %w", manaType, err)
+	}
+	for i := uint32(0); i < count; i++ {
+		persistable, parseErr := Parse(marshalUtil)
+		if parseErr != nil {
+			return nil, errors.Errorf("failed to parse %s checkpoint: %w", manaType, parseErr)
+		}
+		if err = vector.FromPersistable(persistable); err != nil {
+			return nil, err
+		}
+	}
+	return vector, nil
+}
+
+// events returns the logged events of the given type and nodes within (after, until] in chronological order.
+func (h *History) events(manaType Type, nodeIDs []identity.ID, after, until time.Time) (events EventSlice, err error) {
+	lookup := make(map[identity.ID]struct{}, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		lookup[nodeID] = struct{}{}
+	}
+
+	for bucket := eventBucket(after); bucket <= eventBucket(until); bucket++ {
+		var parseErr error
+		if err = h.store.Iterate(eventBucketPrefix(manaType, bucket), func(key kvstore.Key, value kvstore.Value) bool {
+			if t := eventTime(key); !t.After(after) || t.After(until) {
+				return true
+			}
+			var persistableEvent *PersistableEvent
+			if persistableEvent, parseErr = parseEvent(marshalutil.New(value)); parseErr != nil {
+				return false
+			}
+			if _, found := lookup[persistableEvent.NodeID]; len(lookup) > 0 && !found {
+				return true
+			}
+			var ev Event
+			if ev, parseErr = FromPersistableEvent(persistableEvent); parseErr != nil {
+				return false
+			}
+			events = append(events, ev)
+			return true
+		}); err != nil || parseErr != nil {
+			return nil, errors.Errorf("failed to read %s events: %w", manaType, errors.CombineErrors(err, parseErr))
+		}
+	}
+	events.Sort()
+	return events, nil
+}
+
+// replayEvent applies a pledge or revoke event to the given vector.
+func replayEvent(vector BaseManaVector, ev Event) error {
+	switch v := vector.(type) {
+	case *AccessBaseManaVector:
+		pledgeEvent, ok := ev.(*PledgedEvent)
+		if !ok {
+			return errors.Errorf("cannot replay event of type %d on access mana: %w", ev.Type(), ErrUnknownManaEvent)
+		}
+		v.Lock()
+		defer v.Unlock()
+		if _, exist := v.vector[pledgeEvent.NodeID]; !exist {
+			v.vector[pledgeEvent.NodeID] = &AccessBaseMana{model: v.model}
+		}
+		v.vector[pledgeEvent.NodeID].add(pledgeEvent.Amount, pledgeEvent.Time)
+	case *ConsensusBaseManaVector:
+		v.Lock()
+		defer v.Unlock()
+		switch typedEvent := ev.(type) {
+		case *PledgedEvent:
+			if _, exist := v.vector[typedEvent.NodeID]; !exist {
+				v.vector[typedEvent.NodeID] = &ConsensusBaseMana{}
+			}
+			v.vector[typedEvent.NodeID].BaseMana1 += typedEvent.Amount
+		case *RevokedEvent:
+			if _, exist := v.vector[typedEvent.NodeID]; !exist {
+				v.vector[typedEvent.NodeID] = &ConsensusBaseMana{}
+			}
+			return v.vector[typedEvent.NodeID].revoke(typedEvent.Amount)
+		default:
+			return errors.Errorf("cannot replay event of type %d on consensus mana: %w", ev.Type(), ErrUnknownManaEvent)
+		}
+	default:
+		return errors.Errorf("cannot replay events on %s mana: %w", vector.Type(), ErrUnknownManaType)
+	}
+	return nil
+}
+
+func checkpointKey(manaType Type, t time.Time) []byte {
+	return append([]byte{historyPrefixCheckpoint, byte(manaType)}, encodeTime(t)...)
+}
+
+func eventBucket(t time.Time) uint64 {
+	return uint64(t.UnixNano() / int64(eventBucketDuration))
+}
+
+func eventBucketPrefix(manaType Type, bucket uint64) []byte {
+	prefix := make([]byte, 10)
+	prefix[0] = historyPrefixEvent
+	prefix[1] = byte(manaType)
+	binary.BigEndian.PutUint64(prefix[2:], bucket)
+	return prefix
+}
+
+func eventKey(ev *PersistableEvent) []byte {
+	key := append(eventBucketPrefix(ev.ManaType, eventBucket(ev.Time)), encodeTime(ev.Time)...)
+	return append(key, ev.Bytes()...)
+}
+
+// eventTime returns the time of the event with the given key.
+func eventTime(key []byte) time.Time {
+	return decodeTime(key[10:18])
+}
+
+func encodeTime(t time.Time) []byte {
+	bytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(bytes, uint64(t.UnixNano()))
+	return bytes
+}
+
+func decodeTime(bytes []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(bytes)))
+}
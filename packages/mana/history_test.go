@@ -0,0 +1,168 @@
+package mana
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func TestHistory_ConsensusMana(t *testing.T) {
+	history := NewHistory(mapdb.NewMapDB(), time.Hour)
+	nodeID1 := identity.GenerateIdentity().ID()
+	nodeID2 := identity.GenerateIdentity().ID()
+	base := time.Unix(1600000000, 0)
+
+	vector, err := NewBaseManaVector(ConsensusMana)
+	require.NoError(t, err)
+	vector.SetMana(nodeID1, &ConsensusBaseMana{BaseMana1: 100})
+	require.NoError(t, history.Initialize(vector, base))
+
+	require.NoError(t, history.LogEvent(&RevokedEvent{NodeID: nodeID1, Amount: 40, Time: base.Add(30 * time.Minute), ManaType: ConsensusMana}))
+	require.NoError(t, history.LogEvent(&PledgedEvent{NodeID: nodeID2, Amount: 40, Time: base.Add(30 * time.Minute), ManaType: ConsensusMana}))
+	require.NoError(t, history.LogEvent(&PledgedEvent{NodeID: nodeID2, Amount: 10, Time: base.Add(150 * time.Minute), ManaType: ConsensusMana}))
+	require.NoError(t, history.CreateCheckpoints(base.Add(3*time.Hour)))
+
+	metadata, err := history.Metadata(ConsensusMana)
+	require.NoError(t, err)
+	assert.Equal(t, 4, metadata.Checkpoints)
+	assert.Equal(t, base.Add(3*time.Hour), metadata.LatestCheckpoint)
+
+	assertMana := func(nodeID identity.ID, at time.Time, expected float64) {
+		value, manaErr := history.ManaAt(ConsensusMana, nodeID, at)
+		require.NoError(t, manaErr)
+		assert.Equal(t, expected, value)
+	}
+	assertMana(nodeID1, base.Add(10*time.Minute), 100)
+	assertMana(nodeID2, base.Add(10*time.Minute), 0)
+	assertMana(nodeID1, base.Add(2*time.Hour), 60)
+	assertMana(nodeID2, base.Add(2*time.Hour), 40)
+	assertMana(nodeID2, base.Add(4*time.Hour), 50)
+
+	// a late event invalidates the checkpoints after it
+	require.NoError(t, history.LogEvent(&PledgedEvent{NodeID: nodeID1, Amount: 5, Time: base.Add(90 * time.Minute), ManaType: ConsensusMana}))
+	metadata, err = history.Metadata(ConsensusMana)
+	require.NoError(t, err)
+	assert.Equal(t, 2, metadata.Checkpoints)
+	assertMana(nodeID1, base.Add(2*time.Hour), 65)
+
+	_, err = history.ManaAt(ConsensusMana, nodeID1, base.Add(-time.Minute))
+	assert.ErrorIs(t, err, ErrHistoryNotAvailable)
+
+	events, err := history.Events(ConsensusMana, []identity.ID{nodeID2}, base, base.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	// pruning moves the base of the history
+	require.NoError(t, history.CreateCheckpoints(base.Add(3*time.Hour)))
+	require.NoError(t, history.Prune(base.Add(150*time.Minute)))
+	metadata, err = history.Metadata(ConsensusMana)
+	require.NoError(t, err)
+	assert.Equal(t, base.Add(2*time.Hour), metadata.OldestCheckpoint)
+	assertMana(nodeID2, base.Add(4*time.Hour), 50)
+	events, err = history.Events(ConsensusMana, nil, base, base.Add(4*time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+func TestHistory_AccessMana(t *testing.T) {
+	history := NewHistory(mapdb.NewMapDB(), time.Hour)
+	nodeID := identity.GenerateIdentity().ID()
+	base := time.Unix(1600000000, 0)
+
+	live, err := NewBaseManaVector(AccessMana)
+	require.NoError(t, err)
+	require.NoError(t, history.Initialize(live, base))
+
+	for _, pledge := range []*PledgedEvent{
+		{NodeID: nodeID, Amount: 1000, Time: base.Add(10 * time.Minute), ManaType: AccessMana},
+		{NodeID: nodeID, Amount: 500, Time: base.Add(70 * time.Minute), ManaType: AccessMana},
+	} {
+		live.(*AccessBaseManaVector).Lock()
+		if _, exist := live.(*AccessBaseManaVector).vector[nodeID]; !exist {
+			live.(*AccessBaseManaVector).vector[nodeID] = &AccessBaseMana{}
+		}
+		live.(*AccessBaseManaVector).vector[nodeID].add(pledge.Amount, pledge.Time)
+		live.(*AccessBaseManaVector).Unlock()
+		require.NoError(t, history.LogEvent(pledge))
+	}
+	require.NoError(t, history.CreateCheckpoints(base.Add(2*time.Hour)))
+
+	at := base.Add(150 * time.Minute)
+	expected, _, err := live.GetMana(nodeID, at)
+	require.NoError(t, err)
+	value, err := history.ManaAt(AccessMana, nodeID, at)
+	require.NoError(t, err)
+	assert.InDelta(t, expected, value, 1e-9)
+}
+
+func TestHistory_Replay(t *testing.T) {
+	history := NewHistory(mapdb.NewMapDB(), time.Hour)
+	genesisNodeID := identity.GenerateIdentity().ID()
+	nodeID1 := identity.GenerateIdentity().ID()
+	nodeID2 := identity.GenerateIdentity().ID()
+	base := time.Unix(1600000000, 0)
+
+	live, err := NewBaseManaVector(AccessMana)
+	require.NoError(t, err)
+	require.NoError(t, history.Initialize(live, base))
+	consensus, err := NewBaseManaVector(ConsensusMana)
+	require.NoError(t, err)
+	require.NoError(t, history.Initialize(consensus, base))
+
+	tx1 := &TxInfo{
+		TimeStamp:     base.Add(10 * time.Minute),
+		TransactionID: ledgerstate.TransactionID{1},
+		PledgeID:      map[Type]identity.ID{AccessMana: nodeID1, ConsensusMana: nodeID1},
+		InputInfos:    []InputInfo{{TimeStamp: base, Amount: 1000, InputID: ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 0)}},
+	}
+	tx2 := &TxInfo{
+		TimeStamp:     base.Add(70 * time.Minute),
+		TransactionID: ledgerstate.TransactionID{2},
+		PledgeID:      map[Type]identity.ID{AccessMana: nodeID2, ConsensusMana: nodeID2},
+		InputInfos:    []InputInfo{{TimeStamp: tx1.TimeStamp, Amount: 1000, InputID: ledgerstate.NewOutputID(tx1.TransactionID, 0)}},
+	}
+	revokedNodeID := genesisNodeID
+	for _, tx := range []*TxInfo{tx1, tx2} {
+		live.(*AccessBaseManaVector).Lock()
+		if _, exist := live.(*AccessBaseManaVector).vector[tx.PledgeID[AccessMana]]; !exist {
+			live.(*AccessBaseManaVector).vector[tx.PledgeID[AccessMana]] = &AccessBaseMana{}
+		}
+		pledged := live.(*AccessBaseManaVector).vector[tx.PledgeID[AccessMana]].pledge(tx)
+		live.(*AccessBaseManaVector).Unlock()
+
+		require.NoError(t, history.LogEvent(&PledgedEvent{NodeID: tx.PledgeID[AccessMana], Amount: pledged, Time: tx.TimeStamp, ManaType: AccessMana, TransactionID: tx.TransactionID}))
+		require.NoError(t, history.LogEvent(&RevokedEvent{NodeID: revokedNodeID, Amount: 1000, Time: tx.TimeStamp, ManaType: ConsensusMana, TransactionID: tx.TransactionID, InputID: tx.InputInfos[0].InputID}))
+		require.NoError(t, history.LogEvent(&PledgedEvent{NodeID: tx.PledgeID[ConsensusMana], Amount: 1000, Time: tx.TimeStamp, ManaType: ConsensusMana, TransactionID: tx.TransactionID}))
+		revokedNodeID = tx.PledgeID[ConsensusMana]
+	}
+
+	// replaying with the default model reproduces the live vector
+	at := base.Add(3 * time.Hour)
+	replayed, err := history.Replay(DefaultModel(), at)
+	require.NoError(t, err)
+	for _, nodeID := range []identity.ID{nodeID1, nodeID2} {
+		expected, _, manaErr := live.GetMana(nodeID, at)
+		require.NoError(t, manaErr)
+		value, _, manaErr := replayed.GetMana(nodeID, at)
+		require.NoError(t, manaErr)
+		assert.InDelta(t, expected, value, 1e-9)
+	}
+
+	// an alternative model changes the result
+	capped, err := history.Replay(NewCappedModel(DefaultModel(), 0.5), at)
+	require.NoError(t, err)
+	assert.Equal(t, capped.Model().Name(), NewCappedModel(DefaultModel(), 0.5).Name())
+	capped.ForEach(func(_ identity.ID, bm BaseMana) bool {
+		assert.LessOrEqual(t, bm.BaseValue(), 0.5)
+		return true
+	})
+
+	_, err = history.Replay(DefaultModel(), base.Add(-time.Minute))
+	assert.ErrorIs(t, err, ErrHistoryNotAvailable)
+}
@@ -0,0 +1,244 @@
+package mana
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Model defines the formulas of the access mana: how much base mana the inputs of a transaction pledge, how the base
+// mana decays over time and how the effective base mana follows the base mana.
+//
+// The effective base mana is only updated at the end of an interval, with the base mana value that already decayed
+// until then. UpdateEffectiveMana has to be linear in both mana values, as pledges in the past are accounted for by
+// adding the effective mana of the pledged amount alone.
+type Model interface {
+	// Name returns a human readable name of the model.
+	Name() string
+	// PendingMana returns the base mana pledged by spending funds of the given amount that were created at `created` and
+	// spent at `spent`.
+	PendingMana(amount float64, created, spent time.Time) float64
+	// DecayBaseMana returns the value that the given base mana at `from` decayed to at `to`.
+	DecayBaseMana(baseMana float64, from, to time.Time) float64
+	// UpdateEffectiveMana returns the effective base mana at `to`, given the effective base mana at `from` and the base
+	// mana at `to`.
+	UpdateEffectiveMana(effectiveMana, baseMana float64, from, to time.Time) float64
+	// AddBaseMana returns the base mana of a node after the given amount was pledged to it.
+	AddBaseMana(baseMana, pledged float64) float64
+}
+
+var (
+	// defaultModel is the model of the access base mana entries that belong to no vector with an own model.
+	defaultModel Model = NewExponentialModel(emaCoeff2, Decay)
+	// defaultModelMutex guards defaultModel, which is read by the vectors while the plugins may replace it.
+	defaultModelMutex sync.RWMutex
+)
+
+// DefaultModel returns the model that is used by the access base mana vectors without an own model.
+func DefaultModel() Model {
+	defaultModelMutex.RLock()
+	defer defaultModelMutex.RUnlock()
+
+	return defaultModel
+}
+
+// SetDefaultModel sets the model that is used by the access base mana vectors without an own model. It is replaced by
+// calls to SetCoefficients.
+func SetDefaultModel(model Model) {
+	if model == nil {
+		panic("mana model must not be nil")
+	}
+
+	defaultModelMutex.Lock()
+	defer defaultModelMutex.Unlock()
+
+	defaultModel = model
+}
+
+// region ExponentialModel /////////////////////////////////////////////////////////////////////////////////////////////
+
+// ExponentialModel is the default model of the access mana. The pending mana of funds grows towards their amount and
+// the base mana decays exponentially with the rate Decay, while the effective base mana is the exponential moving
+// average of the base mana.
+type ExponentialModel struct {
+	// EmaCoefficient is the coefficient of the moving average of the effective base mana, in 1/s.
+	EmaCoefficient float64
+	// Decay is the decay rate (gamma) of the base mana, in 1/s.
+	Decay float64
+}
+
+// NewExponentialModel creates a new ExponentialModel with the given coefficients.
+func NewExponentialModel(emaCoefficient, decay float64) *ExponentialModel {
+	return &ExponentialModel{
+		EmaCoefficient: emaCoefficient,
+		Decay:          decay,
+	}
+}
+
+// Name returns a human readable name of the model.
+func (e *ExponentialModel) Name() string {
+	return fmt.Sprintf("exponential(ema=%g, decay=%g)", e.EmaCoefficient, e.Decay)
+}
+
+// PendingMana returns the base mana pledged by spending funds of the given amount that were created at `created` and
+// spent at `spent`.
+func (e *ExponentialModel) PendingMana(amount float64, created, spent time.Time) float64 {
+	return amount * (1 - math.Pow(math.E, -e.Decay*(spent.Sub(created).Seconds())))
+}
+
+// DecayBaseMana returns the value that the given base mana at `from` decayed to at `to`.
+func (e *ExponentialModel) DecayBaseMana(baseMana float64, from, to time.Time) float64 {
+	return baseMana * math.Pow(math.E, -e.Decay*to.Sub(from).Seconds())
+}
+
+// UpdateEffectiveMana returns the effective base mana at `to`, given the effective base mana at `from` and the base
+// mana at `to`.
+func (e *ExponentialModel) UpdateEffectiveMana(effectiveMana, baseMana float64, from, to time.Time) float64 {
+	n := to.Sub(from).Seconds()
+	if e.EmaCoefficient == e.Decay {
+		return math.Pow(math.E, -e.Decay*n)*effectiveMana + e.Decay*n*baseMana
+	}
+	return math.Pow(math.E, -e.EmaCoefficient*n)*effectiveMana +
+		(math.Pow(math.E, -e.Decay*n)-math.Pow(math.E, -e.EmaCoefficient*n))/
+			(e.EmaCoefficient-e.Decay)*e.EmaCoefficient/math.Pow(math.E, -e.Decay*n)*baseMana
+}
+
+// AddBaseMana returns the base mana of a node after the given amount was pledged to it.
+func (e *ExponentialModel) AddBaseMana(baseMana, pledged float64) float64 {
+	return baseMana + pledged
+}
+
+var _ Model = &ExponentialModel{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region LinearModel //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// LinearModel accrues the pending mana of funds linearly until it reaches their amount and decays the base mana by a
+// constant amount per second. The effective base mana is the exponential moving average of the base mana, which is
+// approximated by its value at the end of every update interval.
+type LinearModel struct {
+	// AccrualRate is the fraction of the funds that accrues as pending mana per second, in 1/s.
+	AccrualRate float64
+	// DecayRate is the base mana that is lost per second, in mana/s.
+	DecayRate float64
+	// EmaCoefficient is the coefficient of the moving average of the effective base mana, in 1/s.
+	EmaCoefficient float64
+}
+
+// NewLinearModel creates a new LinearModel with the given rates.
+func NewLinearModel(accrualRate, decayRate, emaCoefficient float64) *LinearModel {
+	return &LinearModel{
+		AccrualRate:    accrualRate,
+		DecayRate:      decayRate,
+		EmaCoefficient: emaCoefficient,
+	}
+}
+
+// Name returns a human readable name of the model.
+func (l *LinearModel) Name() string {
+	return fmt.Sprintf("linear(accrual=%g, decay=%g, ema=%g)", l.AccrualRate, l.DecayRate, l.EmaCoefficient)
+}
+
+// PendingMana returns the base mana pledged by spending funds of the given amount that were created at `created` and
+// spent at `spent`.
+func (l *LinearModel) PendingMana(amount float64, created, spent time.Time) float64 {
+	return amount * math.Min(1, math.Max(0, l.AccrualRate*spent.Sub(created).Seconds()))
+}
+
+// DecayBaseMana returns the value that the given base mana at `from` decayed to at `to`.
+func (l *LinearModel) DecayBaseMana(baseMana float64, from, to time.Time) float64 {
+	return math.Max(0, baseMana-l.DecayRate*to.Sub(from).Seconds())
+}
+
+// UpdateEffectiveMana returns the effective base mana at `to`, given the effective base mana at `from` and the base
+// mana at `to`.
+func (l *LinearModel) UpdateEffectiveMana(effectiveMana, baseMana float64, from, to time.Time) float64 {
+	weight := math.Pow(math.E, -l.EmaCoefficient*to.Sub(from).Seconds())
+	return weight*effectiveMana + (1-weight)*baseMana
+}
+
+// AddBaseMana returns the base mana of a node after the given amount was pledged to it.
+func (l *LinearModel) AddBaseMana(baseMana, pledged float64) float64 {
+	return baseMana + pledged
+}
+
+var _ Model = &LinearModel{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CappedModel //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// CappedModel limits the base mana of every node to Cap and uses the wrapped model for everything else.
+type CappedModel struct {
+	Model
+	// Cap is the maximum base mana of a node.
+	Cap float64
+}
+
+// NewCappedModel creates a new CappedModel that limits the base mana of the given model.
+func NewCappedModel(model Model, limit float64) *CappedModel {
+	return &CappedModel{
+		Model: model,
+		Cap:   limit,
+	}
+}
+
+// Name returns a human readable name of the model.
+func (c *CappedModel) Name() string {
+	return fmt.Sprintf("capped(%s, cap=%g)", c.Model.Name(), c.Cap)
+}
+
+// AddBaseMana returns the base mana of a node after the given amount was pledged to it, which is at most Cap.
+func (c *CappedModel) AddBaseMana(baseMana, pledged float64) float64 {
+	return math.Min(c.Cap, c.Model.AddBaseMana(baseMana, pledged))
+}
+
+var _ Model = &CappedModel{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region EpochModel ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// EpochModel evaluates the wrapped model in steps of Epoch: all times are truncated to the start of their epoch, so that
+// funds only accrue mana and the base mana only decays when an epoch boundary is crossed.
+type EpochModel struct {
+	Model
+	// Epoch is the duration of an epoch.
+	Epoch time.Duration
+}
+
+// NewEpochModel creates a new EpochModel that evaluates the given model in steps of the given duration.
+func NewEpochModel(model Model, epoch time.Duration) *EpochModel {
+	return &EpochModel{
+		Model: model,
+		Epoch: epoch,
+	}
+}
+
+// Name returns a human readable name of the model.
+func (e *EpochModel) Name() string {
+	return fmt.Sprintf("epoch(%s, epoch=%s)", e.Model.Name(), e.Epoch)
+}
+
+// PendingMana returns the base mana pledged by spending funds of the given amount that were created at `created` and
+// spent at `spent`.
+func (e *EpochModel) PendingMana(amount float64, created, spent time.Time) float64 {
+	return e.Model.PendingMana(amount, created.Truncate(e.Epoch), spent.Truncate(e.Epoch))
+}
+
+// DecayBaseMana returns the value that the given base mana at `from` decayed to at `to`.
+func (e *EpochModel) DecayBaseMana(baseMana float64, from, to time.Time) float64 {
+	return e.Model.DecayBaseMana(baseMana, from.Truncate(e.Epoch), to.Truncate(e.Epoch))
+}
+
+// UpdateEffectiveMana returns the effective base mana at `to`, given the effective base mana at `from` and the base
+// mana at `to`.
+func (e *EpochModel) UpdateEffectiveMana(effectiveMana, baseMana float64, from, to time.Time) float64 {
+	return e.Model.UpdateEffectiveMana(effectiveMana, baseMana, from.Truncate(e.Epoch), to.Truncate(e.Epoch))
+}
+
+var _ Model = &EpochModel{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,61 @@
+package mana
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModels(t *testing.T) {
+	start := time.Unix(1600000000, 0).Truncate(time.Hour)
+	halfLife := 6 * time.Hour
+
+	exponential := NewExponentialModel(0.00003209, 0.00003209)
+	assert.InDelta(t, 0.5, exponential.PendingMana(1, start, start.Add(halfLife)), delta)
+	assert.InDelta(t, 0.5, exponential.DecayBaseMana(1, start, start.Add(halfLife)), delta)
+
+	linear := NewLinearModel(0.001, 0.1, 0.00003209)
+	assert.Equal(t, 100.0, linear.PendingMana(1000, start, start.Add(100*time.Second)))
+	assert.Equal(t, 1000.0, linear.PendingMana(1000, start, start.Add(time.Hour)))
+	assert.Equal(t, 90.0, linear.DecayBaseMana(100, start, start.Add(100*time.Second)))
+	assert.Equal(t, 0.0, linear.DecayBaseMana(100, start, start.Add(time.Hour)))
+	assert.Equal(t, 100.0, linear.UpdateEffectiveMana(100, 100, start, start.Add(time.Hour)))
+
+	capped := NewCappedModel(exponential, 10)
+	assert.Equal(t, 10.0, capped.AddBaseMana(8, 5))
+	assert.Equal(t, exponential.PendingMana(1, start, start.Add(halfLife)), capped.PendingMana(1, start, start.Add(halfLife)))
+
+	epoch := NewEpochModel(exponential, time.Hour)
+	assert.Equal(t, 0.0, epoch.PendingMana(1, start.Add(10*time.Minute), start.Add(50*time.Minute)))
+	assert.Equal(t, 1.0, epoch.DecayBaseMana(1, start, start.Add(59*time.Minute)))
+	assert.Equal(t, exponential.DecayBaseMana(1, start, start.Add(time.Hour)), epoch.DecayBaseMana(1, start.Add(59*time.Minute), start.Add(61*time.Minute)))
+}
+
+func TestSetDefaultModel_Concurrent(t *testing.T) {
+	previousModel := DefaultModel()
+	defer SetDefaultModel(previousModel)
+
+	vector := &AccessBaseManaVector{vector: make(map[identity.ID]*AccessBaseMana)}
+	model := NewLinearModel(0.001, 0.1, 0.00003209)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			SetDefaultModel(model)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			assert.NotNil(t, vector.Model())
+		}
+	}()
+	wg.Wait()
+
+	assert.Equal(t, model, vector.Model())
+}
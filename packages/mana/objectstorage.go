@@ -21,4 +21,7 @@ const (
 
 	// PrefixConsensusPastMetadata is the storage prefix for consensus mana past vector metadata storage.
 	PrefixConsensusPastMetadata
+
+	// PrefixHistory is the storage prefix for the checkpoints and the event log of the mana history.
+	PrefixHistory
 )
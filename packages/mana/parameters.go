@@ -33,7 +33,8 @@ var (
 	Decay = 0.00003209
 )
 
-// SetCoefficients sets the coefficients for mana calculation.
+// SetCoefficients sets the coefficients for mana calculation and resets the default model to the exponential model
+// with these coefficients.
 func SetCoefficients(ema1 float64, ema2 float64, dec float64) {
 	if ema1 <= 0.0 {
 		panic("invalid emaCoefficient1 parameter, value must be greater than 0.")
@@ -47,4 +48,5 @@ func SetCoefficients(ema1 float64, ema2 float64, dec float64) {
 	emaCoeff1 = ema1
 	emaCoeff2 = ema2
 	Decay = dec
+	SetDefaultModel(NewExponentialModel(emaCoeff2, Decay))
 }
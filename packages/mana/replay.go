@@ -0,0 +1,108 @@
+package mana
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// Replay recomputes the access base mana vector at time t with the given model from the event log of the history.
+//
+// The replay starts from the oldest access checkpoint, whose values were computed by the node with its own model. The
+// transactions after it are reconstructed from the consensus event log: the revoke events of a transaction contain its
+// inputs and the pledge events of the transactions that created the inputs contain their timestamps. Inputs that were
+// created before the oldest consensus checkpoint are considered to be created at the time of the oldest access
+// checkpoint. The access pledge events only provide the node that the transactions pledged their access mana to.
+func (h *History) Replay(model Model, t time.Time) (*AccessBaseManaVector, error) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	accessCheckpoints, err := h.checkpointTimes(AccessMana)
+	if err != nil {
+		return nil, err
+	}
+	consensusCheckpoints, err := h.checkpointTimes(ConsensusMana)
+	if err != nil {
+		return nil, err
+	}
+	if len(accessCheckpoints) == 0 || len(consensusCheckpoints) == 0 || t.Before(accessCheckpoints[0]) {
+		return nil, errors.Errorf("no checkpoint before %s: %w", t, ErrHistoryNotAvailable)
+	}
+	base := accessCheckpoints[0]
+
+	vector, err := h.loadCheckpoint(AccessMana, base, WithModel(model))
+	if err != nil {
+		return nil, err
+	}
+	accessEvents, err := h.events(AccessMana, nil, base, t)
+	if err != nil {
+		return nil, err
+	}
+	consensusEvents, err := h.events(ConsensusMana, nil, consensusCheckpoints[0], t)
+	if err != nil {
+		return nil, err
+	}
+
+	accessPledgeIDs := make(map[ledgerstate.TransactionID]identity.ID)
+	for _, ev := range accessEvents {
+		if pledgeEvent, ok := ev.(*PledgedEvent); ok {
+			accessPledgeIDs[pledgeEvent.TransactionID] = pledgeEvent.NodeID
+		}
+	}
+
+	// collect the creation times of all known outputs and the inputs of the transactions after the base
+	created := make(map[ledgerstate.TransactionID]time.Time)
+	transactions := make(map[ledgerstate.TransactionID]*TxInfo)
+	var ordered []*TxInfo
+	txInfo := func(transactionID ledgerstate.TransactionID, timestamp time.Time) *TxInfo {
+		if tx, exists := transactions[transactionID]; exists {
+			return tx
+		}
+		tx := &TxInfo{TimeStamp: timestamp, TransactionID: transactionID}
+		transactions[transactionID] = tx
+		ordered = append(ordered, tx)
+		return tx
+	}
+	for _, ev := range consensusEvents {
+		switch typedEvent := ev.(type) {
+		case *PledgedEvent:
+			created[typedEvent.TransactionID] = typedEvent.Time
+			if typedEvent.Time.After(base) {
+				txInfo(typedEvent.TransactionID, typedEvent.Time)
+			}
+		case *RevokedEvent:
+			if typedEvent.Time.After(base) {
+				tx := txInfo(typedEvent.TransactionID, typedEvent.Time)
+				tx.InputInfos = append(tx.InputInfos, InputInfo{Amount: typedEvent.Amount, InputID: typedEvent.InputID})
+			}
+		}
+	}
+
+	accessVector := vector.(*AccessBaseManaVector)
+	for _, tx := range ordered {
+		nodeID, exists := accessPledgeIDs[tx.TransactionID]
+		if !exists || tx.TransactionID == ledgerstate.GenesisTransactionID {
+			continue
+		}
+		for i := range tx.InputInfos {
+			tx.InputInfos[i].TimeStamp = base
+			if timestamp, known := created[tx.InputInfos[i].InputID.TransactionID()]; known {
+				tx.InputInfos[i].TimeStamp = timestamp
+			}
+		}
+		tx.PledgeID = map[Type]identity.ID{AccessMana: nodeID}
+		if _, exist := accessVector.vector[nodeID]; !exist {
+			accessVector.vector[nodeID] = &AccessBaseMana{model: model}
+		}
+		accessVector.vector[nodeID].pledge(tx)
+	}
+	for _, baseMana := range accessVector.vector {
+		if err = baseMana.update(t); err != nil && !errors.Is(err, ErrAlreadyUpdated) {
+			return nil, err
+		}
+	}
+	return accessVector, nil
+}
@@ -0,0 +1,331 @@
+// Package manaflow aggregates the mana pledge and revoke events of the node, so that it can be queried who pledged how
+// much mana to whom over time.
+package manaflow
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+// DefaultRetention is the default duration the records are kept in the index.
+const DefaultRetention = 7 * 24 * time.Hour
+
+// Record is a single flow of mana between a pledger address and a node.
+type Record struct {
+	// TransactionID is the transaction that pledged or revoked the mana.
+	TransactionID ledgerstate.TransactionID
+	// Pledger is the base58 encoded address that owned the funds moved by the transaction.
+	Pledger string
+	// NodeID is the node that received or lost the mana.
+	NodeID identity.ID
+	// ManaType is the type of the mana.
+	ManaType mana.Type
+	// Amount is the amount of mana.
+	Amount float64
+	// Time is the time of the transaction.
+	Time time.Time
+	// Revoked is true if the mana was revoked from the node.
+	Revoked bool
+}
+
+// Index is an in-memory index of the mana pledge and revoke events by pledger address, receiving node and transaction.
+// It is not persisted, so it only contains the events since the node started. All slices of records are ordered by
+// time.
+type Index struct {
+	retention time.Duration
+	started   time.Time
+
+	records   []*Record
+	byNode    map[identity.ID][]*Record
+	byPledger map[string][]*Record
+	byTx      map[ledgerstate.TransactionID][]*Record
+	mutex     sync.RWMutex
+}
+
+// Option is a function setting an index option.
+type Option func(i *Index)
+
+// WithRetention sets the duration the records are kept in the index.
+func WithRetention(retention time.Duration) Option {
+	return func(i *Index) {
+		i.retention = retention
+	}
+}
+
+// NewIndex creates a new, empty Index.
+func NewIndex(opts ...Option) *Index {
+	i := &Index{
+		retention: DefaultRetention,
+		started:   time.Now(),
+		byNode:    make(map[identity.ID][]*Record),
+		byPledger: make(map[string][]*Record),
+		byTx:      make(map[ledgerstate.TransactionID][]*Record),
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// AddPledge indexes a pledge event. The pledged amount is attributed to the input addresses of the transaction
+// proportionally to the given input balances. Pledges without input addresses, like the ones of the snapshot, are not
+// indexed.
+func (i *Index) AddPledge(ev *mana.PledgedEvent, inputBalances map[string]uint64) {
+	var total uint64
+	for _, balance := range inputBalances {
+		total += balance
+	}
+	if total == 0 {
+		return
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	for pledger, balance := range inputBalances {
+		i.add(&Record{
+			TransactionID: ev.TransactionID,
+			Pledger:       pledger,
+			NodeID:        ev.NodeID,
+			ManaType:      ev.ManaType,
+			Amount:        ev.Amount * float64(balance) / float64(total),
+			Time:          ev.Time,
+		})
+	}
+}
+
+// AddRevoke indexes a revoke event of the input owned by the given address.
+func (i *Index) AddRevoke(ev *mana.RevokedEvent, pledger string) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.add(&Record{
+		TransactionID: ev.TransactionID,
+		Pledger:       pledger,
+		NodeID:        ev.NodeID,
+		ManaType:      ev.ManaType,
+		Amount:        ev.Amount,
+		Time:          ev.Time,
+		Revoked:       true,
+	})
+}
+
+// Prune removes the records older than the retention of the index, counted back from the given time.
+func (i *Index) Prune(now time.Time) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	cutoff := now.Add(-i.retention)
+	pruned := prunedCount(i.records, cutoff)
+	if pruned == 0 {
+		return
+	}
+	// every key of a pruned record loses a prefix of its records, which is cut once per key
+	nodes := make(map[identity.ID]struct{})
+	pledgers := make(map[string]struct{})
+	transactions := make(map[ledgerstate.TransactionID]struct{})
+	for _, record := range i.records[:pruned] {
+		nodes[record.NodeID] = struct{}{}
+		pledgers[record.Pledger] = struct{}{}
+		transactions[record.TransactionID] = struct{}{}
+	}
+	for nodeID := range nodes {
+		if remaining := pruneRecords(i.byNode[nodeID], cutoff); len(remaining) > 0 {
+			i.byNode[nodeID] = remaining
+		} else {
+			delete(i.byNode, nodeID)
+		}
+	}
+	for pledger := range pledgers {
+		if remaining := pruneRecords(i.byPledger[pledger], cutoff); len(remaining) > 0 {
+			i.byPledger[pledger] = remaining
+		} else {
+			delete(i.byPledger, pledger)
+		}
+	}
+	for transactionID := range transactions {
+		if remaining := pruneRecords(i.byTx[transactionID], cutoff); len(remaining) > 0 {
+			i.byTx[transactionID] = remaining
+		} else {
+			delete(i.byTx, transactionID)
+		}
+	}
+	i.records = pruneRecords(i.records, cutoff)
+}
+
+// CoveredSince returns the time since which the index contains all events, as of the given time. It is the later one of
+// the start of the node and the retention of the index counted back from the given time.
+func (i *Index) CoveredSince(now time.Time) time.Time {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	if cutoff := now.Add(-i.retention); cutoff.After(i.started) {
+		return cutoff
+	}
+	return i.started
+}
+
+// Oldest returns the time of the oldest record in the index.
+func (i *Index) Oldest() (time.Time, bool) {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	if len(i.records) == 0 {
+		return time.Time{}, false
+	}
+	return i.records[0].Time, true
+}
+
+// Transaction returns the records of the given transaction.
+func (i *Index) Transaction(transactionID ledgerstate.TransactionID) []*Record {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return cloneRecords(i.byTx[transactionID])
+}
+
+// Query selects the records of a query.
+type Query struct {
+	// ManaType is the type of mana.
+	ManaType mana.Type
+	// Start is the inclusive start of the time range.
+	Start time.Time
+	// End is the exclusive end of the time range, zero for no end.
+	End time.Time
+	// Limit is the maximum number of results, zero for no limit.
+	Limit int
+}
+
+func (q Query) matches(record *Record) bool {
+	return record.ManaType == q.ManaType && !record.Time.Before(q.Start) && (q.End.IsZero() || record.Time.Before(q.End))
+}
+
+// Total aggregates the mana flow between a pledger address and a node.
+type Total struct {
+	// Pledger is the base58 encoded address of the pledger.
+	Pledger string
+	// NodeID is the node.
+	NodeID identity.ID
+	// Pledged is the total amount of mana pledged.
+	Pledged float64
+	// Revoked is the total amount of mana revoked.
+	Revoked float64
+	// Transactions is the number of distinct transactions.
+	Transactions int
+}
+
+// TopPledgers returns the addresses that pledged the most mana to the given node, ordered by the pledged amount.
+func (i *Index) TopPledgers(nodeID identity.ID, query Query) []*Total {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return aggregate(i.byNode[nodeID], query, func(record *Record) string { return record.Pledger })
+}
+
+// TopReceivers returns the nodes the given address pledged the most mana to, ordered by the pledged amount.
+func (i *Index) TopReceivers(pledger string, query Query) []*Total {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return aggregate(i.byPledger[pledger], query, func(record *Record) string { return record.NodeID.String() })
+}
+
+// Flows returns the largest flows of mana between pledger addresses and nodes, ordered by the pledged amount.
+func (i *Index) Flows(query Query) []*Total {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return aggregate(i.records, query, func(record *Record) string { return record.Pledger + record.NodeID.String() })
+}
+
+func (i *Index) add(record *Record) {
+	i.records = insertRecord(i.records, record)
+	i.byNode[record.NodeID] = insertRecord(i.byNode[record.NodeID], record)
+	i.byPledger[record.Pledger] = insertRecord(i.byPledger[record.Pledger], record)
+	i.byTx[record.TransactionID] = insertRecord(i.byTx[record.TransactionID], record)
+}
+
+func aggregate(records []*Record, query Query, key func(record *Record) string) []*Total {
+	totals := make(map[string]*Total)
+	transactions := make(map[string]map[ledgerstate.TransactionID]struct{})
+	for _, record := range records {
+		if !query.matches(record) {
+			continue
+		}
+		k := key(record)
+		total, exists := totals[k]
+		if !exists {
+			total = &Total{Pledger: record.Pledger, NodeID: record.NodeID}
+			totals[k] = total
+			transactions[k] = make(map[ledgerstate.TransactionID]struct{})
+		}
+		if record.Revoked {
+			total.Revoked += record.Amount
+		} else {
+			total.Pledged += record.Amount
+		}
+		transactions[k][record.TransactionID] = struct{}{}
+	}
+
+	result := make([]*Total, 0, len(totals))
+	for k, total := range totals {
+		total.Transactions = len(transactions[k])
+		if total.Pledged > 0 {
+			result = append(result, total)
+		}
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Pledged != result[b].Pledged {
+			return result[a].Pledged > result[b].Pledged
+		}
+		return result[a].Pledger < result[b].Pledger
+	})
+	if query.Limit > 0 && len(result) > query.Limit {
+		result = result[:query.Limit]
+	}
+	return result
+}
+
+// insertRecord inserts the record into the records that are ordered by time.
+func insertRecord(records []*Record, record *Record) []*Record {
+	// events arrive mostly in order, so the insertion position is usually at the end
+	position := sort.Search(len(records), func(k int) bool {
+		return records[k].Time.After(record.Time)
+	})
+	records = append(records, nil)
+	copy(records[position+1:], records[position:])
+	records[position] = record
+
+	return records
+}
+
+// prunedCount returns the number of records, which are ordered by time, that are older than the cutoff.
+func prunedCount(records []*Record, cutoff time.Time) int {
+	return sort.Search(len(records), func(k int) bool {
+		return !records[k].Time.Before(cutoff)
+	})
+}
+
+// pruneRecords returns the records, which are ordered by time, that are not older than the cutoff. They are copied, so
+// that the pruned records can be garbage collected.
+func pruneRecords(records []*Record, cutoff time.Time) []*Record {
+	pruned := prunedCount(records, cutoff)
+	if pruned == 0 {
+		return records
+	}
+
+	return append([]*Record(nil), records[pruned:]...)
+}
+
+func cloneRecords(records []*Record) []*Record {
+	result := make([]*Record, len(records))
+	for k, record := range records {
+		c := *record
+		result[k] = &c
+	}
+	return result
+}
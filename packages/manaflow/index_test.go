@@ -0,0 +1,107 @@
+package manaflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+func TestIndex(t *testing.T) {
+	index := NewIndex(WithRetention(24 * time.Hour))
+	node1 := identity.GenerateIdentity().ID()
+	node2 := identity.GenerateIdentity().ID()
+	tx1 := ledgerstate.TransactionID{1}
+	tx2 := ledgerstate.TransactionID{2}
+	tx3 := ledgerstate.TransactionID{3}
+	start := time.Unix(1600000000, 0)
+
+	// tx1 moves the funds of two addresses and pledges to node1
+	index.AddPledge(&mana.PledgedEvent{NodeID: node1, Amount: 100, Time: start, ManaType: mana.ConsensusMana, TransactionID: tx1},
+		map[string]uint64{"addrA": 300, "addrB": 100})
+	index.AddPledge(&mana.PledgedEvent{NodeID: node2, Amount: 50, Time: start.Add(time.Hour), ManaType: mana.ConsensusMana, TransactionID: tx2},
+		map[string]uint64{"addrB": 10})
+	// tx3 spends the output of addrB created by tx2, so node2 loses the mana and node1 receives it
+	index.AddRevoke(&mana.RevokedEvent{NodeID: node2, Amount: 50, Time: start.Add(2 * time.Hour), ManaType: mana.ConsensusMana, TransactionID: tx3}, "addrB")
+	index.AddPledge(&mana.PledgedEvent{NodeID: node1, Amount: 50, Time: start.Add(2 * time.Hour), ManaType: mana.ConsensusMana, TransactionID: tx3},
+		map[string]uint64{"addrB": 10})
+	// snapshot pledges have no pledger
+	index.AddPledge(&mana.PledgedEvent{NodeID: node1, Amount: 1000, Time: start, ManaType: mana.ConsensusMana}, nil)
+
+	pledgers := index.TopPledgers(node1, Query{ManaType: mana.ConsensusMana})
+	require.Len(t, pledgers, 2)
+	assert.Equal(t, "addrA", pledgers[0].Pledger)
+	assert.Equal(t, 75.0, pledgers[0].Pledged)
+	assert.Equal(t, "addrB", pledgers[1].Pledger)
+	assert.Equal(t, 75.0, pledgers[1].Pledged)
+	assert.Equal(t, 2, pledgers[1].Transactions)
+
+	// the time range and the limit are applied
+	pledgers = index.TopPledgers(node1, Query{ManaType: mana.ConsensusMana, Start: start.Add(time.Hour), Limit: 1})
+	require.Len(t, pledgers, 1)
+	assert.Equal(t, Total{Pledger: "addrB", NodeID: node1, Pledged: 50, Transactions: 1}, *pledgers[0])
+	assert.Empty(t, index.TopPledgers(node1, Query{ManaType: mana.AccessMana}))
+
+	receivers := index.TopReceivers("addrB", Query{ManaType: mana.ConsensusMana})
+	require.Len(t, receivers, 2)
+	assert.Equal(t, node1, receivers[0].NodeID)
+	assert.Equal(t, node2, receivers[1].NodeID)
+	assert.Equal(t, 50.0, receivers[1].Revoked)
+
+	assert.Len(t, index.Flows(Query{ManaType: mana.ConsensusMana}), 3)
+	assert.Len(t, index.Transaction(tx3), 2)
+
+	index.Prune(start.Add(25 * time.Hour))
+	oldest, ok := index.Oldest()
+	require.True(t, ok)
+	assert.Equal(t, start.Add(time.Hour), oldest)
+	assert.Empty(t, index.Transaction(tx1))
+	assert.Len(t, index.TopPledgers(node1, Query{ManaType: mana.ConsensusMana}), 1)
+}
+
+func TestIndex_PruneOutOfOrder(t *testing.T) {
+	index := NewIndex(WithRetention(time.Hour))
+	node := identity.GenerateIdentity().ID()
+	start := time.Unix(1600000000, 0)
+
+	// the events of a node arrive out of order, the ones of the first 30 minutes are pruned
+	for _, minutes := range []int{40, 10, 50, 20, 30, 0} {
+		index.AddPledge(&mana.PledgedEvent{
+			NodeID:        node,
+			Amount:        1,
+			Time:          start.Add(time.Duration(minutes) * time.Minute),
+			ManaType:      mana.ConsensusMana,
+			TransactionID: ledgerstate.TransactionID{byte(minutes)},
+		}, map[string]uint64{"addrA": 1})
+	}
+	index.Prune(start.Add(90 * time.Minute))
+
+	pledgers := index.TopPledgers(node, Query{ManaType: mana.ConsensusMana})
+	require.Len(t, pledgers, 1)
+	assert.Equal(t, 3.0, pledgers[0].Pledged)
+	assert.Len(t, index.byNode[node], 3)
+	assert.Len(t, index.byPledger["addrA"], 3)
+	assert.Len(t, index.byTx, 3)
+	assert.Empty(t, index.Transaction(ledgerstate.TransactionID{20}))
+	assert.Len(t, index.Transaction(ledgerstate.TransactionID{30}), 1)
+
+	index.Prune(start.Add(3 * time.Hour))
+	assert.Empty(t, index.records)
+	assert.Empty(t, index.byNode)
+	assert.Empty(t, index.byPledger)
+	assert.Empty(t, index.byTx)
+}
+
+func TestIndex_CoveredSince(t *testing.T) {
+	index := NewIndex(WithRetention(time.Hour))
+	index.started = time.Unix(1600000000, 0)
+
+	// the index only contains the events since the node started
+	assert.Equal(t, index.started, index.CoveredSince(index.started.Add(30*time.Minute)))
+	assert.Equal(t, index.started.Add(time.Hour), index.CoveredSince(index.started.Add(2*time.Hour)))
+}
@@ -4,11 +4,12 @@ import (
 	"bytes"
 	"context"
 	"net"
+	"strconv"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/typeutils"
 
 	"github.com/iotaledger/goshimmer/packages/gossip"
@@ -22,7 +23,10 @@ import (
 	"github.com/iotaledger/hive.go/logger"
 )
 
-const defaultReconnectInterval = 5 * time.Second
+const (
+	defaultReconnectInterval = 5 * time.Second
+	defaultResolveInterval   = time.Minute
+)
 
 // ConnectionDirection is an enum for the type of connection between local peer and the other peer in the gossip layer.
 type ConnectionDirection string
@@ -45,17 +49,41 @@ const (
 )
 
 // KnownPeerToAdd defines a type that is used in .AddPeer() method.
+// Address can either contain an IP or a DNS name, the latter is periodically re-resolved by the manager.
 type KnownPeerToAdd struct {
 	PublicKey ed25519.PublicKey `json:"publicKey"`
 	Address   string            `json:"address"`
+	Labels    []string          `json:"labels,omitempty"`
+	Disabled  bool              `json:"disabled,omitempty"`
+}
+
+// KnownPeerToUpdate defines a type that is used in .UpdatePeer() method.
+// Fields that are nil are left unchanged.
+type KnownPeerToUpdate struct {
+	PublicKey ed25519.PublicKey `json:"publicKey"`
+	Labels    *[]string         `json:"labels,omitempty"`
+	Enabled   *bool             `json:"enabled,omitempty"`
 }
 
 // KnownPeer defines a peer record in the manualpeering layer.
 type KnownPeer struct {
-	PublicKey     ed25519.PublicKey   `json:"publicKey"`
-	Address       string              `json:"address"`
-	ConnDirection ConnectionDirection `json:"connectionDirection"`
-	ConnStatus    ConnectionStatus    `json:"connectionStatus"`
+	PublicKey       ed25519.PublicKey   `json:"publicKey"`
+	Address         string              `json:"address"`
+	ResolvedAddress string              `json:"resolvedAddress,omitempty"`
+	Labels          []string            `json:"labels,omitempty"`
+	Enabled         bool                `json:"enabled"`
+	ConnDirection   ConnectionDirection `json:"connectionDirection"`
+	ConnStatus      ConnectionStatus    `json:"connectionStatus"`
+	ConnHistory     ConnectionHistory   `json:"connectionHistory"`
+}
+
+// ConnectionHistory holds the connection statistics of a known peer.
+type ConnectionHistory struct {
+	LastConnected       time.Time `json:"lastConnected,omitempty"`
+	LastDisconnected    time.Time `json:"lastDisconnected,omitempty"`
+	LastFailure         time.Time `json:"lastFailure,omitempty"`
+	LastFailureReason   string    `json:"lastFailureReason,omitempty"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
 }
 
 // Manager is the core entity in the manualpeering package.
@@ -66,6 +94,7 @@ type KnownPeer struct {
 // And vice versa, if a peer is being removed from the list of known peers,
 // manager will make sure gossip drops that connection.
 // Manager also subscribes to the gossip events and in case the connection with a manual peer fails it will reconnect.
+// If a store is provided, the peers added via AddPeer are persisted and loaded again when the manager is started.
 type Manager struct {
 	gm                *gossip.Manager
 	log               *logger.Logger
@@ -76,6 +105,8 @@ type Manager struct {
 	stopMutex         sync.RWMutex
 	isStopped         bool
 	reconnectInterval time.Duration
+	resolveInterval   time.Duration
+	store             *peerStore
 	knownPeersMutex   sync.RWMutex
 	knownPeers        map[identity.ID]*knownPeer
 
@@ -83,36 +114,76 @@ type Manager struct {
 	onGossipNeighborAddedClosure   *events.Closure
 }
 
+// ManagerOption defines a single option for the NewManager function.
+type ManagerOption func(m *Manager)
+
+// WithStore returns a ManagerOption that makes the manager persist its known peers in the given store.
+func WithStore(store kvstore.KVStore) ManagerOption {
+	return func(m *Manager) {
+		m.store = newPeerStore(store)
+	}
+}
+
+// WithReconnectInterval returns a ManagerOption that sets the interval between two connection attempts.
+func WithReconnectInterval(interval time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.reconnectInterval = interval
+	}
+}
+
+// WithResolveInterval returns a ManagerOption that sets the interval in which peer addresses are resolved again.
+func WithResolveInterval(interval time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.resolveInterval = interval
+	}
+}
+
 // NewManager initializes a new Manager instance.
-func NewManager(gm *gossip.Manager, local *peer.Local, log *logger.Logger) *Manager {
+func NewManager(gm *gossip.Manager, local *peer.Local, log *logger.Logger, opts ...ManagerOption) *Manager {
 	m := &Manager{
 		gm:                gm,
 		local:             local,
 		log:               log,
 		reconnectInterval: defaultReconnectInterval,
+		resolveInterval:   defaultResolveInterval,
 		knownPeers:        map[identity.ID]*knownPeer{},
 	}
+	for _, o := range opts {
+		o(m)
+	}
 	m.onGossipNeighborRemovedClosure = events.NewClosure(m.onGossipNeighborRemoved)
 	m.onGossipNeighborAddedClosure = events.NewClosure(m.onGossipNeighborAdded)
 	return m
 }
 
-// AddPeer adds multiple peers to the list of known peers.
+// AddPeer adds multiple peers to the list of known peers and persists them if the manager has a store.
 func (m *Manager) AddPeer(peers ...*KnownPeerToAdd) error {
+	return m.addPeers(peers, true)
+}
+
+// AddStaticPeer adds multiple peers to the list of known peers without persisting them,
+// e.g. the peers that are defined in the config file.
+func (m *Manager) AddStaticPeer(peers ...*KnownPeerToAdd) error {
+	return m.addPeers(peers, false)
+}
+
+// RemovePeer removes multiple peers from the list of known peers.
+func (m *Manager) RemovePeer(keys ...ed25519.PublicKey) error {
 	var resultErr error
-	for _, p := range peers {
-		if err := m.addPeer(p); err != nil {
+	for _, key := range keys {
+		if err := m.removePeer(key); err != nil {
 			resultErr = errors.CombineErrors(resultErr, err)
 		}
 	}
 	return resultErr
 }
 
-// RemovePeer removes multiple peers from the list of known peers.
-func (m *Manager) RemovePeer(keys ...ed25519.PublicKey) error {
+// UpdatePeer changes the labels or the enabled state of multiple known peers.
+// Disabling a peer drops its connection in the gossip layer, but keeps it in the list of known peers.
+func (m *Manager) UpdatePeer(peers ...*KnownPeerToUpdate) error {
 	var resultErr error
-	for _, key := range keys {
-		if err := m.removePeer(key); err != nil {
+	for _, p := range peers {
+		if err := m.updatePeer(p); err != nil {
 			resultErr = errors.CombineErrors(resultErr, err)
 		}
 	}
@@ -123,6 +194,8 @@ func (m *Manager) RemovePeer(keys ...ed25519.PublicKey) error {
 type GetPeersConfig struct {
 	// If true, GetPeers returns peers that have actual connection established in the gossip layer.
 	OnlyConnected bool `json:"onlyConnected"`
+	// If not empty, GetPeers returns only peers that have the given label.
+	Label string `json:"label,omitempty"`
 }
 
 // GetPeersOption defines a single option for GetPeers method.
@@ -142,6 +215,9 @@ func (c *GetPeersConfig) ToOptions() (opts []GetPeersOption) {
 	if c.OnlyConnected {
 		opts = append(opts, WithOnlyConnectedPeers())
 	}
+	if c.Label != "" {
+		opts = append(opts, WithLabel(c.Label))
+	}
 	return opts
 }
 
@@ -152,6 +228,13 @@ func WithOnlyConnectedPeers() GetPeersOption {
 	}
 }
 
+// WithLabel returns a GetPeersOption that sets Label field to the given label.
+func WithLabel(label string) GetPeersOption {
+	return func(conf *GetPeersConfig) {
+		conf.Label = label
+	}
+}
+
 // GetPeers returns the list of known peers.
 func (m *Manager) GetPeers(opts ...GetPeersOption) []*KnownPeer {
 	conf := BuildGetPeersConfig(opts)
@@ -159,26 +242,24 @@ func (m *Manager) GetPeers(opts ...GetPeersOption) []*KnownPeer {
 	defer m.knownPeersMutex.RUnlock()
 	peers := make([]*KnownPeer, 0, len(m.knownPeers))
 	for _, kp := range m.knownPeers {
-		connStatus := kp.getConnStatus()
-		if !conf.OnlyConnected || connStatus == ConnStatusConnected {
-			peers = append(peers, &KnownPeer{
-				PublicKey:     kp.peer.PublicKey(),
-				Address:       kp.peerAddress,
-				ConnDirection: kp.connDirection,
-				ConnStatus:    connStatus,
-			})
+		kp.mutex.RLock()
+		if (!conf.OnlyConnected || kp.connStatus == ConnStatusConnected) && (conf.Label == "" || kp.hasLabel(conf.Label)) {
+			peers = append(peers, kp.toKnownPeer())
 		}
+		kp.mutex.RUnlock()
 	}
 	return peers
 }
 
 // Start subscribes to the gossip layer events and starts internal background workers.
+// If the manager has a store, the persisted peers are added to the list of known peers.
 // Calling multiple times has no effect.
 func (m *Manager) Start() {
 	m.startOnce.Do(func() {
 		m.gm.NeighborsEvents(gossip.NeighborsGroupManual).NeighborRemoved.Attach(m.onGossipNeighborRemovedClosure)
 		m.gm.NeighborsEvents(gossip.NeighborsGroupManual).NeighborAdded.Attach(m.onGossipNeighborAddedClosure)
 		m.isStarted.Set()
+		m.loadPersistedPeers()
 	})
 }
 
@@ -199,45 +280,145 @@ func (m *Manager) Stop() (err error) {
 }
 
 type knownPeer struct {
-	peer          *peer.Peer
+	publicKey     ed25519.PublicKey
 	peerAddress   string
 	connDirection ConnectionDirection
-	connStatus    *atomic.Value
 	removeCh      chan struct{}
 	doneCh        chan struct{}
+	updateCh      chan struct{}
+
+	mutex           sync.RWMutex
+	peer            *peer.Peer
+	resolvedAddress string
+	labels          []string
+	enabled         bool
+	persistent      bool
+	connStatus      ConnectionStatus
+	connHistory     ConnectionHistory
 }
 
-func newKnownPeer(p *KnownPeerToAdd, connDirection ConnectionDirection) (*knownPeer, error) {
-	tcpAddress, err := net.ResolveTCPAddr("tcp", p.Address)
+func newKnownPeer(p *KnownPeerToAdd, connDirection ConnectionDirection, persistent bool) (*knownPeer, error) {
+	_, port, err := net.SplitHostPort(p.Address)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse peer address")
 	}
-	services := service.New()
-	// Peering key is required in order to initialize a peer,
-	// but it's not used in both manualpeering and gossip layers so we just specify the default one.
-	services.Update(service.PeeringKey, "tcp", 14626)
-	services.Update(service.GossipKey, tcpAddress.Network(), tcpAddress.Port)
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse port of peer address %s", p.Address)
+	}
 	kp := &knownPeer{
-		peer:          peer.NewPeer(identity.New(p.PublicKey), tcpAddress.IP, services),
+		publicKey:     p.PublicKey,
 		peerAddress:   p.Address,
 		connDirection: connDirection,
-		connStatus:    &atomic.Value{},
 		removeCh:      make(chan struct{}),
 		doneCh:        make(chan struct{}),
+		updateCh:      make(chan struct{}, 1),
+		labels:        append([]string{}, p.Labels...),
+		enabled:       !p.Disabled,
+		persistent:    persistent,
+		connStatus:    ConnStatusDisconnected,
 	}
-	kp.setConnStatus(ConnStatusDisconnected)
 	return kp, nil
 }
 
+// resolve resolves the address of the known peer and reports whether the resolved address has changed.
+func (kp *knownPeer) resolve() (bool, error) {
+	tcpAddress, err := net.ResolveTCPAddr("tcp", kp.peerAddress)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to resolve peer address %s", kp.peerAddress)
+	}
+	kp.mutex.Lock()
+	defer kp.mutex.Unlock()
+	if kp.peer != nil && kp.resolvedAddress == tcpAddress.String() {
+		return false, nil
+	}
+	services := service.New()
+	// Peering key is required in order to initialize a peer,
+	// but it's not used in both manualpeering and gossip layers so we just specify the default one.
+	services.Update(service.PeeringKey, "tcp", 14626)
+	services.Update(service.GossipKey, tcpAddress.Network(), tcpAddress.Port)
+	kp.peer = peer.NewPeer(identity.New(kp.publicKey), tcpAddress.IP, services)
+	kp.resolvedAddress = tcpAddress.String()
+	return true, nil
+}
+
+func (kp *knownPeer) hasLabel(label string) bool {
+	for _, l := range kp.labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
 func (kp *knownPeer) getConnStatus() ConnectionStatus {
-	return kp.connStatus.Load().(ConnectionStatus)
+	kp.mutex.RLock()
+	defer kp.mutex.RUnlock()
+	return kp.connStatus
 }
 
 func (kp *knownPeer) setConnStatus(cs ConnectionStatus) {
-	kp.connStatus.Store(cs)
+	kp.mutex.Lock()
+	defer kp.mutex.Unlock()
+	kp.connStatus = cs
+	if cs == ConnStatusConnected {
+		kp.connHistory.LastConnected = time.Now()
+		kp.connHistory.ConsecutiveFailures = 0
+	} else {
+		kp.connHistory.LastDisconnected = time.Now()
+	}
+}
+
+func (kp *knownPeer) recordFailure(err error) {
+	kp.mutex.Lock()
+	defer kp.mutex.Unlock()
+	kp.connHistory.LastFailure = time.Now()
+	kp.connHistory.LastFailureReason = err.Error()
+	kp.connHistory.ConsecutiveFailures++
 }
 
-func (m *Manager) addPeer(p *KnownPeerToAdd) error {
+// notifyUpdate wakes up the connection loop of the known peer without blocking.
+func (kp *knownPeer) notifyUpdate() {
+	select {
+	case kp.updateCh <- struct{}{}:
+	default:
+	}
+}
+
+// toKnownPeer returns the public representation of the known peer, kp.mutex must be held by the caller.
+func (kp *knownPeer) toKnownPeer() *KnownPeer {
+	return &KnownPeer{
+		PublicKey:       kp.publicKey,
+		Address:         kp.peerAddress,
+		ResolvedAddress: kp.resolvedAddress,
+		Labels:          append([]string{}, kp.labels...),
+		Enabled:         kp.enabled,
+		ConnDirection:   kp.connDirection,
+		ConnStatus:      kp.connStatus,
+		ConnHistory:     kp.connHistory,
+	}
+}
+
+// toKnownPeerToAdd returns the persistable representation of the known peer, kp.mutex must be held by the caller.
+func (kp *knownPeer) toKnownPeerToAdd() *KnownPeerToAdd {
+	return &KnownPeerToAdd{
+		PublicKey: kp.publicKey,
+		Address:   kp.peerAddress,
+		Labels:    append([]string{}, kp.labels...),
+		Disabled:  !kp.enabled,
+	}
+}
+
+func (m *Manager) addPeers(peers []*KnownPeerToAdd, persistent bool) error {
+	var resultErr error
+	for _, p := range peers {
+		if err := m.addPeer(p, persistent); err != nil {
+			resultErr = errors.CombineErrors(resultErr, err)
+		}
+	}
+	return resultErr
+}
+
+func (m *Manager) addPeer(p *KnownPeerToAdd, persistent bool) error {
 	if !m.isStarted.IsSet() {
 		return errors.New("manualpeering manager hasn't been started yet")
 	}
@@ -252,15 +433,27 @@ func (m *Manager) addPeer(p *KnownPeerToAdd) error {
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	kp, err := newKnownPeer(p, connDirection)
+	kp, err := newKnownPeer(p, connDirection, persistent)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	if _, exists := m.knownPeers[kp.peer.ID()]; exists {
-		return nil
+	peerID := identity.NewID(p.PublicKey)
+	if existing, exists := m.knownPeers[peerID]; exists {
+		if !persistent {
+			return nil
+		}
+		existing.mutex.Lock()
+		defer existing.mutex.Unlock()
+		existing.persistent = true
+		return errors.WithStack(m.persistPeer(existing.toKnownPeerToAdd()))
+	}
+	if persistent {
+		if err := m.persistPeer(p); err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	m.log.Infow("Adding new peer to the list of known peers in manualpeering", "peer", p)
-	m.knownPeers[kp.peer.ID()] = kp
+	m.knownPeers[peerID] = kp
 	go func() {
 		defer close(kp.doneCh)
 		m.keepPeerConnected(kp)
@@ -268,6 +461,29 @@ func (m *Manager) addPeer(p *KnownPeerToAdd) error {
 	return nil
 }
 
+func (m *Manager) updatePeer(p *KnownPeerToUpdate) error {
+	m.knownPeersMutex.RLock()
+	defer m.knownPeersMutex.RUnlock()
+	kp, exists := m.knownPeers[identity.NewID(p.PublicKey)]
+	if !exists {
+		return errors.Newf("peer with public key %s is not a known peer", p.PublicKey)
+	}
+	m.log.Infow("Updating peer in the list of known peers in manualpeering", "peer", p)
+	kp.mutex.Lock()
+	defer kp.mutex.Unlock()
+	if p.Labels != nil {
+		kp.labels = append([]string{}, *p.Labels...)
+	}
+	if p.Enabled != nil {
+		kp.enabled = *p.Enabled
+	}
+	kp.notifyUpdate()
+	if !kp.persistent {
+		return nil
+	}
+	return errors.WithStack(m.persistPeer(kp.toKnownPeerToAdd()))
+}
+
 func (m *Manager) removePeer(key ed25519.PublicKey) error {
 	m.knownPeersMutex.Lock()
 	defer m.knownPeersMutex.Unlock()
@@ -275,6 +491,11 @@ func (m *Manager) removePeer(key ed25519.PublicKey) error {
 		"publicKey", key)
 	peerID := identity.NewID(key)
 	err := m.removePeerByID(peerID)
+	if m.store != nil {
+		if deleteErr := m.store.delete(key); deleteErr != nil {
+			err = errors.CombineErrors(err, deleteErr)
+		}
+	}
 	return errors.WithStack(err)
 }
 
@@ -304,6 +525,31 @@ func (m *Manager) removePeerByID(peerID identity.ID) error {
 	return nil
 }
 
+func (m *Manager) persistPeer(p *KnownPeerToAdd) error {
+	if m.store == nil {
+		return nil
+	}
+	return m.store.save(p)
+}
+
+func (m *Manager) loadPersistedPeers() {
+	if m.store == nil {
+		return
+	}
+	peers, err := m.store.loadAll()
+	if err != nil {
+		m.log.Errorw("Failed to load persisted known peers, continuing without them...", "err", err)
+		return
+	}
+	if len(peers) == 0 {
+		return
+	}
+	m.log.Infow("Adding persisted peers to the list of known peers in manualpeering", "count", len(peers))
+	if err := m.addPeers(peers, true); err != nil {
+		m.log.Errorw("Failed to add some of the persisted peers", "err", err)
+	}
+}
+
 func (m *Manager) keepPeerConnected(kp *knownPeer) {
 	ctx, ctxCancel := context.WithCancel(context.Background())
 	cancelContextOnRemove := func() {
@@ -312,31 +558,21 @@ func (m *Manager) keepPeerConnected(kp *knownPeer) {
 	}
 	go cancelContextOnRemove()
 
-	ticker := time.NewTicker(m.reconnectInterval)
-	defer ticker.Stop()
+	reconnectTicker := time.NewTicker(m.reconnectInterval)
+	defer reconnectTicker.Stop()
+	resolveTicker := time.NewTicker(m.resolveInterval)
+	defer resolveTicker.Stop()
 
-	peerID := kp.peer.ID()
 	for {
-		if kp.getConnStatus() == ConnStatusDisconnected {
-			m.log.Infow(
-				"Peer is disconnected, calling gossip layer to establish the connection",
-				"peer", kp.peer, "connectionDirection", kp.connDirection,
-			)
-			var err error
-			if kp.connDirection == ConnDirectionOutbound {
-				err = m.gm.AddOutbound(ctx, kp.peer, gossip.NeighborsGroupManual)
-			} else if kp.connDirection == ConnDirectionInbound {
-				err = m.gm.AddInbound(ctx, kp.peer, gossip.NeighborsGroupManual, server.WithNoDefaultTimeout())
-			}
-			if err != nil && !errors.Is(err, gossip.ErrDuplicateNeighbor) && !errors.Is(err, context.Canceled) {
-				m.log.Errorw(
-					"Failed to connect a neighbor in the gossip layer",
-					"peerID", peerID, "connectionDirection", kp.connDirection, "err", err,
-				)
-			}
-		}
+		m.syncPeerConnection(ctx, kp)
 		select {
-		case <-ticker.C:
+		case <-reconnectTicker.C:
+		case <-kp.updateCh:
+		case <-resolveTicker.C:
+			// the peer needs to be reconnected if its address has changed since the last resolution
+			if m.resolvePeerAddress(kp) && kp.getConnStatus() == ConnStatusConnected {
+				m.dropPeer(kp)
+			}
 		case <-kp.removeCh:
 			<-ctx.Done()
 			return
@@ -344,6 +580,72 @@ func (m *Manager) keepPeerConnected(kp *knownPeer) {
 	}
 }
 
+// syncPeerConnection makes sure that the connection state of the peer in the gossip layer matches its enabled state.
+func (m *Manager) syncPeerConnection(ctx context.Context, kp *knownPeer) {
+	kp.mutex.RLock()
+	enabled, connStatus, p := kp.enabled, kp.connStatus, kp.peer
+	kp.mutex.RUnlock()
+
+	if !enabled {
+		if connStatus == ConnStatusConnected {
+			m.dropPeer(kp)
+		}
+		return
+	}
+	if connStatus != ConnStatusDisconnected {
+		return
+	}
+	if p == nil {
+		if !m.resolvePeerAddress(kp) {
+			return
+		}
+		kp.mutex.RLock()
+		p = kp.peer
+		kp.mutex.RUnlock()
+	}
+
+	m.log.Infow(
+		"Peer is disconnected, calling gossip layer to establish the connection",
+		"peer", p, "connectionDirection", kp.connDirection,
+	)
+	var err error
+	if kp.connDirection == ConnDirectionOutbound {
+		err = m.gm.AddOutbound(ctx, p, gossip.NeighborsGroupManual)
+	} else if kp.connDirection == ConnDirectionInbound {
+		err = m.gm.AddInbound(ctx, p, gossip.NeighborsGroupManual, server.WithNoDefaultTimeout())
+	}
+	if err != nil && !errors.Is(err, gossip.ErrDuplicateNeighbor) && !errors.Is(err, context.Canceled) {
+		m.log.Errorw(
+			"Failed to connect a neighbor in the gossip layer",
+			"peerID", p.ID(), "connectionDirection", kp.connDirection, "err", err,
+		)
+		kp.recordFailure(err)
+	}
+}
+
+// resolvePeerAddress resolves the address of the peer and reports whether it has changed.
+func (m *Manager) resolvePeerAddress(kp *knownPeer) bool {
+	changed, err := kp.resolve()
+	if err != nil {
+		m.log.Warnw("Failed to resolve the address of a known peer", "address", kp.peerAddress, "err", err)
+		kp.recordFailure(err)
+		return false
+	}
+	if changed {
+		kp.mutex.RLock()
+		m.log.Infow("Resolved the address of a known peer", "address", kp.peerAddress, "resolvedAddress", kp.resolvedAddress)
+		kp.mutex.RUnlock()
+	}
+	return changed
+}
+
+func (m *Manager) dropPeer(kp *knownPeer) {
+	peerID := identity.NewID(kp.publicKey)
+	if err := m.gm.DropNeighbor(peerID, gossip.NeighborsGroupManual); err != nil && !errors.Is(err, gossip.ErrUnknownNeighbor) {
+		m.log.Errorw("Failed to drop a known peer in the gossip layer", "peerID", peerID, "err", err)
+	}
+}
+
 func (m *Manager) onGossipNeighborRemoved(neighbor *gossip.Neighbor) {
 	m.changeNeighborStatus(neighbor, ConnStatusDisconnected)
 }
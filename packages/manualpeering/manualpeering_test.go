@@ -0,0 +1,102 @@
+package manualpeering
+
+import (
+	"net"
+	"testing"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/gossip"
+	"github.com/iotaledger/goshimmer/packages/gossip/server"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+var log = logger.NewExampleLogger("manualpeering")
+
+func TestManager_PersistedPeers(t *testing.T) {
+	store := mapdb.NewMapDB()
+	persisted, removed, static := newTestPeer(t, "bootstrap"), newTestPeer(t), newTestPeer(t)
+
+	mgr := newTestManager(t, store)
+	mgr.Start()
+	require.NoError(t, mgr.AddPeer(persisted, removed))
+	require.NoError(t, mgr.AddStaticPeer(static))
+	require.NoError(t, mgr.RemovePeer(removed.PublicKey))
+	enabled := false
+	require.NoError(t, mgr.UpdatePeer(&KnownPeerToUpdate{PublicKey: persisted.PublicKey, Enabled: &enabled}))
+	assert.ElementsMatch(t, []ed25519.PublicKey{persisted.PublicKey, static.PublicKey}, publicKeys(mgr.GetPeers()))
+	require.NoError(t, mgr.Stop())
+
+	// only the peers that were added at runtime and not removed are loaded again, with their last state
+	reloaded := newTestManager(t, store)
+	reloaded.Start()
+	defer func() { require.NoError(t, reloaded.Stop()) }()
+	peers := reloaded.GetPeers()
+	require.Len(t, peers, 1)
+	assert.Equal(t, persisted.PublicKey, peers[0].PublicKey)
+	assert.Equal(t, persisted.Address, peers[0].Address)
+	assert.Equal(t, persisted.Labels, peers[0].Labels)
+	assert.False(t, peers[0].Enabled)
+	assert.Len(t, reloaded.GetPeers(WithLabel("bootstrap")), 1)
+
+	// a static peer becomes persistent if it is added at runtime
+	require.NoError(t, reloaded.AddStaticPeer(static))
+	require.NoError(t, reloaded.AddPeer(static))
+	persistedPeers, err := newPeerStore(store).loadAll()
+	require.NoError(t, err)
+	assert.Len(t, persistedPeers, 2)
+}
+
+func TestManager_NotStarted(t *testing.T) {
+	mgr := newTestManager(t, mapdb.NewMapDB())
+	assert.Error(t, mgr.AddPeer(newTestPeer(t)))
+	assert.Error(t, mgr.Stop())
+}
+
+// newTestManager creates a Manager that persists its peers in the given store and uses a gossip layer that listens on
+// a local port.
+func newTestManager(t *testing.T, store kvstore.KVStore) *Manager {
+	listener, err := net.ListenTCP("tcp", &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+
+	services := service.New()
+	services.Update(service.PeeringKey, "peering", 0)
+	services.Update(service.GossipKey, listener.Addr().Network(), listener.Addr().(*net.TCPAddr).Port)
+	db, err := peer.NewDB(mapdb.NewMapDB())
+	require.NoError(t, err)
+	local, err := peer.NewLocal(listener.Addr().(*net.TCPAddr).IP, services, db)
+	require.NoError(t, err)
+
+	srv := server.ServeTCP(local, listener, log)
+	gossipManager := gossip.NewManager(local, func(tangle.MessageID) ([]byte, error) { return nil, nil }, log)
+	gossipManager.Start(srv)
+	t.Cleanup(func() {
+		gossipManager.Stop()
+		srv.Close()
+		_ = listener.Close()
+	})
+
+	return NewManager(gossipManager, local, log, WithStore(store))
+}
+
+// newTestPeer returns a disabled peer with the given labels, so that the manager does not try to connect to it.
+func newTestPeer(t *testing.T, labels ...string) *KnownPeerToAdd {
+	publicKey, _, err := ed25519.GenerateKey()
+	require.NoError(t, err)
+
+	return &KnownPeerToAdd{PublicKey: publicKey, Address: "127.0.0.1:14666", Labels: labels, Disabled: true}
+}
+
+func publicKeys(peers []*KnownPeer) (keys []ed25519.PublicKey) {
+	for _, p := range peers {
+		keys = append(keys, p.PublicKey)
+	}
+	return keys
+}
@@ -0,0 +1,55 @@
+package manualpeering
+
+import (
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/kvstore"
+)
+
+// peerStore persists the known peers that were added at runtime, keyed by their public key.
+type peerStore struct {
+	store kvstore.KVStore
+}
+
+func newPeerStore(store kvstore.KVStore) *peerStore {
+	return &peerStore{store: store}
+}
+
+func (s *peerStore) save(p *KnownPeerToAdd) error {
+	value, err := json.Marshal(p)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal known peer")
+	}
+	if err := s.store.Set(p.PublicKey.Bytes(), value); err != nil {
+		return errors.Wrapf(err, "failed to persist known peer %s", p.PublicKey)
+	}
+	return nil
+}
+
+func (s *peerStore) delete(key ed25519.PublicKey) error {
+	if err := s.store.Delete(key.Bytes()); err != nil && !errors.Is(err, kvstore.ErrKeyNotFound) {
+		return errors.Wrapf(err, "failed to delete persisted known peer %s", key)
+	}
+	return nil
+}
+
+func (s *peerStore) loadAll() (peers []*KnownPeerToAdd, err error) {
+	iterErr := s.store.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		p := &KnownPeerToAdd{}
+		if err = json.Unmarshal(value, p); err != nil {
+			err = errors.Wrapf(err, "failed to unmarshal persisted known peer with key %x", key)
+			return false
+		}
+		peers = append(peers, p)
+		return true
+	})
+	if iterErr != nil {
+		return nil, errors.Wrap(iterErr, "failed to iterate over persisted known peers")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return peers, nil
+}
@@ -0,0 +1,36 @@
+package manualpeering
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerStore(t *testing.T) {
+	store := newPeerStore(mapdb.NewMapDB())
+
+	publicKey1, _, err := ed25519.GenerateKey()
+	require.NoError(t, err)
+	publicKey2, _, err := ed25519.GenerateKey()
+	require.NoError(t, err)
+
+	peer1 := &KnownPeerToAdd{PublicKey: publicKey1, Address: "node1.example.com:14666", Labels: []string{"bootstrap"}}
+	peer2 := &KnownPeerToAdd{PublicKey: publicKey2, Address: "127.0.0.1:14666", Disabled: true}
+	require.NoError(t, store.save(peer1))
+	require.NoError(t, store.save(peer2))
+
+	peers, err := store.loadAll()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*KnownPeerToAdd{peer1, peer2}, peers)
+
+	require.NoError(t, store.delete(publicKey1))
+	// deleting an unknown peer is not an error
+	require.NoError(t, store.delete(publicKey1))
+
+	peers, err = store.loadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []*KnownPeerToAdd{peer2}, peers)
+}
@@ -0,0 +1,239 @@
+package simulation
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+// gossip is the virtual gossip layer that connects the nodes of the simulated Network. Whether a message sent over a
+// link is lost and how long it takes to arrive is derived from the seed, the link and the message, so that the network
+// conditions do not depend on the order in which the nodes process their messages.
+type gossip struct {
+	options    *Options
+	nodes      []*Node
+	neighbors  [][]int
+	partitions []int
+	attempts   map[transmission]int
+	stats      GossipStats
+	closed     bool
+	pending    sync.WaitGroup
+	mutex      sync.RWMutex
+}
+
+// transmission identifies a message sent over a directed link.
+type transmission struct {
+	from      int
+	to        int
+	messageID tangle.MessageID
+}
+
+// GossipStats contains the statistics of the virtual gossip layer.
+type GossipStats struct {
+	Sent        int `json:"sent"`
+	Lost        int `json:"lost"`
+	Partitioned int `json:"partitioned"`
+	Requests    int `json:"requests"`
+}
+
+func newGossip(options *Options, rng *rand.Rand) *gossip {
+	return &gossip{
+		options:    options,
+		neighbors:  newTopology(options.NodeCount, options.NeighborCount, rng),
+		partitions: make([]int, options.NodeCount),
+		attempts:   make(map[transmission]int),
+	}
+}
+
+// newTopology connects the nodes in a ring, so that the network is connected, and adds random links until every node
+// has at least neighborCount neighbors (or is connected to all other nodes).
+func newTopology(nodeCount, neighborCount int, rng *rand.Rand) [][]int {
+	links := make([]map[int]struct{}, nodeCount)
+	for i := range links {
+		links[i] = make(map[int]struct{})
+	}
+	connect := func(a, b int) {
+		links[a][b] = struct{}{}
+		links[b][a] = struct{}{}
+	}
+	if nodeCount > 1 {
+		for i := 0; i < nodeCount; i++ {
+			connect(i, (i+1)%nodeCount)
+		}
+	}
+	if neighborCount > nodeCount-1 {
+		neighborCount = nodeCount - 1
+	}
+	for i := 0; i < nodeCount; i++ {
+		for _, candidate := range rng.Perm(nodeCount) {
+			if len(links[i]) >= neighborCount {
+				break
+			}
+			if candidate != i {
+				connect(i, candidate)
+			}
+		}
+	}
+
+	neighbors := make([][]int, nodeCount)
+	for i, adjacent := range links {
+		for j := range adjacent {
+			neighbors[i] = append(neighbors[i], j)
+		}
+		sort.Ints(neighbors[i])
+	}
+	return neighbors
+}
+
+// partition splits the network into the given groups, the nodes that are not part of any group form their own group.
+func (g *gossip) partition(groups ...[]int) error {
+	partitions := make([]int, len(g.partitions))
+	for i, group := range groups {
+		for _, index := range group {
+			if index < 0 || index >= len(partitions) {
+				return errors.Errorf("node %d does not exist", index)
+			}
+			if partitions[index] != 0 {
+				return errors.Errorf("node %d is part of more than one group", index)
+			}
+			partitions[index] = i + 1
+		}
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.partitions = partitions
+	return nil
+}
+
+// heal removes all partitions.
+func (g *gossip) heal() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.partitions = make([]int, len(g.partitions))
+}
+
+// broadcast sends the message to all neighbors of the given node.
+func (g *gossip) broadcast(from int, message *tangle.Message) {
+	for _, to := range g.neighbors[from] {
+		g.send(from, to, message.ID(), message.Bytes())
+	}
+}
+
+// request asks all neighbors of the given node for the message with the given ID.
+func (g *gossip) request(from int, messageID tangle.MessageID) {
+	g.mutex.Lock()
+	g.stats.Requests++
+	g.mutex.Unlock()
+
+	for _, to := range g.neighbors[from] {
+		latency, ok := g.transmit(from, to, messageID)
+		if !ok {
+			continue
+		}
+		neighbor, requester := to, from
+		g.after(latency, func() {
+			g.nodes[neighbor].Tangle.Storage.Message(messageID).Consume(func(message *tangle.Message) {
+				g.send(neighbor, requester, messageID, message.Bytes())
+			})
+		})
+	}
+}
+
+// send delivers the data to the given node unless the link is partitioned or the transmission is lost.
+func (g *gossip) send(from, to int, messageID tangle.MessageID, data []byte) {
+	latency, ok := g.transmit(from, to, messageID)
+	if !ok {
+		return
+	}
+	g.after(latency, func() {
+		g.nodes[to].Tangle.ProcessGossipMessage(data, g.nodes[from].peer)
+	})
+}
+
+// transmit decides the fate of a transmission and updates the statistics accordingly.
+func (g *gossip) transmit(from, to int, messageID tangle.MessageID) (latency time.Duration, ok bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.closed {
+		return 0, false
+	}
+	g.stats.Sent++
+	if g.partitions[from] != g.partitions[to] {
+		g.stats.Partitioned++
+		return 0, false
+	}
+
+	key := transmission{from: from, to: to, messageID: messageID}
+	attempt := g.attempts[key]
+	g.attempts[key] = attempt + 1
+
+	rng := rand.New(rand.NewSource(g.transmissionSeed(key, attempt)))
+	if rng.Float64() < g.options.PacketLoss {
+		g.stats.Lost++
+		return 0, false
+	}
+	latency = g.options.MinLatency
+	if spread := g.options.MaxLatency - g.options.MinLatency; spread > 0 {
+		latency += time.Duration(rng.Int63n(int64(spread)))
+	}
+	return latency, true
+}
+
+// transmissionSeed derives the seed of a single transmission from the seed of the simulation.
+func (g *gossip) transmissionSeed(key transmission, attempt int) int64 {
+	hash := fnv.New64a()
+	buffer := make([]byte, 8)
+	for _, value := range []uint64{uint64(g.options.Seed), uint64(key.from), uint64(key.to), uint64(attempt)} {
+		binary.BigEndian.PutUint64(buffer, value)
+		_, _ = hash.Write(buffer)
+	}
+	_, _ = hash.Write(key.messageID[:])
+	return int64(hash.Sum64())
+}
+
+// after executes f after the given delay unless the gossip layer is closed in the meantime.
+func (g *gossip) after(delay time.Duration, f func()) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.closed {
+		return
+	}
+	g.pending.Add(1)
+	time.AfterFunc(delay, func() {
+		defer g.pending.Done()
+		if !g.isClosed() {
+			f()
+		}
+	})
+}
+
+// close stops the delivery of all pending transmissions and waits for the ones that are currently being delivered.
+func (g *gossip) close() {
+	g.mutex.Lock()
+	g.closed = true
+	g.mutex.Unlock()
+
+	g.pending.Wait()
+}
+
+func (g *gossip) isClosed() bool {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	return g.closed
+}
+
+// statistics returns a copy of the statistics of the gossip layer.
+func (g *gossip) statistics() GossipStats {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	return g.stats
+}
@@ -0,0 +1,330 @@
+// Package simulation provides an in-process network of GoShimmer nodes that exchange messages over a virtual gossip
+// layer. It allows to study the behavior of the Tangle under configurable latency, packet loss, partitions and mana
+// distributions without the need for Docker.
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+// genesisBalance is the balance of every output of the genesis snapshot.
+const genesisBalance = 1000000
+
+// ErrNoGenesisOutputs is returned if all genesis outputs have already been used for double spends.
+var ErrNoGenesisOutputs = errors.New("no unspent genesis outputs left")
+
+// Network is a simulated network of nodes that run their own Tangle and are connected via a virtual gossip layer.
+// The identities, the topology, the mana distribution, the issuers chosen by Run and the network conditions of every
+// transmission are derived from the seed. The processing inside of the Tangles is still concurrent, so two runs with the
+// same seed experience the same network but not necessarily the same message IDs.
+type Network struct {
+	options *Options
+	nodes   []*Node
+	gossip  *gossip
+	voting  *voting
+	tracker *tracker
+
+	consensusManaByID map[identity.ID]float64
+	totalMana         float64
+
+	genesisOutputs []*genesisOutput
+	rng            *rand.Rand
+	sequence       int
+	mutex          sync.Mutex
+}
+
+// genesisOutput is an output of the genesis snapshot together with the key that can spend it.
+type genesisOutput struct {
+	id      ledgerstate.OutputID
+	keyPair ed25519.KeyPair
+}
+
+// NewNetwork creates a new simulated Network with the given options.
+func NewNetwork(options ...Option) (*Network, error) {
+	opts := defaultOptions()
+	for _, option := range options {
+		option(opts)
+	}
+	if opts.NodeCount < 1 {
+		return nil, errors.Errorf("a network needs at least one node, got %d", opts.NodeCount)
+	}
+	if opts.MaxLatency < opts.MinLatency {
+		return nil, errors.Errorf("maximum latency %s is smaller than minimum latency %s", opts.MaxLatency, opts.MinLatency)
+	}
+	if opts.PacketLoss < 0 || opts.PacketLoss >= 1 {
+		return nil, errors.Errorf("packet loss must be in [0, 1), got %f", opts.PacketLoss)
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	network := &Network{
+		options:           opts,
+		voting:            newVoting(opts.NodeCount),
+		tracker:           newTracker(),
+		consensusManaByID: make(map[identity.ID]float64),
+		rng:               rng,
+	}
+
+	identities := make([]*identity.LocalIdentity, opts.NodeCount)
+	for i := range identities {
+		keyPair := newKeyPair(rng)
+		identities[i] = identity.NewLocalIdentity(keyPair.PublicKey, keyPair.PrivateKey)
+	}
+	mana := opts.ManaDistribution(opts.NodeCount, rng)
+	if len(mana) != opts.NodeCount {
+		return nil, errors.Errorf("mana distribution returned %d values for %d nodes", len(mana), opts.NodeCount)
+	}
+	for i, localIdentity := range identities {
+		network.consensusManaByID[localIdentity.ID()] = mana[i]
+		network.totalMana += mana[i]
+	}
+	network.gossip = newGossip(opts, rng)
+
+	// every node needs its own copy of the snapshot, as the outputs are stored in the object storage of the node
+	var snapshotBytes bytes.Buffer
+	if _, err := network.createGenesisSnapshot(rng).WriteTo(&snapshotBytes); err != nil {
+		return nil, errors.Errorf("failed to serialize genesis snapshot: %w", err)
+	}
+	for i, localIdentity := range identities {
+		snapshot := &ledgerstate.Snapshot{}
+		if _, err := snapshot.ReadFrom(bytes.NewReader(snapshotBytes.Bytes())); err != nil {
+			return nil, errors.Errorf("failed to read genesis snapshot: %w", err)
+		}
+		node := newNode(network, i, localIdentity, mana[i])
+		if err := node.Tangle.LedgerState.LoadSnapshot(snapshot); err != nil {
+			return nil, errors.Errorf("failed to load genesis snapshot of node %d: %w", i, err)
+		}
+		network.nodes = append(network.nodes, node)
+	}
+	network.gossip.nodes = network.nodes
+
+	return network, nil
+}
+
+// Nodes returns the nodes of the network.
+func (n *Network) Nodes() []*Node {
+	return n.nodes
+}
+
+// Neighbors returns the indices of the gossip neighbors of the node with the given index.
+func (n *Network) Neighbors(index int) []int {
+	return append([]int{}, n.gossip.neighbors[index]...)
+}
+
+// Partition splits the network into the given groups of node indices, messages are no longer exchanged between nodes
+// of different groups. The nodes that are not part of any group form their own group.
+func (n *Network) Partition(groups ...[]int) error {
+	return n.gossip.partition(groups...)
+}
+
+// Heal removes all partitions of the network.
+func (n *Network) Heal() {
+	n.gossip.heal()
+}
+
+// IssueData issues a data message from the node with the given index.
+func (n *Network) IssueData(issuer int) (*tangle.Message, error) {
+	if err := n.checkIndex(issuer); err != nil {
+		return nil, err
+	}
+	n.mutex.Lock()
+	n.sequence++
+	data := []byte(fmt.Sprintf("simulation message %d", n.sequence))
+	n.mutex.Unlock()
+
+	return n.issue(issuer, payload.NewGenericDataPayload(data))
+}
+
+// IssueDoubleSpend spends the next unspent genesis output twice: once from the node with index first and, after the
+// given delay, once from the node with index second. It returns the messages that contain the conflicting transactions.
+// Following FCoB, both transactions are rejected if the delay is shorter than the liked threshold.
+func (n *Network) IssueDoubleSpend(first, second int, delay time.Duration) (messages [2]*tangle.Message, err error) {
+	if err = n.checkIndex(first); err != nil {
+		return
+	}
+	if err = n.checkIndex(second); err != nil {
+		return
+	}
+
+	n.mutex.Lock()
+	if len(n.genesisOutputs) == 0 {
+		n.mutex.Unlock()
+		return messages, ErrNoGenesisOutputs
+	}
+	output := n.genesisOutputs[0]
+	n.genesisOutputs = n.genesisOutputs[1:]
+	firstTransaction := n.spend(output, n.nodes[first])
+	n.mutex.Unlock()
+
+	issuedAt := time.Now()
+	if messages[0], err = n.issue(first, firstTransaction); err != nil {
+		return
+	}
+	time.Sleep(delay)
+
+	n.mutex.Lock()
+	secondTransaction := n.spend(output, n.nodes[second])
+	n.mutex.Unlock()
+
+	n.tracker.recordConflict(firstTransaction.ID(), secondTransaction.ID(), issuedAt)
+	messages[1], err = n.issue(second, secondTransaction)
+	return
+}
+
+// Run issues data messages with the given rate (messages per second) for the given duration. The issuer of every
+// message is chosen randomly, weighted by the access mana of the nodes.
+func (n *Network) Run(duration time.Duration, rate float64) {
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+	defer ticker.Stop()
+
+	deadline := time.Now().Add(duration)
+	for now := range ticker.C {
+		if now.After(deadline) {
+			return
+		}
+		// the error is already recorded by the tracker
+		_, _ = n.IssueData(n.randomIssuer())
+	}
+}
+
+// Report returns the report of the simulation up to now.
+func (n *Network) Report() *Report {
+	return n.tracker.report(n)
+}
+
+// Shutdown stops the gossip layer and shuts down the Tangles of all nodes.
+func (n *Network) Shutdown() {
+	n.gossip.close()
+
+	var wg sync.WaitGroup
+	for _, node := range n.nodes {
+		wg.Add(1)
+		go func(node *Node) {
+			defer wg.Done()
+			node.shutdown()
+		}(node)
+	}
+	wg.Wait()
+}
+
+func (n *Network) issue(issuer int, p payload.Payload) (*tangle.Message, error) {
+	issuingTime := time.Now()
+	message, err := n.nodes[issuer].Tangle.IssuePayload(p)
+	if err != nil {
+		n.tracker.recordIssueFailure()
+		return nil, errors.Errorf("node %d failed to issue payload: %w", issuer, err)
+	}
+	n.tracker.recordMessageIssued(issuer, message.ID(), issuingTime)
+	return message, nil
+}
+
+// spend creates a transaction that moves the funds of the genesis output to a new address and pledges its mana to the
+// given node.
+func (n *Network) spend(output *genesisOutput, pledgeNode *Node) *ledgerstate.Transaction {
+	keyPair := newKeyPair(n.rng)
+	essence := ledgerstate.NewTransactionEssence(0, clock.SyncedTime(), pledgeNode.ID, pledgeNode.ID,
+		ledgerstate.NewInputs(ledgerstate.NewUTXOInput(output.id)),
+		ledgerstate.NewOutputs(ledgerstate.NewSigLockedSingleOutput(genesisBalance, ledgerstate.NewED25519Address(keyPair.PublicKey))),
+	)
+	signature := ledgerstate.NewED25519Signature(output.keyPair.PublicKey, output.keyPair.PrivateKey.Sign(essence.Bytes()))
+	return ledgerstate.NewTransaction(essence, ledgerstate.UnlockBlocks{ledgerstate.NewSignatureUnlockBlock(signature)})
+}
+
+// createGenesisSnapshot creates the snapshot that is loaded by all nodes.
+func (n *Network) createGenesisSnapshot(rng *rand.Rand) *ledgerstate.Snapshot {
+	keyPairs := make(map[string]ed25519.KeyPair)
+	outputs := make([]ledgerstate.Output, n.options.GenesisOutputs)
+	for i := range outputs {
+		keyPair := newKeyPair(rng)
+		address := ledgerstate.NewED25519Address(keyPair.PublicKey)
+		keyPairs[address.Base58()] = keyPair
+		outputs[i] = ledgerstate.NewSigLockedSingleOutput(genesisBalance, address)
+	}
+
+	essence := ledgerstate.NewTransactionEssence(0, time.Unix(tangle.DefaultGenesisTime, 0), identity.ID{}, identity.ID{},
+		ledgerstate.NewInputs(ledgerstate.NewUTXOInput(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 0))),
+		ledgerstate.NewOutputs(outputs...),
+	)
+	unlockBlocks := ledgerstate.UnlockBlocks{ledgerstate.NewReferenceUnlockBlock(0)}
+	transactionID := ledgerstate.NewTransaction(essence, unlockBlocks).ID()
+
+	unspentOutputs := make([]bool, len(essence.Outputs()))
+	for i, output := range essence.Outputs() {
+		unspentOutputs[i] = true
+		n.genesisOutputs = append(n.genesisOutputs, &genesisOutput{
+			id:      ledgerstate.NewOutputID(transactionID, uint16(i)),
+			keyPair: keyPairs[output.Address().Base58()],
+		})
+	}
+
+	return &ledgerstate.Snapshot{
+		Transactions: map[ledgerstate.TransactionID]ledgerstate.Record{
+			transactionID: {
+				Essence:        essence,
+				UnlockBlocks:   unlockBlocks,
+				UnspentOutputs: unspentOutputs,
+			},
+		},
+	}
+}
+
+// randomIssuer returns the index of a node chosen randomly, weighted by its mana.
+func (n *Network) randomIssuer() int {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.totalMana <= 0 {
+		return n.rng.Intn(len(n.nodes))
+	}
+	target := n.rng.Float64() * n.totalMana
+	for _, node := range n.nodes {
+		if target < node.Mana {
+			return node.Index
+		}
+		target -= node.Mana
+	}
+	return len(n.nodes) - 1
+}
+
+func (n *Network) checkIndex(index int) error {
+	if index < 0 || index >= len(n.nodes) {
+		return errors.Errorf("node %d does not exist", index)
+	}
+	return nil
+}
+
+func (n *Network) consensusMana() map[identity.ID]float64 {
+	consensusMana := make(map[identity.ID]float64, len(n.consensusManaByID))
+	for id, mana := range n.consensusManaByID {
+		consensusMana[id] = mana
+	}
+	return consensusMana
+}
+
+func (n *Network) accessMana(id identity.ID) float64 {
+	return n.consensusManaByID[id]
+}
+
+func (n *Network) totalAccessMana() float64 {
+	return n.totalMana
+}
+
+// newKeyPair derives a key pair from the given random number generator.
+func newKeyPair(rng *rand.Rand) ed25519.KeyPair {
+	seed := make([]byte, ed25519.SeedSize)
+	rng.Read(seed)
+	privateKey := ed25519.PrivateKeyFromSeed(seed)
+	return ed25519.KeyPair{PrivateKey: privateKey, PublicKey: privateKey.Public()}
+}
@@ -0,0 +1,86 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+func TestNewNetwork_Deterministic(t *testing.T) {
+	newNetwork := func(seed int64) *Network {
+		network, err := NewNetwork(WithSeed(seed), WithNodes(4), WithNeighbors(2), WithManaDistribution(ZipfMana(1, 1000)))
+		require.NoError(t, err)
+		t.Cleanup(network.Shutdown)
+		return network
+	}
+	first, second, other := newNetwork(42), newNetwork(42), newNetwork(43)
+
+	for i := range first.Nodes() {
+		assert.Equal(t, first.Nodes()[i].ID, second.Nodes()[i].ID)
+		assert.Equal(t, first.Nodes()[i].Mana, second.Nodes()[i].Mana)
+		assert.Equal(t, first.Neighbors(i), second.Neighbors(i))
+		assert.NotEqual(t, first.Nodes()[i].ID, other.Nodes()[i].ID)
+	}
+
+	// the network conditions only depend on the seed, the link and the message
+	messageID := tangle.MessageID{1, 2, 3}
+	key := transmission{from: 0, to: 1, messageID: messageID}
+	assert.Equal(t, first.gossip.transmissionSeed(key, 0), second.gossip.transmissionSeed(key, 0))
+	assert.NotEqual(t, first.gossip.transmissionSeed(key, 0), first.gossip.transmissionSeed(key, 1))
+	assert.NotEqual(t, first.gossip.transmissionSeed(key, 0), other.gossip.transmissionSeed(key, 0))
+}
+
+func TestNetwork_Run(t *testing.T) {
+	network, err := NewNetwork(WithSeed(1), WithNodes(5), WithNeighbors(2), WithLatency(10*time.Millisecond, 50*time.Millisecond), WithPacketLoss(0.05))
+	require.NoError(t, err)
+	defer network.Shutdown()
+
+	network.Run(time.Second, 20)
+
+	// the second transaction arrives after the liked threshold of FCoB, so the first one wins
+	go func() {
+		_, err := network.IssueDoubleSpend(0, 3, 3*time.Second)
+		assert.NoError(t, err)
+	}()
+	network.Run(6*time.Second, 20)
+
+	assert.Eventually(t, func() bool {
+		report := network.Report()
+		return report.Conflicts.Accepted == 1 && report.Messages.Confirmed > 0
+	}, 10*time.Second, 100*time.Millisecond)
+
+	report := network.Report()
+	t.Log(report)
+	assert.Zero(t, report.Errors.Count)
+	assert.Zero(t, report.Conflicts.Diverged)
+	assert.Greater(t, report.Gossip.Lost, 0)
+	assert.Greater(t, report.Messages.ConfirmationTime.Count, 0)
+}
+
+func TestNetwork_Partition(t *testing.T) {
+	network, err := NewNetwork(WithSeed(2), WithNodes(4), WithNeighbors(2))
+	require.NoError(t, err)
+	defer network.Shutdown()
+
+	require.NoError(t, network.Partition([]int{0, 1}, []int{2, 3}))
+	message, err := network.IssueData(0)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return network.Nodes()[1].Tangle.Storage.Message(message.ID()).Consume(func(*tangle.Message) {})
+	}, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(200 * time.Millisecond)
+	assert.False(t, network.Nodes()[2].Tangle.Storage.Message(message.ID()).Consume(func(*tangle.Message) {}))
+	assert.Greater(t, network.Report().Gossip.Partitioned, 0)
+
+	// after healing, the message reaches the other partition as part of the past cone of new messages
+	network.Heal()
+	network.Run(time.Second, 10)
+	assert.Eventually(t, func() bool {
+		return network.Nodes()[2].Tangle.Storage.Message(message.ID()).Consume(func(*tangle.Message) {})
+	}, 5*time.Second, 10*time.Millisecond)
+}
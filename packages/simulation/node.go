@@ -0,0 +1,205 @@
+package simulation
+
+import (
+	"net"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/datastructure/walker"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/consensus/fcob"
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/markers"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+const (
+	schedulerBufferSize = 1024 * 1024
+	schedulerRate       = time.Second / 5000
+)
+
+// Node is a single node of the simulated Network. It runs its own Tangle and FCoB consensus mechanism, the FPC voting is
+// replaced by the ideal voting of the Network.
+type Node struct {
+	Index     int
+	ID        identity.ID
+	Mana      float64
+	Tangle    *tangle.Tangle
+	Consensus *fcob.ConsensusMechanism
+
+	network *Network
+	peer    *peer.Peer
+}
+
+func newNode(network *Network, index int, localIdentity *identity.LocalIdentity, mana float64) *Node {
+	node := &Node{
+		Index:     index,
+		ID:        localIdentity.ID(),
+		Mana:      mana,
+		Consensus: fcob.NewConsensusMechanism(),
+		network:   network,
+	}
+	services := service.New()
+	services.Update(service.PeeringKey, "sim", index)
+	node.peer = peer.NewPeer(localIdentity.Identity, net.IPv4(127, 0, 0, 1), services)
+
+	node.Tangle = tangle.New(
+		tangle.Identity(localIdentity),
+		tangle.Consensus(node.Consensus),
+		tangle.SchedulerConfig(tangle.SchedulerParams{
+			MaxBufferSize:               schedulerBufferSize,
+			Rate:                        schedulerRate,
+			AccessManaRetrieveFunc:      network.accessMana,
+			TotalAccessManaRetrieveFunc: network.totalAccessMana,
+		}),
+		tangle.ApprovalWeights(tangle.NewCManaWeightProvider(network.consensusMana, clock.SyncedTime)),
+		tangle.SyncTimeWindow(tangle.DefaultSyncTimeWindow),
+		tangle.StartSynced(true),
+		tangle.CacheTimeProvider(database.NewCacheTimeProvider(0)),
+	)
+	node.Tangle.Setup()
+	node.setupEvents()
+
+	return node
+}
+
+// setupEvents connects the Tangle of the node to the virtual gossip layer and mirrors the behavior of the message layer
+// plugin for the confirmation of messages and branches.
+func (n *Node) setupEvents() {
+	onError := events.NewClosure(func(err error) {
+		n.network.tracker.recordError(errors.Errorf("node %d: %w", n.Index, err))
+	})
+	n.Tangle.Events.Error.Attach(onError)
+	n.Consensus.Events.Error.Attach(onError)
+
+	n.Tangle.MessageFactory.Events.MessageConstructed.Attach(events.NewClosure(func(message *tangle.Message) {
+		n.Tangle.ProcessGossipMessage(message.Bytes(), n.peer)
+	}))
+	n.Tangle.Storage.Events.MessageStored.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		n.Tangle.Storage.Message(messageID).Consume(func(message *tangle.Message) {
+			n.Tangle.WeightProvider.Update(message.IssuingTime(), identity.NewID(message.IssuerPublicKey()))
+		})
+	}))
+	n.Tangle.Booker.Events.MessageBooked.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		n.Tangle.Storage.Message(messageID).Consume(func(message *tangle.Message) {
+			n.network.gossip.broadcast(n.Index, message)
+		})
+	}))
+	n.Tangle.Requester.Events.SendRequest.Attach(events.NewClosure(func(sendRequest *tangle.SendRequestEvent) {
+		n.network.gossip.request(n.Index, sendRequest.ID)
+	}))
+
+	n.Consensus.Events.Vote.Attach(events.NewClosure(func(id string, initialOpinion opinion.Opinion) {
+		n.network.voting.cast(id, initialOpinion, n.Mana)
+		n.awaitVote(id)
+	}))
+
+	n.Tangle.ApprovalWeightManager.Events.MarkerConfirmation.Attach(events.NewClosure(n.onMarkerConfirmed))
+	n.Tangle.ApprovalWeightManager.Events.BranchConfirmation.Attach(events.NewClosure(n.onBranchConfirmed))
+	n.Tangle.ApprovalWeightManager.Events.MessageFinalized.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		n.network.tracker.recordMessageConfirmed(n.Index, messageID, time.Now())
+	}))
+}
+
+// awaitVote hands the outcome of the voting on the given conflict to the consensus mechanism once it is finalized.
+func (n *Node) awaitVote(id string) {
+	n.network.gossip.after(n.network.options.VoteDelay, func() {
+		outcome, finalized := n.network.voting.outcome(id)
+		if !finalized {
+			n.awaitVote(id)
+			return
+		}
+		n.Consensus.ProcessVote(&vote.OpinionEvent{
+			ID:      id,
+			Opinion: outcome,
+			Ctx:     vote.Context{ID: id, Type: vote.ConflictType},
+		})
+
+		transactionID, err := ledgerstate.TransactionIDFromBase58(id)
+		if err != nil {
+			n.network.tracker.recordError(errors.Errorf("node %d: %w", n.Index, err))
+			return
+		}
+		n.network.tracker.recordVoteFinalized(n.Index, transactionID, outcome == opinion.Like, time.Now())
+	})
+}
+
+func (n *Node) onMarkerConfirmed(marker markers.Marker, newLevel int, transition events.ThresholdEventTransition) {
+	if transition != events.ThresholdLevelIncreased {
+		return
+	}
+	messageID := n.Tangle.Booker.MarkersManager.MessageID(&marker)
+
+	n.Tangle.Utils.WalkMessageAndMetadata(n.propagateFinalizedApprovalWeight, tangle.MessageIDs{messageID}, false)
+}
+
+func (n *Node) propagateFinalizedApprovalWeight(message *tangle.Message, messageMetadata *tangle.MessageMetadata, finalizedWalker *walker.Walker) {
+	// stop walking to past cone if reach a marker
+	if messageMetadata.StructureDetails().IsPastMarker && messageMetadata.IsFinalized() {
+		return
+	}
+
+	if !n.setMessageFinalized(messageMetadata) {
+		return
+	}
+
+	// mark weak parents as finalized but do not propagate the finalized flag to their past cone
+	message.ForEachWeakParent(func(parentID tangle.MessageID) {
+		n.Tangle.Storage.MessageMetadata(parentID).Consume(func(messageMetadata *tangle.MessageMetadata) {
+			n.setMessageFinalized(messageMetadata)
+		})
+	})
+
+	message.ForEachStrongParent(func(parentID tangle.MessageID) {
+		finalizedWalker.Push(parentID)
+	})
+}
+
+func (n *Node) setMessageFinalized(messageMetadata *tangle.MessageMetadata) (modified bool) {
+	if modified = messageMetadata.SetFinalized(true); !modified {
+		return
+	}
+
+	n.Tangle.Storage.Message(messageMetadata.ID()).Consume(func(message *tangle.Message) {
+		n.Tangle.WeightProvider.Update(message.IssuingTime(), identity.NewID(message.IssuerPublicKey()))
+	})
+
+	n.Tangle.Utils.ComputeIfTransaction(messageMetadata.ID(), func(transactionID ledgerstate.TransactionID) {
+		if err := n.Tangle.LedgerState.UTXODAG.SetTransactionConfirmed(transactionID); err != nil {
+			n.network.tracker.recordError(errors.Errorf("node %d: %w", n.Index, err))
+		}
+	})
+
+	n.Tangle.ApprovalWeightManager.Events.MessageFinalized.Trigger(messageMetadata.ID())
+
+	return modified
+}
+
+func (n *Node) onBranchConfirmed(branchID ledgerstate.BranchID, newLevel int, transition events.ThresholdEventTransition) {
+	if transition != events.ThresholdLevelIncreased {
+		return
+	}
+
+	if _, err := n.Tangle.LedgerState.BranchDAG.SetBranchMonotonicallyLiked(branchID, true); err != nil {
+		n.network.tracker.recordError(errors.Errorf("node %d: %w", n.Index, err))
+		return
+	}
+	if _, err := n.Tangle.LedgerState.BranchDAG.SetBranchFinalized(branchID, true); err != nil {
+		n.network.tracker.recordError(errors.Errorf("node %d: %w", n.Index, err))
+		return
+	}
+
+	n.network.tracker.recordBranchConfirmed(n.Index, branchID, time.Now())
+}
+
+func (n *Node) shutdown() {
+	n.Tangle.Shutdown()
+}
@@ -0,0 +1,144 @@
+package simulation
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// region Options //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Option is a function setting an option of the simulated Network.
+type Option func(*Options)
+
+// Options is a container for all configurable parameters of the simulated Network.
+type Options struct {
+	Seed             int64
+	NodeCount        int
+	NeighborCount    int
+	MinLatency       time.Duration
+	MaxLatency       time.Duration
+	PacketLoss       float64
+	ManaDistribution ManaDistribution
+	GenesisOutputs   int
+	VoteDelay        time.Duration
+}
+
+func defaultOptions() *Options {
+	return &Options{
+		Seed:             0,
+		NodeCount:        8,
+		NeighborCount:    4,
+		MinLatency:       10 * time.Millisecond,
+		MaxLatency:       50 * time.Millisecond,
+		PacketLoss:       0,
+		ManaDistribution: UniformMana(1000),
+		GenesisOutputs:   10,
+		VoteDelay:        time.Second,
+	}
+}
+
+// WithSeed sets the seed from which the identities, the topology, the mana and the network conditions are derived.
+func WithSeed(seed int64) Option {
+	return func(options *Options) {
+		options.Seed = seed
+	}
+}
+
+// WithNodes sets the number of nodes in the network.
+func WithNodes(count int) Option {
+	return func(options *Options) {
+		options.NodeCount = count
+	}
+}
+
+// WithNeighbors sets the number of gossip neighbors every node tries to have.
+func WithNeighbors(count int) Option {
+	return func(options *Options) {
+		options.NeighborCount = count
+	}
+}
+
+// WithLatency sets the range of the latency of a message sent over a link.
+func WithLatency(min, max time.Duration) Option {
+	return func(options *Options) {
+		options.MinLatency = min
+		options.MaxLatency = max
+	}
+}
+
+// WithPacketLoss sets the probability that a message sent over a link is lost.
+func WithPacketLoss(probability float64) Option {
+	return func(options *Options) {
+		options.PacketLoss = probability
+	}
+}
+
+// WithManaDistribution sets how the mana is distributed among the nodes.
+func WithManaDistribution(distribution ManaDistribution) Option {
+	return func(options *Options) {
+		options.ManaDistribution = distribution
+	}
+}
+
+// WithGenesisOutputs sets the number of outputs in the genesis snapshot, i.e. the number of double spends that can be
+// issued during a simulation.
+func WithGenesisOutputs(count int) Option {
+	return func(options *Options) {
+		options.GenesisOutputs = count
+	}
+}
+
+// WithVoteDelay sets the time it takes a node to finalize its opinion about a conflict once all nodes have voted. It
+// replaces the rounds of FPC, which are not part of the simulation.
+func WithVoteDelay(delay time.Duration) Option {
+	return func(options *Options) {
+		options.VoteDelay = delay
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ManaDistribution /////////////////////////////////////////////////////////////////////////////////////////////
+
+// ManaDistribution returns the mana of each of the nodeCount nodes. It is used for both access and consensus mana.
+type ManaDistribution func(nodeCount int, rng *rand.Rand) []float64
+
+// UniformMana distributes the total mana equally among all nodes.
+func UniformMana(total float64) ManaDistribution {
+	return func(nodeCount int, _ *rand.Rand) []float64 {
+		mana := make([]float64, nodeCount)
+		for i := range mana {
+			mana[i] = total / float64(nodeCount)
+		}
+		return mana
+	}
+}
+
+// ZipfMana distributes the total mana according to Zipf's law with the exponent s, the ranks are assigned randomly.
+func ZipfMana(s float64, total float64) ManaDistribution {
+	return func(nodeCount int, rng *rand.Rand) []float64 {
+		weights := make([]float64, nodeCount)
+		var sum float64
+		for i := range weights {
+			weights[i] = 1 / math.Pow(float64(i+1), s)
+			sum += weights[i]
+		}
+		mana := make([]float64, nodeCount)
+		for i, rank := range rng.Perm(nodeCount) {
+			mana[i] = total * weights[rank] / sum
+		}
+		return mana
+	}
+}
+
+// FixedMana assigns the given mana values to the nodes in order. Missing values are set to 0.
+func FixedMana(values ...float64) ManaDistribution {
+	return func(nodeCount int, _ *rand.Rand) []float64 {
+		mana := make([]float64, nodeCount)
+		copy(mana, values)
+		return mana
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,124 @@
+package simulation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Report summarizes the outcome of a simulation.
+type Report struct {
+	Seed      int64          `json:"seed"`
+	Nodes     []*NodeReport  `json:"nodes"`
+	Messages  MessageReport  `json:"messages"`
+	Conflicts ConflictReport `json:"conflicts"`
+	Gossip    GossipStats    `json:"gossip"`
+	Errors    ErrorReport    `json:"errors"`
+}
+
+// NodeReport contains the statistics of a single node.
+type NodeReport struct {
+	Index     int     `json:"index"`
+	ID        string  `json:"id"`
+	Mana      float64 `json:"mana"`
+	Neighbors []int   `json:"neighbors"`
+	Issued    int     `json:"issued"`
+	Confirmed int     `json:"confirmed"`
+}
+
+// MessageReport contains the confirmation statistics of the issued messages. A message is confirmed once all nodes
+// confirmed it, and orphaned if no node confirmed it (this includes the messages of rejected double spends).
+type MessageReport struct {
+	Issued             int           `json:"issued"`
+	IssueFailures      int           `json:"issueFailures"`
+	Confirmed          int           `json:"confirmed"`
+	PartiallyConfirmed int           `json:"partiallyConfirmed"`
+	Orphaned           int           `json:"orphaned"`
+	OrphanageRate      float64       `json:"orphanageRate"`
+	ConfirmationTime   DurationStats `json:"confirmationTime"`
+}
+
+// ConflictReport contains the statistics of the issued double spends. A conflict is accepted once all nodes confirmed
+// the same transaction, rejected once all nodes rejected both transactions and diverged if the nodes resolved it
+// differently.
+type ConflictReport struct {
+	Issued         int           `json:"issued"`
+	Accepted       int           `json:"accepted"`
+	Rejected       int           `json:"rejected"`
+	Diverged       int           `json:"diverged"`
+	Unresolved     int           `json:"unresolved"`
+	ResolutionTime DurationStats `json:"resolutionTime"`
+}
+
+// ErrorReport contains the number of errors the nodes faced and the first of them.
+type ErrorReport struct {
+	Count  int      `json:"count"`
+	Errors []string `json:"errors"`
+}
+
+// DurationStats summarizes a set of durations.
+type DurationStats struct {
+	Count  int           `json:"count"`
+	Min    time.Duration `json:"min"`
+	Mean   time.Duration `json:"mean"`
+	Median time.Duration `json:"median"`
+	P95    time.Duration `json:"p95"`
+	Max    time.Duration `json:"max"`
+}
+
+func newDurationStats(durations []time.Duration) DurationStats {
+	if len(durations) == 0 {
+		return DurationStats{}
+	}
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum time.Duration
+	for _, duration := range sorted {
+		sum += duration
+	}
+	return DurationStats{
+		Count:  len(sorted),
+		Min:    sorted[0],
+		Mean:   sum / time.Duration(len(sorted)),
+		Median: sorted[len(sorted)/2],
+		P95:    sorted[(len(sorted)*95)/100],
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+// String returns a human readable representation of the DurationStats.
+func (d DurationStats) String() string {
+	if d.Count == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("min=%s mean=%s median=%s p95=%s max=%s (n=%d)",
+		d.Min.Round(time.Millisecond), d.Mean.Round(time.Millisecond), d.Median.Round(time.Millisecond),
+		d.P95.Round(time.Millisecond), d.Max.Round(time.Millisecond), d.Count)
+}
+
+// String returns a human readable representation of the Report.
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Simulation report (seed %d)\n", r.Seed)
+	fmt.Fprintf(&b, "Messages:  issued=%d confirmed=%d partially=%d orphaned=%d (%.2f%%) failures=%d\n",
+		r.Messages.Issued, r.Messages.Confirmed, r.Messages.PartiallyConfirmed, r.Messages.Orphaned,
+		r.Messages.OrphanageRate*100, r.Messages.IssueFailures)
+	fmt.Fprintf(&b, "           confirmation time: %s\n", r.Messages.ConfirmationTime)
+	fmt.Fprintf(&b, "Conflicts: issued=%d accepted=%d rejected=%d diverged=%d unresolved=%d\n",
+		r.Conflicts.Issued, r.Conflicts.Accepted, r.Conflicts.Rejected, r.Conflicts.Diverged, r.Conflicts.Unresolved)
+	fmt.Fprintf(&b, "           resolution time: %s\n", r.Conflicts.ResolutionTime)
+	fmt.Fprintf(&b, "Gossip:    sent=%d lost=%d partitioned=%d requests=%d\n",
+		r.Gossip.Sent, r.Gossip.Lost, r.Gossip.Partitioned, r.Gossip.Requests)
+	fmt.Fprintf(&b, "Errors:    %d\n", r.Errors.Count)
+	for _, err := range r.Errors.Errors {
+		fmt.Fprintf(&b, "           %s\n", err)
+	}
+	fmt.Fprintf(&b, "Nodes:\n")
+	for _, node := range r.Nodes {
+		fmt.Fprintf(&b, "  %3d %s mana=%.2f issued=%d confirmed=%d neighbors=%v\n",
+			node.Index, node.ID, node.Mana, node.Issued, node.Confirmed, node.Neighbors)
+	}
+	return b.String()
+}
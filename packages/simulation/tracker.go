@@ -0,0 +1,236 @@
+package simulation
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+// maxRecordedErrors is the maximum number of errors that are kept for the report.
+const maxRecordedErrors = 10
+
+// tracker collects the events of all nodes that are needed to create the Report of a simulation.
+type tracker struct {
+	messages          map[tangle.MessageID]*messageRecord
+	conflicts         []*conflictRecord
+	conflictsByBranch map[ledgerstate.BranchID]*conflictRecord
+	issueFailures     int
+	errorCount        int
+	errors            []string
+	mutex             sync.Mutex
+}
+
+// messageRecord tracks a single message. Confirmations are recorded even if the message was not issued by the
+// simulation (yet), but only issued messages are part of the report.
+type messageRecord struct {
+	issued    bool
+	issuer    int
+	issuedAt  time.Time
+	confirmed map[int]time.Time
+}
+
+// conflictRecord tracks a pair of conflicting transactions. A node resolved the conflict once it confirmed one of the
+// transactions or finalized its vote to reject both of them.
+type conflictRecord struct {
+	transactionIDs [2]ledgerstate.TransactionID
+	issuedAt       time.Time
+	outcomes       map[int]ledgerstate.TransactionID
+	resolvedAt     map[int]time.Time
+	rejected       map[int]map[ledgerstate.TransactionID]time.Time
+}
+
+// resolve records the outcome of the conflict for the given node unless it was already resolved.
+func (c *conflictRecord) resolve(node int, outcome ledgerstate.TransactionID, resolvedAt time.Time) {
+	if _, resolved := c.outcomes[node]; resolved {
+		return
+	}
+	c.outcomes[node] = outcome
+	c.resolvedAt[node] = resolvedAt
+}
+
+func newTracker() *tracker {
+	return &tracker{
+		messages:          make(map[tangle.MessageID]*messageRecord),
+		conflictsByBranch: make(map[ledgerstate.BranchID]*conflictRecord),
+	}
+}
+
+func (t *tracker) recordMessageIssued(issuer int, messageID tangle.MessageID, issuedAt time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	record := t.message(messageID)
+	record.issued = true
+	record.issuer = issuer
+	record.issuedAt = issuedAt
+}
+
+func (t *tracker) recordMessageConfirmed(node int, messageID tangle.MessageID, confirmedAt time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	record := t.message(messageID)
+	if _, exists := record.confirmed[node]; !exists {
+		record.confirmed[node] = confirmedAt
+	}
+}
+
+func (t *tracker) recordConflict(first, second ledgerstate.TransactionID, issuedAt time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	record := &conflictRecord{
+		transactionIDs: [2]ledgerstate.TransactionID{first, second},
+		issuedAt:       issuedAt,
+		outcomes:       make(map[int]ledgerstate.TransactionID),
+		resolvedAt:     make(map[int]time.Time),
+		rejected:       make(map[int]map[ledgerstate.TransactionID]time.Time),
+	}
+	t.conflicts = append(t.conflicts, record)
+	t.conflictsByBranch[ledgerstate.NewBranchID(first)] = record
+	t.conflictsByBranch[ledgerstate.NewBranchID(second)] = record
+}
+
+func (t *tracker) recordBranchConfirmed(node int, branchID ledgerstate.BranchID, confirmedAt time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	record, exists := t.conflictsByBranch[branchID]
+	if !exists {
+		return
+	}
+	for _, transactionID := range record.transactionIDs {
+		if ledgerstate.NewBranchID(transactionID) == branchID {
+			record.resolve(node, transactionID, confirmedAt)
+		}
+	}
+}
+
+func (t *tracker) recordVoteFinalized(node int, transactionID ledgerstate.TransactionID, liked bool, finalizedAt time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	record, exists := t.conflictsByBranch[ledgerstate.NewBranchID(transactionID)]
+	if !exists || liked {
+		return
+	}
+	if _, exists := record.rejected[node]; !exists {
+		record.rejected[node] = make(map[ledgerstate.TransactionID]time.Time)
+	}
+	record.rejected[node][transactionID] = finalizedAt
+	if len(record.rejected[node]) == len(record.transactionIDs) {
+		record.resolve(node, ledgerstate.GenesisTransactionID, finalizedAt)
+	}
+}
+
+func (t *tracker) recordIssueFailure() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.issueFailures++
+}
+
+func (t *tracker) recordError(err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.errorCount++
+	if len(t.errors) < maxRecordedErrors {
+		t.errors = append(t.errors, err.Error())
+	}
+}
+
+// message returns the record of the given message and creates it if it does not exist yet.
+func (t *tracker) message(messageID tangle.MessageID) *messageRecord {
+	record, exists := t.messages[messageID]
+	if !exists {
+		record = &messageRecord{confirmed: make(map[int]time.Time)}
+		t.messages[messageID] = record
+	}
+	return record
+}
+
+// report creates the report of the given network from the tracked events.
+func (t *tracker) report(network *Network) *Report {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	nodeCount := len(network.nodes)
+	report := &Report{
+		Seed:   network.options.Seed,
+		Nodes:  make([]*NodeReport, nodeCount),
+		Gossip: network.gossip.statistics(),
+		Errors: ErrorReport{Count: t.errorCount, Errors: append([]string{}, t.errors...)},
+	}
+	for i, node := range network.nodes {
+		report.Nodes[i] = &NodeReport{
+			Index:     i,
+			ID:        node.ID.String(),
+			Mana:      node.Mana,
+			Neighbors: network.Neighbors(i),
+		}
+	}
+
+	var confirmationTimes []time.Duration
+	report.Messages.IssueFailures = t.issueFailures
+	for _, record := range t.messages {
+		if !record.issued {
+			continue
+		}
+		report.Messages.Issued++
+		report.Nodes[record.issuer].Issued++
+
+		var lastConfirmation time.Time
+		for node, confirmedAt := range record.confirmed {
+			report.Nodes[node].Confirmed++
+			if confirmedAt.After(lastConfirmation) {
+				lastConfirmation = confirmedAt
+			}
+		}
+		switch len(record.confirmed) {
+		case 0:
+			report.Messages.Orphaned++
+		case nodeCount:
+			report.Messages.Confirmed++
+			confirmationTimes = append(confirmationTimes, lastConfirmation.Sub(record.issuedAt))
+		default:
+			report.Messages.PartiallyConfirmed++
+		}
+	}
+	if report.Messages.Issued > 0 {
+		report.Messages.OrphanageRate = float64(report.Messages.Orphaned) / float64(report.Messages.Issued)
+	}
+	report.Messages.ConfirmationTime = newDurationStats(confirmationTimes)
+
+	var resolutionTimes []time.Duration
+	report.Conflicts.Issued = len(t.conflicts)
+	for _, record := range t.conflicts {
+		outcomes := make(map[ledgerstate.TransactionID]struct{})
+		var lastResolution time.Time
+		for node, outcome := range record.outcomes {
+			outcomes[outcome] = struct{}{}
+			if record.resolvedAt[node].After(lastResolution) {
+				lastResolution = record.resolvedAt[node]
+			}
+		}
+		switch {
+		case len(outcomes) > 1:
+			report.Conflicts.Diverged++
+			continue
+		case len(record.outcomes) < nodeCount:
+			report.Conflicts.Unresolved++
+			continue
+		}
+		if _, rejected := outcomes[ledgerstate.GenesisTransactionID]; rejected {
+			report.Conflicts.Rejected++
+		} else {
+			report.Conflicts.Accepted++
+		}
+		resolutionTimes = append(resolutionTimes, lastResolution.Sub(record.issuedAt))
+	}
+	report.Conflicts.ResolutionTime = newDurationStats(resolutionTimes)
+
+	return report
+}
@@ -0,0 +1,64 @@
+package simulation
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+// voting replaces FPC in the simulation. Every node casts its initial opinion about a conflict and, once all nodes have
+// voted, the opinion of the mana-weighted majority is finalized. This models an FPC that always reaches agreement, so
+// the simulation focuses on the gossip and tangle layers.
+type voting struct {
+	voterCount int
+	ballots    map[string]*ballot
+	mutex      sync.Mutex
+}
+
+// ballot contains the mana that voted for and against a single conflict.
+type ballot struct {
+	like    float64
+	dislike float64
+	voters  int
+}
+
+func newVoting(voterCount int) *voting {
+	return &voting{
+		voterCount: voterCount,
+		ballots:    make(map[string]*ballot),
+	}
+}
+
+// cast adds the opinion of a node with the given mana to the ballot of the conflict with the given ID.
+func (v *voting) cast(id string, o opinion.Opinion, mana float64) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	b, exists := v.ballots[id]
+	if !exists {
+		b = &ballot{}
+		v.ballots[id] = b
+	}
+	b.voters++
+	if o == opinion.Like {
+		b.like += mana
+		return
+	}
+	b.dislike += mana
+}
+
+// outcome returns the opinion of the majority of the votes cast for the conflict with the given ID, ties are disliked.
+// It returns false if not all nodes have voted yet.
+func (v *voting) outcome(id string) (o opinion.Opinion, finalized bool) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	b, exists := v.ballots[id]
+	if !exists || b.voters < v.voterCount {
+		return opinion.Unknown, false
+	}
+	if b.like > b.dislike {
+		return opinion.Like, true
+	}
+	return opinion.Dislike, true
+}
@@ -68,6 +68,15 @@ func (o *ConsensusManager) PayloadLiked(messageID MessageID) (liked bool) {
 	return
 }
 
+// TransactionLiked returns whether the given Transaction is liked by the ConsensusMechanism.
+func (o *ConsensusManager) TransactionLiked(transactionID ledgerstate.TransactionID) (liked bool) {
+	if o.tangle.Options.ConsensusMechanism == nil {
+		return
+	}
+
+	return o.tangle.Options.ConsensusMechanism.TransactionLiked(transactionID)
+}
+
 // MessageEligible returns whether the given messageID is marked as eligible.
 func (o *ConsensusManager) MessageEligible(messageID MessageID) (eligible bool) {
 	if messageID == EmptyMessageID {
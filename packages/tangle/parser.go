@@ -10,10 +10,12 @@ import (
 	"github.com/iotaledger/hive.go/bytesfilter"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/typeutils"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/pow"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
 )
 
 const (
@@ -516,6 +518,132 @@ func (f *TransactionFilter) getRejectCallback() (result func(msg *Message, err e
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region PayloadManaFilter ///////////////////////////////////////////////////////////////////////////////////////////
+
+// ManaRequirement defines the minimum mana that the issuer of a message needs to have.
+type ManaRequirement struct {
+	// AccessMana is the minimum access mana of the issuer.
+	AccessMana float64
+	// ConsensusMana is the minimum consensus mana of the issuer.
+	ConsensusMana float64
+}
+
+// IssuerManaRetrieverFunc is a function type to retrieve the mana of the issuer of a message (e.g. via the mana plugin).
+type IssuerManaRetrieverFunc func(nodeID identity.ID) (float64, error)
+
+// PayloadManaFilter filters messages whose issuer does not have the mana required for the type of their payload.
+// Messages with payload types without a requirement always pass the filter. Issuers that are unknown to the mana
+// retriever have no mana. Only if the retriever returns ErrIssuerManaUnavailable, because the node does not know the mana
+// of any issuer yet (e.g. it is not synced), the message passes the filter.
+type PayloadManaFilter struct {
+	accessManaRetriever    IssuerManaRetrieverFunc
+	consensusManaRetriever IssuerManaRetrieverFunc
+	requirements           map[payload.Type]ManaRequirement
+	requirementsMutex      sync.RWMutex
+
+	onAcceptCallback func(msg *Message, peer *peer.Peer)
+	onRejectCallback func(msg *Message, err error, peer *peer.Peer)
+
+	onAcceptCallbackMutex sync.RWMutex
+	onRejectCallbackMutex sync.RWMutex
+}
+
+// NewPayloadManaFilter creates a new payload mana filter that retrieves the mana of the issuers with the given functions.
+func NewPayloadManaFilter(accessManaRetriever, consensusManaRetriever IssuerManaRetrieverFunc) *PayloadManaFilter {
+	return &PayloadManaFilter{
+		accessManaRetriever:    accessManaRetriever,
+		consensusManaRetriever: consensusManaRetriever,
+		requirements:           make(map[payload.Type]ManaRequirement),
+	}
+}
+
+// SetRequirement sets the mana that the issuer of a message with the given payload type needs to have.
+func (f *PayloadManaFilter) SetRequirement(payloadType payload.Type, requirement ManaRequirement) {
+	f.requirementsMutex.Lock()
+	defer f.requirementsMutex.Unlock()
+	f.requirements[payloadType] = requirement
+}
+
+// Requirement returns the mana that the issuer of a message with the given payload type needs to have.
+func (f *PayloadManaFilter) Requirement(payloadType payload.Type) (requirement ManaRequirement, exists bool) {
+	f.requirementsMutex.RLock()
+	defer f.requirementsMutex.RUnlock()
+	requirement, exists = f.requirements[payloadType]
+	return
+}
+
+// Filter checks whether the issuer of the given message has the mana required for its payload type and calls the
+// corresponding callback.
+func (f *PayloadManaFilter) Filter(msg *Message, peer *peer.Peer) {
+	payloadType := msg.Payload().Type()
+	requirement, exists := f.Requirement(payloadType)
+	if !exists {
+		f.getAcceptCallback()(msg, peer)
+		return
+	}
+
+	issuerID := identity.NewID(msg.IssuerPublicKey())
+	if err := checkIssuerMana(issuerID, payloadType, "access", requirement.AccessMana, f.accessManaRetriever); err != nil {
+		f.getRejectCallback()(msg, err, peer)
+		return
+	}
+	if err := checkIssuerMana(issuerID, payloadType, "consensus", requirement.ConsensusMana, f.consensusManaRetriever); err != nil {
+		f.getRejectCallback()(msg, err, peer)
+		return
+	}
+	f.getAcceptCallback()(msg, peer)
+}
+
+// OnAccept registers the given callback as the acceptance function of the filter.
+func (f *PayloadManaFilter) OnAccept(callback func(msg *Message, peer *peer.Peer)) {
+	f.onAcceptCallbackMutex.Lock()
+	f.onAcceptCallback = callback
+	f.onAcceptCallbackMutex.Unlock()
+}
+
+// OnReject registers the given callback as the rejection function of the filter.
+func (f *PayloadManaFilter) OnReject(callback func(msg *Message, err error, peer *peer.Peer)) {
+	f.onRejectCallbackMutex.Lock()
+	f.onRejectCallback = callback
+	f.onRejectCallbackMutex.Unlock()
+}
+
+func (f *PayloadManaFilter) getAcceptCallback() (result func(msg *Message, peer *peer.Peer)) {
+	f.onAcceptCallbackMutex.RLock()
+	result = f.onAcceptCallback
+	f.onAcceptCallbackMutex.RUnlock()
+	return
+}
+
+func (f *PayloadManaFilter) getRejectCallback() (result func(msg *Message, err error, peer *peer.Peer)) {
+	f.onRejectCallbackMutex.RLock()
+	result = f.onRejectCallback
+	f.onRejectCallbackMutex.RUnlock()
+	return
+}
+
+// checkIssuerMana returns an error if the issuer has less than the required mana of the given type. It fails open only
+// if the mana is unavailable on this node, every other retriever error rejects the message.
+func checkIssuerMana(issuerID identity.ID, payloadType payload.Type, manaType string, required float64, retriever IssuerManaRetrieverFunc) error {
+	if required <= 0 || retriever == nil {
+		return nil
+	}
+	issuerMana, err := retriever(issuerID)
+	if err != nil {
+		if errors.Is(err, ErrIssuerManaUnavailable) {
+			return nil
+		}
+		return errors.Errorf("failed to retrieve %s mana of issuer %s: %w", manaType, issuerID, err)
+	}
+	if issuerMana < required {
+		return errors.Errorf("%s payload requires %g %s mana, but issuer %s has %g: %w",
+			payloadType, required, manaType, issuerID, issuerMana, ErrInsufficientIssuerMana)
+	}
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region Errors ///////////////////////////////////////////////////////////////////////////////////////////////////////
 
 var (
@@ -533,6 +661,13 @@ var (
 
 	// ErrInvalidMessageAndTransactionTimestamp is returned when the message its transaction timestamps are invalid.
 	ErrInvalidMessageAndTransactionTimestamp = fmt.Errorf("invalid message and transaction timestamp")
+
+	// ErrInsufficientIssuerMana is returned when the issuer of a message does not have the mana required for its payload.
+	ErrInsufficientIssuerMana = errors.New("insufficient mana of the message issuer")
+
+	// ErrIssuerManaUnavailable is returned by an IssuerManaRetrieverFunc when the node cannot determine the mana of any
+	// issuer, e.g. because it is not synced.
+	ErrIssuerManaUnavailable = errors.New("issuer mana unavailable")
 )
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
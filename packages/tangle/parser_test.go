@@ -151,6 +151,68 @@ func TestPowFilter_Filter(t *testing.T) {
 	m.AssertExpectations(t)
 }
 
+func TestPayloadManaFilter_Filter(t *testing.T) {
+	richIssuer := identity.GenerateIdentity()
+	poorIssuer := identity.GenerateIdentity()
+	unknownIssuer := identity.GenerateIdentity()
+	unsyncedIssuer := identity.GenerateIdentity()
+	accessMana := func(nodeID identity.ID) (float64, error) {
+		switch nodeID {
+		case richIssuer.ID():
+			return 100, nil
+		case poorIssuer.ID():
+			return 5, nil
+		case unsyncedIssuer.ID():
+			return 0, ErrIssuerManaUnavailable
+		default:
+			return 0, errors.New("mana not available")
+		}
+	}
+	filter := NewPayloadManaFilter(accessMana, nil)
+	filter.SetRequirement(payload.GenericDataPayloadType, ManaRequirement{AccessMana: 10, ConsensusMana: 10})
+
+	// set callbacks
+	m := &messageCallbackMock{}
+	filter.OnAccept(m.Accept)
+	filter.OnReject(m.Reject)
+
+	newMessage := func(issuer *identity.Identity, p payload.Payload) *Message {
+		return &Message{payload: p, issuerPublicKey: issuer.PublicKey()}
+	}
+
+	t.Run("accept issuer with enough mana", func(t *testing.T) {
+		msg := newMessage(richIssuer, payload.NewGenericDataPayload([]byte("hello world")))
+		m.On("Accept", msg, testPeer)
+		filter.Filter(msg, testPeer)
+	})
+
+	t.Run("reject issuer with too little mana", func(t *testing.T) {
+		msg := newMessage(poorIssuer, payload.NewGenericDataPayload([]byte("hello world")))
+		m.On("Reject", msg, mock.MatchedBy(func(err error) bool { return errors.Is(err, ErrInsufficientIssuerMana) }), testPeer)
+		filter.Filter(msg, testPeer)
+	})
+
+	t.Run("reject issuer with unknown mana", func(t *testing.T) {
+		msg := newMessage(unknownIssuer, payload.NewGenericDataPayload([]byte("hello world")))
+		m.On("Reject", msg, mock.Anything, testPeer)
+		filter.Filter(msg, testPeer)
+	})
+
+	t.Run("accept issuer if mana is unavailable", func(t *testing.T) {
+		msg := newMessage(unsyncedIssuer, payload.NewGenericDataPayload([]byte("hello world")))
+		m.On("Accept", msg, testPeer)
+		filter.Filter(msg, testPeer)
+	})
+
+	t.Run("accept payload type without requirement", func(t *testing.T) {
+		msg := newMessage(poorIssuer, &testTxPayload{})
+		m.On("Accept", msg, testPeer)
+		filter.Filter(msg, testPeer)
+	})
+
+	m.AssertExpectations(t)
+}
+
 type bytesCallbackMock struct{ mock.Mock }
 
 func (m *bytesCallbackMock) Accept(msg []byte, p *peer.Peer)            { m.Called(msg, p) }
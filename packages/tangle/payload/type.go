@@ -52,6 +52,20 @@ func NewType(typeNumber uint32, typeName string, typeUnmarshaler UnmarshalerFunc
 	return
 }
 
+// TypeByName returns the registered Type with the given name.
+func TypeByName(typeName string) (payloadType Type, exists bool) {
+	typeRegisterMutex.RLock()
+	defer typeRegisterMutex.RUnlock()
+
+	for registeredType, definition := range typeRegister {
+		if definition.Name == typeName {
+			return registeredType, true
+		}
+	}
+
+	return
+}
+
 // TypeFromBytes unmarshals a Type from a sequence of bytes.
 func TypeFromBytes(typeBytes []byte) (typeResult Type, consumedBytes int, err error) {
 	marshalUtil := marshalutil.New(typeBytes)
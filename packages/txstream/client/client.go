@@ -17,6 +17,7 @@ type Client struct {
 	chSend        chan txstream.Message
 	chSubscribe   chan ledgerstate.Address
 	chUnsubscribe chan ledgerstate.Address
+	chConnected   chan struct{}
 	shutdown      chan bool
 	Events        Events
 }
@@ -66,6 +67,7 @@ func New(clientID string, log *logger.Logger, dial DialFunc) *Client {
 		chSend:        make(chan txstream.Message),
 		chSubscribe:   make(chan ledgerstate.Address),
 		chUnsubscribe: make(chan ledgerstate.Address),
+		chConnected:   make(chan struct{}),
 		shutdown:      make(chan bool),
 		Events: Events{
 			TransactionReceived:        events.NewEvent(handleTransactionReceived),
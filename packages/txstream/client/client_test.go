@@ -304,3 +304,48 @@ func TestSubscribe(t *testing.T) {
 	)
 	require.EqualValues(t, txMsg.Tx.ID(), reqTx.ID())
 }
+
+func TestResubscribeAfterReconnect(t *testing.T) {
+	ledger := utxodbledger.New(log)
+	t.Cleanup(ledger.Detach)
+
+	done := make(chan struct{})
+	t.Cleanup(func() { close(done) })
+
+	serverConns := make(chan net.Conn, 2)
+	dial := DialFunc(func() (string, net.Conn, error) {
+		conn1, conn2 := net.Pipe()
+		serverConns <- conn2
+		go server.Run(conn2, log.Named("txstream/server"), ledger, done)
+		return "pipe", conn1, nil
+	})
+
+	n := New("test", log.Named("txstream/client"), dial)
+	t.Cleanup(n.Close)
+
+	_, chainAddress := createAliasChain(t, ledger, creatorIndex, stateControlIndex, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 100})
+	n.Subscribe(chainAddress)
+
+	// drop the connection on the server side, so that the client has to reconnect
+	require.NoError(t, (<-serverConns).Close())
+	select {
+	case <-serverConns:
+	case <-time.After(2 * retryAfter):
+		t.Fatalf("timeout")
+	}
+
+	// the subscription is restored on the new connection, so we receive the request either in the backlog or as a new
+	// transaction
+	var reqTx *ledgerstate.Transaction
+	send(t, n,
+		func() {
+			reqTx = postRequest(t, ledger, 2, chainAddress)
+		},
+		func(msg txstream.Message) bool {
+			if msg, ok := msg.(*txstream.MsgTransaction); ok {
+				return msg.Tx.ID() == reqTx.ID()
+			}
+			return false
+		},
+	)
+}
@@ -64,6 +64,8 @@ func (n *Client) connect(dial DialFunc, msgChopper *chopper.Chopper) bool {
 		bconn.Close()
 	}()
 	n.Events.Connected.Trigger()
+	// the server forgets the subscriptions of a closed connection, so we restore them on every (re)connect
+	go n.notifyConnected()
 
 	n.log.Debugf("established connection with server at %s", addr)
 
@@ -96,6 +98,8 @@ func (n *Client) connect(dial DialFunc, msgChopper *chopper.Chopper) bool {
 				n.log.Warnw("bconn read error", "err", err)
 			}
 		}
+		// the read loop ends when the server closes the connection, which we only notice through the Close event
+		_ = bconn.Close()
 	}()
 
 	// send client ID
@@ -159,9 +163,21 @@ func (n *Client) decodeReceivedMessage(data []byte, msgChopper *chopper.Chopper)
 	return nil
 }
 
-// sendMessage is a thread-safe request to send a message to the server
+// notifyConnected signals the subscriptions loop that a new connection has been established.
+func (n *Client) notifyConnected() {
+	select {
+	case n.chConnected <- struct{}{}:
+	case <-n.shutdown:
+	}
+}
+
+// sendMessage is a thread-safe request to send a message to the server. It blocks until the client is connected or
+// shut down.
 func (n *Client) sendMessage(msg txstream.Message) {
-	n.chSend <- msg
+	select {
+	case n.chSend <- msg:
+	case <-n.shutdown:
+	}
 }
 
 // send writes a message into the server connection
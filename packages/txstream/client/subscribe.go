@@ -7,14 +7,21 @@ import (
 	"github.com/iotaledger/goshimmer/packages/txstream"
 )
 
-// Subscribe subscribes to real-time updates for the given address
+// Subscribe subscribes to real-time updates for the given address. The subscriptions are restored automatically when
+// the client reconnects to the server.
 func (n *Client) Subscribe(addr ledgerstate.Address) {
-	n.chSubscribe <- addr
+	select {
+	case n.chSubscribe <- addr:
+	case <-n.shutdown:
+	}
 }
 
 // Unsubscribe unsubscribes the address
 func (n *Client) Unsubscribe(addr ledgerstate.Address) {
-	n.chUnsubscribe <- addr
+	select {
+	case n.chUnsubscribe <- addr:
+	case <-n.shutdown:
+	}
 }
 
 func (n *Client) subscriptionsLoop() {
@@ -37,6 +44,9 @@ func (n *Client) subscriptionsLoop() {
 			}
 		case addr := <-n.chUnsubscribe:
 			delete(subscriptions, addr.Array())
+		case <-n.chConnected:
+			// a new connection does not know any subscriptions yet
+			n.sendSubscriptions(subscriptions)
 		case <-ticker1m.C:
 			// send subscriptions once every minute
 			n.sendSubscriptions(subscriptions)
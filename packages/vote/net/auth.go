@@ -0,0 +1,205 @@
+package net
+
+import (
+	"crypto/rand"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// nonceLength is the length of the random nonce of a query.
+	nonceLength = 16
+	// maxNoncesPerIssuer is the maximum number of nonces that are remembered for a single querier.
+	maxNoncesPerIssuer = 1024
+	// maxNonceIssuers is the maximum number of queriers whose nonces are remembered.
+	maxNonceIssuers = 4096
+)
+
+var (
+	// ErrUnsignedQuery is returned when a query does not contain a signature of the querier.
+	ErrUnsignedQuery = errors.New("query is not signed")
+	// ErrInvalidQuerySignature is returned when the signature of a query is invalid.
+	ErrInvalidQuerySignature = errors.New("invalid query signature")
+	// ErrQueryExpired is returned when the timestamp of a query is too far from the current time.
+	ErrQueryExpired = errors.New("query timestamp out of range")
+	// ErrQueryReplayed is returned when the nonce of a signed query has already been seen.
+	ErrQueryReplayed = errors.New("query nonce already seen")
+	// ErrUnsignedReply is returned when a reply does not contain a signature of the queried node.
+	ErrUnsignedReply = errors.New("reply is not signed")
+	// ErrInvalidReplySignature is returned when the signature of a reply is invalid or was not made by the queried node.
+	ErrInvalidReplySignature = errors.New("invalid reply signature")
+)
+
+// Signer signs queries and replies with the identity of the node.
+type Signer interface {
+	// PublicKey returns the public key of the node.
+	PublicKey() ed25519.PublicKey
+	// Sign signs the given data with the private key of the node.
+	Sign(data []byte) ed25519.Signature
+}
+
+// signRequest adds a fresh nonce and timestamp to the request and signs it with the given signer.
+func signRequest(request *QueryRequest, signer Signer, now time.Time) error {
+	request.Nonce = make([]byte, nonceLength)
+	if _, err := rand.Read(request.Nonce); err != nil {
+		return errors.Errorf("failed to create nonce: %w", err)
+	}
+	request.Timestamp = now.UnixNano()
+	request.IssuerPublicKey = signer.PublicKey().Bytes()
+	request.Signature = signer.Sign(requestEssence(request)).Bytes()
+	return nil
+}
+
+// verifyRequest checks the signature and the timestamp of the request and returns the public key of the querier.
+func verifyRequest(request *QueryRequest, now time.Time, maxAge time.Duration) (publicKey ed25519.PublicKey, err error) {
+	if len(request.Signature) == 0 {
+		return publicKey, ErrUnsignedQuery
+	}
+	if publicKey, _, err = ed25519.PublicKeyFromBytes(request.IssuerPublicKey); err != nil {
+		return publicKey, errors.Errorf("failed to parse querier public key: %w", ErrInvalidQuerySignature)
+	}
+	signature, _, err := ed25519.SignatureFromBytes(request.Signature)
+	if err != nil || !publicKey.VerifySignature(requestEssence(request), signature) {
+		return publicKey, ErrInvalidQuerySignature
+	}
+	if age := now.Sub(time.Unix(0, request.Timestamp)); age > maxAge || age < -maxAge {
+		return publicKey, errors.Errorf("query is %s old: %w", age, ErrQueryExpired)
+	}
+	return publicKey, nil
+}
+
+// nonceCache remembers the nonces of the signed queries of every querier until their timestamps are out of range, so
+// that a query cannot be replayed while it is still accepted. Its size is bounded: a querier that exceeds
+// maxNoncesPerIssuer nonces within the accepted age is rejected until its nonces expire, and once maxNonceIssuers
+// queriers are known, the querier that has been idle for the longest time is forgotten.
+type nonceCache struct {
+	maxAge      time.Duration
+	issuers     map[identity.ID]*issuerNonces
+	issuersLock sync.Mutex
+}
+
+// issuerNonces holds the nonces of a single querier together with the time at which they expire.
+type issuerNonces struct {
+	nonces   map[string]time.Time
+	lastSeen time.Time
+}
+
+// newNonceCache creates a nonceCache for queries whose timestamps may be off by the given maximum age.
+func newNonceCache(maxAge time.Duration) *nonceCache {
+	return &nonceCache{
+		maxAge:  maxAge,
+		issuers: make(map[identity.ID]*issuerNonces),
+	}
+}
+
+// add records the nonce of a verified query of the given querier. It returns an error if the nonce has already been
+// seen or if the querier has too many nonces that have not expired yet.
+func (c *nonceCache) add(issuerID identity.ID, nonce []byte, timestamp int64, now time.Time) error {
+	c.issuersLock.Lock()
+	defer c.issuersLock.Unlock()
+
+	issuer, exists := c.issuers[issuerID]
+	if !exists {
+		if len(c.issuers) >= maxNonceIssuers {
+			c.evictIdlestIssuer()
+		}
+		issuer = &issuerNonces{nonces: make(map[string]time.Time)}
+		c.issuers[issuerID] = issuer
+	}
+	issuer.lastSeen = now
+
+	if _, seen := issuer.nonces[string(nonce)]; seen {
+		return ErrQueryReplayed
+	}
+	if len(issuer.nonces) >= maxNoncesPerIssuer {
+		issuer.pruneExpired(now)
+		if len(issuer.nonces) >= maxNoncesPerIssuer {
+			return errors.Errorf("querier %s sent more than %d queries within %s: %w", issuerID, maxNoncesPerIssuer, c.maxAge, ErrQueryReplayed)
+		}
+	}
+	// the query is rejected as expired once its timestamp is older than the maximum age
+	issuer.nonces[string(nonce)] = time.Unix(0, timestamp).Add(c.maxAge)
+
+	return nil
+}
+
+// evictIdlestIssuer forgets the querier that has not sent a query for the longest time.
+func (c *nonceCache) evictIdlestIssuer() {
+	var idlestID identity.ID
+	var idlestIssuer *issuerNonces
+	for issuerID, issuer := range c.issuers {
+		if idlestIssuer == nil || issuer.lastSeen.Before(idlestIssuer.lastSeen) {
+			idlestID, idlestIssuer = issuerID, issuer
+		}
+	}
+	delete(c.issuers, idlestID)
+}
+
+// pruneExpired removes the nonces of the queries that would be rejected as expired anyway.
+func (i *issuerNonces) pruneExpired(now time.Time) {
+	for nonce, expiry := range i.nonces {
+		if now.After(expiry) {
+			delete(i.nonces, nonce)
+		}
+	}
+}
+
+// signReply signs the opinions of the reply together with the request they answer.
+func signReply(reply *QueryReply, request *QueryRequest, signer Signer) {
+	reply.PublicKey = signer.PublicKey().Bytes()
+	reply.Signature = signer.Sign(replyEssence(reply, request)).Bytes()
+}
+
+// verifyReply checks that the reply answers the given request and was signed by the node with the given public key.
+func verifyReply(reply *QueryReply, request *QueryRequest, publicKey ed25519.PublicKey) error {
+	if len(reply.Signature) == 0 {
+		return ErrUnsignedReply
+	}
+	replyPublicKey, _, err := ed25519.PublicKeyFromBytes(reply.PublicKey)
+	if err != nil || replyPublicKey != publicKey {
+		return errors.Errorf("reply signed by %s instead of %s: %w", replyPublicKey, publicKey, ErrInvalidReplySignature)
+	}
+	signature, _, err := ed25519.SignatureFromBytes(reply.Signature)
+	if err != nil || !publicKey.VerifySignature(replyEssence(reply, request), signature) {
+		return ErrInvalidReplySignature
+	}
+	return nil
+}
+
+// requestEssence returns the signed content of a request.
+func requestEssence(request *QueryRequest) []byte {
+	marshalUtil := marshalutil.New()
+	writeStrings(marshalUtil, request.ConflictIDs)
+	writeStrings(marshalUtil, request.TimestampIDs)
+	marshalUtil.WriteBytes(request.IssuerPublicKey)
+	marshalUtil.WriteInt64(request.Timestamp)
+	marshalUtil.WriteBytes(request.Nonce)
+	return marshalUtil.Bytes()
+}
+
+// replyEssence returns the signed content of a reply, which binds the opinions to the nonce and the IDs of the request.
+func replyEssence(reply *QueryReply, request *QueryRequest) []byte {
+	requestHash := blake2b.Sum256(requestEssence(request))
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteBytes(requestHash[:])
+	marshalUtil.WriteUint32(uint32(len(reply.Opinion)))
+	for _, opinion := range reply.Opinion {
+		marshalUtil.WriteInt32(opinion)
+	}
+	marshalUtil.WriteBytes(reply.PublicKey)
+	return marshalUtil.Bytes()
+}
+
+func writeStrings(marshalUtil *marshalutil.MarshalUtil, values []string) {
+	marshalUtil.WriteUint32(uint32(len(values)))
+	for _, value := range values {
+		marshalUtil.WriteUint32(uint32(len(value)))
+		marshalUtil.WriteBytes([]byte(value))
+	}
+}
@@ -0,0 +1,307 @@
+package net
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+const (
+	// DefaultBatchWindow is the default time that a query waits for other queries to the same node to be sent with.
+	DefaultBatchWindow = 50 * time.Millisecond
+	// DefaultQueryTimeout is the default timeout of a batched query.
+	DefaultQueryTimeout = 6500 * time.Millisecond
+	// DefaultIdleConnectionTimeout is the default time after which unused connections are closed.
+	DefaultIdleConnectionTimeout = 5 * time.Minute
+)
+
+// ErrClientClosed is returned when a query is issued on a closed Client.
+var ErrClientClosed = errors.New("client is closed")
+
+// Client queries the opinions of other nodes. It keeps the connections to the queried nodes open across rounds and
+// sends concurrent queries to the same node as a single request.
+type Client struct {
+	signer          Signer
+	tlsCertificate  *tls.Certificate
+	unsignedReplies bool
+	batchWindow     time.Duration
+	queryTimeout    time.Duration
+	idleTimeout     time.Duration
+	netRxEvent      *events.Event
+	netTxEvent      *events.Event
+
+	connections map[string]*connection
+	batches     map[string]*batch
+	lastPruning time.Time
+	closed      bool
+	mutex       sync.Mutex
+}
+
+// connection is a pooled connection to a node.
+type connection struct {
+	conn     *grpc.ClientConn
+	client   VoterQueryClient
+	lastUsed time.Time
+}
+
+// batch collects the IDs of the queries to a node until it is sent.
+type batch struct {
+	request        *QueryRequest
+	conflictIndex  map[string]int
+	timestampIndex map[string]int
+	done           chan struct{}
+	opinions       []int32
+	err            error
+}
+
+// NewClient creates a new Client with the given options.
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{
+		batchWindow:  DefaultBatchWindow,
+		queryTimeout: DefaultQueryTimeout,
+		idleTimeout:  DefaultIdleConnectionTimeout,
+		connections:  make(map[string]*connection),
+		batches:      make(map[string]*batch),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ClientOption is a function that configures a Client.
+type ClientOption func(*Client)
+
+// WithClientSigner signs all queries with the given signer, so that the queried nodes can identify the querier.
+func WithClientSigner(signer Signer) ClientOption {
+	return func(c *Client) {
+		c.signer = signer
+	}
+}
+
+// WithClientTLS connects to the queried nodes over TLS with the given certificate and only accepts the certificates of
+// the expected nodes.
+func WithClientTLS(certificate tls.Certificate) ClientOption {
+	return func(c *Client) {
+		c.tlsCertificate = &certificate
+	}
+}
+
+// WithUnsignedReplies accepts replies that are not signed by the queried node. Signed replies are still verified.
+func WithUnsignedReplies(accept bool) ClientOption {
+	return func(c *Client) {
+		c.unsignedReplies = accept
+	}
+}
+
+// WithBatchWindow sets the time that a query waits for other queries to the same node. A window of 0 disables batching.
+func WithBatchWindow(window time.Duration) ClientOption {
+	return func(c *Client) {
+		c.batchWindow = window
+	}
+}
+
+// WithQueryTimeout sets the timeout of the requests.
+func WithQueryTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.queryTimeout = timeout
+	}
+}
+
+// WithIdleConnectionTimeout sets the time after which unused connections are closed.
+func WithIdleConnectionTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.idleTimeout = timeout
+	}
+}
+
+// WithClientEvents sets the events that are triggered with the size of the received and sent messages.
+func WithClientEvents(netRxEvent, netTxEvent *events.Event) ClientOption {
+	return func(c *Client) {
+		c.netRxEvent = netRxEvent
+		c.netTxEvent = netTxEvent
+	}
+}
+
+// Query queries the node with the given public key at the given address for its opinions on the given conflicts and
+// timestamps.
+func (c *Client) Query(ctx context.Context, address string, publicKey ed25519.PublicKey, conflictIDs, timestampIDs []string) (opinion.Opinions, error) {
+	key := address + "/" + publicKey.String()
+
+	c.mutex.Lock()
+	if c.closed {
+		c.mutex.Unlock()
+		return nil, ErrClientClosed
+	}
+	b, exists := c.batches[key]
+	if !exists {
+		b = newBatch()
+		if c.batchWindow > 0 {
+			c.batches[key] = b
+		}
+		go c.send(key, address, publicKey, b)
+	}
+	conflictPositions := b.addIDs(&b.request.ConflictIDs, b.conflictIndex, conflictIDs)
+	timestampPositions := b.addIDs(&b.request.TimestampIDs, b.timestampIndex, timestampIDs)
+	c.mutex.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-b.done:
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	opinions := make(opinion.Opinions, 0, len(conflictPositions)+len(timestampPositions))
+	for _, position := range conflictPositions {
+		opinions = append(opinions, opinion.ConvertInt32Opinion(b.opinions[position]))
+	}
+	for _, position := range timestampPositions {
+		opinions = append(opinions, opinion.ConvertInt32Opinion(b.opinions[len(b.request.ConflictIDs)+position]))
+	}
+	return opinions, nil
+}
+
+// Close closes all connections of the client.
+func (c *Client) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.closed = true
+	var err error
+	for key, conn := range c.connections {
+		if closeErr := conn.conn.Close(); closeErr != nil {
+			err = errors.Errorf("failed to close connection to %s: %w", key, closeErr)
+		}
+		delete(c.connections, key)
+	}
+	return err
+}
+
+// send waits for the batch window, then sends the batched request and verifies the reply.
+func (c *Client) send(key, address string, publicKey ed25519.PublicKey, b *batch) {
+	defer close(b.done)
+
+	if c.batchWindow > 0 {
+		time.Sleep(c.batchWindow)
+	}
+
+	c.mutex.Lock()
+	if c.batches[key] == b {
+		delete(c.batches, key)
+	}
+	if c.closed {
+		c.mutex.Unlock()
+		b.err = ErrClientClosed
+		return
+	}
+	client, err := c.client(key, address, publicKey)
+	c.mutex.Unlock()
+	if err != nil {
+		b.err = err
+		return
+	}
+
+	if c.signer != nil {
+		if b.err = signRequest(b.request, c.signer, time.Now()); b.err != nil {
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.queryTimeout)
+	defer cancel()
+	reply, err := client.Opinion(ctx, b.request)
+	if err != nil {
+		b.err = errors.Errorf("failed to send query to %s: %w", address, err)
+		return
+	}
+	if c.netRxEvent != nil {
+		c.netRxEvent.Trigger(uint64(proto.Size(reply)))
+	}
+	if c.netTxEvent != nil {
+		c.netTxEvent.Trigger(uint64(proto.Size(b.request)))
+	}
+
+	if err = verifyReply(reply, b.request, publicKey); err != nil && !(c.unsignedReplies && errors.Is(err, ErrUnsignedReply)) {
+		b.err = err
+		return
+	}
+	if len(reply.Opinion) != len(b.request.ConflictIDs)+len(b.request.TimestampIDs) {
+		b.err = errors.Errorf("received %d opinions for %d IDs", len(reply.Opinion), len(b.request.ConflictIDs)+len(b.request.TimestampIDs))
+		return
+	}
+	b.opinions = reply.Opinion
+}
+
+// client returns the pooled client of the node with the given address and public key. It needs to be called with the
+// lock of the Client.
+func (c *Client) client(key, address string, publicKey ed25519.PublicKey) (VoterQueryClient, error) {
+	now := time.Now()
+	c.prune(now)
+
+	if conn, exists := c.connections[key]; exists {
+		conn.lastUsed = now
+		return conn.client, nil
+	}
+
+	dialOption := grpc.WithInsecure()
+	if c.tlsCertificate != nil {
+		dialOption = grpc.WithTransportCredentials(credentials.NewTLS(clientTLSConfig(*c.tlsCertificate, publicKey)))
+	}
+	conn, err := grpc.Dial(address, dialOption)
+	if err != nil {
+		return nil, errors.Errorf("unable to connect to FPC service: %w", err)
+	}
+	c.connections[key] = &connection{conn: conn, client: NewVoterQueryClient(conn), lastUsed: now}
+	return c.connections[key].client, nil
+}
+
+// prune closes the connections that have not been used for the idle timeout.
+func (c *Client) prune(now time.Time) {
+	if now.Sub(c.lastPruning) < c.idleTimeout/2 {
+		return
+	}
+	c.lastPruning = now
+	for key, conn := range c.connections {
+		if now.Sub(conn.lastUsed) > c.idleTimeout {
+			_ = conn.conn.Close()
+			delete(c.connections, key)
+		}
+	}
+}
+
+func newBatch() *batch {
+	return &batch{
+		request:        &QueryRequest{},
+		conflictIndex:  make(map[string]int),
+		timestampIndex: make(map[string]int),
+		done:           make(chan struct{}),
+	}
+}
+
+// addIDs adds the given IDs to the batch and returns their positions in the request.
+func (b *batch) addIDs(requestIDs *[]string, index map[string]int, ids []string) []int {
+	positions := make([]int, len(ids))
+	for i, id := range ids {
+		position, exists := index[id]
+		if !exists {
+			position = len(*requestIDs)
+			index[id] = position
+			*requestIDs = append(*requestIDs, id)
+		}
+		positions[i] = position
+	}
+	return positions
+}
@@ -0,0 +1,189 @@
+package net
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+func TestQuery(t *testing.T) {
+	serverIdentity := identity.GenerateLocalIdentity()
+	clientIdentity := identity.GenerateLocalIdentity()
+	serverCertificate, err := NewCertificate(serverIdentity)
+	require.NoError(t, err)
+	clientCertificate, err := NewCertificate(clientIdentity)
+	require.NoError(t, err)
+
+	// the querier may send two queries in a burst
+	rateLimiter := NewRateLimiter(func(querierID identity.ID) float64 {
+		if querierID == clientIdentity.ID() {
+			return 2
+		}
+		return 0
+	}, time.Second)
+	address := startServer(t, WithServerSigner(serverIdentity), WithServerTLS(serverCertificate),
+		WithRequireAuthentication(true), WithRateLimiter(rateLimiter))
+
+	client := NewClient(WithClientSigner(clientIdentity), WithClientTLS(clientCertificate), WithBatchWindow(100*time.Millisecond))
+	defer client.Close()
+
+	// concurrent queries are sent as a single request
+	var wg sync.WaitGroup
+	results := make([]opinion.Opinions, 3)
+	queries := [][]string{{"like"}, {"dislike", "like"}, {"unknown"}}
+	for i := range queries {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var queryErr error
+			results[i], queryErr = client.Query(context.Background(), address, serverIdentity.PublicKey(), queries[i], nil)
+			assert.NoError(t, queryErr)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, opinion.Opinions{opinion.Like}, results[0])
+	assert.Equal(t, opinion.Opinions{opinion.Dislike, opinion.Like}, results[1])
+	assert.Equal(t, opinion.Opinions{opinion.Unknown}, results[2])
+
+	// the connection is reused for the second query, which consumes the last query of the burst
+	opinions, err := client.Query(context.Background(), address, serverIdentity.PublicKey(), nil, []string{"like"})
+	require.NoError(t, err)
+	assert.Equal(t, opinion.Opinions{opinion.Like}, opinions)
+	assert.Len(t, client.connections, 1)
+	_, err = client.Query(context.Background(), address, serverIdentity.PublicKey(), []string{"like"}, nil)
+	assert.Error(t, err)
+
+	// the certificate of the server does not belong to the expected node
+	_, err = client.Query(context.Background(), address, clientIdentity.PublicKey(), []string{"like"}, nil)
+	assert.Error(t, err)
+
+	// anonymous queries are rejected
+	anonymousClient := NewClient(WithClientTLS(clientCertificate), WithBatchWindow(0))
+	defer anonymousClient.Close()
+	_, err = anonymousClient.Query(context.Background(), address, serverIdentity.PublicKey(), []string{"like"}, nil)
+	assert.Error(t, err)
+}
+
+func TestSignatures(t *testing.T) {
+	querier := identity.GenerateLocalIdentity()
+	queried := identity.GenerateLocalIdentity()
+	now := time.Now()
+
+	request := &QueryRequest{ConflictIDs: []string{"a", "b"}, TimestampIDs: []string{"c"}}
+	_, err := verifyRequest(request, now, time.Minute)
+	assert.ErrorIs(t, err, ErrUnsignedQuery)
+	require.NoError(t, signRequest(request, querier, now))
+	publicKey, err := verifyRequest(request, now, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, querier.PublicKey(), publicKey)
+	_, err = verifyRequest(request, now.Add(2*time.Minute), time.Minute)
+	assert.ErrorIs(t, err, ErrQueryExpired)
+
+	reply := &QueryReply{Opinion: []int32{1, 2, 4}}
+	assert.ErrorIs(t, verifyReply(reply, request, queried.PublicKey()), ErrUnsignedReply)
+	signReply(reply, request, queried)
+	assert.NoError(t, verifyReply(reply, request, queried.PublicKey()))
+	assert.ErrorIs(t, verifyReply(reply, request, querier.PublicKey()), ErrInvalidReplySignature)
+
+	// the reply cannot be replayed for another request or with other opinions
+	otherRequest := &QueryRequest{ConflictIDs: []string{"a", "b"}, TimestampIDs: []string{"c"}}
+	require.NoError(t, signRequest(otherRequest, querier, now))
+	assert.ErrorIs(t, verifyReply(reply, otherRequest, queried.PublicKey()), ErrInvalidReplySignature)
+	reply.Opinion[0] = 2
+	assert.ErrorIs(t, verifyReply(reply, request, queried.PublicKey()), ErrInvalidReplySignature)
+
+	request.ConflictIDs[0] = "x"
+	_, err = verifyRequest(request, now, time.Minute)
+	assert.ErrorIs(t, err, ErrInvalidQuerySignature)
+}
+
+func TestReplayedQuery(t *testing.T) {
+	querier := identity.GenerateLocalIdentity()
+	vs := New(&testVoter{}, func(string, vote.ObjectType) opinion.Opinion {
+		return opinion.Like
+	}, "", nil, nil, nil)
+
+	request := &QueryRequest{ConflictIDs: []string{"a"}}
+	require.NoError(t, signRequest(request, querier, time.Now()))
+	_, err := vs.Opinion(context.Background(), request)
+	require.NoError(t, err)
+
+	// the same signed query is rejected while its timestamp is still in range
+	_, err = vs.Opinion(context.Background(), request)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Contains(t, err.Error(), ErrQueryReplayed.Error())
+}
+
+func TestNonceCache(t *testing.T) {
+	querier := identity.GenerateLocalIdentity().ID()
+	cache := newNonceCache(time.Minute)
+	now := time.Now()
+
+	assert.NoError(t, cache.add(querier, []byte("nonce"), now.UnixNano(), now))
+	assert.ErrorIs(t, cache.add(querier, []byte("nonce"), now.UnixNano(), now), ErrQueryReplayed)
+	// the same nonce of another querier is not a replay
+	idleQuerier := identity.GenerateLocalIdentity().ID()
+	assert.NoError(t, cache.add(idleQuerier, []byte("nonce"), now.UnixNano(), now))
+
+	// a querier can not remember more than maxNoncesPerIssuer nonces that have not expired yet
+	for i := 1; i < maxNoncesPerIssuer; i++ {
+		require.NoError(t, cache.add(querier, []byte{byte(i), byte(i >> 8)}, now.UnixNano(), now))
+	}
+	assert.ErrorIs(t, cache.add(querier, []byte("another nonce"), now.UnixNano(), now), ErrQueryReplayed)
+	// until they expire
+	later := now.Add(2 * time.Minute)
+	assert.NoError(t, cache.add(querier, []byte("another nonce"), later.UnixNano(), later))
+	assert.Len(t, cache.issuers[querier].nonces, 1)
+
+	// the querier that has been idle for the longest time is forgotten once maxNonceIssuers queriers are known
+	for i := len(cache.issuers); i < maxNonceIssuers; i++ {
+		require.NoError(t, cache.add(identity.GenerateLocalIdentity().ID(), []byte("nonce"), later.UnixNano(), later))
+	}
+	assert.NoError(t, cache.add(identity.GenerateLocalIdentity().ID(), []byte("nonce"), later.UnixNano(), later.Add(time.Second)))
+	assert.Len(t, cache.issuers, maxNonceIssuers)
+	assert.NotContains(t, cache.issuers, idleQuerier)
+	assert.Contains(t, cache.issuers, querier)
+}
+
+func startServer(t *testing.T, opts ...ServerOption) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	opinions := map[string]opinion.Opinion{"like": opinion.Like, "dislike": opinion.Dislike}
+	vs := New(&testVoter{}, func(id string, objectType vote.ObjectType) opinion.Opinion {
+		return opinions[id]
+	}, listener.Addr().String(), nil, nil, nil, opts...)
+	RegisterVoterQueryServer(vs.grpcServer, vs)
+	go func() {
+		_ = vs.grpcServer.Serve(listener)
+	}()
+	t.Cleanup(vs.Shutdown)
+
+	return listener.Addr().String()
+}
+
+// testVoter is a voter without ongoing votes.
+type testVoter struct{}
+
+func (testVoter) Vote(string, vote.ObjectType, opinion.Opinion) error {
+	return nil
+}
+
+func (testVoter) IntermediateOpinion(string) (opinion.Opinion, error) {
+	return opinion.Unknown, vote.ErrVotingNotFound
+}
+
+func (testVoter) Events() vote.Events {
+	return vote.Events{}
+}
@@ -0,0 +1,87 @@
+package net
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/identity"
+)
+
+// ErrRateLimited is returned when a querier exceeded its query rate.
+var ErrRateLimited = errors.New("query rate limit exceeded")
+
+// QueryRateFunc returns the number of queries per second that the querier with the given ID is allowed to send.
+// Anonymous queriers are identified by the empty ID.
+type QueryRateFunc func(querierID identity.ID) float64
+
+// RateLimiter limits the queries of every querier with a token bucket, whose rate is determined by a QueryRateFunc and
+// whose capacity allows a burst of the given duration.
+type RateLimiter struct {
+	rate      QueryRateFunc
+	burst     time.Duration
+	idleAfter time.Duration
+
+	buckets      map[string]*tokenBucket
+	lastPruning  time.Time
+	bucketsMutex sync.Mutex
+}
+
+// tokenBucket holds the remaining queries of a single querier.
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewRateLimiter creates a new RateLimiter with the given rate function that allows bursts of the given duration.
+func NewRateLimiter(rate QueryRateFunc, burst time.Duration) *RateLimiter {
+	return &RateLimiter{
+		rate:      rate,
+		burst:     burst,
+		idleAfter: 10 * burst,
+		buckets:   make(map[string]*tokenBucket),
+	}
+}
+
+// Allow consumes a query of the querier with the given ID, who sent the query from the given address. Anonymous
+// queriers are limited per address.
+func (r *RateLimiter) Allow(querierID identity.ID, address string, now time.Time) bool {
+	key := querierID.String()
+	if querierID == (identity.ID{}) {
+		key = address
+	}
+	rate := r.rate(querierID)
+	capacity := math.Max(1, rate*r.burst.Seconds())
+
+	r.bucketsMutex.Lock()
+	defer r.bucketsMutex.Unlock()
+
+	r.prune(now)
+
+	bucket, exists := r.buckets[key]
+	if !exists {
+		bucket = &tokenBucket{tokens: capacity, updated: now}
+		r.buckets[key] = bucket
+	}
+	bucket.tokens = math.Min(capacity, bucket.tokens+rate*now.Sub(bucket.updated).Seconds())
+	bucket.updated = now
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// prune removes the buckets of the queriers that have been idle for a while, as their buckets are full anyway.
+func (r *RateLimiter) prune(now time.Time) {
+	if now.Sub(r.lastPruning) < r.idleAfter {
+		return
+	}
+	r.lastPruning = now
+	for key, bucket := range r.buckets {
+		if now.Sub(bucket.updated) > r.idleAfter {
+			delete(r.buckets, key)
+		}
+	}
+}
@@ -2,10 +2,19 @@ package net
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
+	"time"
 
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotaledger/goshimmer/packages/metrics"
@@ -17,16 +26,71 @@ import (
 // If there's no opinion, the function should return Unknown.
 type OpinionRetriever func(id string, objectType vote.ObjectType) opinion.Opinion
 
+// DefaultMaxQueryAge is the default maximum difference between the timestamp of a signed query and the local time.
+const DefaultMaxQueryAge = 30 * time.Second
+
 // New creates a new VoterServer.
-func New(voter vote.Voter, opnRetriever OpinionRetriever, bindAddr string, netRxEvent, netTxEvent, queryReceivedEvent *events.Event) *VoterServer {
-	return &VoterServer{
+func New(voter vote.Voter, opnRetriever OpinionRetriever, bindAddr string, netRxEvent, netTxEvent, queryReceivedEvent *events.Event, opts ...ServerOption) *VoterServer {
+	vs := &VoterServer{
 		voter:              voter,
 		opnRetriever:       opnRetriever,
 		bindAddr:           bindAddr,
-		grpcServer:         grpc.NewServer(),
 		netRxEvent:         netRxEvent,
 		netTxEvent:         netTxEvent,
 		queryReceivedEvent: queryReceivedEvent,
+		maxQueryAge:        DefaultMaxQueryAge,
+	}
+	for _, opt := range opts {
+		opt(vs)
+	}
+	vs.nonces = newNonceCache(vs.maxQueryAge)
+
+	var grpcOpts []grpc.ServerOption
+	if vs.tlsCertificate != nil {
+		grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(serverTLSConfig(*vs.tlsCertificate))))
+	}
+	vs.grpcServer = grpc.NewServer(grpcOpts...)
+
+	return vs
+}
+
+// ServerOption is a function that configures a VoterServer.
+type ServerOption func(*VoterServer)
+
+// WithServerSigner signs all replies with the given signer, so that the queriers can verify their origin.
+func WithServerSigner(signer Signer) ServerOption {
+	return func(vs *VoterServer) {
+		vs.signer = signer
+	}
+}
+
+// WithServerTLS serves the queries over TLS with the given certificate and identifies the queriers by their client
+// certificates.
+func WithServerTLS(certificate tls.Certificate) ServerOption {
+	return func(vs *VoterServer) {
+		vs.tlsCertificate = &certificate
+	}
+}
+
+// WithRequireAuthentication rejects all queries whose querier cannot be identified by a signature or a client
+// certificate.
+func WithRequireAuthentication(required bool) ServerOption {
+	return func(vs *VoterServer) {
+		vs.requireAuthentication = required
+	}
+}
+
+// WithMaxQueryAge sets the maximum difference between the timestamp of a signed query and the local time.
+func WithMaxQueryAge(maxQueryAge time.Duration) ServerOption {
+	return func(vs *VoterServer) {
+		vs.maxQueryAge = maxQueryAge
+	}
+}
+
+// WithRateLimiter limits the queries of every querier with the given RateLimiter.
+func WithRateLimiter(rateLimiter *RateLimiter) ServerOption {
+	return func(vs *VoterServer) {
+		vs.rateLimiter = rateLimiter
 	}
 }
 
@@ -39,11 +103,26 @@ type VoterServer struct {
 	netRxEvent         *events.Event
 	netTxEvent         *events.Event
 	queryReceivedEvent *events.Event
+
+	signer                Signer
+	tlsCertificate        *tls.Certificate
+	requireAuthentication bool
+	maxQueryAge           time.Duration
+	nonces                *nonceCache
+	rateLimiter           *RateLimiter
 	UnimplementedVoterQueryServer
 }
 
 // Opinion replies the query request with an opinion and triggers the events.
 func (vs *VoterServer) Opinion(ctx context.Context, req *QueryRequest) (*QueryReply, error) {
+	querierID, address, err := vs.authenticate(ctx, req)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if vs.rateLimiter != nil && !vs.rateLimiter.Allow(querierID, address, time.Now()) {
+		return nil, status.Error(codes.ResourceExhausted, ErrRateLimited.Error())
+	}
+
 	reply := &QueryReply{
 		Opinion: make([]int32, len(req.ConflictIDs)+len(req.TimestampIDs)),
 	}
@@ -65,6 +144,9 @@ func (vs *VoterServer) Opinion(ctx context.Context, req *QueryRequest) (*QueryRe
 		}
 		reply.Opinion[i+len(req.ConflictIDs)] = int32(vs.opnRetriever(id, vote.TimestampType))
 	}
+	if vs.signer != nil {
+		signReply(reply, req, vs.signer)
+	}
 
 	if vs.netRxEvent != nil {
 		vs.netRxEvent.Trigger(uint64(proto.Size(req)))
@@ -79,6 +161,45 @@ func (vs *VoterServer) Opinion(ctx context.Context, req *QueryRequest) (*QueryRe
 	return reply, nil
 }
 
+// authenticate identifies the querier by the signature of the request or by its client certificate. The returned ID is
+// empty for anonymous queriers, which are only accepted if no authentication is required.
+func (vs *VoterServer) authenticate(ctx context.Context, req *QueryRequest) (querierID identity.ID, address string, err error) {
+	var certificateKey *ed25519.PublicKey
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, splitErr := net.SplitHostPort(p.Addr.String()); splitErr == nil {
+			address = host
+		}
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) != 0 {
+			if publicKey, keyErr := certificatePublicKey([][]byte{tlsInfo.State.PeerCertificates[0].Raw}); keyErr == nil {
+				certificateKey = &publicKey
+			}
+		}
+	}
+
+	if len(req.Signature) != 0 {
+		now := time.Now()
+		publicKey, verifyErr := verifyRequest(req, now, vs.maxQueryAge)
+		if verifyErr != nil {
+			return querierID, address, verifyErr
+		}
+		if certificateKey != nil && *certificateKey != publicKey {
+			return querierID, address, errors.Errorf("query signed by %s but sent by %s: %w", publicKey, *certificateKey, ErrInvalidQuerySignature)
+		}
+		// a signed query must not be accepted twice while its timestamp is in range
+		if nonceErr := vs.nonces.add(identity.NewID(publicKey), req.Nonce, req.Timestamp, now); nonceErr != nil {
+			return querierID, address, nonceErr
+		}
+		return identity.NewID(publicKey), address, nil
+	}
+	if certificateKey != nil {
+		return identity.NewID(*certificateKey), address, nil
+	}
+	if vs.requireAuthentication {
+		return querierID, address, ErrUnsignedQuery
+	}
+	return querierID, address, nil
+}
+
 // Run starts the voting server.
 func (vs *VoterServer) Run() error {
 	listener, err := net.Listen("tcp", vs.bindAddr)
@@ -0,0 +1,109 @@
+package net
+
+import (
+	"crypto"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+)
+
+// ErrUnexpectedPeerCertificate is returned when the TLS certificate of a peer does not belong to the expected node.
+var ErrUnexpectedPeerCertificate = errors.New("unexpected peer certificate")
+
+// certificateValidity is the validity of the self-signed certificates of the nodes.
+const certificateValidity = 10 * 365 * 24 * time.Hour
+
+// NewCertificate creates a self-signed TLS certificate for the key of the given signer. The nodes do not rely on a
+// certificate authority: the certificates are pinned to the public keys of the nodes instead.
+func NewCertificate(signer Signer) (tls.Certificate, error) {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: signer.PublicKey().String()},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(certificateValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	cryptoSigner := &tlsSigner{signer}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, cryptoSigner.Public(), cryptoSigner)
+	if err != nil {
+		return tls.Certificate{}, errors.Errorf("failed to create certificate: %w", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{certificate}, PrivateKey: cryptoSigner}, nil
+}
+
+// serverTLSConfig returns the TLS configuration of a server that asks the queriers for their certificates.
+func serverTLSConfig(certificate tls.Certificate) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientAuth:   tls.RequestClientCert,
+		MinVersion:   tls.VersionTLS13,
+	}
+}
+
+// clientTLSConfig returns the TLS configuration of a client that only accepts the certificate of the node with the given
+// public key.
+func clientTLSConfig(certificate tls.Certificate, publicKey ed25519.PublicKey) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS13,
+		// the certificates are self-signed and verified against the public key of the peer below
+		InsecureSkipVerify: true, //nolint:gosec // the peer certificate is pinned
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			certificatePublicKey, err := certificatePublicKey(rawCerts)
+			if err != nil {
+				return err
+			}
+			if certificatePublicKey != publicKey {
+				return errors.Errorf("certificate of %s instead of %s: %w", certificatePublicKey, publicKey, ErrUnexpectedPeerCertificate)
+			}
+			return nil
+		},
+	}
+}
+
+// certificatePublicKey returns the node public key of the leaf of the given certificate chain.
+func certificatePublicKey(rawCerts [][]byte) (publicKey ed25519.PublicKey, err error) {
+	if len(rawCerts) == 0 {
+		return publicKey, errors.Errorf("no certificate: %w", ErrUnexpectedPeerCertificate)
+	}
+	certificate, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return publicKey, errors.Errorf("failed to parse certificate: %w", err)
+	}
+	key, ok := certificate.PublicKey.(stded25519.PublicKey)
+	if !ok {
+		return publicKey, errors.Errorf("certificate key is not an ed25519 key: %w", ErrUnexpectedPeerCertificate)
+	}
+	if err = certificate.CheckSignature(certificate.SignatureAlgorithm, certificate.RawTBSCertificate, certificate.Signature); err != nil {
+		return publicKey, errors.Errorf("invalid certificate signature: %w", err)
+	}
+	publicKey, _, err = ed25519.PublicKeyFromBytes(key)
+	return publicKey, err
+}
+
+// tlsSigner adapts a Signer to the crypto.Signer interface used by the TLS stack.
+type tlsSigner struct {
+	Signer
+}
+
+// Public returns the public key of the signer.
+func (t *tlsSigner) Public() crypto.PublicKey {
+	return stded25519.PublicKey(t.PublicKey().Bytes())
+}
+
+// Sign signs the given message, as ed25519 signs the message itself instead of a digest.
+func (t *tlsSigner) Sign(_ io.Reader, message []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts.HashFunc() != crypto.Hash(0) {
+		return nil, errors.Errorf("ed25519 cannot sign pre-hashed messages")
+	}
+	return t.Signer.Sign(message).Bytes(), nil
+}
@@ -0,0 +1,215 @@
+package statement
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+// objectIDLength is the length of the IDs of the conflicts and timestamps that opinions are stated about.
+const objectIDLength = ledgerstate.TransactionIDLength
+
+const (
+	// storePrefixOpinion is the prefix of the persisted opinions in the store of the Registry.
+	storePrefixOpinion byte = iota
+	// storePrefixEquivocation is the prefix of the persisted equivocations in the store of the Registry.
+	storePrefixEquivocation
+)
+
+// region RegistryEvents ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// RegistryEvents represents events triggered by the Registry.
+type RegistryEvents struct {
+	// Equivocation is triggered when a node stated conflicting opinions about the same object in the same round.
+	Equivocation *events.Event
+}
+
+func newRegistryEvents() *RegistryEvents {
+	return &RegistryEvents{
+		Equivocation: events.NewEvent(equivocationEventHandler),
+	}
+}
+
+func equivocationEventHandler(handler interface{}, params ...interface{}) {
+	handler.(func(*Equivocation))(params[0].(*Equivocation))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Equivocation /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Equivocation is the evidence that a node stated conflicting opinions about the same conflict or timestamp in the same
+// round.
+type Equivocation struct {
+	// NodeID is the ID of the node that equivocated.
+	NodeID identity.ID
+	// ObjectType is the type of the object that the opinions are about.
+	ObjectType vote.ObjectType
+	// ObjectID is the ID of the conflict or timestamp that the opinions are about.
+	ObjectID [objectIDLength]byte
+	// Round is the round that both opinions were stated for.
+	Round uint8
+	// Opinions are the conflicting opinions, in the order in which they were received.
+	Opinions [2]opinion.Opinion
+	// MessageIDs are the IDs of the statements that contained the conflicting opinions.
+	MessageIDs [2]tangle.MessageID
+	// DetectedTime is the time when the equivocation was detected.
+	DetectedTime time.Time
+}
+
+// ObjectIDBase58 returns the base58 encoded ID of the conflict or timestamp that the opinions are about.
+func (e *Equivocation) ObjectIDBase58() string {
+	return base58.Encode(e.ObjectID[:])
+}
+
+// Bytes returns a marshaled version of the Equivocation.
+func (e *Equivocation) Bytes() []byte {
+	return marshalutil.New().
+		Write(e.NodeID).
+		WriteUint8(uint8(e.ObjectType)).
+		WriteBytes(e.ObjectID[:]).
+		WriteUint8(e.Round).
+		WriteByte(byte(e.Opinions[0])).
+		Write(e.MessageIDs[0]).
+		WriteByte(byte(e.Opinions[1])).
+		Write(e.MessageIDs[1]).
+		WriteTime(e.DetectedTime).
+		Bytes()
+}
+
+// EquivocationFromBytes parses an Equivocation from a byte slice.
+func EquivocationFromBytes(bytes []byte) (equivocation *Equivocation, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	equivocation = &Equivocation{}
+	if equivocation.NodeID, err = identity.IDFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse node ID: %w", err)
+	}
+	objectType, err := marshalUtil.ReadUint8()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse object type: %w", err)
+	}
+	equivocation.ObjectType = vote.ObjectType(objectType)
+	objectID, err := marshalUtil.ReadBytes(objectIDLength)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse object ID: %w", err)
+	}
+	copy(equivocation.ObjectID[:], objectID)
+	if equivocation.Round, err = marshalUtil.ReadUint8(); err != nil {
+		return nil, errors.Errorf("failed to parse round: %w", err)
+	}
+	for i := range equivocation.Opinions {
+		opinionByte, err := marshalUtil.ReadByte()
+		if err != nil {
+			return nil, errors.Errorf("failed to parse opinion: %w", err)
+		}
+		equivocation.Opinions[i] = opinion.Opinion(opinionByte)
+		if equivocation.MessageIDs[i], err = tangle.MessageIDFromMarshalUtil(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse message ID: %w", err)
+		}
+	}
+	if equivocation.DetectedTime, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse detection time: %w", err)
+	}
+
+	return equivocation, nil
+}
+
+// String returns a human readable version of the Equivocation.
+func (e *Equivocation) String() string {
+	return stringify.Struct("Equivocation",
+		stringify.StructField("NodeID", e.NodeID.String()),
+		stringify.StructField("ObjectType", strconv.Itoa(int(e.ObjectType))),
+		stringify.StructField("ObjectID", e.ObjectIDBase58()),
+		stringify.StructField("Round", strconv.Itoa(int(e.Round))),
+		stringify.StructField("Opinions", e.Opinions[0].String()+"/"+e.Opinions[1].String()),
+		stringify.StructField("MessageIDs", e.MessageIDs[0].Base58()+"/"+e.MessageIDs[1].Base58()),
+		stringify.StructField("DetectedTime", e.DetectedTime),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region persistedOpinion /////////////////////////////////////////////////////////////////////////////////////////////
+
+// persistedOpinion is an opinion of a node as it is persisted in the store of the Registry.
+type persistedOpinion struct {
+	nodeID       identity.ID
+	objectType   vote.ObjectType
+	objectID     [objectIDLength]byte
+	opinion      Opinion
+	messageID    tangle.MessageID
+	receivedTime time.Time
+}
+
+// key returns the key of the opinion in the store, which is unique per node, object and round.
+func (p *persistedOpinion) key() []byte {
+	return marshalutil.New(2 + len(p.nodeID) + objectIDLength + 1).
+		WriteByte(storePrefixOpinion).
+		Write(p.nodeID).
+		WriteUint8(uint8(p.objectType)).
+		WriteBytes(p.objectID[:]).
+		WriteUint8(p.opinion.Round).
+		Bytes()
+}
+
+func (p *persistedOpinion) value() []byte {
+	return marshalutil.New().
+		WriteByte(byte(p.opinion.Value)).
+		Write(p.messageID).
+		WriteTime(p.receivedTime).
+		Bytes()
+}
+
+// persistedOpinionFromBytes parses a persistedOpinion from its key and value in the store.
+func persistedOpinionFromBytes(key, value []byte) (p *persistedOpinion, err error) {
+	p = &persistedOpinion{}
+
+	keyUtil := marshalutil.New(key)
+	if _, err = keyUtil.ReadByte(); err != nil {
+		return nil, errors.Errorf("failed to parse prefix: %w", err)
+	}
+	if p.nodeID, err = identity.IDFromMarshalUtil(keyUtil); err != nil {
+		return nil, errors.Errorf("failed to parse node ID: %w", err)
+	}
+	objectType, err := keyUtil.ReadUint8()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse object type: %w", err)
+	}
+	p.objectType = vote.ObjectType(objectType)
+	objectID, err := keyUtil.ReadBytes(objectIDLength)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse object ID: %w", err)
+	}
+	copy(p.objectID[:], objectID)
+	if p.opinion.Round, err = keyUtil.ReadUint8(); err != nil {
+		return nil, errors.Errorf("failed to parse round: %w", err)
+	}
+
+	valueUtil := marshalutil.New(value)
+	opinionByte, err := valueUtil.ReadByte()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse opinion: %w", err)
+	}
+	p.opinion.Value = opinion.Opinion(opinionByte)
+	if p.messageID, err = tangle.MessageIDFromMarshalUtil(valueUtil); err != nil {
+		return nil, errors.Errorf("failed to parse message ID: %w", err)
+	}
+	if p.receivedTime, err = valueUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse received time: %w", err)
+	}
+
+	return p, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -5,11 +5,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/byteutils"
 	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
 
 	"github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/vote"
 	"github.com/iotaledger/goshimmer/packages/vote/opinion"
 )
 
@@ -17,15 +21,160 @@ import (
 
 // Registry holds the opinions of all the nodes.
 type Registry struct {
+	// Events contains the events triggered by the audit of the received statements.
+	Events *RegistryEvents
+
 	nodesView map[identity.ID]*View
 	mu        sync.RWMutex
+
+	store              kvstore.KVStore
+	equivocations      []*Equivocation
+	equivocationsMutex sync.RWMutex
+}
+
+// RegistryOption is a function setting a registry option.
+type RegistryOption func(r *Registry)
+
+// WithStore persists the opinions received with statements and the detected equivocations in the given store.
+func WithStore(store kvstore.KVStore) RegistryOption {
+	return func(r *Registry) {
+		r.store = store
+	}
 }
 
 // NewRegistry returns a new registry.
-func NewRegistry() *Registry {
-	return &Registry{
+func NewRegistry(opts ...RegistryOption) *Registry {
+	r := &Registry{
+		Events:    newRegistryEvents(),
 		nodesView: make(map[identity.ID]*View),
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Load restores the opinions and equivocations that were persisted in the store of the registry.
+func (r *Registry) Load() (err error) {
+	if r.store == nil {
+		return nil
+	}
+
+	var parseErr error
+	if err = r.store.Iterate([]byte{storePrefixOpinion}, func(key kvstore.Key, value kvstore.Value) bool {
+		p, err := persistedOpinionFromBytes(key, value)
+		if err != nil {
+			parseErr = errors.Errorf("failed to parse persisted opinion with key %x: %w", key, err)
+			return false
+		}
+
+		v := r.NodeView(p.nodeID)
+		switch p.objectType {
+		case vote.ConflictType:
+			v.cMutex.Lock()
+			v.Conflicts[p.objectID] = restoreEntry(v.Conflicts[p.objectID], p)
+			v.cMutex.Unlock()
+		case vote.TimestampType:
+			v.tMutex.Lock()
+			v.Timestamps[p.objectID] = restoreEntry(v.Timestamps[p.objectID], p)
+			v.tMutex.Unlock()
+		}
+		return true
+	}); err != nil {
+		return errors.Errorf("failed to iterate over persisted opinions: %w", err)
+	}
+	if parseErr != nil {
+		return parseErr
+	}
+
+	r.equivocationsMutex.Lock()
+	defer r.equivocationsMutex.Unlock()
+	if err = r.store.Iterate([]byte{storePrefixEquivocation}, func(key kvstore.Key, value kvstore.Value) bool {
+		equivocation, err := EquivocationFromBytes(value)
+		if err != nil {
+			parseErr = errors.Errorf("failed to parse persisted equivocation with key %x: %w", key, err)
+			return false
+		}
+		r.equivocations = append(r.equivocations, equivocation)
+		return true
+	}); err != nil {
+		return errors.Errorf("failed to iterate over persisted equivocations: %w", err)
+	}
+
+	return parseErr
+}
+
+// AddStatement adds the opinions of the given statement, which was issued by the given node in the message with the
+// given ID, to the view of the node. Opinions that conflict with an opinion that the node stated before for the same
+// round are not added, but reported as equivocations.
+func (r *Registry) AddStatement(nodeID identity.ID, messageID tangle.MessageID, statement *Statement) (equivocations []*Equivocation, err error) {
+	v := r.NodeView(nodeID)
+	now := clock.SyncedTime()
+
+	var persisted []*persistedOpinion
+	for _, c := range statement.Conflicts {
+		added, equivocation := v.addConflictStatement(c, messageID, now)
+		if equivocation != nil {
+			equivocations = append(equivocations, equivocation)
+		}
+		if added {
+			persisted = append(persisted, &persistedOpinion{nodeID: nodeID, objectType: vote.ConflictType, objectID: c.ID, opinion: c.Opinion, messageID: messageID, receivedTime: now})
+		}
+	}
+	for _, t := range statement.Timestamps {
+		added, equivocation := v.addTimestampStatement(t, messageID, now)
+		if equivocation != nil {
+			equivocations = append(equivocations, equivocation)
+		}
+		if added {
+			persisted = append(persisted, &persistedOpinion{nodeID: nodeID, objectType: vote.TimestampType, objectID: t.ID, opinion: t.Opinion, messageID: messageID, receivedTime: now})
+		}
+	}
+	v.UpdateLastStatementReceivedTime(now)
+
+	r.equivocationsMutex.Lock()
+	r.equivocations = append(r.equivocations, equivocations...)
+	r.equivocationsMutex.Unlock()
+
+	err = r.persist(persisted, equivocations)
+
+	for _, equivocation := range equivocations {
+		r.Events.Equivocation.Trigger(equivocation)
+	}
+
+	return equivocations, err
+}
+
+// Equivocations returns the detected equivocations, optionally only the ones of the given nodes.
+func (r *Registry) Equivocations(nodeIDs ...identity.ID) (equivocations []*Equivocation) {
+	r.equivocationsMutex.RLock()
+	defer r.equivocationsMutex.RUnlock()
+
+	filter := make(map[identity.ID]bool, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		filter[nodeID] = true
+	}
+	for _, equivocation := range r.equivocations {
+		if len(filter) == 0 || filter[equivocation.NodeID] {
+			equivocations = append(equivocations, equivocation)
+		}
+	}
+
+	return equivocations
+}
+
+// EquivocatingNodes returns the number of detected equivocations per node.
+func (r *Registry) EquivocatingNodes() map[identity.ID]int {
+	r.equivocationsMutex.RLock()
+	defer r.equivocationsMutex.RUnlock()
+
+	nodes := make(map[identity.ID]int)
+	for _, equivocation := range r.equivocations {
+		nodes[equivocation.NodeID]++
+	}
+
+	return nodes
 }
 
 // NodeView returns the view of the given node, and adds a new view if not present.
@@ -58,15 +207,17 @@ func (r *Registry) NodesView() []*View {
 	return views
 }
 
-// Clean deletes all the entries older than the given duration d.
-func (r *Registry) Clean(d time.Duration) {
+// Clean deletes all the entries and equivocations older than the given duration d.
+func (r *Registry) Clean(d time.Duration) error {
 	now := clock.SyncedTime()
 
+	var deleted [][]byte
 	for _, v := range r.NodesView() {
 		v.cMutex.Lock()
 		// loop over the conflicts
 		for id, c := range v.Conflicts {
 			if c.Timestamp.Add(d).Before(now) {
+				deleted = append(deleted, entryKeys(v.NodeID, vote.ConflictType, id, c)...)
 				delete(v.Conflicts, id)
 			}
 		}
@@ -76,11 +227,66 @@ func (r *Registry) Clean(d time.Duration) {
 		// loop over the timestamps
 		for id, t := range v.Timestamps {
 			if t.Timestamp.Add(d).Before(now) {
+				deleted = append(deleted, entryKeys(v.NodeID, vote.TimestampType, id, t)...)
 				delete(v.Timestamps, id)
 			}
 		}
 		v.tMutex.Unlock()
 	}
+
+	r.equivocationsMutex.Lock()
+	equivocations := r.equivocations[:0]
+	for _, equivocation := range r.equivocations {
+		if equivocation.DetectedTime.Add(d).Before(now) {
+			deleted = append(deleted, equivocationKey(equivocation))
+			continue
+		}
+		equivocations = append(equivocations, equivocation)
+	}
+	r.equivocations = equivocations
+	r.equivocationsMutex.Unlock()
+
+	if r.store == nil || len(deleted) == 0 {
+		return nil
+	}
+	batch := r.store.Batched()
+	for _, key := range deleted {
+		if err := batch.Delete(key); err != nil {
+			batch.Cancel()
+			return errors.Errorf("failed to delete persisted statement: %w", err)
+		}
+	}
+	if err := batch.Commit(); err != nil {
+		return errors.Errorf("failed to delete persisted statements: %w", err)
+	}
+
+	return nil
+}
+
+// persist writes the given opinions and equivocations to the store of the registry.
+func (r *Registry) persist(opinions []*persistedOpinion, equivocations []*Equivocation) error {
+	if r.store == nil || len(opinions)+len(equivocations) == 0 {
+		return nil
+	}
+
+	batch := r.store.Batched()
+	for _, p := range opinions {
+		if err := batch.Set(p.key(), p.value()); err != nil {
+			batch.Cancel()
+			return errors.Errorf("failed to persist opinion: %w", err)
+		}
+	}
+	for _, equivocation := range equivocations {
+		if err := batch.Set(equivocationKey(equivocation), equivocation.Bytes()); err != nil {
+			batch.Cancel()
+			return errors.Errorf("failed to persist equivocation: %w", err)
+		}
+	}
+	if err := batch.Commit(); err != nil {
+		return errors.Errorf("failed to persist statement: %w", err)
+	}
+
+	return nil
 }
 
 // endregion /////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -91,6 +297,67 @@ func (r *Registry) Clean(d time.Duration) {
 type Entry struct {
 	Opinions
 	Timestamp time.Time
+	// MessageIDs are the IDs of the statements that contained the opinions, by round. Opinions that were not added
+	// from a statement have no message ID.
+	MessageIDs map[uint8]tangle.MessageID
+}
+
+// opinion returns the opinion of the given round.
+func (e Entry) opinion(round uint8) (o Opinion, exists bool) {
+	for _, o = range e.Opinions {
+		if o.Round == round {
+			return o, true
+		}
+	}
+
+	return Opinion{}, false
+}
+
+// addStatement adds the opinion of the statement with the given message ID to the entry. It returns the opinion that
+// was stated before for the same round, if any, in which case the opinion is not added.
+func (e Entry) addStatement(o Opinion, messageID tangle.MessageID, now time.Time) (updated Entry, previous Opinion, exists bool) {
+	if previous, exists = e.opinion(o.Round); exists {
+		return e, previous, true
+	}
+
+	if len(e.Opinions) == 0 {
+		e.Timestamp = now
+	}
+	if e.MessageIDs == nil {
+		e.MessageIDs = make(map[uint8]tangle.MessageID)
+	}
+	e.Opinions = append(e.Opinions, o)
+	e.MessageIDs[o.Round] = messageID
+
+	return e, Opinion{}, false
+}
+
+// restoreEntry adds the given persisted opinion to the entry.
+func restoreEntry(e Entry, p *persistedOpinion) Entry {
+	if len(e.Opinions) == 0 || p.receivedTime.Before(e.Timestamp) {
+		e.Timestamp = p.receivedTime
+	}
+	if e.MessageIDs == nil {
+		e.MessageIDs = make(map[uint8]tangle.MessageID)
+	}
+	e.Opinions = append(e.Opinions, p.opinion)
+	e.MessageIDs[p.opinion.Round] = p.messageID
+
+	return e
+}
+
+// entryKeys returns the keys of the persisted opinions of the given entry.
+func entryKeys(nodeID identity.ID, objectType vote.ObjectType, objectID [objectIDLength]byte, e Entry) (keys [][]byte) {
+	for round := range e.MessageIDs {
+		p := &persistedOpinion{nodeID: nodeID, objectType: objectType, objectID: objectID, opinion: Opinion{Round: round}}
+		keys = append(keys, p.key())
+	}
+
+	return keys
+}
+
+func equivocationKey(e *Equivocation) []byte {
+	return byteutils.ConcatBytes([]byte{storePrefixEquivocation}, e.NodeID.Bytes(), []byte{byte(e.ObjectType)}, e.ObjectID[:], []byte{e.Round})
 }
 
 // endregion /////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -146,6 +413,54 @@ func (v *View) AddConflicts(conflicts Conflicts) {
 	}
 }
 
+// addConflictStatement adds the opinion about a conflict contained in the statement with the given message ID. It
+// returns if the opinion was added and the Equivocation if the node stated a different opinion for the same round.
+func (v *View) addConflictStatement(c Conflict, messageID tangle.MessageID, now time.Time) (added bool, equivocation *Equivocation) {
+	v.cMutex.Lock()
+	defer v.cMutex.Unlock()
+
+	entry, previous, exists := v.Conflicts[c.ID].addStatement(c.Opinion, messageID, now)
+	if exists {
+		return false, v.equivocation(vote.ConflictType, c.ID, previous, v.Conflicts[c.ID].MessageIDs[c.Round], c.Opinion, messageID, now)
+	}
+	v.Conflicts[c.ID] = entry
+
+	return true, nil
+}
+
+// addTimestampStatement adds the opinion about a timestamp contained in the statement with the given message ID. It
+// returns if the opinion was added and the Equivocation if the node stated a different opinion for the same round.
+func (v *View) addTimestampStatement(t Timestamp, messageID tangle.MessageID, now time.Time) (added bool, equivocation *Equivocation) {
+	v.tMutex.Lock()
+	defer v.tMutex.Unlock()
+
+	entry, previous, exists := v.Timestamps[t.ID].addStatement(t.Opinion, messageID, now)
+	if exists {
+		return false, v.equivocation(vote.TimestampType, t.ID, previous, v.Timestamps[t.ID].MessageIDs[t.Round], t.Opinion, messageID, now)
+	}
+	v.Timestamps[t.ID] = entry
+
+	return true, nil
+}
+
+// equivocation returns the Equivocation of the two given opinions of the same round, or nil if they are equal.
+func (v *View) equivocation(objectType vote.ObjectType, objectID [objectIDLength]byte, previous Opinion, previousMessageID tangle.MessageID,
+	current Opinion, messageID tangle.MessageID, now time.Time) *Equivocation {
+	if previous.Value == current.Value {
+		return nil
+	}
+
+	return &Equivocation{
+		NodeID:       v.NodeID,
+		ObjectType:   objectType,
+		ObjectID:     objectID,
+		Round:        current.Round,
+		Opinions:     [2]opinion.Opinion{previous.Value, current.Value},
+		MessageIDs:   [2]tangle.MessageID{previousMessageID, messageID},
+		DetectedTime: now,
+	}
+}
+
 // AddTimestamp appends the given timestamp to the given view.
 func (v *View) AddTimestamp(t Timestamp) {
 	v.tMutex.Lock()
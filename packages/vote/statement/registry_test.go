@@ -2,8 +2,11 @@ package statement
 
 import (
 	"testing"
+	"time"
 
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -38,3 +41,61 @@ func TestRegistry(t *testing.T) {
 	assert.Equal(t, 1, len(o))
 	assert.Equal(t, false, o.Finalized(2))
 }
+
+func TestRegistry_AddStatement(t *testing.T) {
+	store := mapdb.NewMapDB()
+	r := NewRegistry(WithStore(store))
+
+	var triggered []*Equivocation
+	r.Events.Equivocation.Attach(events.NewClosure(func(equivocation *Equivocation) {
+		triggered = append(triggered, equivocation)
+	}))
+
+	nodeID := identity.GenerateIdentity().ID()
+	txA, err := ledgerstate.TransactionIDFromRandomness()
+	require.NoError(t, err)
+	msgA, msgB, msgC := tangle.MessageID{1}, tangle.MessageID{2}, tangle.MessageID{3}
+
+	equivocations, err := r.AddStatement(nodeID, msgA, New(Conflicts{{txA, Opinion{opinion.Like, 1}}}, Timestamps{{msgA, Opinion{opinion.Like, 1}}}))
+	require.NoError(t, err)
+	assert.Empty(t, equivocations)
+
+	// restating the same opinion is not an equivocation
+	equivocations, err = r.AddStatement(nodeID, msgB, New(Conflicts{{txA, Opinion{opinion.Like, 1}}, {txA, Opinion{opinion.Like, 2}}}, nil))
+	require.NoError(t, err)
+	assert.Empty(t, equivocations)
+
+	equivocations, err = r.AddStatement(nodeID, msgC, New(Conflicts{{txA, Opinion{opinion.Dislike, 2}}}, nil))
+	require.NoError(t, err)
+	require.Len(t, equivocations, 1)
+	assert.Equal(t, equivocations, triggered)
+	assert.Equal(t, nodeID, equivocations[0].NodeID)
+	assert.Equal(t, uint8(2), equivocations[0].Round)
+	assert.Equal(t, [2]opinion.Opinion{opinion.Like, opinion.Dislike}, equivocations[0].Opinions)
+	assert.Equal(t, [2]tangle.MessageID{msgB, msgC}, equivocations[0].MessageIDs)
+	assert.Equal(t, map[identity.ID]int{nodeID: 1}, r.EquivocatingNodes())
+
+	// the conflicting opinion is not added
+	entry := r.NodeView(nodeID).Conflicts[txA]
+	assert.Equal(t, Opinions{{opinion.Like, 1}, {opinion.Like, 2}}, entry.Opinions)
+	assert.Equal(t, map[uint8]tangle.MessageID{1: msgA, 2: msgB}, entry.MessageIDs)
+
+	restored := NewRegistry(WithStore(store))
+	require.NoError(t, restored.Load())
+	assert.ElementsMatch(t, entry.Opinions, restored.NodeView(nodeID).Conflicts[txA].Opinions)
+	assert.Equal(t, entry.MessageIDs, restored.NodeView(nodeID).Conflicts[txA].MessageIDs)
+	assert.Equal(t, Opinions{{opinion.Like, 1}}, restored.NodeView(nodeID).TimestampOpinion(msgA))
+	require.Len(t, restored.Equivocations(nodeID), 1)
+	assert.Equal(t, equivocations[0].Bytes(), restored.Equivocations(nodeID)[0].Bytes())
+
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, restored.Clean(time.Millisecond))
+	assert.Empty(t, restored.NodeView(nodeID).Conflicts)
+	assert.Empty(t, restored.Equivocations())
+
+	cleaned := NewRegistry(WithStore(store))
+	require.NoError(t, cleaned.Load())
+	assert.Empty(t, cleaned.NodeView(nodeID).Conflicts)
+	assert.Empty(t, cleaned.NodeView(nodeID).Timestamps)
+	assert.Empty(t, cleaned.Equivocations())
+}
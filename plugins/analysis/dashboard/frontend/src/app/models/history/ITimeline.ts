@@ -0,0 +1,5 @@
+export interface ITimeline {
+    enabled: boolean;
+    start: number;
+    end: number;
+}
@@ -0,0 +1,11 @@
+export interface ITopologyLink {
+    source: string;
+    target: string;
+}
+
+export interface ITopology {
+    networkVersion: string;
+    time: string;
+    nodes: string[];
+    links: ITopologyLink[];
+}
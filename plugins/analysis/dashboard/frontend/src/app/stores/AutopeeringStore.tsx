@@ -10,6 +10,8 @@ import { connectWebSocket, registerHandler } from "../services/WS";
 import { buildCircleNodeShader } from "../utils/circleNodeShader";
 import { parseColor } from "../utils/colorHelper";
 import { Neighbors } from "../models/Neighbors";
+import { ITimeline } from "../models/history/ITimeline";
+import { ITopology } from "../models/history/ITopology";
 import { manaStore } from "../../main";
 import tinycolor from "tinycolor2";
 
@@ -26,6 +28,8 @@ const VERTEX_SIZE = 14;
 const VERTEX_SIZE_ACTIVE = 24;
 const VERTEX_SIZE_CONNECTED = 18;
 const statusWebSocketPath = "/ws";
+const historyTimelinePath = "/api/history/timeline";
+const historyTopologyPath = "/api/history/topology";
 
 export const shortenedIDCharCount = 8;
 
@@ -57,6 +61,23 @@ export class AutopeeringStore {
     @observable
     public manaColoringActive: boolean;
 
+    @observable
+    public historyEnabled: boolean = false;
+
+    @observable
+    public timelineStart: number = 0;
+
+    @observable
+    public timelineEnd: number = 0;
+
+    // the time of the displayed topology in replay mode, undefined means live mode
+    @observable
+    public replayTime?: number;
+
+    // the time the user is currently pointing at with the timeline scrubber
+    @observable
+    public scrubberTime: number = 0;
+
     @observable
     public readonly versions: ObservableSet = new ObservableSet()
 
@@ -87,10 +108,11 @@ export class AutopeeringStore {
 
     constructor() {
         this.manaColoringActive = false;
-        registerHandler(WSMsgType.addNode, msg => this.onAddNode(msg));
-        registerHandler(WSMsgType.removeNode, msg => this.onRemoveNode(msg));
-        registerHandler(WSMsgType.connectNodes, msg => this.onConnectNodes(msg));
-        registerHandler(WSMsgType.disconnectNodes, msg => this.onDisconnectNodes(msg));
+        // live updates of the displayed network are ignored while replaying its history
+        registerHandler(WSMsgType.addNode, msg => this.isReplaying(msg.networkVersion) || this.onAddNode(msg));
+        registerHandler(WSMsgType.removeNode, msg => this.isReplaying(msg.networkVersion) || this.onRemoveNode(msg));
+        registerHandler(WSMsgType.connectNodes, msg => this.isReplaying(msg.networkVersion) || this.onConnectNodes(msg));
+        registerHandler(WSMsgType.disconnectNodes, msg => this.isReplaying(msg.networkVersion) || this.onDisconnectNodes(msg));
         registerHandler(WSMsgType.MsgManaDashboardAddress, msg => this.setManaDashboardAddress(msg))
     }
 
@@ -125,6 +147,10 @@ export class AutopeeringStore {
 
     @action
     public handleVersionSelection = (userSelectedVersion: string) => {
+        if (this.replayTime !== undefined) {
+            // restore the live data of the network we are leaving
+            this.goLive();
+        }
         this.userSelectedNetworkVersion = userSelectedVersion;
         if (this.selectedNetworkVersion !== userSelectedVersion) {
             // we switch network, should redraw the graph.
@@ -173,6 +199,100 @@ export class AutopeeringStore {
         }
     }
 
+    // fetches the time range that is covered by the history of the analysis server
+    public async fetchTimeline(): Promise<void> {
+        try {
+            const res = await fetch(historyTimelinePath);
+            const timeline: ITimeline = await res.json();
+            this.updateTimeline(timeline);
+        } catch (err) {
+            console.log("Failed to fetch the history timeline: %s", err);
+        }
+    }
+
+    // displays the topology of the selected network at the given time
+    public async showTopologyAt(time: number): Promise<void> {
+        this.updateReplayTime(time);
+        await this.fetchTopology(`${historyTopologyPath}?networkVersion=${encodeURIComponent(this.selectedNetworkVersion)}&time=${time}`);
+    }
+
+    // leaves the replay mode and displays the current topology of the selected network again
+    public async goLive(): Promise<void> {
+        this.updateReplayTime(undefined);
+        await this.fetchTopology(`${historyTopologyPath}?networkVersion=${encodeURIComponent(this.selectedNetworkVersion)}`);
+    }
+
+    @action
+    public updateScrubberTime(time: number): void {
+        this.scrubberTime = time;
+    }
+
+    @action
+    private updateTimeline(timeline: ITimeline): void {
+        this.historyEnabled = timeline.enabled;
+        this.timelineStart = timeline.start;
+        this.timelineEnd = timeline.end;
+        if (this.replayTime === undefined) {
+            this.scrubberTime = timeline.end;
+        }
+    }
+
+    @action
+    private updateReplayTime(time?: number): void {
+        this.replayTime = time;
+    }
+
+    private isReplaying(networkVersion: string): boolean {
+        return this.replayTime !== undefined && this.selectedNetworkVersion === networkVersion;
+    }
+
+    private async fetchTopology(url: string): Promise<void> {
+        try {
+            const res = await fetch(url);
+            if (res.status !== 200) {
+                console.log("Failed to fetch topology: %s", await res.text());
+                return;
+            }
+            const topology: ITopology = await res.json();
+            this.applyTopology(topology);
+        } catch (err) {
+            console.log("Failed to fetch topology: %s", err);
+        }
+    }
+
+    // replaces the known nodes and connections of a network with the given topology and redraws the graph
+    @action
+    private applyTopology(topology: ITopology): void {
+        const version = topology.networkVersion;
+        const nodeSet = new ObservableSet<string>(topology.nodes);
+        const connectionSet = new ObservableSet<string>();
+        const neighborMap = new ObservableMap<string, INeighbors>();
+        for (const link of topology.links) {
+            connectionSet.add(link.source + link.target);
+            if (neighborMap.get(link.source) === undefined) {
+                neighborMap.set(link.source, new Neighbors());
+            }
+            if (neighborMap.get(link.target) === undefined) {
+                neighborMap.set(link.target, new Neighbors());
+            }
+            // @ts-ignore
+            neighborMap.get(link.source).out.add(link.target);
+            // @ts-ignore
+            neighborMap.get(link.target).in.add(link.source);
+        }
+
+        this.clearNodeSelection();
+        this.versions.add(version);
+        this.nodes.set(version, nodeSet);
+        this.connections.set(version, connectionSet);
+        this.neighbors.set(version, neighborMap);
+
+        if (this.graph && this.selectedNetworkVersion === version) {
+            this.stop();
+            this.start();
+        }
+    }
+
     @action
     private autoSelectNetwork = () => {
         if (this.versions.size === 0) {
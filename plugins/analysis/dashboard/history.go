@@ -0,0 +1,166 @@
+package dashboard
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+
+	analysisserver "github.com/iotaledger/goshimmer/plugins/analysis/server"
+)
+
+// the maximum time range that can be queried at once from the history.
+const maxHistoryQueryRange = 24 * time.Hour
+
+// timeline is the JSON response of the timeline route.
+type timeline struct {
+	Enabled bool  `json:"enabled"`
+	Start   int64 `json:"start"`
+	End     int64 `json:"end"`
+}
+
+// metricHeartbeat is the JSON representation of a stored metric heartbeat.
+type metricHeartbeat struct {
+	NodeID   string  `json:"nodeID"`
+	Time     int64   `json:"time"`
+	OS       string  `json:"os"`
+	Arch     string  `json:"arch"`
+	NumCPU   int     `json:"numCPU"`
+	CPUUsage float64 `json:"cpuUsage"`
+	MemUsage uint64  `json:"memUsage"`
+}
+
+func setupHistoryRoutes(e *echo.Echo) {
+	e.GET("/api/history/timeline", timelineHandler)
+	e.GET("/api/history/topology", topologyHandler)
+	e.GET("/api/history/metrics", metricHeartbeatsHandler)
+	e.GET("/api/history/fpc", fpcConflictRecordsHandler)
+}
+
+// returns the time range which is covered by the history in unix milliseconds.
+func timelineHandler(c echo.Context) error {
+	history := analysisserver.GetHistory()
+	if history == nil {
+		return c.JSON(http.StatusOK, &timeline{})
+	}
+	now := time.Now()
+	start, ok := history.Timeline()
+	if !ok {
+		start = now
+	}
+	return c.JSON(http.StatusOK, &timeline{
+		Enabled: true,
+		Start:   toUnixMilli(start),
+		End:     toUnixMilli(now),
+	})
+}
+
+// returns the autopeering graph of a network at the given time, or the live graph if no time is given.
+func topologyHandler(c echo.Context) error {
+	topology, err := requestedTopology(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, topology)
+}
+
+// requestedTopology returns the topology of the network given by the networkVersion query parameter.
+// If the time query parameter is set, the topology is reconstructed from the history.
+func requestedTopology(c echo.Context) (*analysisserver.Topology, error) {
+	networkVersion := c.QueryParam("networkVersion")
+	if c.QueryParam("time") == "" {
+		networkMap, exists := analysisserver.Networks[networkVersion]
+		if !exists {
+			return nil, errors.Wrapf(ErrNotFound, "unknown network version %s", networkVersion)
+		}
+		return networkMap.Topology(), nil
+	}
+
+	history := analysisserver.GetHistory()
+	if history == nil {
+		return nil, errors.Wrap(ErrNotFound, "history is disabled")
+	}
+	t, err := parseUnixMilliParam(c, "time")
+	if err != nil {
+		return nil, err
+	}
+	topology, err := history.Topology(networkVersion, t)
+	if err != nil {
+		return nil, errors.Wrap(ErrInternalError, err.Error())
+	}
+	return topology, nil
+}
+
+// returns the metric heartbeats received in the given time range.
+func metricHeartbeatsHandler(c echo.Context) error {
+	history := analysisserver.GetHistory()
+	if history == nil {
+		return errors.Wrap(ErrNotFound, "history is disabled")
+	}
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		return err
+	}
+	heartbeats, err := history.MetricHeartbeats(from, to)
+	if err != nil {
+		return errors.Wrap(ErrInternalError, err.Error())
+	}
+	result := make([]*metricHeartbeat, len(heartbeats))
+	for i, timedHeartbeat := range heartbeats {
+		hb := timedHeartbeat.MetricHeartbeat
+		result[i] = &metricHeartbeat{
+			NodeID:   analysisserver.ShortNodeIDString(hb.OwnID),
+			Time:     toUnixMilli(timedHeartbeat.Time),
+			OS:       hb.OS,
+			Arch:     hb.Arch,
+			NumCPU:   hb.NumCPU,
+			CPUUsage: hb.CPUUsage,
+			MemUsage: hb.MemoryUsage,
+		}
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// returns the FPC conflicts that were reported as finalized in the given time range.
+func fpcConflictRecordsHandler(c echo.Context) error {
+	history := analysisserver.GetHistory()
+	if history == nil {
+		return errors.Wrap(ErrNotFound, "history is disabled")
+	}
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		return err
+	}
+	records, err := history.FPCConflictRecords(from, to)
+	if err != nil {
+		return errors.Wrap(ErrInternalError, err.Error())
+	}
+	return c.JSON(http.StatusOK, records)
+}
+
+func parseTimeRange(c echo.Context) (from, to time.Time, err error) {
+	if from, err = parseUnixMilliParam(c, "from"); err != nil {
+		return
+	}
+	if to, err = parseUnixMilliParam(c, "to"); err != nil {
+		return
+	}
+	if to.Before(from) || to.Sub(from) > maxHistoryQueryRange {
+		err = errors.Wrapf(ErrInvalidParameter, "time range must be positive and at most %s", maxHistoryQueryRange)
+	}
+	return
+}
+
+func parseUnixMilliParam(c echo.Context, name string) (time.Time, error) {
+	value, err := strconv.ParseInt(c.QueryParam(name), 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(ErrInvalidParameter, "invalid %s parameter: %s", name, err)
+	}
+	return time.Unix(0, value*int64(time.Millisecond)), nil
+}
+
+func toUnixMilli(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -84,6 +84,8 @@ func setupRoutes(e *echo.Echo) {
 	}
 
 	e.GET("/ws", websocketRoute)
+	setupHistoryRoutes(e)
+	setupTopologyRoutes(e)
 	e.GET("/", indexRoute)
 
 	// used to route into the dashboard index
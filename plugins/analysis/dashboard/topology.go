@@ -0,0 +1,54 @@
+package dashboard
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+)
+
+const (
+	exportFormatJSON    = "json"
+	exportFormatGraphML = "graphml"
+)
+
+func setupTopologyRoutes(e *echo.Echo) {
+	e.GET("/api/topology/metrics", topologyMetricsHandler)
+	e.GET("/api/topology/export", topologyExportHandler)
+}
+
+// returns the graph metrics of the requested topology.
+func topologyMetricsHandler(c echo.Context) error {
+	topology, err := requestedTopology(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, topology.Metrics())
+}
+
+// returns the requested topology as a downloadable JSON or GraphML file.
+func topologyExportHandler(c echo.Context) error {
+	topology, err := requestedTopology(c)
+	if err != nil {
+		return err
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = exportFormatJSON
+	}
+	fileName := fmt.Sprintf("topology-%s-%d.%s", topology.NetworkVersion, toUnixMilli(topology.Time), format)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+
+	switch format {
+	case exportFormatJSON:
+		return c.JSON(http.StatusOK, topology)
+	case exportFormatGraphML:
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationXMLCharsetUTF8)
+		c.Response().WriteHeader(http.StatusOK)
+		return topology.WriteGraphML(c.Response())
+	default:
+		return errors.Wrapf(ErrInvalidParameter, "unsupported export format %s", format)
+	}
+}
@@ -145,6 +145,8 @@ func runEventsRecordManager() {
 				return
 			case <-ticker.C:
 				cleanUp(cleanUpPeriod)
+				detectPartitions()
+				pruneHistory()
 			}
 		}
 	}, shutdown.PriorityAnalysis); err != nil {
@@ -183,6 +185,16 @@ func cleanUp(interval time.Duration) {
 	}
 }
 
+// removes records from the history that are older than its retention period.
+func pruneHistory() {
+	if history == nil {
+		return
+	}
+	if err := history.Prune(time.Now()); err != nil {
+		Events.Error.Trigger(err)
+	}
+}
+
 func (nm *NetworkMap) getEventsToReplay() (map[string]time.Time, map[string]map[string]time.Time) {
 	nm.lock.RLock()
 	defer nm.lock.RUnlock()
@@ -237,6 +249,23 @@ type EventHandlers struct {
 // EventHandlersConsumer defines the consumer function of an *EventHandlers.
 type EventHandlersConsumer = func(handler *EventHandlers)
 
+// Topology returns a snapshot of the current autopeering graph of the network.
+func (nm *NetworkMap) Topology() *Topology {
+	nm.lock.RLock()
+	defer nm.lock.RUnlock()
+	nodes := make(map[string]struct{}, len(nm.nodes))
+	for nodeID := range nm.nodes {
+		nodes[nodeID] = struct{}{}
+	}
+	links := make(map[TopologyLink]struct{})
+	for sourceID, targetMap := range nm.links {
+		for targetID := range targetMap {
+			links[TopologyLink{Source: sourceID, Target: targetID}] = struct{}{}
+		}
+	}
+	return newTopology(nm.version, time.Now(), nodes, links)
+}
+
 //// Methods for metrics calculation plugin ////
 
 // NumOfNeighbors returns a map of nodeIDs to their neighbor count.
@@ -24,6 +24,10 @@ var Events = struct {
 	FPCHeartbeat *events.Event
 	// MetricHeartbeat triggers when an MetricHeartbeat heartbeat has been received.
 	MetricHeartbeat *events.Event
+	// NetworkPartitioned triggers when a network splits up into more partitions.
+	NetworkPartitioned *events.Event
+	// NetworkHealed triggers when a partitioned network becomes connected again.
+	NetworkHealed *events.Event
 }{
 	events.NewEvent(addNodeCaller),
 	events.NewEvent(removeNodeCaller),
@@ -33,6 +37,8 @@ var Events = struct {
 	events.NewEvent(heartbeatPacketCaller),
 	events.NewEvent(fpcHeartbeatPacketCaller),
 	events.NewEvent(metricHeartbeatPacketCaller),
+	events.NewEvent(partitionCaller),
+	events.NewEvent(partitionCaller),
 }
 
 // AddNodeEvent is the payload type of an AddNode event.
@@ -77,6 +83,10 @@ func disconnectNodesCaller(handler interface{}, params ...interface{}) {
 	handler.(func(*DisconnectNodesEvent))(params[0].(*DisconnectNodesEvent))
 }
 
+func partitionCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*PartitionEvent))(params[0].(*PartitionEvent))
+}
+
 func errorCaller(handler interface{}, params ...interface{}) {
 	handler.(func(error))(params[0].(error))
 }
@@ -0,0 +1,316 @@
+package server
+
+import (
+	"encoding/binary"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/kvstore"
+
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+	"github.com/iotaledger/goshimmer/plugins/analysis/packet"
+)
+
+// historyBucketDuration defines the time span of records that share the same key prefix.
+const historyBucketDuration = time.Minute
+
+const (
+	recordTypeHeartbeat byte = iota
+	recordTypeFPCHeartbeat
+	recordTypeMetricHeartbeat
+	recordTypeMetadata
+)
+
+var firstBucketKey = []byte{recordTypeMetadata}
+
+// History stores the packets received by the analysis server in a time-series layout.
+// Every record is keyed by its type, the bucket of its arrival time, its exact arrival time and the sender,
+// so that the records of a time range can be retrieved by iterating over a few bucket prefixes.
+type History struct {
+	store       kvstore.KVStore
+	retention   time.Duration
+	firstBucket int64
+	mutex       sync.RWMutex
+}
+
+// TimedHeartbeat is a Heartbeat together with the time it was received by the analysis server.
+type TimedHeartbeat struct {
+	Time      time.Time
+	Heartbeat *packet.Heartbeat
+}
+
+// TimedMetricHeartbeat is a MetricHeartbeat together with the time it was received by the analysis server.
+type TimedMetricHeartbeat struct {
+	Time            time.Time
+	MetricHeartbeat *packet.MetricHeartbeat
+}
+
+// FPCConflictRecord defines a conflict that a node reported as finalized.
+type FPCConflictRecord struct {
+	ConflictID string          `json:"conflictID"`
+	NodeID     string          `json:"nodeID"`
+	Outcome    opinion.Opinion `json:"outcome"`
+	Time       time.Time       `json:"time"`
+}
+
+// Topology is a snapshot of the autopeering graph of a network.
+type Topology struct {
+	NetworkVersion string          `json:"networkVersion"`
+	Time           time.Time       `json:"time"`
+	Nodes          []string        `json:"nodes"`
+	Links          []*TopologyLink `json:"links"`
+}
+
+// TopologyLink is a directed connection between two nodes of a Topology.
+type TopologyLink struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// NewHistory creates a new History on top of the given store, records older than retention are pruned.
+func NewHistory(store kvstore.KVStore, retention time.Duration) (*History, error) {
+	h := &History{
+		store:       store,
+		retention:   retention,
+		firstBucket: -1,
+	}
+	value, err := store.Get(firstBucketKey)
+	if err != nil && !errors.Is(err, kvstore.ErrKeyNotFound) {
+		return nil, errors.Wrap(err, "failed to load the first bucket of the analysis history")
+	}
+	if err == nil {
+		h.firstBucket = int64(binary.BigEndian.Uint64(value))
+	}
+	return h, nil
+}
+
+// RecordHeartbeat stores the serialized heartbeat received at the given time.
+func (h *History) RecordHeartbeat(t time.Time, hb *packet.Heartbeat, data []byte) error {
+	return h.record(recordTypeHeartbeat, t, hb.OwnID, data)
+}
+
+// RecordFPCHeartbeat stores the serialized FPC heartbeat received at the given time.
+func (h *History) RecordFPCHeartbeat(t time.Time, hb *packet.FPCHeartbeat, data []byte) error {
+	return h.record(recordTypeFPCHeartbeat, t, hb.OwnID, data)
+}
+
+// RecordMetricHeartbeat stores the serialized metric heartbeat received at the given time.
+func (h *History) RecordMetricHeartbeat(t time.Time, hb *packet.MetricHeartbeat, data []byte) error {
+	return h.record(recordTypeMetricHeartbeat, t, hb.OwnID, data)
+}
+
+// Heartbeats returns the heartbeats received in the time range [from, to] ordered by their arrival.
+func (h *History) Heartbeats(from, to time.Time) ([]*TimedHeartbeat, error) {
+	var result []*TimedHeartbeat
+	err := h.forEachRecord(recordTypeHeartbeat, from, to, func(t time.Time, data []byte) error {
+		hb, err := packet.ParseHeartbeat(data)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse stored heartbeat")
+		}
+		result = append(result, &TimedHeartbeat{Time: t, Heartbeat: hb})
+		return nil
+	})
+	return result, err
+}
+
+// MetricHeartbeats returns the metric heartbeats received in the time range [from, to] ordered by their arrival.
+// Heartbeats that were sent by a different version of GoShimmer are skipped.
+func (h *History) MetricHeartbeats(from, to time.Time) ([]*TimedMetricHeartbeat, error) {
+	var result []*TimedMetricHeartbeat
+	err := h.forEachRecord(recordTypeMetricHeartbeat, from, to, func(t time.Time, data []byte) error {
+		hb, err := packet.ParseMetricHeartbeat(data)
+		if errors.Is(err, packet.ErrInvalidMetricHeartbeatVersion) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to parse stored metric heartbeat")
+		}
+		result = append(result, &TimedMetricHeartbeat{Time: t, MetricHeartbeat: hb})
+		return nil
+	})
+	return result, err
+}
+
+// FPCConflictRecords returns the conflicts that were reported as finalized in the time range [from, to].
+// Heartbeats that were sent by a different version of GoShimmer are skipped.
+func (h *History) FPCConflictRecords(from, to time.Time) ([]*FPCConflictRecord, error) {
+	var result []*FPCConflictRecord
+	err := h.forEachRecord(recordTypeFPCHeartbeat, from, to, func(t time.Time, data []byte) error {
+		hb, err := packet.ParseFPCHeartbeat(data)
+		if errors.Is(err, packet.ErrInvalidFPCHeartbeatVersion) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to parse stored FPC heartbeat")
+		}
+		nodeID := ShortNodeIDString(hb.OwnID)
+		for conflictID, outcome := range hb.Finalized {
+			result = append(result, &FPCConflictRecord{
+				ConflictID: conflictID,
+				NodeID:     nodeID,
+				Outcome:    outcome,
+				Time:       t,
+			})
+		}
+		return nil
+	})
+	return result, err
+}
+
+// Topology reconstructs the autopeering graph of the given network as it was at time t.
+// Like the live NetworkMap, it contains the nodes and links that were reported within cleanUpPeriod before t.
+func (h *History) Topology(networkVersion string, t time.Time) (*Topology, error) {
+	heartbeats, err := h.Heartbeats(t.Add(-cleanUpPeriod), t)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	nodes := make(map[string]struct{})
+	links := make(map[TopologyLink]struct{})
+	for _, timedHeartbeat := range heartbeats {
+		hb := timedHeartbeat.Heartbeat
+		if string(hb.NetworkID) != networkVersion {
+			continue
+		}
+		nodeID := ShortNodeIDString(hb.OwnID)
+		nodes[nodeID] = struct{}{}
+		for _, outboundID := range hb.OutboundIDs {
+			neighborID := ShortNodeIDString(outboundID)
+			nodes[neighborID] = struct{}{}
+			links[TopologyLink{Source: nodeID, Target: neighborID}] = struct{}{}
+		}
+		for _, inboundID := range hb.InboundIDs {
+			neighborID := ShortNodeIDString(inboundID)
+			nodes[neighborID] = struct{}{}
+			links[TopologyLink{Source: neighborID, Target: nodeID}] = struct{}{}
+		}
+	}
+
+	return newTopology(networkVersion, t, nodes, links), nil
+}
+
+// Timeline returns the time of the oldest bucket in the history and whether the history contains any records.
+func (h *History) Timeline() (start time.Time, ok bool) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	if h.firstBucket < 0 {
+		return time.Time{}, false
+	}
+	return bucketTime(h.firstBucket), true
+}
+
+// Prune removes all the records that are older than the retention period.
+func (h *History) Prune(now time.Time) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.firstBucket < 0 {
+		return nil
+	}
+	cutoffBucket := bucketOf(now.Add(-h.retention))
+	for ; h.firstBucket < cutoffBucket; h.firstBucket++ {
+		for _, recordType := range []byte{recordTypeHeartbeat, recordTypeFPCHeartbeat, recordTypeMetricHeartbeat} {
+			if err := h.store.DeletePrefix(bucketPrefix(recordType, h.firstBucket)); err != nil {
+				return errors.Wrap(err, "failed to prune the analysis history")
+			}
+		}
+	}
+	return errors.WithStack(h.storeFirstBucket())
+}
+
+func (h *History) record(recordType byte, t time.Time, ownID []byte, data []byte) error {
+	bucket := bucketOf(t)
+	key := make([]byte, 0, 1+8+8+len(ownID))
+	key = append(key, bucketPrefix(recordType, bucket)...)
+	key = append(key, uint64Bytes(uint64(t.UnixNano()))...)
+	key = append(key, ownID...)
+	if err := h.store.Set(key, data); err != nil {
+		return errors.Wrap(err, "failed to store analysis record")
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.firstBucket >= 0 && h.firstBucket <= bucket {
+		return nil
+	}
+	h.firstBucket = bucket
+	return errors.WithStack(h.storeFirstBucket())
+}
+
+// forEachRecord calls consumer for every record of the given type in the time range [from, to], ordered by time.
+func (h *History) forEachRecord(recordType byte, from, to time.Time, consumer func(t time.Time, data []byte) error) error {
+	type timedRecord struct {
+		time time.Time
+		data []byte
+	}
+	var records []*timedRecord
+	for bucket := bucketOf(from); bucket <= bucketOf(to); bucket++ {
+		prefix := bucketPrefix(recordType, bucket)
+		if err := h.store.Iterate(prefix, func(key kvstore.Key, value kvstore.Value) bool {
+			t := time.Unix(0, int64(binary.BigEndian.Uint64(key[len(prefix):len(prefix)+8])))
+			if t.Before(from) || t.After(to) {
+				return true
+			}
+			records = append(records, &timedRecord{time: t, data: append([]byte{}, value...)})
+			return true
+		}); err != nil {
+			return errors.Wrap(err, "failed to iterate over analysis records")
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].time.Before(records[j].time)
+	})
+	for _, r := range records {
+		if err := consumer(r.time, r.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *History) storeFirstBucket() error {
+	return h.store.Set(firstBucketKey, uint64Bytes(uint64(h.firstBucket)))
+}
+
+func newTopology(networkVersion string, t time.Time, nodes map[string]struct{}, links map[TopologyLink]struct{}) *Topology {
+	topology := &Topology{
+		NetworkVersion: networkVersion,
+		Time:           t,
+		Nodes:          make([]string, 0, len(nodes)),
+		Links:          make([]*TopologyLink, 0, len(links)),
+	}
+	for nodeID := range nodes {
+		topology.Nodes = append(topology.Nodes, nodeID)
+	}
+	for link := range links {
+		link := link
+		topology.Links = append(topology.Links, &link)
+	}
+	sort.Strings(topology.Nodes)
+	sort.Slice(topology.Links, func(i, j int) bool {
+		if topology.Links[i].Source != topology.Links[j].Source {
+			return topology.Links[i].Source < topology.Links[j].Source
+		}
+		return topology.Links[i].Target < topology.Links[j].Target
+	})
+	return topology
+}
+
+func bucketOf(t time.Time) int64 {
+	return t.UnixNano() / int64(historyBucketDuration)
+}
+
+func bucketTime(bucket int64) time.Time {
+	return time.Unix(0, bucket*int64(historyBucketDuration))
+}
+
+func bucketPrefix(recordType byte, bucket int64) []byte {
+	return append([]byte{recordType}, uint64Bytes(uint64(bucket))...)
+}
+
+func uint64Bytes(value uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, value)
+	return b
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/protocol/tlv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/plugins/analysis/packet"
+)
+
+func TestHistory_Topology(t *testing.T) {
+	history, err := NewHistory(mapdb.NewMapDB(), time.Hour)
+	require.NoError(t, err)
+
+	_, ok := history.Timeline()
+	assert.False(t, ok)
+
+	nodeA, nodeB, nodeC := sha256.Sum256([]byte{'A'}), sha256.Sum256([]byte{'B'}), sha256.Sum256([]byte{'C'})
+	start := time.Now().Add(-30 * time.Minute)
+
+	// A -> B is reported at the start, A -> C one minute later
+	recordHeartbeat(t, history, start, &packet.Heartbeat{NetworkID: []byte("v0.1.0"), OwnID: nodeA[:], OutboundIDs: [][]byte{nodeB[:]}})
+	recordHeartbeat(t, history, start.Add(time.Minute), &packet.Heartbeat{NetworkID: []byte("v0.1.0"), OwnID: nodeA[:], OutboundIDs: [][]byte{nodeC[:]}})
+	// heartbeats of other networks are ignored
+	recordHeartbeat(t, history, start, &packet.Heartbeat{NetworkID: []byte("v0.2.0"), OwnID: nodeC[:]})
+
+	timelineStart, ok := history.Timeline()
+	require.True(t, ok)
+	assert.False(t, timelineStart.After(start))
+
+	topology, err := history.Topology("v0.1.0", start.Add(time.Second))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{ShortNodeIDString(nodeA[:]), ShortNodeIDString(nodeB[:])}, topology.Nodes)
+	assert.Equal(t, []*TopologyLink{{Source: ShortNodeIDString(nodeA[:]), Target: ShortNodeIDString(nodeB[:])}}, topology.Links)
+
+	topology, err = history.Topology("v0.1.0", start.Add(time.Minute+time.Second))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{ShortNodeIDString(nodeA[:]), ShortNodeIDString(nodeC[:])}, topology.Nodes)
+	assert.Equal(t, []*TopologyLink{{Source: ShortNodeIDString(nodeA[:]), Target: ShortNodeIDString(nodeC[:])}}, topology.Links)
+
+	// nothing was reported within the clean up period
+	topology, err = history.Topology("v0.1.0", start.Add(10*time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, topology.Nodes)
+
+	// all records are older than the retention period
+	require.NoError(t, history.Prune(start.Add(2*time.Hour)))
+	heartbeats, err := history.Heartbeats(start.Add(-time.Minute), start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, heartbeats)
+}
+
+func recordHeartbeat(t *testing.T, history *History, receivedAt time.Time, hb *packet.Heartbeat) {
+	msg, err := packet.NewHeartbeatMessage(hb)
+	require.NoError(t, err)
+	require.NoError(t, history.RecordHeartbeat(receivedAt, hb, msg[tlv.HeaderMessageDefinition.MaxBytesLength:]))
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"sync"
+)
+
+// minPartitionComponentSize defines the minimum size of a connected component to be considered a partition.
+// Single nodes without neighbors are ignored, since freshly started nodes are not connected yet.
+const minPartitionComponentSize = 2
+
+var (
+	// maps the network version to the number of partitions that were detected in the last check.
+	partitionCounts      = make(map[string]int)
+	partitionCountsMutex sync.Mutex
+)
+
+// PartitionEvent is the payload type of the NetworkPartitioned and NetworkHealed events.
+type PartitionEvent struct {
+	NetworkVersion string
+	// Partitions contains the node IDs of every connected component of the network, the largest first.
+	Partitions [][]string
+}
+
+// detectPartitions checks the connectivity of every known network
+// and triggers an event whenever a network splits up or becomes connected again.
+func detectPartitions() {
+	partitionCountsMutex.Lock()
+	defer partitionCountsMutex.Unlock()
+
+	for networkVersion, networkMap := range Networks {
+		var partitions [][]string
+		for _, component := range networkMap.NetworkGraph().ConnectedComponents() {
+			if len(component) >= minPartitionComponentSize {
+				partitions = append(partitions, component)
+			}
+		}
+
+		previousCount := partitionCounts[networkVersion]
+		partitionCounts[networkVersion] = len(partitions)
+		switch {
+		case len(partitions) > 1 && len(partitions) > previousCount:
+			Events.NetworkPartitioned.Trigger(&PartitionEvent{NetworkVersion: networkVersion, Partitions: partitions})
+		case len(partitions) <= 1 && previousCount > 1:
+			Events.NetworkHealed.Trigger(&PartitionEvent{NetworkVersion: networkVersion, Partitions: partitions})
+		}
+	}
+}
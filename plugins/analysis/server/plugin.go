@@ -18,9 +18,11 @@ import (
 	"github.com/iotaledger/hive.go/protocol"
 	flag "github.com/spf13/pflag"
 
+	databasePkg "github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/plugins/analysis/packet"
 	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/goshimmer/plugins/database"
 )
 
 const (
@@ -30,12 +32,20 @@ const (
 	// CfgAnalysisServerBindAddress defines the bind address of the analysis server.
 	CfgAnalysisServerBindAddress = "analysis.server.bindAddress"
 
+	// CfgAnalysisServerHistoryEnabled defines whether the analysis server stores the received heartbeats.
+	CfgAnalysisServerHistoryEnabled = "analysis.server.history.enabled"
+
+	// CfgAnalysisServerHistoryRetention defines for how long the analysis server keeps the received heartbeats.
+	CfgAnalysisServerHistoryRetention = "analysis.server.history.retention"
+
 	// IdleTimeout defines the idle timeout of the read from the client's connection.
 	IdleTimeout = 1 * time.Minute
 )
 
 func init() {
 	flag.String(CfgAnalysisServerBindAddress, "0.0.0.0:16178", "the bind address of the analysis server")
+	flag.Bool(CfgAnalysisServerHistoryEnabled, true, "whether the analysis server stores the received heartbeats")
+	flag.Duration(CfgAnalysisServerHistoryRetention, 7*24*time.Hour, "for how long the analysis server keeps the received heartbeats")
 }
 
 var (
@@ -45,6 +55,8 @@ var (
 	server *tcp.TCPServer
 	prot   *protocol.Protocol
 	log    *logger.Logger
+
+	history *History
 )
 
 // Plugin gets the plugin instance.
@@ -55,10 +67,26 @@ func Plugin() *node.Plugin {
 	return plugin
 }
 
+// GetHistory returns the history of the received heartbeats or nil if it is disabled.
+func GetHistory() *History {
+	return history
+}
+
 func configure(_ *node.Plugin) {
 	log = logger.NewLogger(PluginName)
 	server = tcp.NewServer()
 
+	if config.Node().Bool(CfgAnalysisServerHistoryEnabled) {
+		var err error
+		history, err = NewHistory(
+			database.StoreRealm([]byte{databasePkg.PrefixAnalysis}),
+			config.Node().Duration(CfgAnalysisServerHistoryRetention),
+		)
+		if err != nil {
+			log.Fatalf("Failed to load the history of the analysis server: %s", err)
+		}
+	}
+
 	server.Events.Connect.Attach(events.NewClosure(HandleConnection))
 	server.Events.Error.Attach(events.NewClosure(func(err error) {
 		log.Errorf("error in server: %s", err.Error())
@@ -66,6 +94,12 @@ func configure(_ *node.Plugin) {
 	Events.Error.Attach(events.NewClosure(func(err error) {
 		log.Errorf("error in analysis server: %s", err.Error())
 	}))
+	Events.NetworkPartitioned.Attach(events.NewClosure(func(ev *PartitionEvent) {
+		log.Warnf("network %s is partitioned into %d parts", ev.NetworkVersion, len(ev.Partitions))
+	}))
+	Events.NetworkHealed.Attach(events.NewClosure(func(ev *PartitionEvent) {
+		log.Infof("network %s is not partitioned anymore", ev.NetworkVersion)
+	}))
 }
 
 func run(_ *node.Plugin) {
@@ -147,6 +181,11 @@ func processHeartbeatPacket(data []byte) {
 		return
 	}
 	updateAutopeeringMap(heartbeatPacket)
+	if history != nil {
+		if err := history.RecordHeartbeat(time.Now(), heartbeatPacket, data); err != nil {
+			Events.Error.Trigger(err)
+		}
+	}
 }
 
 // processHeartbeatPacket parses the serialized data into a FPC Heartbeat packet and triggers its event.
@@ -161,6 +200,11 @@ func processFPCHeartbeatPacket(data []byte) {
 		return
 	}
 	Events.FPCHeartbeat.Trigger(hb)
+	if history != nil {
+		if err := history.RecordFPCHeartbeat(time.Now(), hb, data); err != nil {
+			Events.Error.Trigger(err)
+		}
+	}
 }
 
 // processMetricHeartbeatPacket parses the serialized data into a Metric Heartbeat packet and triggers its event.
@@ -175,4 +219,9 @@ func processMetricHeartbeatPacket(data []byte) {
 		return
 	}
 	Events.MetricHeartbeat.Trigger(hb)
+	if history != nil {
+		if err := history.RecordMetricHeartbeat(time.Now(), hb, data); err != nil {
+			Events.Error.Trigger(err)
+		}
+	}
 }
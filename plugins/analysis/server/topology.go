@@ -0,0 +1,167 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/xml"
+	"io"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/graph"
+)
+
+const (
+	// graphMLNamespace is the XML namespace of GraphML documents.
+	graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+	// maxMinCutNodeCount is the largest topology whose min cut is computed, as its cost grows with the cube of the
+	// number of nodes.
+	maxMinCutNodeCount = 500
+	// metricsCacheSize is the number of topologies whose metrics are cached.
+	metricsCacheSize = 16
+)
+
+// metricsCache contains the metrics of the recently requested topologies by their fingerprint, so that they are only
+// computed again if the topology changed.
+var metricsCache = struct {
+	entries map[[sha256.Size]byte]*TopologyMetrics
+	order   [][sha256.Size]byte
+	sync.Mutex
+}{entries: make(map[[sha256.Size]byte]*TopologyMetrics)}
+
+// TopologyMetrics contains graph metrics of a Topology, links are treated as undirected. MinCut is -1 if the topology
+// has too many nodes to compute it.
+type TopologyMetrics struct {
+	NetworkVersion       string      `json:"networkVersion"`
+	NodeCount            int         `json:"nodeCount"`
+	LinkCount            int         `json:"linkCount"`
+	ConnectedComponents  int         `json:"connectedComponents"`
+	LargestComponentSize int         `json:"largestComponentSize"`
+	Diameter             int         `json:"diameter"`
+	MinCut               int         `json:"minCut"`
+	DegreeDistribution   map[int]int `json:"degreeDistribution"`
+}
+
+// Graph returns the undirected graph of the topology.
+func (t *Topology) Graph() *graph.Graph {
+	g := graph.New(t.Nodes)
+	for _, link := range t.Links {
+		g.AddEdge(link.Source, link.Target)
+	}
+	return g
+}
+
+// Metrics returns the graph metrics of the topology. They are cached, so that they are only computed again if the
+// topology changed.
+func (t *Topology) Metrics() *TopologyMetrics {
+	fingerprint := t.fingerprint()
+	metricsCache.Lock()
+	cached, exists := metricsCache.entries[fingerprint]
+	metricsCache.Unlock()
+	if exists {
+		metrics := *cached
+		return &metrics
+	}
+
+	metrics := t.computeMetrics()
+	metricsCache.Lock()
+	defer metricsCache.Unlock()
+	if _, exists := metricsCache.entries[fingerprint]; !exists {
+		if len(metricsCache.order) == metricsCacheSize {
+			delete(metricsCache.entries, metricsCache.order[0])
+			metricsCache.order = metricsCache.order[1:]
+		}
+		metricsCache.entries[fingerprint] = metrics
+		metricsCache.order = append(metricsCache.order, fingerprint)
+	}
+	cachedMetrics := *metrics
+	return &cachedMetrics
+}
+
+// computeMetrics computes the graph metrics of the topology.
+func (t *Topology) computeMetrics() *TopologyMetrics {
+	g := t.Graph()
+	components := g.ConnectedComponents()
+	metrics := &TopologyMetrics{
+		NetworkVersion:      t.NetworkVersion,
+		NodeCount:           len(t.Nodes),
+		LinkCount:           len(t.Links),
+		ConnectedComponents: len(components),
+		Diameter:            g.Diameter(),
+		MinCut:              -1,
+		DegreeDistribution:  g.DegreeDistribution(),
+	}
+	if len(components) > 0 {
+		metrics.LargestComponentSize = len(components[0])
+	}
+	if len(t.Nodes) <= maxMinCutNodeCount {
+		metrics.MinCut = g.MinCut()
+	}
+	return metrics
+}
+
+// fingerprint returns a hash of the network version, the nodes and the links of the topology, which are sorted.
+func (t *Topology) fingerprint() (fingerprint [sha256.Size]byte) {
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, t.NetworkVersion)
+	for _, nodeID := range t.Nodes {
+		_, _ = io.WriteString(hash, "\x00"+nodeID)
+	}
+	for _, link := range t.Links {
+		_, _ = io.WriteString(hash, "\x01"+link.Source+"\x00"+link.Target)
+	}
+	copy(fingerprint[:], hash.Sum(nil))
+	return fingerprint
+}
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID string `xml:"id,attr"`
+}
+
+type graphMLEdge struct {
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+}
+
+// WriteGraphML writes the topology as a directed GraphML document to w.
+func (t *Topology) WriteGraphML(w io.Writer) error {
+	doc := &graphMLDocument{
+		XMLNS: graphMLNamespace,
+		Graph: graphMLGraph{
+			ID:          t.NetworkVersion,
+			EdgeDefault: "directed",
+			Nodes:       make([]graphMLNode, len(t.Nodes)),
+			Edges:       make([]graphMLEdge, len(t.Links)),
+		},
+	}
+	for i, nodeID := range t.Nodes {
+		doc.Graph.Nodes[i] = graphMLNode{ID: nodeID}
+	}
+	for i, link := range t.Links {
+		doc.Graph.Edges[i] = graphMLEdge{Source: link.Source, Target: link.Target}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return errors.Wrap(err, "failed to write GraphML header")
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return errors.Wrap(err, "failed to encode topology as GraphML")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopology_Metrics(t *testing.T) {
+	topology := newTopology("v0.1.0", time.Now(),
+		map[string]struct{}{"A": {}, "B": {}, "C": {}, "D": {}},
+		map[TopologyLink]struct{}{{Source: "A", Target: "B"}: {}, {Source: "B", Target: "C"}: {}},
+	)
+
+	metrics := topology.Metrics()
+	assert.Equal(t, 4, metrics.NodeCount)
+	assert.Equal(t, 2, metrics.LinkCount)
+	assert.Equal(t, 2, metrics.ConnectedComponents)
+	assert.Equal(t, 3, metrics.LargestComponentSize)
+	assert.Equal(t, 2, metrics.Diameter)
+	assert.Equal(t, 0, metrics.MinCut)
+	assert.Equal(t, map[int]int{0: 1, 1: 2, 2: 1}, metrics.DegreeDistribution)
+}
+
+func TestTopology_Metrics_Cached(t *testing.T) {
+	nodes := map[string]struct{}{"A": {}, "B": {}, "C": {}}
+	links := map[TopologyLink]struct{}{{Source: "A", Target: "B"}: {}, {Source: "B", Target: "C"}: {}, {Source: "C", Target: "A"}: {}}
+	topology := newTopology("v0.1.0", time.Now(), nodes, links)
+
+	metrics := topology.Metrics()
+	assert.Equal(t, 2, metrics.MinCut)
+	metricsCache.Lock()
+	_, cached := metricsCache.entries[topology.fingerprint()]
+	metricsCache.Unlock()
+	assert.True(t, cached)
+
+	// the same topology at a later time uses the cached metrics, a changed one is computed again
+	assert.Equal(t, metrics, newTopology("v0.1.0", time.Now(), nodes, links).Metrics())
+	delete(links, TopologyLink{Source: "C", Target: "A"})
+	assert.Equal(t, 1, newTopology("v0.1.0", time.Now(), nodes, links).Metrics().MinCut)
+}
+
+func TestTopology_Metrics_MinCutNodeLimit(t *testing.T) {
+	nodes := make(map[string]struct{})
+	links := make(map[TopologyLink]struct{})
+	for i := 0; i <= maxMinCutNodeCount; i++ {
+		nodes[fmt.Sprint(i)] = struct{}{}
+		links[TopologyLink{Source: fmt.Sprint(i), Target: fmt.Sprint((i + 1) % (maxMinCutNodeCount + 1))}] = struct{}{}
+	}
+
+	metrics := newTopology("v0.1.0", time.Now(), nodes, links).Metrics()
+	assert.Equal(t, maxMinCutNodeCount+1, metrics.NodeCount)
+	assert.Equal(t, -1, metrics.MinCut)
+}
+
+func TestTopology_WriteGraphML(t *testing.T) {
+	topology := newTopology("v0.1.0", time.Now(),
+		map[string]struct{}{"A": {}, "B": {}},
+		map[TopologyLink]struct{}{{Source: "A", Target: "B"}: {}},
+	)
+
+	var buf bytes.Buffer
+	require.NoError(t, topology.WriteGraphML(&buf))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <graph id="v0.1.0" edgedefault="directed">
+    <node id="A"></node>
+    <node id="B"></node>
+    <edge source="A" target="B"></edge>
+  </graph>
+</graphml>`, buf.String())
+}
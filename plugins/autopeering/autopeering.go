@@ -47,7 +47,9 @@ func createPeerSel() {
 
 	peerSel = selection.New(local.GetInstance(), discovery.Discovery(),
 		selection.Logger(log),
-		selection.NeighborValidator(selection.ValidatorFunc(isValidNeighbor)),
+		selection.NeighborValidator(selection.ValidatorFunc(func(p *peer.Peer) bool {
+			return isValidNeighbor(p) && Diversity().IsValid(p)
+		})),
 		selection.UseMana(Parameters.Mana),
 		selection.ManaFunc(evalMana),
 		selection.R(Parameters.R),
@@ -99,6 +101,10 @@ func start(shutdownSignal <-chan struct{}) {
 	// start the neighbor selection process.
 	Selection().Start(srv)
 
+	if Parameters.Diversity.RotationInterval > 0 {
+		go rotateNeighbors(shutdownSignal)
+	}
+
 	log.Infof("%s started: ID=%s Address=%s/%s", PluginName, lPeer.ID(), localAddr.String(), localAddr.Network())
 
 	<-shutdownSignal
@@ -0,0 +1,81 @@
+package autopeering
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/autopeering/selection"
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/diversity"
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// maxRotationCheckInterval is the maximum time between two checks whether a neighbor needs to be rotated.
+const maxRotationCheckInterval = time.Minute
+
+var (
+	// the diversity constraints of the neighbor selection
+	diversityGuard     *diversity.Guard
+	diversityGuardOnce sync.Once
+)
+
+// Diversity returns the guard that enforces the diversity constraints of the neighbor selection.
+func Diversity() *diversity.Guard {
+	diversityGuardOnce.Do(createDiversityGuard)
+	return diversityGuard
+}
+
+func createDiversityGuard() {
+	options := []diversity.Option{
+		diversity.WithMaxPerSubnet(Parameters.Diversity.MaxPerSubnet),
+		diversity.WithRotationInterval(Parameters.Diversity.RotationInterval),
+	}
+	if Parameters.Diversity.HighManaNeighbor {
+		neighborhoodSize := selection.DefaultInboundNeighborSize + selection.DefaultOutboundNeighborSize
+		options = append(options, diversity.WithHighManaNeighbor(neighborhoodSize, highManaNodes, time.Minute))
+	}
+	diversityGuard = diversity.NewGuard(options...)
+}
+
+// highManaNodes returns the IDs of the nodes with the highest consensus mana.
+func highManaNodes() ([]identity.ID, error) {
+	if !manaEnabled {
+		return nil, nil
+	}
+	nodes, _, err := messagelayer.GetHighestManaNodes(mana.ConsensusMana, Parameters.Diversity.HighManaSetSize)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]identity.ID, len(nodes))
+	for i, node := range nodes {
+		ids[i] = node.ID
+	}
+	return ids, nil
+}
+
+// rotateNeighbors periodically drops the neighbor chosen by the diversity guard, so that the selection replaces it.
+func rotateNeighbors(shutdownSignal <-chan struct{}) {
+	checkInterval := Parameters.Diversity.RotationInterval
+	if checkInterval > maxRotationCheckInterval {
+		checkInterval = maxRotationCheckInterval
+	}
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			id, ok := Diversity().RotationCandidate()
+			if !ok {
+				continue
+			}
+			log.Infof("Rotating neighbor: %s", id)
+			Diversity().RecordRotation()
+			Selection().RemoveNeighbor(id)
+		case <-shutdownSignal:
+			return
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package autopeering
 
-import "github.com/iotaledger/hive.go/configuration"
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/configuration"
+)
 
 // ParametersDefinition contains the definition of configuration parameters used by the autopeering plugin.
 type ParametersDefinition struct {
@@ -14,6 +18,18 @@ type ParametersDefinition struct {
 	Ro float64 `default:"2.0" usage:"Ro parameter"`
 
 	EnableGossipIntegration bool `default:"true" usage:"enable/disable autopeering for gossip layer"`
+
+	// Diversity contains the optional constraints on the neighbor selection that make eclipse attacks harder.
+	Diversity struct {
+		// MaxPerSubnet defines the maximum number of neighbors within the same /16 IPv4 or /48 IPv6 subnet.
+		MaxPerSubnet int `default:"0" usage:"the maximum number of neighbors per /16 IPv4 or /48 IPv6 subnet (0 to disable)"`
+		// HighManaNeighbor defines whether a neighbor slot is reserved for a node of the high consensus mana set.
+		HighManaNeighbor bool `default:"false" usage:"reserve a neighbor slot for a node of the high consensus mana set"`
+		// HighManaSetSize defines the number of nodes with the highest consensus mana that form the high mana set.
+		HighManaSetSize uint `default:"20" usage:"the number of nodes with the highest consensus mana that form the high mana set"`
+		// RotationInterval defines after which time the oldest neighbor is dropped to rotate the neighborhood.
+		RotationInterval time.Duration `default:"0s" usage:"the time after which the oldest neighbor is dropped (0 to disable)"`
+	}
 }
 
 // Parameters contains the configuration parameters of the autopeering plugin.
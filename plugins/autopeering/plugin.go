@@ -133,4 +133,16 @@ func configureEvents() {
 	peerSel.Events().Dropped.Attach(events.NewClosure(func(ev *selection.DroppedEvent) {
 		log.Infof("Peering dropped: %s", ev.DroppedID)
 	}))
+
+	// keep track of the neighborhood for the diversity constraints
+	onPeering := events.NewClosure(func(ev *selection.PeeringEvent) {
+		if ev.Status {
+			Diversity().AddNeighbor(ev.Peer)
+		}
+	})
+	peerSel.Events().OutgoingPeering.Attach(onPeering)
+	peerSel.Events().IncomingPeering.Attach(onPeering)
+	peerSel.Events().Dropped.Attach(events.NewClosure(func(ev *selection.DroppedEvent) {
+		Diversity().RemoveNeighbor(ev.DroppedID)
+	}))
 }
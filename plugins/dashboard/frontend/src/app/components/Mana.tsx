@@ -12,6 +12,7 @@ import ManaGauge from "app/components/ManaGauge";
 import ManaAllowedPledgeID from "app/components/ManaAllowedPledgeID";
 import ManaPercentile from "app/components/ManaPercentile";
 import ManaEventList from "app/components/ManaEventList";
+import ManaFlowGraph from "app/components/ManaFlowGraph";
 
 interface Props {
     nodeStore?: NodeStore;
@@ -22,6 +23,17 @@ interface Props {
 @inject("manaStore")
 @observer
 export class Mana extends React.Component<Props, any> {
+    manaFlowInterval: any;
+
+    componentDidMount(): void {
+        this.props.manaStore.fetchManaFlows();
+        this.manaFlowInterval = setInterval(this.props.manaStore.fetchManaFlows, 30000);
+    }
+
+    componentWillUnmount(): void {
+        clearInterval(this.manaFlowInterval);
+    }
+
     render() {
         let manaStore = this.props.manaStore;
         let nodeStore = this.props.nodeStore;
@@ -183,6 +195,12 @@ export class Mana extends React.Component<Props, any> {
                         <ManaLeaderboard data={manaStore.activeRichestFeedConsensus} title={"Active Consensus Leaderboard"}/>
                     </Col>
                 </Row>
+                <Row className={"mb-3"}>
+                    <Col>
+                        <ManaFlowGraph data={manaStore.manaFlowGraphInput} error={manaStore.manaFlowError}
+                                       coveredSince={manaStore.manaFlowCoveredSince} title={"Consensus Mana Flow"}/>
+                    </Col>
+                </Row>
                 <Row className={"mb-3"}>
                     <Col>
                         <ManaHistogram data={manaStore.accessHistogramInput} title={"Access Distribution"}/>
@@ -0,0 +1,50 @@
+import {observer} from "mobx-react";
+import * as React from "react";
+import Card from "react-bootstrap/Card";
+import {Chart} from "react-google-charts"
+
+interface Props {
+    data;
+    error: string;
+    coveredSince: number;
+    title;
+}
+
+@observer
+export default class ManaFlowGraph extends React.Component<Props, any> {
+    render() {
+        return (
+            <Card>
+                <Card.Body>
+                    <Card.Title>
+                        {this.props.title}{" "}
+                        <i style={{fontSize: '0.8rem'}}>
+                            pledger addresses to nodes, last 24h
+                            {this.props.coveredSince ? `, only known since ${new Date(this.props.coveredSince * 1000).toLocaleString()}` : ""}
+                        </i>
+                    </Card.Title>
+                    {
+                        this.props.error ? <small>{this.props.error}</small> :
+                        this.props.data.length === 0 ? <small>No mana was pledged in the last 24h.</small> :
+                        <Chart
+                            width={'100%'}
+                            height={'400px'}
+                            chartType="Sankey"
+                            loader={<div>Loading Chart</div>}
+                            data={[
+                                ['From', 'To', 'Mana'],
+                                ...this.props.data
+                            ]}
+                            options={{
+                                sankey: {
+                                    node: {colors: ['#41aea9', '#a6f6f1']},
+                                    link: {colorMode: 'gradient', colors: ['#41aea9', '#a6f6f1']},
+                                },
+                            }}
+                        />
+                    }
+                </Card.Body>
+            </Card>
+        );
+    }
+}
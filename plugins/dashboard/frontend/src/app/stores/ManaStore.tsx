@@ -87,6 +87,24 @@ class RevokeEvent extends ManaEvent{
     }
 }
 
+export class ManaFlow {
+    pledger: string;
+    shortNodeID: string;
+    nodeID: string;
+    pledged: number;
+    revoked: number;
+    transactions: number;
+}
+
+class ManaFlowResponse {
+    manaType: string;
+    start: number;
+    end: number;
+    coveredSince: number;
+    flows: Array<ManaFlow>;
+    error: string;
+}
+
 const emptyRow = (<tr><td colSpan={4}>There are no nodes to view with the current search parameters.</td></tr>)
 const emptyListItem = (<ListGroupItem>There are no events to view with the current search parameters.</ListGroupItem>)
 
@@ -94,6 +112,8 @@ const emptyListItem = (<ListGroupItem>There are no events to view with the curre
 const maxStoredManaValues = 100;
 // number of previous pledge/revoke events we keep track of. (/2 of plugins/dashboard/maxManaEventsBufferSize)
 const maxEventsStored = 100;
+// number of flows displayed in the mana flow graph
+const maxManaFlows = 25;
 
 export class ManaStore {
     // mana values
@@ -140,6 +160,13 @@ export class ManaStore {
     @observable displayedAccessEvents: Array<ManaEvent> = [];
     @observable displayedConsensusEvents: Array<ManaEvent> = [];
 
+    // largest flows of consensus mana from pledger addresses to nodes in the last 24h
+    @observable manaFlows: Array<ManaFlow> = [];
+    @observable manaFlowError: string = "";
+    // the time since which the flows are known if it is later than the start of the time range, as the node only
+    // indexes the events since it started and within its retention
+    @observable manaFlowCoveredSince: number = 0;
+
     ownID: string;
 
     nodeNotSyncedListItem = (<ListGroupItem>Wait for node to be synced to display mana events.</ListGroupItem>);
@@ -158,6 +185,28 @@ export class ManaStore {
         registerHandler(WSMsgType.ManaRevoke, this.addNewRevoke);
     };
 
+    fetchManaFlows = async () => {
+        try {
+            let res = await fetch(`/api/mana/flow?manaType=Consensus&limit=${maxManaFlows}`);
+            let result: ManaFlowResponse = await res.json();
+            this.updateManaFlows(result);
+        } catch (err) {
+            this.updateManaFlows({error: err.toString()} as ManaFlowResponse);
+        }
+    };
+
+    @action
+    updateManaFlows = (res: ManaFlowResponse) => {
+        this.manaFlowError = res.error ? res.error : "";
+        this.manaFlows = res.flows ? res.flows : [];
+        this.manaFlowCoveredSince = res.coveredSince > res.start ? res.coveredSince : 0;
+    };
+
+    @computed
+    get manaFlowGraphInput() {
+        return this.manaFlows.map((flow) => [flow.pledger.substr(0, 10) + "...", flow.shortNodeID, flow.pledged]);
+    }
+
     @action
     updateNodeSearch(searchNode: string): void {
         this.searchNode = searchNode.trim();
@@ -0,0 +1,20 @@
+package manaflow
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/configuration"
+)
+
+// ParametersDefinition contains the definition of the parameters used by the manaflow plugin.
+type ParametersDefinition struct {
+	// Retention defines how long the pledge and revoke events are kept in the index.
+	Retention time.Duration `default:"168h" usage:"how long the pledge and revoke events are kept in the index"`
+}
+
+// Parameters contains the configuration used by the manaflow plugin.
+var Parameters = &ParametersDefinition{}
+
+func init() {
+	configuration.BindParameters(Parameters, "manaflow")
+}
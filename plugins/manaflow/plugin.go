@@ -0,0 +1,120 @@
+package manaflow
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/node"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/manaflow"
+	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// PluginName is the name of the manaflow plugin.
+const PluginName = "ManaFlow"
+
+// pruneInterval is the interval in which the records older than the retention are removed from the index.
+const pruneInterval = 10 * time.Minute
+
+var (
+	// plugin is the plugin instance of the manaflow plugin.
+	plugin     *node.Plugin
+	pluginOnce sync.Once
+	index      *manaflow.Index
+	indexOnce  sync.Once
+
+	onPledgeEventClosure *events.Closure
+	onRevokeEventClosure *events.Closure
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	pluginOnce.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Enabled, configure, run)
+	})
+	return plugin
+}
+
+// Index returns the index of the mana flows of the node.
+func Index() (*manaflow.Index, error) {
+	if node.IsSkipped(Plugin()) {
+		return nil, errors.Errorf("%s plugin is disabled", PluginName)
+	}
+	indexOnce.Do(func() {
+		index = manaflow.NewIndex(manaflow.WithRetention(Parameters.Retention))
+	})
+	return index, nil
+}
+
+func configure(_ *node.Plugin) {
+	onPledgeEventClosure = events.NewClosure(onPledge)
+	onRevokeEventClosure = events.NewClosure(onRevoke)
+	mana.Events().Pledged.Attach(onPledgeEventClosure)
+	mana.Events().Revoked.Attach(onRevokeEventClosure)
+}
+
+func run(_ *node.Plugin) {
+	if err := daemon.BackgroundWorker(PluginName, func(shutdownSignal <-chan struct{}) {
+		ticker := time.NewTicker(pruneInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				flows, _ := Index()
+				flows.Prune(time.Now())
+			case <-shutdownSignal:
+				mana.Events().Pledged.Detach(onPledgeEventClosure)
+				mana.Events().Revoked.Detach(onRevokeEventClosure)
+				return
+			}
+		}
+	}, shutdown.PriorityMana); err != nil {
+		plugin.Panicf("Failed to start as daemon: %s", err)
+	}
+}
+
+func onPledge(ev *mana.PledgedEvent) {
+	if ev.TransactionID == ledgerstate.GenesisTransactionID {
+		return
+	}
+	flows, _ := Index()
+	flows.AddPledge(ev, inputBalances(ev.TransactionID))
+}
+
+func onRevoke(ev *mana.RevokedEvent) {
+	var pledger string
+	messagelayer.Tangle().LedgerState.CachedOutput(ev.InputID).Consume(func(output ledgerstate.Output) {
+		pledger = output.Address().Base58()
+	})
+	if pledger == "" {
+		return
+	}
+	flows, _ := Index()
+	flows.AddRevoke(ev, pledger)
+}
+
+// inputBalances returns the total balance of the inputs of the transaction by the address owning them.
+func inputBalances(transactionID ledgerstate.TransactionID) map[string]uint64 {
+	balances := make(map[string]uint64)
+	messagelayer.Tangle().LedgerState.Transaction(transactionID).Consume(func(transaction *ledgerstate.Transaction) {
+		for _, input := range transaction.Essence().Inputs() {
+			utxoInput, ok := input.(*ledgerstate.UTXOInput)
+			if !ok {
+				continue
+			}
+			messagelayer.Tangle().LedgerState.CachedOutput(utxoInput.ReferencedOutputID()).Consume(func(output ledgerstate.Output) {
+				output.Balances().ForEach(func(_ ledgerstate.Color, balance uint64) bool {
+					balances[output.Address().Base58()] += balance
+					return true
+				})
+			})
+		}
+	})
+	return balances
+}
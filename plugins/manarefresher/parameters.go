@@ -1,11 +1,19 @@
 package manarefresher
 
-import "github.com/iotaledger/hive.go/configuration"
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/configuration"
+)
 
 // ParametersDefinition contains the definition of the parameters used by the manarefresher plugin.
 type ParametersDefinition struct {
 	// RefreshInterval defines the interval for refreshing delegated mana.
 	RefreshInterval uint `default:"25" usage:"interval for refreshing delegated mana (minutes)"`
+	// ExpiryWindow defines the time before the timelock of a delegation when it is reported as expiring.
+	ExpiryWindow time.Duration `default:"1h" usage:"time before the timelock of a delegation when it is reported as expiring"`
+	// ReclaimedRetention defines how long reclaimed delegations are kept in the delegation registry.
+	ReclaimedRetention time.Duration `default:"168h" usage:"how long reclaimed delegations are kept in the delegation registry"`
 }
 
 // Parameters contains the configuration used by the manarefresher plugin.
@@ -12,6 +12,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 )
 
 var (
@@ -45,17 +46,25 @@ func configure(_ *node.Plugin) {
 	if Parameters.RefreshInterval < minRefreshInterval {
 		panic(fmt.Sprintf("manarefresh interval of %d minutes is too small, minimum is %d minutes", Parameters.RefreshInterval, minRefreshInterval))
 	}
+	configureRegistry()
 }
 
 func run(_ *node.Plugin) {
 	if err := daemon.BackgroundWorker("ManaRefresher-plugin", func(shutdownSignal <-chan struct{}) {
+		registerDelegatedOutputs()
 		ticker := time.NewTicker(time.Duration(Parameters.RefreshInterval) * time.Minute)
 		defer ticker.Stop()
+		registryTicker := time.NewTicker(registryCheckInterval)
+		defer registryTicker.Stop()
 		for {
 			select {
 			case <-shutdownSignal:
+				messagelayer.Tangle().LedgerState.UTXODAG.Events().TransactionConfirmed.Detach(onTransactionConfirmedClosure)
 				return
 
+			case <-registryTicker.C:
+				updateRegistry()
+
 			case <-ticker.C:
 				err := refresher.Refresh()
 				if err != nil {
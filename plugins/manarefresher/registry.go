@@ -0,0 +1,101 @@
+package manarefresher
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/events"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
+	db_pkg "github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/delegation"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/plugins/database"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// registryCheckInterval is the interval in which the timelocks of the registered delegations are checked.
+const registryCheckInterval = time.Minute
+
+var (
+	registry                      *delegation.Registry
+	onTransactionConfirmedClosure *events.Closure
+)
+
+func configureRegistry() {
+	var err error
+	registry, err = delegation.NewRegistry(
+		database.Store().WithRealm([]byte{db_pkg.PrefixDelegation}),
+		delegation.WithExpiryWindow(Parameters.ExpiryWindow),
+	)
+	if err != nil {
+		plugin.Panicf("failed to load delegation registry: %s", err)
+	}
+
+	registry.Events.Created.Attach(events.NewClosure(func(d *delegation.Delegation) {
+		plugin.LogInfof("delegation %s of %d to %s created", d.AliasAddress.Base58(), d.Amount(), d.Receiver.Base58())
+	}))
+	registry.Events.Expiring.Attach(events.NewClosure(func(d *delegation.Delegation) {
+		plugin.LogInfof("delegation %s to %s expires at %s", d.AliasAddress.Base58(), d.Receiver.Base58(), d.Timelock)
+	}))
+	registry.Events.Expired.Attach(events.NewClosure(func(d *delegation.Delegation) {
+		plugin.LogInfof("delegation %s to %s expired", d.AliasAddress.Base58(), d.Receiver.Base58())
+	}))
+	registry.Events.Reclaimed.Attach(events.NewClosure(func(d *delegation.Delegation) {
+		plugin.LogInfof("delegation %s to %s reclaimed", d.AliasAddress.Base58(), d.Receiver.Base58())
+	}))
+
+	onTransactionConfirmedClosure = events.NewClosure(func(transactionID ledgerstate.TransactionID) {
+		messagelayer.Tangle().LedgerState.Transaction(transactionID).Consume(func(transaction *ledgerstate.Transaction) {
+			if err := registry.ProcessTransaction(transaction); err != nil {
+				plugin.LogErrorf("failed to update delegation registry: %s", err)
+			}
+		})
+	})
+	messagelayer.Tangle().LedgerState.UTXODAG.Events().TransactionConfirmed.Attach(onTransactionConfirmedClosure)
+}
+
+// registerDelegatedOutputs adds the delegations to the node that were confirmed before the registry was tracking them.
+func registerDelegatedOutputs() {
+	for _, alias := range refresher.receiver.Scan() {
+		if d, exists := registry.Delegation(alias.GetAliasAddress()); exists && d.OutputID == alias.ID() {
+			continue
+		}
+		messagelayer.Tangle().LedgerState.Transaction(alias.ID().TransactionID()).Consume(func(transaction *ledgerstate.Transaction) {
+			if err := registry.ProcessTransaction(transaction); err != nil {
+				plugin.LogErrorf("failed to register delegation %s: %s", alias.GetAliasAddress().Base58(), err)
+			}
+		})
+	}
+}
+
+// updateRegistry updates the timelock status of the registered delegations and removes old reclaimed delegations.
+func updateRegistry() {
+	now := clock.SyncedTime()
+	if err := registry.CheckTimelocks(now); err != nil {
+		plugin.LogErrorf("failed to check delegation timelocks: %s", err)
+	}
+	if err := registry.Prune(now.Add(-Parameters.ReclaimedRetention)); err != nil {
+		plugin.LogErrorf("failed to prune delegation registry: %s", err)
+	}
+}
+
+// Delegations returns the registered delegations to the given delegation address. If receiver is nil, the
+// delegations to the node are returned.
+func Delegations(receiver ledgerstate.Address) ([]*delegation.Delegation, error) {
+	if registry == nil {
+		return nil, errors.Errorf("manarefresher plugin is disabled")
+	}
+	if receiver == nil {
+		receiver = refresher.receiver.Address()
+	}
+	return registry.Delegations(receiver), nil
+}
+
+// DelegationReceivers returns the summaries of all delegation addresses the registry knows delegations to.
+func DelegationReceivers() ([]*delegation.ReceiverSummary, error) {
+	if registry == nil {
+		return nil, errors.Errorf("manarefresher plugin is disabled")
+	}
+	return registry.Receivers(), nil
+}
@@ -10,8 +10,10 @@ import (
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 
+	databasePkg "github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/manualpeering"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/database"
 	"github.com/iotaledger/goshimmer/plugins/gossip"
 
 	"github.com/iotaledger/goshimmer/packages/shutdown"
@@ -40,7 +42,13 @@ func Plugin() *node.Plugin {
 func Manager() *manualpeering.Manager {
 	managerOnce.Do(func() {
 		lPeer := local.GetInstance()
-		manager = manualpeering.NewManager(gossip.Manager(), lPeer, logger.NewLogger(PluginName))
+		manager = manualpeering.NewManager(
+			gossip.Manager(),
+			lPeer,
+			logger.NewLogger(PluginName),
+			manualpeering.WithStore(database.StoreRealm([]byte{databasePkg.PrefixManualPeering})),
+			manualpeering.WithResolveInterval(Parameters.ResolveInterval),
+		)
 	})
 	return manager
 }
@@ -73,7 +81,7 @@ func addPeersFromConfigToManager(mgr *manualpeering.Manager) {
 		plugin.Logger().Errorw("Failed to get known peers from the config file, continuing without them...", "err", err)
 	} else if len(peers) != 0 {
 		plugin.Logger().Infow("Pass known peers list from the config file to the manager", "peers", peers)
-		if err := mgr.AddPeer(peers...); err != nil {
+		if err := mgr.AddStaticPeer(peers...); err != nil {
 			plugin.Logger().Infow("Failed to pass known peers list from the config file to the manager",
 				"peers", peers, "err", err)
 		}
@@ -0,0 +1,30 @@
+package manualpeering
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetKnownPeersFromConfig(t *testing.T) {
+	defer func(knownPeers string) { Parameters.KnownPeers = knownPeers }(Parameters.KnownPeers)
+
+	Parameters.KnownPeers = ""
+	peers, err := getKnownPeersFromConfig()
+	require.NoError(t, err)
+	assert.Empty(t, peers)
+
+	Parameters.KnownPeers = `[{"publicKey": "EYsaGXnUVA9aTYL9FwYEvoQ8d1HCJveQVL7vogu6pqCP", "address": "node.example.com:14666", "labels": ["bootstrap"]}]`
+	peers, err = getKnownPeersFromConfig()
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+	assert.Equal(t, "EYsaGXnUVA9aTYL9FwYEvoQ8d1HCJveQVL7vogu6pqCP", peers[0].PublicKey.String())
+	assert.Equal(t, "node.example.com:14666", peers[0].Address)
+	assert.Equal(t, []string{"bootstrap"}, peers[0].Labels)
+	assert.False(t, peers[0].Disabled)
+
+	Parameters.KnownPeers = `{"publicKey": "EYsaGXnUVA9aTYL9FwYEvoQ8d1HCJveQVL7vogu6pqCP"}`
+	_, err = getKnownPeersFromConfig()
+	assert.Error(t, err)
+}
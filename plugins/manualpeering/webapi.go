@@ -17,6 +17,7 @@ const RouteManualPeers = "manualpeering/peers"
 
 func configureWebAPI() {
 	webapi.Server().POST(RouteManualPeers, addPeersHandler)
+	webapi.Server().PUT(RouteManualPeers, updatePeersHandler)
 	webapi.Server().DELETE(RouteManualPeers, removePeersHandler)
 	webapi.Server().GET(RouteManualPeers, getPeersHandler)
 }
@@ -26,7 +27,8 @@ An example of the HTTP JSON request:
 [
     {
         "publicKey": "EYsaGXnUVA9aTYL9FwYEvoQ8d1HCJveQVL7vogu6pqCP",
-        "address": "172.19.0.3:14666"
+        "address": "172.19.0.3:14666",
+        "labels": ["bootstrap"]
     }
 ]
 */
@@ -49,6 +51,35 @@ func addPeersHandler(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+/*
+An example of the HTTP JSON request:
+[
+    {
+        "publicKey": "EYsaGXnUVA9aTYL9FwYEvoQ8d1HCJveQVL7vogu6pqCP",
+        "labels": ["bootstrap", "eu"],
+        "enabled": false
+    }
+]
+*/
+func updatePeersHandler(c echo.Context) error {
+	var peers []*manualpeering.KnownPeerToUpdate
+	if err := webapi.ParseJSONRequest(c, &peers); err != nil {
+		plugin.Logger().Errorw("Failed to parse peers to update from the request", "err", err)
+		return c.JSON(
+			http.StatusBadRequest,
+			jsonmodels.NewErrorResponse(errors.Wrap(err, "Invalid update peers request")),
+		)
+	}
+	if err := Manager().UpdatePeer(peers...); err != nil {
+		plugin.Logger().Errorw(
+			"Can't update some of the peers from the HTTP request in manualpeering manager",
+			"err", err,
+		)
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
 /*
 An example of the HTTP JSON request:
 [
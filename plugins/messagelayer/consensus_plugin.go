@@ -2,6 +2,7 @@ package messagelayer
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"strconv"
@@ -15,11 +16,10 @@ import (
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/node"
-	"google.golang.org/grpc"
-	"google.golang.org/protobuf/proto"
 
 	clockPkg "github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/goshimmer/packages/consensus/fcob"
+	db_pkg "github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/drng"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
@@ -34,6 +34,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/vote/statement"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/discovery"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/database"
 )
 
 // region Plugin ///////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -45,6 +46,8 @@ var (
 	voter               *fpc.FPC
 	voterOnce           sync.Once
 	voterServer         *votenet.VoterServer
+	voterClient         *votenet.Client
+	voterClientOnce     sync.Once
 	registry            *statement.Registry
 	registryOnce        sync.Once
 	dRNGState           *drng.State
@@ -69,6 +72,11 @@ func ConsensusPlugin() *node.Plugin {
 }
 
 func configureConsensusPlugin(plugin *node.Plugin) {
+	if !FCOBEnabled() {
+		plugin.LogInfof("FPC is disabled, as the %s consensus mechanism is used", Parameters.ConsensusMechanism)
+		return
+	}
+
 	configureFPC(plugin)
 
 	// subscribe to FCOB events
@@ -81,11 +89,22 @@ func configureConsensusPlugin(plugin *node.Plugin) {
 		plugin.LogErrorf("FCOB error: %s", err)
 	}))
 
+	// subscribe to the audit of the received statements
+	Registry().Events.Equivocation.Attach(events.NewClosure(func(equivocation *statement.Equivocation) {
+		plugin.LogWarnf("node %s equivocated in round %d about %s: %s in statement %s and %s in statement %s",
+			equivocation.NodeID, equivocation.Round, equivocation.ObjectIDBase58(),
+			equivocation.Opinions[0], equivocation.MessageIDs[0].Base58(), equivocation.Opinions[1], equivocation.MessageIDs[1].Base58())
+	}))
+
 	// subscribe to message-layer
 	Tangle().ConsensusManager.Events.MessageOpinionFormed.Attach(events.NewClosure(readStatement))
 }
 
 func runConsensusPlugin(plugin *node.Plugin) {
+	if !FCOBEnabled() {
+		return
+	}
+
 	runFPC(plugin)
 }
 
@@ -97,10 +116,30 @@ func Voter() vote.DRNGRoundBasedVoter {
 	return voter
 }
 
+// VoterClient returns the client that is used to query the opinions of other nodes.
+func VoterClient() *votenet.Client {
+	voterClientOnce.Do(func() {
+		opts := []votenet.ClientOption{
+			votenet.WithClientSigner(local.GetInstance().LocalIdentity()),
+			votenet.WithUnsignedReplies(!FPCParameters.RequireSignedReplies),
+			votenet.WithBatchWindow(FPCParameters.QueryBatchWindow),
+			votenet.WithClientEvents(metrics.Events().FPCInboundBytes, metrics.Events().FPCOutboundBytes),
+		}
+		if FPCParameters.TLS {
+			opts = append(opts, votenet.WithClientTLS(fpcCertificate()))
+		}
+		voterClient = votenet.NewClient(opts...)
+	})
+	return voterClient
+}
+
 // Registry returns the registry.
 func Registry() *statement.Registry {
 	registryOnce.Do(func() {
-		registry = statement.NewRegistry()
+		registry = statement.NewRegistry(statement.WithStore(database.Store().WithRealm([]byte{db_pkg.PrefixStatement})))
+		if err := registry.Load(); err != nil {
+			ConsensusPlugin().Panicf("failed to load statement registry: %s", err)
+		}
 	})
 	return registry
 }
@@ -138,6 +177,7 @@ func configureFPC(plugin *node.Plugin) {
 		}
 	}))
 
+	Voter().Events().Failed.Attach(events.NewClosure(ConsensusMechanism().ProcessFailedVote))
 	Voter().Events().Failed.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
 		if ev.Ctx.Type == vote.ConflictType {
 			plugin.LogWarnf("FPC failed for transaction with id '%s' - last opinion: '%s'", ev.ID, ev.Opinion)
@@ -152,10 +192,19 @@ func runFPC(plugin *node.Plugin) {
 		if err := daemon.BackgroundWorker(ServerWorkerName, func(shutdownSignal <-chan struct{}) {
 			stopped := make(chan struct{})
 			bindAddr := FPCParameters.BindAddress
+			opts := []votenet.ServerOption{
+				votenet.WithServerSigner(local.GetInstance().LocalIdentity()),
+				votenet.WithRequireAuthentication(FPCParameters.RequireSignedQueries),
+				votenet.WithRateLimiter(votenet.NewRateLimiter(queryRate, time.Duration(FPCParameters.RoundInterval)*time.Second)),
+			}
+			if FPCParameters.TLS {
+				opts = append(opts, votenet.WithServerTLS(fpcCertificate()))
+			}
 			voterServer = votenet.New(Voter(), OpinionRetriever, bindAddr,
 				metrics.Events().FPCInboundBytes,
 				metrics.Events().FPCOutboundBytes,
 				metrics.Events().QueryReceived,
+				opts...,
 			)
 
 			go func() {
@@ -204,6 +253,15 @@ func runFPC(plugin *node.Plugin) {
 		plugin.Panicf("Failed to start as daemon: %s", err)
 	}
 
+	if err := daemon.BackgroundWorker("FPCVoterClient", func(shutdownSignal <-chan struct{}) {
+		<-shutdownSignal
+		if err := VoterClient().Close(); err != nil {
+			plugin.LogWarnf("Error closing FPC connections: %s", err)
+		}
+	}, shutdown.PriorityFPC); err != nil {
+		plugin.Panicf("Failed to start as daemon: %s", err)
+	}
+
 	if err := daemon.BackgroundWorker("StatementCleaner", func(shutdownSignal <-chan struct{}) {
 		plugin.LogInfof("Started Statement Cleaner")
 		defer plugin.LogInfof("Stopped Statement Cleaner")
@@ -213,7 +271,9 @@ func runFPC(plugin *node.Plugin) {
 		for {
 			select {
 			case <-ticker.C:
-				Registry().Clean(time.Duration(StatementParameters.DeleteAfter) * time.Minute)
+				if err := Registry().Clean(time.Duration(StatementParameters.DeleteAfter) * time.Minute); err != nil {
+					plugin.LogErrorf("failed to clean statement registry: %s", err)
+				}
 			case <-shutdownSignal:
 				break exit
 			}
@@ -223,6 +283,31 @@ func runFPC(plugin *node.Plugin) {
 	}
 }
 
+// fpcCertificate returns the TLS certificate derived from the identity of the node.
+func fpcCertificate() tls.Certificate {
+	certificate, err := votenet.NewCertificate(local.GetInstance().LocalIdentity())
+	if err != nil {
+		plugin.LogFatalf("could not create FPC certificate: %s", err)
+	}
+	return certificate
+}
+
+// queryRate returns the queries per second that the given querier may send, which grow with its consensus mana.
+func queryRate(querierID identity.ID) float64 {
+	if querierID == (identity.ID{}) {
+		return FPCParameters.QueryRateMin
+	}
+	consensusMana, _, err := GetConsensusMana(querierID)
+	if err != nil {
+		return FPCParameters.QueryRateMin
+	}
+	totalMana, _, err := GetTotalMana(mana.ConsensusMana)
+	if err != nil || totalMana == 0 {
+		return FPCParameters.QueryRateMin
+	}
+	return FPCParameters.QueryRateMin + (FPCParameters.QueryRateMax-FPCParameters.QueryRateMin)*consensusMana/totalMana
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region OpinionGivers ////////////////////////////////////////////////////////////////////////////////////////////////
@@ -350,24 +435,7 @@ func (pog *PeerOpinionGiver) Query(ctx context.Context, conflictIDs, timestampID
 		return nil, fmt.Errorf("unable to query opinions, PeerOpinionGiver is nil")
 	}
 
-	var opts []grpc.DialOption
-	opts = append(opts, grpc.WithInsecure())
-
-	// connect to the FPC service
-	conn, err := grpc.Dial(pog.Address(), opts...)
-	if err != nil {
-		return nil, fmt.Errorf("unable to connect to FPC service: %w", err)
-	}
-	defer func() {
-		cerr := conn.Close()
-		if err == nil {
-			err = errors.Errorf("failed to close connection: %w", cerr)
-		}
-	}()
-
-	client := votenet.NewVoterQueryClient(conn)
-	query := &votenet.QueryRequest{ConflictIDs: conflictIDs, TimestampIDs: timestampIDs}
-	reply, err := client.Opinion(ctx, query)
+	opinions, err := VoterClient().Query(ctx, pog.Address(), pog.p.PublicKey(), conflictIDs, timestampIDs)
 	if err != nil {
 		metrics.Events().QueryReplyError.Trigger(&metrics.QueryReplyErrorEvent{
 			ID:           pog.p.ID().String(),
@@ -376,16 +444,7 @@ func (pog *PeerOpinionGiver) Query(ctx context.Context, conflictIDs, timestampID
 		return nil, fmt.Errorf("unable to query opinions: %w", err)
 	}
 
-	metrics.Events().FPCInboundBytes.Trigger(uint64(proto.Size(reply)))
-	metrics.Events().FPCOutboundBytes.Trigger(uint64(proto.Size(query)))
-
-	// convert int32s in reply to opinions
-	opinions := make(opinion.Opinions, len(reply.Opinion))
-	for i, intOpn := range reply.Opinion {
-		opinions[i] = opinion.ConvertInt32Opinion(intOpn)
-	}
-
-	return opinions, err
+	return opinions, nil
 }
 
 // ID returns the identifier of the underlying Peer.
@@ -580,13 +639,9 @@ func readStatement(messageID tangle.MessageID) {
 			return
 		}
 
-		issuerRegistry := Registry().NodeView(issuerID)
-
-		issuerRegistry.AddConflicts(statementPayload.Conflicts)
-
-		issuerRegistry.AddTimestamps(statementPayload.Timestamps)
-
-		issuerRegistry.UpdateLastStatementReceivedTime(clockPkg.SyncedTime())
+		if _, err := Registry().AddStatement(issuerID, messageID, statementPayload); err != nil {
+			plugin.LogErrorf("failed to add statement %s: %s", messageID, err)
+		}
 		Tangle().ConsensusManager.Events.StatementProcessed.Trigger(msg)
 	})
 }
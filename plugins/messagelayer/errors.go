@@ -1,6 +1,7 @@
 package messagelayer
 
-import "github.com/cockroachdb/errors"
+import "github.com/iotaledger/goshimmer/packages/mana"
 
-// ErrQueryNotAllowed is returned when the node is not synced and mana debug mode is disabled.
-var ErrQueryNotAllowed = errors.New("mana query not allowed, node is not synced, debug mode disabled")
+// ErrQueryNotAllowed is returned when the node is not synced and mana debug mode is disabled. It is defined in the mana
+// package, so that the client library can recognize it without depending on the plugins.
+var ErrQueryNotAllowed = mana.ErrQueryNotAllowed
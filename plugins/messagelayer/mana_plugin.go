@@ -2,7 +2,6 @@ package messagelayer
 
 import (
 	"fmt"
-	"math"
 	"os"
 	"sort"
 	"sync"
@@ -31,26 +30,24 @@ const (
 	// PluginName is the name of the mana plugin.
 	PluginName = "Mana"
 
-	// maxConsensusEventsInStorage = 110000
-	// slidingEventsInterval       = 10000 // 10% of maxConsensusEventsInStorage
+	// historySettleDelay is the time the creation of a mana history checkpoint lags behind, so that the transactions
+	// with a timestamp before the checkpoint are confirmed already.
+	historySettleDelay = 5 * time.Minute
 )
 
 var (
 	// manaPlugin is the plugin instance of the mana plugin.
-	manaPlugin         *node.Plugin
-	once               sync.Once
-	manaLogger         *logger.Logger
-	baseManaVectors    map[mana.Type]mana.BaseManaVector
-	osFactory          *objectstorage.Factory
-	storages           map[mana.Type]*objectstorage.ObjectStorage
-	allowedPledgeNodes map[mana.Type]AllowedPledge
-	// consensusBaseManaPastVectorStorage         *objectstorage.ObjectStorage
-	// consensusBaseManaPastVectorMetadataStorage *objectstorage.ObjectStorage
-	// consensusEventsLogStorage                  *objectstorage.ObjectStorage
-	// consensusEventsLogsStorageSize             atomic.Uint32
+	manaPlugin                    *node.Plugin
+	once                          sync.Once
+	manaLogger                    *logger.Logger
+	baseManaVectors               map[mana.Type]mana.BaseManaVector
+	osFactory                     *objectstorage.Factory
+	storages                      map[mana.Type]*objectstorage.ObjectStorage
+	allowedPledgeNodes            map[mana.Type]AllowedPledge
+	manaHistory                   *mana.History
 	onTransactionConfirmedClosure *events.Closure
-	// onPledgeEventClosure          *events.Closure
-	// onRevokeEventClosure          *events.Closure
+	onPledgeEventClosure          *events.Closure
+	onRevokeEventClosure          *events.Closure
 	// debuggingEnabled              bool
 )
 
@@ -66,8 +63,8 @@ func configureManaPlugin(*node.Plugin) {
 	manaLogger = logger.NewLogger(PluginName)
 
 	onTransactionConfirmedClosure = events.NewClosure(onTransactionConfirmed)
-	// onPledgeEventClosure = events.NewClosure(logPledgeEvent)
-	// onRevokeEventClosure = events.NewClosure(logRevokeEvent)
+	onPledgeEventClosure = events.NewClosure(func(ev *mana.PledgedEvent) { logHistoryEvent(ev) })
+	onRevokeEventClosure = events.NewClosure(func(ev *mana.RevokedEvent) { logHistoryEvent(ev) })
 
 	allowedPledgeNodes = make(map[mana.Type]AllowedPledge)
 	baseManaVectors = make(map[mana.Type]mana.BaseManaVector)
@@ -84,11 +81,7 @@ func configureManaPlugin(*node.Plugin) {
 		storages[mana.ResearchAccess] = osFactory.New(mana.PrefixAccessResearch, mana.FromObjectStorage)
 		storages[mana.ResearchConsensus] = osFactory.New(mana.PrefixConsensusResearch, mana.FromObjectStorage)
 	}
-	// consensusEventsLogStorage = osFactory.New(mana.PrefixEventStorage, mana.FromEventObjectStorage)
-	// consensusEventsLogsStorageSize.Store(getConsensusEventLogsStorageSize())
-	// manaLogger.Infof("read %d mana events from storage", consensusEventsLogsStorageSize.Load())
-	// consensusBaseManaPastVectorStorage = osFactory.New(mana.PrefixConsensusPastVector, mana.FromObjectStorage)
-	// consensusBaseManaPastVectorMetadataStorage = osFactory.New(mana.PrefixConsensusPastMetadata, mana.FromMetadataObjectStorage)
+	manaHistory = mana.NewHistory(store.WithRealm([]byte{db_pkg.PrefixMana, mana.PrefixHistory}), ManaParameters.HistoryCheckpointInterval)
 
 	err := verifyPledgeNodes()
 	if err != nil {
@@ -103,23 +96,15 @@ func configureManaPlugin(*node.Plugin) {
 func configureEvents() {
 	// until we have the proper event...
 	Tangle().LedgerState.UTXODAG.Events().TransactionConfirmed.Attach(onTransactionConfirmedClosure)
-	// mana.Events().Pledged.Attach(onPledgeEventClosure)
-	// mana.Events().Revoked.Attach(onRevokeEventClosure)
-}
-
-//func logPledgeEvent(ev *mana.PledgedEvent) {
-//	if ev.ManaType == mana.ConsensusMana {
-//		consensusEventsLogStorage.Store(ev.ToPersistable()).Release()
-//		consensusEventsLogsStorageSize.Inc()
-//	}
-//}
-//
-//func logRevokeEvent(ev *mana.RevokedEvent) {
-//	if ev.ManaType == mana.ConsensusMana {
-//		consensusEventsLogStorage.Store(ev.ToPersistable()).Release()
-//		consensusEventsLogsStorageSize.Inc()
-//	}
-//}
+	mana.Events().Pledged.Attach(onPledgeEventClosure)
+	mana.Events().Revoked.Attach(onRevokeEventClosure)
+}
+
+func logHistoryEvent(ev mana.Event) {
+	if err := manaHistory.LogEvent(ev); err != nil {
+		manaLogger.Errorf("error logging mana event: %v", err)
+	}
+}
 
 func onTransactionConfirmed(transactionID ledgerstate.TransactionID) {
 	Tangle().LedgerState.Transaction(transactionID).Consume(func(transaction *ledgerstate.Transaction) {
@@ -198,8 +183,10 @@ func runManaPlugin(_ *node.Plugin) {
 	mana.SetCoefficients(ema1, ema2, dec)
 	if err := daemon.BackgroundWorker("Mana", func(shutdownSignal <-chan struct{}) {
 		defer manaLogger.Infof("Stopping %s ... done", PluginName)
-		// ticker := time.NewTicker(pruneInterval)
-		// defer ticker.Stop()
+		pruneTicker := time.NewTicker(pruneInterval)
+		defer pruneTicker.Stop()
+		checkpointTicker := time.NewTicker(ManaParameters.HistoryCheckpointInterval)
+		defer checkpointTicker.Stop()
 		cleanupTicker := time.NewTicker(vectorsCleanUpInterval)
 		defer cleanupTicker.Stop()
 		if !readStoredManaVectors() {
@@ -218,18 +205,25 @@ func runManaPlugin(_ *node.Plugin) {
 			}
 		}
 		pruneStorages()
+		initializeHistory()
 		for {
 			select {
 			case <-shutdownSignal:
 				manaLogger.Infof("Stopping %s ...", PluginName)
-				// mana.Events().Pledged.Detach(onPledgeEventClosure)
-				// mana.Events().Pledged.Detach(onRevokeEventClosure)
+				mana.Events().Pledged.Detach(onPledgeEventClosure)
+				mana.Events().Revoked.Detach(onRevokeEventClosure)
 				Tangle().LedgerState.UTXODAG.Events().TransactionConfirmed.Detach(onTransactionConfirmedClosure)
 				storeManaVectors()
 				shutdownStorages()
 				return
-			// case <-ticker.C:
-			// pruneConsensusEventLogsStorage()
+			case <-checkpointTicker.C:
+				if err := manaHistory.CreateCheckpoints(time.Now().Add(-historySettleDelay)); err != nil {
+					manaLogger.Errorf("error creating mana history checkpoints: %v", err)
+				}
+			case <-pruneTicker.C:
+				if err := manaHistory.Prune(time.Now().Add(-ManaParameters.HistoryRetention)); err != nil {
+					manaLogger.Errorf("error pruning mana history: %v", err)
+				}
 			case <-cleanupTicker.C:
 				cleanupManaVectors()
 			}
@@ -275,9 +269,6 @@ func shutdownStorages() {
 	for vectorType := range baseManaVectors {
 		storages[vectorType].Shutdown()
 	}
-	// consensusEventsLogStorage.Shutdown()
-	// consensusBaseManaPastVectorStorage.Shutdown()
-	// consensusBaseManaPastVectorMetadataStorage.Shutdown()
 }
 
 // GetHighestManaNodes returns the n highest type mana nodes in descending order.
@@ -485,279 +476,61 @@ func PendingManaOnOutput(outputID ledgerstate.OutputID) (float64, time.Time) {
 
 // GetPendingMana returns the mana pledged by spending a `value` output that sat for `n` duration.
 func GetPendingMana(value float64, n time.Duration) float64 {
-	return value * (1 - math.Pow(math.E, -mana.Decay*(n.Seconds())))
-}
-
-//// GetLoggedEvents gets the events logs for the node IDs and time frame specified. If none is specified, it returns the logs for all nodes.
-//func GetLoggedEvents(identityIDs []identity.ID, startTime time.Time, endTime time.Time) (map[identity.ID]*EventsLogs, error) {
-//	logs := make(map[identity.ID]*EventsLogs)
-//	lookup := make(map[identity.ID]bool)
-//	getAll := true
-//
-//	if len(identityIDs) > 0 {
-//		getAll = false
-//		for _, nodeID := range identityIDs {
-//			lookup[nodeID] = true
-//		}
-//	}
-//
-//	var err error
-//	consensusEventsLogStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-//		cachedPe := &mana.CachedPersistableEvent{CachedObject: cachedObject}
-//		defer cachedPe.Release()
-//		pbm := cachedPe.Unwrap()
-//
-//		if !getAll {
-//			if !lookup[pbm.NodeID] {
-//				return true
-//			}
-//		}
-//
-//		if _, found := logs[pbm.NodeID]; !found {
-//			logs[pbm.NodeID] = &EventsLogs{}
-//		}
-//
-//		var ev mana.Event
-//		ev, err = mana.FromPersistableEvent(pbm)
-//		if err != nil {
-//			return false
-//		}
-//
-//		if ev.Timestamp().Before(startTime) || ev.Timestamp().After(endTime) {
-//			return true
-//		}
-//		switch ev.Type() {
-//		case mana.EventTypePledge:
-//			logs[pbm.NodeID].Pledge = append(logs[pbm.NodeID].Pledge, ev.(*mana.PledgedEvent))
-//		case mana.EventTypeRevoke:
-//			logs[pbm.NodeID].Revoke = append(logs[pbm.NodeID].Revoke, ev.(*mana.RevokedEvent))
-//		default:
-//			err = mana.ErrUnknownManaEvent
-//			return false
-//		}
-//		return true
-//	})
-//
-//	for ID := range logs {
-//		sort.Slice(logs[ID].Pledge, func(i, j int) bool {
-//			return logs[ID].Pledge[i].Time.Before(logs[ID].Pledge[j].Time)
-//		})
-//		sort.Slice(logs[ID].Revoke, func(i, j int) bool {
-//			return logs[ID].Revoke[i].Time.Before(logs[ID].Revoke[j].Time)
-//		})
-//	}
-//
-//	return logs, err
-//}
-//
-//// GetPastConsensusManaVectorMetadata gets the past consensus mana vector metadata.
-//func GetPastConsensusManaVectorMetadata() *mana.ConsensusBasePastManaVectorMetadata {
-//	cachedObj := consensusBaseManaPastVectorMetadataStorage.Load([]byte(mana.ConsensusBaseManaPastVectorMetadataStorageKey))
-//	cachedMetadata := &mana.CachedConsensusBasePastManaVectorMetadata{CachedObject: cachedObj}
-//	defer cachedMetadata.Release()
-//	return cachedMetadata.Unwrap()
-//}
-//
-//// GetPastConsensusManaVector builds a consensus base mana vector in the past.
-//func GetPastConsensusManaVector(t time.Time) (*mana.ConsensusBaseManaVector, []mana.Event, error) {
-//	baseManaVector, err := mana.NewBaseManaVector(mana.ConsensusMana)
-//	if err != nil {
-//		return nil, nil, err
-//	}
-//	cbmvPast := baseManaVector.(*mana.ConsensusBaseManaVector)
-//	cachedObj := consensusBaseManaPastVectorMetadataStorage.Load([]byte(mana.ConsensusBaseManaPastVectorMetadataStorageKey))
-//	cachedMetadata := &mana.CachedConsensusBasePastManaVectorMetadata{CachedObject: cachedObj}
-//	defer cachedMetadata.Release()
-//
-//	if cachedMetadata.Exists() {
-//		metadata := cachedMetadata.Unwrap()
-//		if t.After(metadata.Timestamp) {
-//			consensusBaseManaPastVectorStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-//				cachedPbm := &mana.CachedPersistableBaseMana{CachedObject: cachedObject}
-//				defer cachedPbm.Release()
-//				p := cachedPbm.Unwrap()
-//				err = cbmvPast.FromPersistable(p)
-//				if err != nil {
-//					manaLogger.Errorf("error while restoring %s mana vector from storage: %w", mana.ConsensusMana.String(), err)
-//					baseManaVector, _ := mana.NewBaseManaVector(mana.ConsensusMana)
-//					cbmvPast = baseManaVector.(*mana.ConsensusBaseManaVector)
-//					return false
-//				}
-//				return true
-//			})
-//		}
-//	}
-//
-//	var eventLogs mana.EventSlice
-//	consensusEventsLogStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-//		cachedPe := &mana.CachedPersistableEvent{CachedObject: cachedObject}
-//		defer cachedPe.Release()
-//		pe := cachedPe.Unwrap()
-//		if pe.Time.After(t) {
-//			return true
-//		}
-//
-//		// already consumed in stored base mana vector.
-//		if cachedMetadata.Exists() && cbmvPast.Size() > 0 {
-//			metadata := cachedMetadata.Unwrap()
-//			if pe.Time.Before(metadata.Timestamp) {
-//				return true
-//			}
-//		}
-//
-//		var ev mana.Event
-//		ev, err = mana.FromPersistableEvent(pe)
-//		if err != nil {
-//			return false
-//		}
-//		eventLogs = append(eventLogs, ev)
-//		return true
-//	})
-//	if err != nil {
-//		return nil, nil, err
-//	}
-//	eventLogs.Sort()
-//	err = cbmvPast.BuildPastBaseVector(eventLogs, t)
-//	if err != nil {
-//		return nil, nil, err
-//	}
-//
-//	err = cbmvPast.UpdateAll(t)
-//	if err != nil {
-//		return nil, nil, err
-//	}
-//
-//	return cbmvPast, eventLogs, nil
-//}
-//
-//func getConsensusEventLogsStorageSize() uint32 {
-//	var size uint32
-//	consensusEventsLogStorage.ForEachKeyOnly(func(key []byte) bool {
-//		size++
-//		return true
-//	}, objectstorage.WithIteratorSkipCache(true))
-//	return size
-//}
-//
-//func pruneConsensusEventLogsStorage() {
-//	if consensusEventsLogsStorageSize.Load() < maxConsensusEventsInStorage {
-//		return
-//	}
-//
-//	cachedObj := consensusBaseManaPastVectorMetadataStorage.Load([]byte(mana.ConsensusBaseManaPastVectorMetadataStorageKey))
-//	cachedMetadata := &mana.CachedConsensusBasePastManaVectorMetadata{CachedObject: cachedObj}
-//	defer cachedMetadata.Release()
-//
-//	bmv, err := mana.NewBaseManaVector(mana.ConsensusMana)
-//	if err != nil {
-//		manaLogger.Errorf("error creating consensus base mana vector: %v", err)
-//		return
-//	}
-//	cbmvPast := bmv.(*mana.ConsensusBaseManaVector)
-//	if cachedMetadata.Exists() {
-//		consensusBaseManaPastVectorStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-//			cachedPbm := &mana.CachedPersistableBaseMana{CachedObject: cachedObject}
-//			pbm := cachedPbm.Unwrap()
-//			if pbm != nil {
-//				err = cbmvPast.FromPersistable(pbm)
-//				if err != nil {
-//					return false
-//				}
-//			}
-//			return true
-//		})
-//		if err != nil {
-//			manaLogger.Errorf("error reading stored consensus base mana vector: %v", err)
-//			return
-//		}
-//	}
-//
-//	var eventLogs mana.EventSlice
-//	consensusEventsLogStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-//		cachedPe := &mana.CachedPersistableEvent{CachedObject: cachedObject}
-//		defer cachedPe.Release()
-//		pe := cachedPe.Unwrap()
-//		var ev mana.Event
-//		ev, err = mana.FromPersistableEvent(pe)
-//
-//		if cachedMetadata.Exists() {
-//			metadata := cachedMetadata.Unwrap()
-//			if ev.Timestamp().Before(metadata.Timestamp) {
-//				manaLogger.Errorf("consensus event storage contains event that is older, than the stored metadata timestamp %s: %s", metadata.Timestamp, ev.String())
-//				return true
-//			}
-//		}
-//
-//		if err != nil {
-//			return false
-//		}
-//		eventLogs = append(eventLogs, ev)
-//		return true
-//	})
-//	if err != nil {
-//		manaLogger.Infof("error reading persistable events: %v", err)
-//		return
-//	}
-//	eventLogs.Sort()
-//	// we always want (maxConsensusEventsInStorage - slidingEventsInterval) number of events left
-//	deleteWindow := len(eventLogs) - (maxConsensusEventsInStorage - slidingEventsInterval)
-//	storageSizeInt := int(consensusEventsLogsStorageSize.Load())
-//	if deleteWindow < 0 || deleteWindow > storageSizeInt {
-//		manaLogger.Errorf("invalid delete window %d for storage size %d, max storage size %d and sliding interval %d",
-//			deleteWindow, storageSizeInt, maxConsensusEventsInStorage, slidingEventsInterval)
-//		return
-//	}
-//	// Make sure to take related events. (we take deleteWindow oldest events)
-//	// Ensures that related events (same time) are not split between different intervals.
-//	prev := eventLogs[deleteWindow-1]
-//	var i int
-//	for i = deleteWindow; i < len(eventLogs); i++ {
-//		if !eventLogs[i].Timestamp().Equal(prev.Timestamp()) {
-//			break
-//		}
-//		prev = eventLogs[i]
-//	}
-//	toBePrunedEvents := eventLogs[:i]
-//	// TODO: later, when we have epochs, we have to make sure that `t` is before the epoch to be "finalized" next.
-//	// Otherwise, we won't be able to calculate the consensus mana for that epoch because we already pruned the events
-//	// leading up to it.
-//	t := toBePrunedEvents[len(toBePrunedEvents)-1].Timestamp()
-//
-//	err = cbmvPast.BuildPastBaseVector(toBePrunedEvents, t)
-//	if err != nil {
-//		manaLogger.Errorf("error building past consensus base mana vector: %w", err)
-//		return
-//	}
-//
-//	// store cbmv
-//	if err = consensusBaseManaPastVectorStorage.Prune(); err != nil {
-//		manaLogger.Errorf("error pruning consensus base mana vector storage: %w", err)
-//		return
-//	}
-//	for _, p := range cbmvPast.ToPersistables() {
-//		consensusBaseManaPastVectorStorage.Store(p).Release()
-//	}
-//
-//	// store the metadata
-//	metadata := &mana.ConsensusBasePastManaVectorMetadata{
-//		Timestamp: t,
-//	}
-//
-//	if err = consensusBaseManaPastVectorMetadataStorage.Prune(); err != nil {
-//		manaLogger.Errorf("error pruning consensus base mana vector metadata storage: %w", err)
-//		return
-//	}
-//	consensusBaseManaPastVectorMetadataStorage.Store(metadata).Release()
-//
-//	var entriesToDelete [][]byte
-//	for _, ev := range toBePrunedEvents {
-//		entriesToDelete = append(entriesToDelete, ev.ToPersistable().ObjectStorageKey())
-//	}
-//	manaLogger.Infof("deleting %d events from consensus event storage", len(entriesToDelete))
-//	consensusEventsLogStorage.DeleteEntriesFromStore(entriesToDelete)
-//	consensusEventsLogsStorageSize.Sub(uint32(len(entriesToDelete)))
-//	manaLogger.Infof("%d events remaining in consensus event storage", consensusEventsLogsStorageSize.Load())
-//}
+	now := time.Now()
+	return mana.DefaultModel().PendingMana(value, now.Add(-n), now)
+}
+
+// initializeHistory uses the current base mana vectors as the base of the mana history if there is no history yet.
+func initializeHistory() {
+	now := time.Now()
+	for _, vectorType := range []mana.Type{mana.AccessMana, mana.ConsensusMana} {
+		if err := manaHistory.Initialize(baseManaVectors[vectorType], now); err != nil {
+			manaLogger.Errorf("error initializing %s mana history: %v", vectorType.String(), err)
+		}
+	}
+}
+
+// GetLoggedEvents gets the events logs for the node IDs and time frame specified. If none is specified, it returns the logs for all nodes.
+func GetLoggedEvents(identityIDs []identity.ID, startTime time.Time, endTime time.Time) (map[identity.ID]*EventsLogs, error) {
+	events, err := manaHistory.Events(mana.ConsensusMana, identityIDs, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make(map[identity.ID]*EventsLogs)
+	for _, ev := range events {
+		switch typedEvent := ev.(type) {
+		case *mana.PledgedEvent:
+			if _, found := logs[typedEvent.NodeID]; !found {
+				logs[typedEvent.NodeID] = &EventsLogs{}
+			}
+			logs[typedEvent.NodeID].Pledge = append(logs[typedEvent.NodeID].Pledge, typedEvent)
+		case *mana.RevokedEvent:
+			if _, found := logs[typedEvent.NodeID]; !found {
+				logs[typedEvent.NodeID] = &EventsLogs{}
+			}
+			logs[typedEvent.NodeID].Revoke = append(logs[typedEvent.NodeID].Revoke, typedEvent)
+		default:
+			return nil, mana.ErrUnknownManaEvent
+		}
+	}
+	return logs, nil
+}
+
+// GetPastManaVectorMetadata returns which part of the history of the given mana type is available.
+func GetPastManaVectorMetadata(manaType mana.Type) (*mana.HistoryMetadata, error) {
+	return manaHistory.Metadata(manaType)
+}
+
+// GetPastManaVector reconstructs the base mana vector of the given type at time t.
+func GetPastManaVector(manaType mana.Type, t time.Time) (mana.BaseManaVector, error) {
+	return manaHistory.ManaVectorAt(manaType, t)
+}
+
+// GetPastMana returns the mana of the given type that the node had at time t.
+func GetPastMana(manaType mana.Type, nodeID identity.ID, t time.Time) (float64, error) {
+	return manaHistory.ManaAt(manaType, nodeID, t)
+}
 
 func cleanupManaVectors() {
 	vectorTypes := []mana.Type{mana.AccessMana, mana.ConsensusMana}
@@ -776,11 +549,11 @@ type AllowedPledge struct {
 	Allowed         set.Set
 }
 
-//// EventsLogs represents the events logs.
-//type EventsLogs struct {
-//	Pledge []*mana.PledgedEvent `json:"pledge"`
-//	Revoke []*mana.RevokedEvent `json:"revoke"`
-//}
+// EventsLogs represents the events logs.
+type EventsLogs struct {
+	Pledge []*mana.PledgedEvent `json:"pledge"`
+	Revoke []*mana.RevokedEvent `json:"revoke"`
+}
 
 // QueryAllowed returns if the mana plugin answers queries or not.
 func QueryAllowed() (allowed bool) {
@@ -19,10 +19,17 @@ type ParametersDefinition struct {
 		GenesisNode string `default:"Gm7W191NDnqyF7KJycZqK7V6ENLwqxTwoKQN4SmpkB24" usage:"the node (base58 public key) that is allowed to attach to the genesis message"`
 	}
 
+	// ConsensusMechanism defines the consensus mechanism that is used to form opinions about conflicts.
+	ConsensusMechanism string `default:"fcob" usage:"the consensus mechanism that forms the opinions about conflicts (fcob or otv)"`
+
 	// FCOB contains parameters related to the transaction quarantine time before applying (if necessary) FPC.
 	FCOB struct {
 		// QuarantineTime determines the duration of the the first half of the quarantime time of the FCoB rule, in seconds.
 		QuarantineTime int `default:"2" usage:"the duration for the first half of the quarantine time of the FCoB rule in sec"`
+		// TraceRetention determines how long the opinion traces of the transactions are kept after their last update.
+		TraceRetention time.Duration `default:"1h" usage:"how long the FCoB opinion traces of the transactions are kept, 0 to disable them"`
+		// MaxTraces determines the number of transactions whose opinion traces are kept.
+		MaxTraces int `default:"10000" usage:"the number of transactions whose FCoB opinion traces are kept, 0 to disable them"`
 	}
 
 	// TangleTimeWindow defines the time window in which the node considers itself as synced according to TangleTime.
@@ -30,6 +37,9 @@ type ParametersDefinition struct {
 
 	// StartSynced defines if the node should start as synced.
 	StartSynced bool `default:"false" usage:"start as synced"`
+
+	// PayloadManaRequirements defines the minimum mana of the issuers of messages with certain payload types.
+	PayloadManaRequirements []string `usage:"minimum mana of the issuer of a payload type as <payload type>:<access|consensus>:<mana>, e.g. chat:access:100"`
 }
 
 // FPCParametersDefinition contains the definition of parameters used by the FPC consensus.
@@ -57,6 +67,26 @@ type FPCParametersDefinition struct {
 
 	// DefaultRandomness defines default randomness used by FPC when no random is received from the dRNG.
 	DefaultRandomness float64 `default:"0.5" usage:"The default randomness used by FPC when no random is received from the dRNG"`
+
+	// RequireSignedReplies defines if the replies to FPC queries must be signed by the queried node. It is disabled by
+	// default, so that the nodes that do not sign their replies yet can still be queried. Signed replies are verified
+	// either way.
+	RequireSignedReplies bool `default:"false" usage:"if the replies to FPC queries must be signed by the queried node"`
+
+	// RequireSignedQueries defines if the FPC service only answers queries that are signed by the querier or sent with a client certificate.
+	RequireSignedQueries bool `default:"false" usage:"if the FPC service only answers authenticated queries"`
+
+	// TLS defines if the FPC queries are sent over TLS with certificates derived from the node identities.
+	TLS bool `default:"false" usage:"if the FPC queries are sent over TLS with certificates derived from the node identities"`
+
+	// QueryBatchWindow defines how long a query waits for other queries to the same node to be sent with.
+	QueryBatchWindow time.Duration `default:"50ms" usage:"how long a query waits for other queries to the same node to be sent with"`
+
+	// QueryRateMin defines the queries per second that a querier without consensus mana may send.
+	QueryRateMin float64 `default:"1" usage:"the queries per second that a querier without consensus mana may send"`
+
+	// QueryRateMax defines the queries per second that a querier holding all consensus mana may send.
+	QueryRateMax float64 `default:"100" usage:"the queries per second that a querier holding all consensus mana may send"`
 }
 
 // StatementParametersDefinition contains the definition of the parameters used by the FPC statements in the tangle.
@@ -101,6 +131,10 @@ type ManaParametersDefinition struct {
 	EnableResearchVectors bool `default:"false" usage:"enable mana research vectors"`
 	// PruneConsensusEventLogsInterval defines the interval to check and prune consensus event logs storage.
 	PruneConsensusEventLogsInterval time.Duration `default:"5m" usage:"interval to check and prune consensus event storage"`
+	// HistoryCheckpointInterval defines the interval between two checkpoints of the mana history.
+	HistoryCheckpointInterval time.Duration `default:"1h" usage:"interval between two checkpoints of the mana history"`
+	// HistoryRetention defines how long the mana history is kept.
+	HistoryRetention time.Duration `default:"168h" usage:"how long the mana history is kept"`
 	// VectorsCleanupInterval defines the interval to clean empty mana nodes from the base mana vectors.
 	VectorsCleanupInterval time.Duration `default:"30m" usage:"interval to cleanup empty mana nodes from the mana vectors"`
 	// DebuggingEnabled defines if the mana plugin responds to queries while not being in sync or not.
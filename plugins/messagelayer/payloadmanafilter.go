@@ -0,0 +1,92 @@
+package messagelayer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/node"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+// configurePayloadManaFilter adds a filter to the parser that rejects the messages whose issuer does not have the mana
+// configured for their payload type.
+func configurePayloadManaFilter(plugin *node.Plugin) {
+	if len(Parameters.PayloadManaRequirements) == 0 {
+		return
+	}
+	requirements, err := parsePayloadManaRequirements(Parameters.PayloadManaRequirements)
+	if err != nil {
+		plugin.Panicf("invalid payload mana requirements: %s", err)
+	}
+
+	filter := tangle.NewPayloadManaFilter(
+		func(nodeID identity.ID) (float64, error) {
+			accessMana, _, err := GetAccessMana(nodeID)
+			return issuerMana(accessMana, err)
+		},
+		func(nodeID identity.ID) (float64, error) {
+			consensusMana, _, err := GetConsensusMana(nodeID)
+			return issuerMana(consensusMana, err)
+		},
+	)
+	for payloadType, requirement := range requirements {
+		filter.SetRequirement(payloadType, requirement)
+		plugin.LogInfof("%s payloads require %g access mana and %g consensus mana of their issuer", payloadType, requirement.AccessMana, requirement.ConsensusMana)
+	}
+	Tangle().Parser.AddMessageFilter(filter)
+}
+
+// issuerMana maps the errors of the mana queries to the semantics of the payload mana filter: nodes that are not in the
+// base mana vector have no mana, and the filter lets messages pass while the node is not synced.
+func issuerMana(value float64, err error) (float64, error) {
+	switch {
+	case errors.Is(err, mana.ErrNodeNotFoundInBaseManaVector):
+		return 0, nil
+	case errors.Is(err, ErrQueryNotAllowed):
+		return 0, errors.Errorf("%v: %w", err, tangle.ErrIssuerManaUnavailable)
+	default:
+		return value, err
+	}
+}
+
+// parsePayloadManaRequirements parses requirements of the form <payload type>:<access|consensus>:<mana>, where the
+// payload type is either the name or the number of a registered payload type.
+func parsePayloadManaRequirements(entries []string) (map[payload.Type]tangle.ManaRequirement, error) {
+	requirements := make(map[payload.Type]tangle.ManaRequirement)
+	for _, entry := range entries {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, errors.Errorf("requirement %s is not of the form <payload type>:<access|consensus>:<mana>", entry)
+		}
+
+		payloadType, exists := payload.TypeByName(parts[0])
+		if !exists {
+			typeNumber, err := strconv.ParseUint(parts[0], 10, 32)
+			if err != nil {
+				return nil, errors.Errorf("unknown payload type %s", parts[0])
+			}
+			payloadType = payload.Type(typeNumber)
+		}
+		minMana, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil || minMana < 0 {
+			return nil, errors.Errorf("invalid mana %s of requirement %s", parts[2], entry)
+		}
+
+		requirement := requirements[payloadType]
+		switch parts[1] {
+		case "access":
+			requirement.AccessMana = minMana
+		case "consensus":
+			requirement.ConsensusMana = minMana
+		default:
+			return nil, errors.Errorf("unknown mana type %s of requirement %s", parts[1], entry)
+		}
+		requirements[payloadType] = requirement
+	}
+	return requirements, nil
+}
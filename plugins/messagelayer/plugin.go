@@ -5,7 +5,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/iotaledger/goshimmer/packages/consensus"
 	"github.com/iotaledger/goshimmer/packages/consensus/fcob"
+	"github.com/iotaledger/goshimmer/packages/consensus/otv"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
@@ -61,6 +63,8 @@ func configure(plugin *node.Plugin) {
 		})
 	}))
 
+	configurePayloadManaFilter(plugin)
+
 	Tangle().Parser.Events.MessageRejected.Attach(events.NewClosure(func(ev *tangle.MessageRejectedEvent, err error) {
 		plugin.LogInfof("message with %s rejected in Parser: %v", ev.Message.ID().Base58(), err)
 	}))
@@ -142,7 +146,7 @@ func Tangle() *tangle.Tangle {
 			tangle.Store(database.Store()),
 			tangle.Identity(local.GetInstance().LocalIdentity()),
 			tangle.Width(Parameters.TangleWidth),
-			tangle.Consensus(ConsensusMechanism()),
+			tangle.Consensus(SelectedConsensusMechanism()),
 			tangle.GenesisNode(Parameters.Snapshot.GenesisNode),
 			tangle.SchedulerConfig(tangle.SchedulerParams{
 				MaxBufferSize:               SchedulerParameters.MaxBufferSize,
@@ -170,20 +174,86 @@ func Tangle() *tangle.Tangle {
 
 // region ConsensusMechanism ///////////////////////////////////////////////////////////////////////////////////////////
 
+const (
+	// FCOBConsensusMechanism is the name of the FCoB consensus mechanism, which resolves conflicts with FPC.
+	FCOBConsensusMechanism = "fcob"
+	// OTVConsensusMechanism is the name of the on tangle voting consensus mechanism, which likes the heaviest branches.
+	OTVConsensusMechanism = "otv"
+)
+
 var (
-	consensusMechanism     *fcob.ConsensusMechanism
-	consensusMechanismOnce sync.Once
+	consensusMechanism             *fcob.ConsensusMechanism
+	consensusMechanismOnce         sync.Once
+	consensusMechanisms            *consensus.Registry
+	consensusMechanismsOnce        sync.Once
+	selectedConsensusMechanism     tangle.ConsensusMechanism
+	selectedConsensusMechanismOnce sync.Once
 )
 
-// ConsensusMechanism return the FcoB ConsensusMechanism used by the Tangle.
+// ConsensusMechanism return the FcoB ConsensusMechanism. It is only used by the Tangle if FCOBEnabled returns true.
 func ConsensusMechanism() *fcob.ConsensusMechanism {
 	consensusMechanismOnce.Do(func() {
-		consensusMechanism = fcob.NewConsensusMechanism()
+		consensusMechanism = fcob.NewConsensusMechanism(
+			fcob.TraceRetention(Parameters.FCOB.TraceRetention),
+			fcob.MaxTraces(Parameters.FCOB.MaxTraces),
+		)
 	})
 
 	return consensusMechanism
 }
 
+// ConsensusMechanisms returns the registry of the consensus mechanisms that can be selected by the configuration.
+func ConsensusMechanisms() *consensus.Registry {
+	consensusMechanismsOnce.Do(func() {
+		consensusMechanisms = consensus.NewRegistry()
+		if err := consensusMechanisms.Register(FCOBConsensusMechanism, func() tangle.ConsensusMechanism {
+			return ConsensusMechanism()
+		}); err != nil {
+			panic(err)
+		}
+		if err := consensusMechanisms.Register(OTVConsensusMechanism, func() tangle.ConsensusMechanism {
+			return otv.NewConsensusMechanism()
+		}); err != nil {
+			panic(err)
+		}
+	})
+
+	return consensusMechanisms
+}
+
+// SelectedConsensusMechanism returns the ConsensusMechanism used by the Tangle, as selected by the configuration.
+func SelectedConsensusMechanism() tangle.ConsensusMechanism {
+	selectedConsensusMechanismOnce.Do(func() {
+		var err error
+		if selectedConsensusMechanism, err = ConsensusMechanisms().New(Parameters.ConsensusMechanism); err != nil {
+			Plugin().LogFatalf("failed to select consensus mechanism: %s", err)
+		}
+		if otvConsensusMechanism, ok := selectedConsensusMechanism.(*otv.ConsensusMechanism); ok {
+			otvConsensusMechanism.Events.Error.Attach(events.NewClosure(func(err error) {
+				Plugin().LogErrorf("OTV error: %s", err)
+			}))
+		}
+	})
+
+	return selectedConsensusMechanism
+}
+
+// FCOBEnabled returns true if the Tangle uses FCoB and FPC to form the opinions about conflicts. It only compares the
+// configured name, so that the FCoB ConsensusMechanism and its workers are only created if FCoB is selected.
+func FCOBEnabled() bool {
+	return Parameters.ConsensusMechanism == FCOBConsensusMechanism
+}
+
+// OpinionFormedTime returns the time when FCoB formed the opinion about the given message, or the zero time if another
+// consensus mechanism is used.
+func OpinionFormedTime(messageID tangle.MessageID) time.Time {
+	if !FCOBEnabled() {
+		return time.Time{}
+	}
+
+	return ConsensusMechanism().OpinionFormedTime(messageID)
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region Scheduler ///////////////////////////////////////////////////////////////////////////////////////////
@@ -1,11 +1,13 @@
 package metrics
 
 import (
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/syncutils"
 	"go.uber.org/atomic"
 
 	"github.com/iotaledger/goshimmer/packages/metrics"
 	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/statement"
 )
 
 var (
@@ -27,6 +29,13 @@ var (
 
 	// opinionQueryReplyErrorCount counts how many opinions we asked for but never heard back (multiple opinions in one query).
 	opinionQueryReplyErrorCount atomic.Uint64
+
+	// equivocationCount counts the conflicting opinions that were received from the same node for the same round.
+	equivocationCount atomic.Uint64
+
+	// equivocatingNodes counts the equivocations per node.
+	equivocatingNodes      = make(map[identity.ID]uint64)
+	equivocatingNodesMutex syncutils.RWMutex
 )
 
 // ActiveConflicts returns the number of currently active conflicts.
@@ -72,6 +81,23 @@ func FPCOpinionQueryReplyErrors() uint64 {
 	return opinionQueryReplyErrorCount.Load()
 }
 
+// FPCEquivocations returns the number of equivocations detected in the received statements since the start of the node.
+func FPCEquivocations() uint64 {
+	return equivocationCount.Load()
+}
+
+// FPCEquivocatingNodes returns the number of equivocations detected in the received statements per node.
+func FPCEquivocatingNodes() map[identity.ID]uint64 {
+	equivocatingNodesMutex.RLock()
+	defer equivocatingNodesMutex.RUnlock()
+
+	nodes := make(map[identity.ID]uint64, len(equivocatingNodes))
+	for nodeID, count := range equivocatingNodes {
+		nodes[nodeID] = count
+	}
+	return nodes
+}
+
 //// logic broken into "process..."  functions to be able to write unit tests ////
 
 func processRoundStats(stats *vote.RoundStats) {
@@ -106,3 +132,11 @@ func processQueryReplyError(ev *metrics.QueryReplyErrorEvent) {
 	// containing this many conflicts to give opinion about
 	opinionQueryReplyErrorCount.Add((uint64)(ev.OpinionCount))
 }
+
+func processEquivocation(equivocation *statement.Equivocation) {
+	equivocationCount.Inc()
+
+	equivocatingNodesMutex.Lock()
+	defer equivocatingNodesMutex.Unlock()
+	equivocatingNodes[equivocation.NodeID]++
+}
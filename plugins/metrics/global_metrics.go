@@ -29,6 +29,7 @@ var (
 	nodesMetrics      = make(map[string]NodeInfo)
 	nodesMetricsMutex sync.RWMutex
 	networkDiameter   atomic.Int32
+	networkComponents atomic.Int32
 )
 
 var onMetricHeartbeatReceived = events.NewClosure(func(hb *packet.MetricHeartbeat) {
@@ -58,12 +59,15 @@ func NodesMetrics() map[string]NodeInfo {
 
 func calculateNetworkDiameter() {
 	diameter := 0
+	components := 0
 	// TODO: send data for all available networkIDs, not just current
 	if analysisserver.Networks[banner.SimplifiedAppVersion] != nil {
 		g := analysisserver.Networks[banner.SimplifiedAppVersion].NetworkGraph()
 		diameter = g.Diameter()
+		components = len(g.ConnectedComponents())
 	}
 	networkDiameter.Store(int32(diameter))
+	networkComponents.Store(int32(components))
 }
 
 // NetworkDiameter returns the current network diameter.
@@ -71,6 +75,11 @@ func NetworkDiameter() int32 {
 	return networkDiameter.Load()
 }
 
+// NetworkConnectedComponents returns the current number of connected components of the network.
+func NetworkConnectedComponents() int32 {
+	return networkComponents.Load()
+}
+
 func shortNodeIDString(b []byte) string {
 	var id identity.ID
 	copy(id[:], b)
@@ -192,6 +192,9 @@ func registerLocalMetrics() {
 		processFailed(ev.Ctx)
 	}))
 
+	// conflicting opinions received from the same node
+	messagelayer.Registry().Events.Equivocation.Attach(events.NewClosure(processEquivocation))
+
 	//// Events coming from metrics package ////
 
 	metrics.Events().FPCInboundBytes.Attach(events.NewClosure(func(amountBytes uint64) {
@@ -15,6 +15,8 @@ var (
 	queryOpRx          prometheus.Gauge
 	queryReplyNotRx    prometheus.Gauge
 	queryOpReplyNotRx  prometheus.Gauge
+	equivocations      prometheus.Gauge
+	equivocatingNodes  *prometheus.GaugeVec
 )
 
 func registerFPCMetrics() {
@@ -50,6 +52,14 @@ func registerFPCMetrics() {
 		Name: "fpc_query_opinion_replies_not_received",
 		Help: " number of opinions that the node failed to gather from peers",
 	})
+	equivocations = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "fpc_statement_equivocations",
+		Help: "number of conflicting opinions received from the same node for the same round",
+	})
+	equivocatingNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fpc_statement_equivocating_nodes",
+		Help: "number of conflicting opinions received from the same node for the same round per node",
+	}, []string{"nodeID"})
 
 	registry.MustRegister(activeConflicts)
 	registry.MustRegister(finalizedConflicts)
@@ -59,6 +69,8 @@ func registerFPCMetrics() {
 	registry.MustRegister(queryOpRx)
 	registry.MustRegister(queryReplyNotRx)
 	registry.MustRegister(queryOpReplyNotRx)
+	registry.MustRegister(equivocations)
+	registry.MustRegister(equivocatingNodes)
 
 	addCollect(collectFPCMetrics)
 }
@@ -72,4 +84,8 @@ func collectFPCMetrics() {
 	queryOpRx.Set(float64(metrics.FPCOpinionQueryReceived()))
 	queryReplyNotRx.Set(float64(metrics.FPCQueryReplyErrors()))
 	queryOpReplyNotRx.Set(float64(metrics.FPCOpinionQueryReplyErrors()))
+	equivocations.Set(float64(metrics.FPCEquivocations()))
+	for nodeID, count := range metrics.FPCEquivocatingNodes() {
+		equivocatingNodes.WithLabelValues(nodeID.String()).Set(float64(count))
+	}
 }
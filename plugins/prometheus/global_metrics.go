@@ -27,6 +27,7 @@ var (
 	// Autopeering related metrics.
 	nodesNeighborCount *prometheus.GaugeVec
 	networkDiameter    prometheus.Gauge
+	networkComponents  prometheus.Gauge
 
 	// FPC related metrics.
 	conflictCount              *prometheus.GaugeVec
@@ -103,6 +104,11 @@ func registerClientsMetrics() {
 		Help: "Autopeering network diameter",
 	})
 
+	networkComponents = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "global_network_connected_components",
+		Help: "Number of connected components of the autopeering network",
+	})
+
 	conflictCount = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "global_conflict_count",
@@ -152,6 +158,7 @@ func registerClientsMetrics() {
 	registry.MustRegister(nodesInfoMemory)
 	registry.MustRegister(nodesNeighborCount)
 	registry.MustRegister(networkDiameter)
+	registry.MustRegister(networkComponents)
 
 	registry.MustRegister(conflictCount)
 	registry.MustRegister(conflictFinalizationRounds)
@@ -191,6 +198,7 @@ func collectNodesInfo() {
 	}
 
 	networkDiameter.Set(float64(metrics.NetworkDiameter()))
+	networkComponents.Set(float64(metrics.NetworkConnectedComponents()))
 }
 
 func opinionToString(o opinion.Opinion) string {
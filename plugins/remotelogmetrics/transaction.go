@@ -12,6 +12,10 @@ import (
 
 // Evaluate evaluates the opinion of the given messageID.
 func onTransactionOpinionFormed(messageID tangle.MessageID) {
+	if !messagelayer.FCOBEnabled() {
+		return
+	}
+
 	var nodeID string
 	if local.GetInstance() != nil {
 		nodeID = local.GetInstance().ID().String()
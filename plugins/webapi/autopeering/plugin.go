@@ -28,6 +28,7 @@ var (
 
 func configure(plugin *node.Plugin) {
 	webapi.Server().GET("autopeering/neighbors", getNeighbors)
+	webapi.Server().GET("autopeering/neighbors/diversity", getDiversity)
 }
 
 // Plugin gets the plugin instance.
@@ -60,6 +61,37 @@ func getNeighbors(c echo.Context) error {
 	return c.JSON(http.StatusOK, jsonmodels.GetNeighborsResponse{KnownPeers: knownPeers, Chosen: chosen, Accepted: accepted})
 }
 
+// getDiversity returns how diverse the current neighborhood of the node is.
+func getDiversity(c echo.Context) error {
+	diagnostics := autopeering.Diversity().Diagnostics()
+
+	response := jsonmodels.GetNeighborhoodDiversityResponse{
+		NeighborCount:     diagnostics.NeighborCount,
+		DistinctSubnets:   diagnostics.DistinctSubnets,
+		LargestSubnet:     diagnostics.LargestSubnet,
+		MaxPerSubnet:      diagnostics.MaxPerSubnet,
+		Subnets:           make([]jsonmodels.SubnetCount, len(diagnostics.Subnets)),
+		DiversityScore:    diagnostics.DiversityScore,
+		HighManaRequired:  diagnostics.HighManaRequired,
+		HighManaNeighbors: diagnostics.HighManaNeighbors,
+		HighManaError:     diagnostics.HighManaError,
+		Rotations:         diagnostics.Rotations,
+		Rejections:        make(map[string]uint64, len(diagnostics.Rejections)),
+	}
+	for i, subnet := range diagnostics.Subnets {
+		response.Subnets[i] = jsonmodels.SubnetCount{Subnet: subnet.Subnet, Count: subnet.Count}
+	}
+	for reason, count := range diagnostics.Rejections {
+		response.Rejections[string(reason)] = count
+	}
+	if diagnostics.RotationInterval > 0 {
+		response.RotationInterval = diagnostics.RotationInterval.String()
+		response.OldestNeighborAge = diagnostics.OldestNeighborAge.String()
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
 func createNeighborFromPeer(p *peer.Peer) jsonmodels.Neighbor {
 	n := jsonmodels.Neighbor{
 		ID:        p.ID().String(),
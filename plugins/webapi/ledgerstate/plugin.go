@@ -97,6 +97,7 @@ func run(*node.Plugin) {
 	webapi.Server().GET("ledgerstate/transactions/:transactionID/metadata", GetTransactionMetadata)
 	webapi.Server().GET("ledgerstate/transactions/:transactionID/inclusionState", GetTransactionInclusionState)
 	webapi.Server().GET("ledgerstate/transactions/:transactionID/consensus", GetTransactionConsensusMetadata)
+	webapi.Server().GET("ledgerstate/transactions/:transactionID/consensus/trace", GetTransactionConsensusTrace)
 	webapi.Server().GET("ledgerstate/transactions/:transactionID/attachments", GetTransactionAttachments)
 	webapi.Server().POST("ledgerstate/transactions", PostTransaction)
 }
@@ -428,8 +429,7 @@ func GetTransactionConsensusMetadata(c echo.Context) (err error) {
 		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 	}
 
-	consensusMechanism := messagelayer.Tangle().Options.ConsensusMechanism.(*fcob.ConsensusMechanism)
-	if consensusMechanism != nil {
+	if consensusMechanism, ok := messagelayer.Tangle().Options.ConsensusMechanism.(*fcob.ConsensusMechanism); ok {
 		if consensusMechanism.Storage.Opinion(transactionID).Consume(func(opinion *fcob.Opinion) {
 			err = c.JSON(http.StatusOK, jsonmodels.NewTransactionConsensusMetadata(transactionID, opinion))
 		}) {
@@ -442,6 +442,26 @@ func GetTransactionConsensusMetadata(c echo.Context) (err error) {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetTransactionConsensusTrace /////////////////////////////////////////////////////////////////////////////////
+
+// GetTransactionConsensusTrace is the handler for the ledgerstate/transactions/:transactionID/consensus/trace endpoint.
+func GetTransactionConsensusTrace(c echo.Context) (err error) {
+	transactionID, err := ledgerstate.TransactionIDFromBase58(c.Param("transactionID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	if consensusMechanism, ok := messagelayer.Tangle().Options.ConsensusMechanism.(*fcob.ConsensusMechanism); ok {
+		if trace, exists := consensusMechanism.TransactionTrace(transactionID); exists {
+			return c.JSON(http.StatusOK, jsonmodels.NewTransactionConsensusTrace(trace))
+		}
+	}
+
+	return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(errors.Errorf("failed to load TransactionConsensusTrace of Transaction with %s", transactionID)))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetTransactionAttachments ////////////////////////////////////////////////////////////////////////////////////
 
 // GetTransactionAttachments is the handler for the ledgerstate/transactions/:transactionID/attachments endpoint.
@@ -5,8 +5,12 @@ import (
 
 	"github.com/labstack/echo"
 
+	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/delegation"
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/plugins/manarefresher"
+	manaPlugin "github.com/iotaledger/goshimmer/plugins/messagelayer"
 )
 
 // region GetDelegatedMana /////////////////////////////////////////////////////////////////////////////////////////////
@@ -46,3 +50,62 @@ type GetDelegatedOutputsResponse struct {
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetDelegations ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetDelegations handles the GetDelegations requests.
+func GetDelegations(c echo.Context) error {
+	var request jsonmodels.GetDelegationsRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, &jsonmodels.GetDelegationsResponse{Error: err.Error()})
+	}
+	receiver, err := manarefresher.DelegationAddress()
+	if request.Receiver != "" {
+		receiver, err = ledgerstate.AddressFromBase58EncodedString(request.Receiver)
+	}
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, &jsonmodels.GetDelegationsResponse{Error: err.Error()})
+	}
+	delegations, err := manarefresher.Delegations(receiver)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, &jsonmodels.GetDelegationsResponse{Error: err.Error()})
+	}
+
+	now := clock.SyncedTime()
+	res := &jsonmodels.GetDelegationsResponse{
+		Receiver:    receiver.Base58(),
+		Delegations: make([]*jsonmodels.Delegation, len(delegations)),
+	}
+	for i, d := range delegations {
+		res.Delegations[i] = jsonmodels.NewDelegation(d, manaPlugin.GetPendingMana(float64(d.Amount()), now.Sub(d.Updated)))
+		if d.Status != delegation.Reclaimed {
+			res.TotalAmount += d.Amount()
+		}
+		res.ConsensusMana += res.Delegations[i].ConsensusMana
+		res.PendingMana += res.Delegations[i].PendingMana
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetDelegationReceivers ///////////////////////////////////////////////////////////////////////////////////////
+
+// GetDelegationReceivers handles the GetDelegationReceivers requests.
+func GetDelegationReceivers(c echo.Context) error {
+	receivers, err := manarefresher.DelegationReceivers()
+	if err != nil {
+		return c.JSON(http.StatusNotFound, &jsonmodels.GetDelegationReceiversResponse{Error: err.Error()})
+	}
+	res := &jsonmodels.GetDelegationReceiversResponse{Receivers: make([]*jsonmodels.DelegationReceiver, len(receivers))}
+	for i, receiver := range receivers {
+		res.Receivers[i] = &jsonmodels.DelegationReceiver{
+			Receiver:    receiver.Receiver.Base58(),
+			Delegations: receiver.Delegations,
+			Amount:      receiver.Amount,
+		}
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,139 @@
+package mana
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/manaflow"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	manaFlowPlugin "github.com/iotaledger/goshimmer/plugins/manaflow"
+)
+
+// defaultManaFlowRange is the time range of a mana flow query without start time.
+const defaultManaFlowRange = 24 * time.Hour
+
+// GetManaFlow handles the request for the largest flows of mana between pledger addresses and nodes.
+func GetManaFlow(c echo.Context) error {
+	return manaFlowHandler(c, func(index *manaflow.Index, _ *jsonmodels.GetManaFlowRequest, query manaflow.Query) ([]*manaflow.Total, error) {
+		return index.Flows(query), nil
+	})
+}
+
+// getManaFlowPledgersHandler handles the request for the addresses that pledged the most mana to a node.
+func getManaFlowPledgersHandler(c echo.Context) error {
+	return manaFlowHandler(c, func(index *manaflow.Index, request *jsonmodels.GetManaFlowRequest, query manaflow.Query) ([]*manaflow.Total, error) {
+		ID, err := mana.IDFromStr(request.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		if request.NodeID == "" {
+			ID = local.GetInstance().ID()
+		}
+		return index.TopPledgers(ID, query), nil
+	})
+}
+
+// getManaFlowReceiversHandler handles the request for the nodes an address pledged the most mana to.
+func getManaFlowReceiversHandler(c echo.Context) error {
+	return manaFlowHandler(c, func(index *manaflow.Index, request *jsonmodels.GetManaFlowRequest, query manaflow.Query) ([]*manaflow.Total, error) {
+		address, err := ledgerstate.AddressFromBase58EncodedString(request.Pledger)
+		if err != nil {
+			return nil, err
+		}
+		return index.TopReceivers(address.Base58(), query), nil
+	})
+}
+
+// manaFlowHandler parses the time range and the mana type of the request and answers it with the flows selected by
+// the given function.
+func manaFlowHandler(c echo.Context, selectFlows func(*manaflow.Index, *jsonmodels.GetManaFlowRequest, manaflow.Query) ([]*manaflow.Total, error)) error {
+	var request jsonmodels.GetManaFlowRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaFlowResponse{Error: err.Error()})
+	}
+	index, err := manaFlowPlugin.Index()
+	if err != nil {
+		return c.JSON(http.StatusNotFound, jsonmodels.GetManaFlowResponse{Error: err.Error()})
+	}
+
+	query := manaflow.Query{ManaType: mana.ConsensusMana, Limit: request.Limit}
+	if request.ManaType != "" {
+		if query.ManaType, err = mana.TypeFromString(request.ManaType); err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetManaFlowResponse{Error: err.Error()})
+		}
+	}
+	query.End = time.Now()
+	if request.End != 0 {
+		query.End = time.Unix(request.End, 0)
+	}
+	query.Start = query.End.Add(-defaultManaFlowRange)
+	if request.Start != 0 {
+		query.Start = time.Unix(request.Start, 0)
+	}
+	if !query.Start.Before(query.End) {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaFlowResponse{Error: "start must be before end"})
+	}
+
+	totals, err := selectFlows(index, &request, query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaFlowResponse{Error: err.Error()})
+	}
+	res := jsonmodels.GetManaFlowResponse{
+		ManaType:     query.ManaType.String(),
+		Start:        query.Start.Unix(),
+		End:          query.End.Unix(),
+		CoveredSince: index.CoveredSince(time.Now()).Unix(),
+		Flows:        make([]*jsonmodels.ManaFlow, len(totals)),
+	}
+	for i, total := range totals {
+		res.Flows[i] = &jsonmodels.ManaFlow{
+			Pledger:      total.Pledger,
+			ShortNodeID:  total.NodeID.String(),
+			NodeID:       base58.Encode(total.NodeID.Bytes()),
+			Pledged:      total.Pledged,
+			Revoked:      total.Revoked,
+			Transactions: total.Transactions,
+		}
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// getManaFlowTransactionHandler handles the request for the mana pledged and revoked by a transaction.
+func getManaFlowTransactionHandler(c echo.Context) error {
+	var request jsonmodels.GetManaFlowTransactionRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaFlowTransactionResponse{Error: err.Error()})
+	}
+	transactionID, err := ledgerstate.TransactionIDFromBase58(request.TransactionID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaFlowTransactionResponse{Error: err.Error()})
+	}
+	index, err := manaFlowPlugin.Index()
+	if err != nil {
+		return c.JSON(http.StatusNotFound, jsonmodels.GetManaFlowTransactionResponse{Error: err.Error()})
+	}
+
+	records := index.Transaction(transactionID)
+	res := jsonmodels.GetManaFlowTransactionResponse{
+		TransactionID: transactionID.Base58(),
+		Records:       make([]*jsonmodels.ManaFlowRecord, len(records)),
+	}
+	for i, record := range records {
+		res.Records[i] = &jsonmodels.ManaFlowRecord{
+			Pledger:     record.Pledger,
+			ShortNodeID: record.NodeID.String(),
+			NodeID:      base58.Encode(record.NodeID.Bytes()),
+			ManaType:    record.ManaType.String(),
+			Amount:      record.Amount,
+			Time:        record.Time.Unix(),
+			Revoked:     record.Revoked,
+		}
+	}
+	return c.JSON(http.StatusOK, res)
+}
@@ -1,14 +1,30 @@
 package mana
 
-//// getPastConsensusVectorMetadataHandler handles the request.
-//func getPastConsensusVectorMetadataHandler(c echo.Context) error {
-//	metadata := manaPlugin.GetPastConsensusManaVectorMetadata()
-//	if metadata == nil {
-//		return c.JSON(http.StatusOK, jsonmodels.PastConsensusVectorMetadataResponse{
-//			Error: "Past consensus mana vector metadata not found",
-//		})
-//	}
-//	return c.JSON(http.StatusOK, jsonmodels.PastConsensusVectorMetadataResponse{
-//		Metadata: metadata,
-//	})
-//}
+import (
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/mana"
+	manaPlugin "github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// getPastConsensusVectorMetadataHandler handles the request.
+func getPastConsensusVectorMetadataHandler(c echo.Context) error {
+	metadata, err := manaPlugin.GetPastManaVectorMetadata(mana.ConsensusMana)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.PastConsensusVectorMetadataResponse{Error: err.Error()})
+	}
+	if metadata.Checkpoints == 0 {
+		return c.JSON(http.StatusOK, jsonmodels.PastConsensusVectorMetadataResponse{
+			Error: "Past consensus mana vector metadata not found",
+		})
+	}
+	return c.JSON(http.StatusOK, jsonmodels.PastConsensusVectorMetadataResponse{
+		Metadata:           &mana.ConsensusBasePastManaVectorMetadata{Timestamp: metadata.LatestCheckpoint},
+		OldestTimestamp:    metadata.OldestCheckpoint.Unix(),
+		Checkpoints:        metadata.Checkpoints,
+		CheckpointInterval: metadata.CheckpointInterval.String(),
+	})
+}
@@ -0,0 +1,50 @@
+package mana
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	manaPlugin "github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// getPastManaHandler handles the request for the mana of a node at a time in the past.
+func getPastManaHandler(c echo.Context) error {
+	var request jsonmodels.GetPastManaRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetPastManaResponse{Error: err.Error()})
+	}
+	ID, err := mana.IDFromStr(request.NodeID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetPastManaResponse{Error: err.Error()})
+	}
+	if request.NodeID == "" {
+		ID = local.GetInstance().ID()
+	}
+	timestamp := time.Unix(request.Timestamp, 0)
+	if timestamp.After(time.Now()) {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetPastManaResponse{Error: "timestamp is in the future"})
+	}
+
+	accessMana, err := manaPlugin.GetPastMana(mana.AccessMana, ID, timestamp)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetPastManaResponse{Error: err.Error()})
+	}
+	consensusMana, err := manaPlugin.GetPastMana(mana.ConsensusMana, ID, timestamp)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetPastManaResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.GetPastManaResponse{
+		ShortNodeID: ID.String(),
+		NodeID:      base58.Encode(ID.Bytes()),
+		Access:      accessMana,
+		Consensus:   consensusMana,
+		Timestamp:   timestamp.Unix(),
+	})
+}
@@ -37,7 +37,14 @@ func configure(_ *node.Plugin) {
 	webapi.Server().GET("mana/allowedManaPledge", allowedManaPledgeHandler)
 	webapi.Server().GET("mana/delegated", GetDelegatedMana)
 	webapi.Server().GET("mana/delegated/outputs", GetDelegatedOutputs)
-	// webapi.Server().GET("/mana/consensus/past", getPastConsensusManaVectorHandler)
-	// webapi.Server().GET("/mana/consensus/logs", getEventLogsHandler)
-	// webapi.Server().GET("/mana/consensus/metadata", getPastConsensusVectorMetadataHandler)
+	webapi.Server().GET("mana/delegations", GetDelegations)
+	webapi.Server().GET("mana/delegations/receivers", GetDelegationReceivers)
+	webapi.Server().GET("mana/flow", GetManaFlow)
+	webapi.Server().GET("mana/flow/pledgers", getManaFlowPledgersHandler)
+	webapi.Server().GET("mana/flow/receivers", getManaFlowReceiversHandler)
+	webapi.Server().GET("mana/flow/transaction", getManaFlowTransactionHandler)
+	webapi.Server().GET("mana/past", getPastManaHandler)
+	webapi.Server().GET("/mana/consensus/past", getPastConsensusManaVectorHandler)
+	webapi.Server().GET("/mana/consensus/logs", getEventLogsHandler)
+	webapi.Server().GET("/mana/consensus/metadata", getPastConsensusVectorMetadataHandler)
 }
@@ -0,0 +1,73 @@
+// Package diagnostic writes the rows of the diagnostic endpoints either as CSV or as JSON Lines.
+package diagnostic
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+)
+
+// MIMEApplicationJSONLines is the content type of the JSON Lines format, with one JSON object per line.
+const MIMEApplicationJSONLines = "application/x-ndjson"
+
+// Writer writes the rows of a diagnostic to a response in the format that the request asked for with the format query
+// parameter. CSV with a header row is the default, so that existing consumers keep working.
+type Writer struct {
+	response    *echo.Response
+	csvWriter   *csv.Writer
+	jsonEncoder *json.Encoder
+}
+
+// NewWriter creates a Writer for the response of the request and writes the header of the response. The table
+// description is written as the first row of a CSV table. It returns an error, before anything is written, if the
+// request asks for an unknown format.
+func NewWriter(c echo.Context, tableDescription []string) (writer *Writer, err error) {
+	writer = &Writer{response: c.Response()}
+
+	switch format := c.QueryParam(jsonmodels.DiagnosticsFormatParameter); format {
+	case "", jsonmodels.DiagnosticsFormatCSV:
+		writer.response.Header().Set(echo.HeaderContentType, "text/csv")
+		writer.response.WriteHeader(http.StatusOK)
+
+		writer.csvWriter = csv.NewWriter(writer.response)
+		if err = writer.csvWriter.Write(tableDescription); err != nil {
+			return nil, errors.Errorf("failed to write table description row: %w", err)
+		}
+	case jsonmodels.DiagnosticsFormatJSONLines:
+		writer.response.Header().Set(echo.HeaderContentType, MIMEApplicationJSONLines)
+		writer.response.WriteHeader(http.StatusOK)
+
+		writer.jsonEncoder = json.NewEncoder(writer.response)
+	default:
+		return nil, errors.Errorf("unknown format %s, use %s or %s", format, jsonmodels.DiagnosticsFormatCSV, jsonmodels.DiagnosticsFormatJSONLines)
+	}
+
+	return writer, nil
+}
+
+// Write writes a row in the format of the response.
+func (w *Writer) Write(row jsonmodels.DiagnosticRow) error {
+	if w.csvWriter != nil {
+		return w.csvWriter.Write(row.CSVRow())
+	}
+
+	return w.jsonEncoder.Encode(row)
+}
+
+// Flush writes the buffered rows to the client.
+func (w *Writer) Flush() error {
+	if w.csvWriter != nil {
+		w.csvWriter.Flush()
+		if err := w.csvWriter.Error(); err != nil {
+			return errors.Errorf("csv writer failed after flush: %w", err)
+		}
+	}
+	w.response.Flush()
+
+	return nil
+}
@@ -1,10 +1,7 @@
 package drng
 
 import (
-	"encoding/csv"
-	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/datastructure/walker"
@@ -14,8 +11,10 @@ import (
 	"github.com/mr-tron/base58"
 
 	"github.com/iotaledger/goshimmer/packages/drng"
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/webapi/tools/diagnostic"
 )
 
 // DiagnosticDRNGMessagesHandler runs the diagnostic over the Tangle.
@@ -26,13 +25,9 @@ func DiagnosticDRNGMessagesHandler(c echo.Context) (err error) {
 // region DiagnosticDRNGMessages code implementation /////////////////////////////////////////////////////////////////////////////////
 
 func runDiagnosticDRNGMessages(c echo.Context) (err error) {
-	// write Header and table description
-	c.Response().Header().Set(echo.HeaderContentType, "text/csv")
-	c.Response().WriteHeader(http.StatusOK)
-
-	csvWriter := csv.NewWriter(c.Response())
-	if err := csvWriter.Write(DiagnosticDRNGMessagesTableDescription); err != nil {
-		return errors.Errorf("failed to write table description row: %w", err)
+	writer, err := diagnostic.NewWriter(c, jsonmodels.DiagnosticDRNGMessagesTableDescription)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 	}
 
 	var writeErr error
@@ -43,7 +38,7 @@ func runDiagnosticDRNGMessages(c echo.Context) (err error) {
 				if messageInfo == nil {
 					return
 				}
-				if err := csvWriter.Write(messageInfo.toCSVRow()); err != nil {
+				if err := writer.Write(messageInfo); err != nil {
 					writeErr = errors.Errorf("failed to write message diagnostic info row: %w", err)
 					return
 				}
@@ -58,58 +53,16 @@ func runDiagnosticDRNGMessages(c echo.Context) (err error) {
 	if writeErr != nil {
 		return writeErr
 	}
-	csvWriter.Flush()
-	if err := csvWriter.Error(); err != nil {
-		return errors.Errorf("csv writer failed after flush: %w", err)
-	}
 
-	return nil
+	return writer.Flush()
 }
 
-// DiagnosticDRNGMessagesTableDescription holds the description of the diagnostic dRNG messages.
-var DiagnosticDRNGMessagesTableDescription = []string{
-	"ID",
-	"IssuerID",
-	"IssuerPublicKey",
-	"IssuanceTime",
-	"ArrivalTime",
-	"SolidTime",
-	"ScheduledTime",
-	"BookedTime",
-	"OpinionFormedTime",
-	"dRNGPayloadType",
-	"InstanceID",
-	"Round",
-	"PreviousSignature",
-	"Signature",
-	"DistributedPK",
-}
-
-// DiagnosticDRNGMessagesInfo holds the information of a dRNG message.
-type DiagnosticDRNGMessagesInfo struct {
-	ID                string
-	IssuerID          string
-	IssuerPublicKey   string
-	IssuanceTimestamp time.Time
-	ArrivalTime       time.Time
-	SolidTime         time.Time
-	ScheduledTime     time.Time
-	BookedTime        time.Time
-	OpinionFormedTime time.Time
-	PayloadType       string
-	InstanceID        uint32
-	Round             uint64
-	PreviousSignature string
-	Signature         string
-	DistributedPK     string
-}
-
-func getDiagnosticDRNGMessageInfo(message *tangle.Message) *DiagnosticDRNGMessagesInfo {
-	msgInfo := &DiagnosticDRNGMessagesInfo{
-		ID:                message.ID().Base58(),
-		IssuanceTimestamp: message.IssuingTime(),
-		IssuerID:          identity.NewID(message.IssuerPublicKey()).String(),
-		IssuerPublicKey:   message.IssuerPublicKey().String(),
+func getDiagnosticDRNGMessageInfo(message *tangle.Message) *jsonmodels.DiagnosticDRNGMessage {
+	msgInfo := &jsonmodels.DiagnosticDRNGMessage{
+		ID:              message.ID().Base58(),
+		IssuanceTime:    message.IssuingTime(),
+		IssuerID:        identity.NewID(message.IssuerPublicKey()).String(),
+		IssuerPublicKey: message.IssuerPublicKey().String(),
 	}
 	drngPayload := message.Payload().(*drng.Payload)
 
@@ -132,32 +85,10 @@ func getDiagnosticDRNGMessageInfo(message *tangle.Message) *DiagnosticDRNGMessag
 		msgInfo.SolidTime = metadata.SolidificationTime()
 		msgInfo.ScheduledTime = metadata.ScheduledTime()
 		msgInfo.BookedTime = metadata.BookedTime()
-		msgInfo.OpinionFormedTime = messagelayer.ConsensusMechanism().OpinionFormedTime(message.ID())
+		msgInfo.OpinionFormedTime = messagelayer.OpinionFormedTime(message.ID())
 	}, false)
 
 	return msgInfo
 }
 
-func (d *DiagnosticDRNGMessagesInfo) toCSVRow() (row []string) {
-	row = []string{
-		d.ID,
-		d.IssuerID,
-		d.IssuerPublicKey,
-		fmt.Sprint(d.IssuanceTimestamp.UnixNano()),
-		fmt.Sprint(d.ArrivalTime.UnixNano()),
-		fmt.Sprint(d.SolidTime.UnixNano()),
-		fmt.Sprint(d.ScheduledTime.UnixNano()),
-		fmt.Sprint(d.BookedTime.UnixNano()),
-		fmt.Sprint(d.OpinionFormedTime.UnixNano()),
-		d.PayloadType,
-		fmt.Sprint(d.InstanceID),
-		fmt.Sprint(d.Round),
-		d.PreviousSignature,
-		d.Signature,
-		d.DistributedPK,
-	}
-
-	return row
-}
-
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1,48 +1,42 @@
 package message
 
 import (
-	"fmt"
 	"net/http"
-	"strings"
-	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/labstack/echo"
 
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/webapi/tools/diagnostic"
 )
 
 // DiagnosticBranchesHandler runs the diagnostic over the Tangle.
 func DiagnosticBranchesHandler(c echo.Context) (err error) {
-	runDiagnosticBranches(c)
-	return
+	return runDiagnosticBranches(c)
 }
 
 // DiagnosticLazyBookedBranchesHandler runs the diagnostic over the Tangle.
 func DiagnosticLazyBookedBranchesHandler(c echo.Context) (err error) {
-	runDiagnosticChildBranches(c, ledgerstate.LazyBookedConflictsBranchID)
-	return
+	return runDiagnosticChildBranches(c, ledgerstate.LazyBookedConflictsBranchID)
 }
 
 // DiagnosticInvalidBranchesHandler runs the diagnostic over the Tangle.
 func DiagnosticInvalidBranchesHandler(c echo.Context) (err error) {
-	runDiagnosticChildBranches(c, ledgerstate.InvalidBranchID)
-	return
+	return runDiagnosticChildBranches(c, ledgerstate.InvalidBranchID)
 }
 
 // region DiagnosticBranches code implementation /////////////////////////////////////////////////////////////////////////////////
 
-func runDiagnosticBranches(c echo.Context) {
-	// write Header and table description
-	c.Response().Header().Set(echo.HeaderContentType, "text/csv")
-	c.Response().WriteHeader(http.StatusOK)
-
-	_, err := fmt.Fprintln(c.Response(), strings.Join(DiagnosticBranchesTableDescription, ","))
+func runDiagnosticBranches(c echo.Context) (err error) {
+	writer, err := diagnostic.NewWriter(c, jsonmodels.DiagnosticBranchesTableDescription)
 	if err != nil {
-		panic(err)
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 	}
 
+	var writeErr error
 	messagelayer.Tangle().LedgerState.BranchDAG.ForEachBranch(func(branch ledgerstate.Branch) {
 		switch branch.ID() {
 		case ledgerstate.MasterBranchID:
@@ -52,72 +46,45 @@ func runDiagnosticBranches(c echo.Context) {
 		case ledgerstate.LazyBookedConflictsBranchID:
 			return
 		default:
-			conflictInfo := getDiagnosticConflictsInfo(branch.ID())
-			_, err = fmt.Fprintln(c.Response(), conflictInfo.toCSV())
-			if err != nil {
-				panic(err)
+			if writeErr != nil {
+				return
+			}
+			if err := writer.Write(getDiagnosticConflictsInfo(branch.ID())); err != nil {
+				writeErr = errors.Errorf("failed to write branch diagnostic info row: %w", err)
 			}
-			c.Response().Flush()
 		}
 	})
+	if writeErr != nil {
+		return writeErr
+	}
 
-	c.Response().Flush()
+	return writer.Flush()
 }
 
-func runDiagnosticChildBranches(c echo.Context, branchID ledgerstate.BranchID) {
-	// write Header and table description
-	c.Response().Header().Set(echo.HeaderContentType, "text/csv")
-	c.Response().WriteHeader(http.StatusOK)
-
-	_, err := fmt.Fprintln(c.Response(), strings.Join(DiagnosticBranchesTableDescription, ","))
+func runDiagnosticChildBranches(c echo.Context, branchID ledgerstate.BranchID) (err error) {
+	writer, err := diagnostic.NewWriter(c, jsonmodels.DiagnosticBranchesTableDescription)
 	if err != nil {
-		panic(err)
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 	}
 
+	var writeErr error
 	messagelayer.Tangle().LedgerState.BranchDAG.ChildBranches(branchID).Consume(func(childBranch *ledgerstate.ChildBranch) {
-		conflictInfo := getDiagnosticConflictsInfo(childBranch.ChildBranchID())
-		_, err = fmt.Fprintln(c.Response(), conflictInfo.toCSV())
-		if err != nil {
-			panic(err)
+		if writeErr != nil {
+			return
+		}
+		if err := writer.Write(getDiagnosticConflictsInfo(childBranch.ChildBranchID())); err != nil {
+			writeErr = errors.Errorf("failed to write branch diagnostic info row: %w", err)
 		}
-		c.Response().Flush()
 	})
+	if writeErr != nil {
+		return writeErr
+	}
 
-	c.Response().Flush()
-}
-
-// DiagnosticBranchesTableDescription holds the description of the diagnostic Branches.
-var DiagnosticBranchesTableDescription = []string{
-	"ID",
-	"ConflictSet",
-	"IssuanceTime",
-	"SolidTime",
-	"OpinionFormedTime",
-	"Liked",
-	"MonotonicallyLiked",
-	"InclusionState",
-	"Finalized",
-	"LazyBooked",
-	"TransactionLiked",
-}
-
-// DiagnosticBranchInfo holds the information of a branch.
-type DiagnosticBranchInfo struct {
-	ID                 string
-	ConflictSet        []string
-	IssuanceTimestamp  time.Time
-	SolidTime          time.Time
-	OpinionFormedTime  time.Time
-	Liked              bool
-	MonotonicallyLiked bool
-	InclusionState     string
-	Finalized          bool
-	LazyBooked         bool
-	TransactionLiked   bool
+	return writer.Flush()
 }
 
-func getDiagnosticConflictsInfo(branchID ledgerstate.BranchID) DiagnosticBranchInfo {
-	conflictInfo := DiagnosticBranchInfo{
+func getDiagnosticConflictsInfo(branchID ledgerstate.BranchID) *jsonmodels.DiagnosticBranch {
+	conflictInfo := &jsonmodels.DiagnosticBranch{
 		ID: branchID.Base58(),
 	}
 
@@ -135,9 +102,9 @@ func getDiagnosticConflictsInfo(branchID ledgerstate.BranchID) DiagnosticBranchI
 		conflictInfo.ConflictSet = messagelayer.Tangle().LedgerState.ConflictSet(transactionID).Base58s()
 
 		messagelayer.Tangle().LedgerState.Transaction(transactionID).Consume(func(transaction *ledgerstate.Transaction) {
-			conflictInfo.IssuanceTimestamp = transaction.Essence().Timestamp()
+			conflictInfo.IssuanceTime = transaction.Essence().Timestamp()
 			messagelayer.Tangle().Storage.Attachments(transactionID).Consume(func(attachment *tangle.Attachment) {
-				conflictInfo.OpinionFormedTime = messagelayer.ConsensusMechanism().OpinionFormedTime(attachment.MessageID())
+				conflictInfo.OpinionFormedTime = messagelayer.OpinionFormedTime(attachment.MessageID())
 			})
 		})
 
@@ -145,31 +112,11 @@ func getDiagnosticConflictsInfo(branchID ledgerstate.BranchID) DiagnosticBranchI
 			conflictInfo.SolidTime = transactionMetadata.SolidificationTime()
 			conflictInfo.Finalized = transactionMetadata.Finalized()
 			conflictInfo.LazyBooked = transactionMetadata.LazyBooked()
-			conflictInfo.TransactionLiked = messagelayer.ConsensusMechanism().TransactionLiked(transactionID)
+			conflictInfo.TransactionLiked = messagelayer.Tangle().ConsensusManager.TransactionLiked(transactionID)
 		})
 	})
 
 	return conflictInfo
 }
 
-func (d DiagnosticBranchInfo) toCSV() (result string) {
-	row := []string{
-		d.ID,
-		strings.Join(d.ConflictSet, ";"),
-		fmt.Sprint(d.IssuanceTimestamp.UnixNano()),
-		fmt.Sprint(d.SolidTime.UnixNano()),
-		fmt.Sprint(d.OpinionFormedTime.UnixNano()),
-		fmt.Sprint(d.Liked),
-		fmt.Sprint(d.MonotonicallyLiked),
-		d.InclusionState,
-		fmt.Sprint(d.Finalized),
-		fmt.Sprint(d.LazyBooked),
-		fmt.Sprint(d.TransactionLiked),
-	}
-
-	result = strings.Join(row, ",")
-
-	return
-}
-
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
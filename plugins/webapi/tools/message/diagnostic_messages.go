@@ -1,12 +1,8 @@
 package message
 
 import (
-	"encoding/csv"
-	"fmt"
 	"net/http"
 	"strconv"
-	"strings"
-	"time"
 
 	"github.com/cockroachdb/errors"
 
@@ -19,6 +15,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/webapi/tools/diagnostic"
 )
 
 // DiagnosticMessagesHandler runs the diagnostic over the Tangle.
@@ -44,13 +41,9 @@ func DiagnosticMessagesRankHandler(c echo.Context) (err error) {
 // region DiagnosticMessages code implementation /////////////////////////////////////////////////////////////////////////////////
 
 func runDiagnosticMessages(c echo.Context, rank ...uint64) (err error) {
-	// write Header and table description
-	c.Response().Header().Set(echo.HeaderContentType, "text/csv")
-	c.Response().WriteHeader(http.StatusOK)
-
-	csvWriter := csv.NewWriter(c.Response())
-	if err := csvWriter.Write(DiagnosticMessagesTableDescription); err != nil {
-		return errors.Errorf("failed to write table description row: %w", err)
+	writer, err := diagnostic.NewWriter(c, jsonmodels.DiagnosticMessagesTableDescription)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 	}
 
 	startRank := uint64(0)
@@ -63,7 +56,7 @@ func runDiagnosticMessages(c echo.Context, rank ...uint64) (err error) {
 		messageInfo := getDiagnosticMessageInfo(messageID)
 
 		if messageInfo.Rank >= startRank {
-			if err := csvWriter.Write(messageInfo.toCSVRow()); err != nil {
+			if err := writer.Write(messageInfo); err != nil {
 				writeErr = errors.Errorf("failed to write message diagnostic info row: %w", err)
 				return
 			}
@@ -76,29 +69,22 @@ func runDiagnosticMessages(c echo.Context, rank ...uint64) (err error) {
 	if writeErr != nil {
 		return writeErr
 	}
-	csvWriter.Flush()
-	if err := csvWriter.Error(); err != nil {
-		return errors.Errorf("csv writer failed after flush: %w", err)
-	}
 
-	return nil
+	return writer.Flush()
 }
 
 func runDiagnosticMessagesOnFirstWeakReferences(c echo.Context) (err error) {
-	// write Header and table description
-	c.Response().Header().Set(echo.HeaderContentType, "text/csv")
-	c.Response().WriteHeader(http.StatusOK)
-
-	csvWriter := csv.NewWriter(c.Response())
-	if err := csvWriter.Write(DiagnosticMessagesTableDescription); err != nil {
-		return errors.Errorf("failed to write table description row: %w", err)
+	writer, err := diagnostic.NewWriter(c, jsonmodels.DiagnosticMessagesTableDescription)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 	}
+
 	var writeErr error
 	messagelayer.Tangle().Utils.WalkMessageID(func(messageID tangle.MessageID, walker *walker.Walker) {
 		messageInfo := getDiagnosticMessageInfo(messageID)
 
 		if len(messageInfo.WeakApprovers) > 0 {
-			if err := csvWriter.Write(messageInfo.toCSVRow()); err != nil {
+			if err := writer.Write(messageInfo); err != nil {
 				writeErr = errors.Errorf("failed to write message diagnostic info row: %w", err)
 				return
 			}
@@ -106,7 +92,7 @@ func runDiagnosticMessagesOnFirstWeakReferences(c echo.Context) (err error) {
 			messagelayer.Tangle().Storage.Message(messageID).Consume(func(message *tangle.Message) {
 				message.ForEachParent(func(parent tangle.Parent) {
 					parentMessageInfo := getDiagnosticMessageInfo(parent.ID)
-					if err := csvWriter.Write(parentMessageInfo.toCSVRow()); err != nil {
+					if err := writer.Write(parentMessageInfo); err != nil {
 						writeErr = errors.Errorf("failed to write parent message diagnostic info row: %w", err)
 						return
 					}
@@ -127,108 +113,20 @@ func runDiagnosticMessagesOnFirstWeakReferences(c echo.Context) (err error) {
 		return writeErr
 	}
 
-	csvWriter.Flush()
-	if err := csvWriter.Error(); err != nil {
-		return errors.Errorf("csv writer failed after flush: %w", err)
-	}
-
-	return nil
-}
-
-// DiagnosticMessagesTableDescription holds the description of the diagnostic messages.
-var DiagnosticMessagesTableDescription = []string{
-	"ID",
-	"IssuerID",
-	"IssuerPublicKey",
-	"IssuanceTime",
-	"ArrivalTime",
-	"SolidTime",
-	"ScheduledTime",
-	"BookedTime",
-	"OpinionFormedTime",
-	"FinalizedTime",
-	"StrongParents",
-	"WeakParents",
-	"StrongApprovers",
-	"WeakApprovers",
-	"BranchID",
-	"InclusionState",
-	"Scheduled",
-	"Booked",
-	"Eligible",
-	"Invalid",
-	"Finalized",
-	"Rank",
-	"IsPastMarker",
-	"PastMarkers",
-	"PMHI",
-	"PMLI",
-	"FutureMarkers",
-	"FMHI",
-	"FMLI",
-	"PayloadType",
-	"TransactionID",
-	"PayloadOpinionFormed",
-	"TimestampOpinionFormed",
-	"MessageOpinionFormed",
-	"MessageOpinionTriggered",
-	"TimestampOpinion",
-	"TimestampLoK",
-}
-
-// DiagnosticMessagesInfo holds the information of a message.
-type DiagnosticMessagesInfo struct {
-	ID                string
-	IssuerID          string
-	IssuerPublicKey   string
-	IssuanceTimestamp time.Time
-	ArrivalTime       time.Time
-	SolidTime         time.Time
-	ScheduledTime     time.Time
-	BookedTime        time.Time
-	OpinionFormedTime time.Time
-	FinalizedTime     time.Time
-	StrongParents     tangle.MessageIDs
-	WeakParents       tangle.MessageIDs
-	StrongApprovers   tangle.MessageIDs
-	WeakApprovers     tangle.MessageIDs
-	BranchID          string
-	InclusionState    string
-	Scheduled         bool
-	Booked            bool
-	Eligible          bool
-	Invalid           bool
-	Finalized         bool
-	Rank              uint64
-	IsPastMarker      bool
-	PastMarkers       string // PastMarkers
-	PMHI              uint64 // PastMarkers Highest Index
-	PMLI              uint64 // PastMarkers Lowest Index
-	FutureMarkers     string // FutureMarkers
-	FMHI              uint64 // FutureMarkers Highest Index
-	FMLI              uint64 // FutureMarkers Lowest Index
-	PayloadType       string
-	TransactionID     string
-	// Consensus information
-	PayloadOpinionFormed    bool
-	TimestampOpinionFormed  bool
-	MessageOpinionFormed    bool
-	MessageOpinionTriggered bool
-	TimestampOpinion        string
-	TimestampLoK            string
+	return writer.Flush()
 }
 
-func getDiagnosticMessageInfo(messageID tangle.MessageID) *DiagnosticMessagesInfo {
-	msgInfo := &DiagnosticMessagesInfo{
+func getDiagnosticMessageInfo(messageID tangle.MessageID) *jsonmodels.DiagnosticMessage {
+	msgInfo := &jsonmodels.DiagnosticMessage{
 		ID: messageID.Base58(),
 	}
 
 	messagelayer.Tangle().Storage.Message(messageID).Consume(func(message *tangle.Message) {
-		msgInfo.IssuanceTimestamp = message.IssuingTime()
+		msgInfo.IssuanceTime = message.IssuingTime()
 		msgInfo.IssuerID = identity.NewID(message.IssuerPublicKey()).String()
 		msgInfo.IssuerPublicKey = message.IssuerPublicKey().String()
-		msgInfo.StrongParents = message.StrongParents()
-		msgInfo.WeakParents = message.WeakParents()
+		msgInfo.StrongParents = message.StrongParents().ToStrings()
+		msgInfo.WeakParents = message.WeakParents().ToStrings()
 		msgInfo.PayloadType = message.Payload().Type().String()
 		if message.Payload().Type() == ledgerstate.TransactionType {
 			msgInfo.TransactionID = message.Payload().(*ledgerstate.Transaction).ID().Base58()
@@ -246,7 +144,7 @@ func getDiagnosticMessageInfo(messageID tangle.MessageID) *DiagnosticMessagesInf
 		msgInfo.Scheduled = metadata.Scheduled()
 		msgInfo.ScheduledTime = metadata.ScheduledTime()
 		msgInfo.BookedTime = metadata.BookedTime()
-		msgInfo.OpinionFormedTime = messagelayer.ConsensusMechanism().OpinionFormedTime(messageID)
+		msgInfo.OpinionFormedTime = messagelayer.OpinionFormedTime(messageID)
 		msgInfo.FinalizedTime = metadata.FinalizedTime()
 		msgInfo.Booked = metadata.IsBooked()
 		msgInfo.Eligible = metadata.IsEligible()
@@ -264,14 +162,14 @@ func getDiagnosticMessageInfo(messageID tangle.MessageID) *DiagnosticMessagesInf
 		}
 	}, false)
 
-	msgInfo.StrongApprovers = messagelayer.Tangle().Utils.ApprovingMessageIDs(messageID, tangle.StrongApprover)
-	msgInfo.WeakApprovers = messagelayer.Tangle().Utils.ApprovingMessageIDs(messageID, tangle.WeakApprover)
+	msgInfo.StrongApprovers = messagelayer.Tangle().Utils.ApprovingMessageIDs(messageID, tangle.StrongApprover).ToStrings()
+	msgInfo.WeakApprovers = messagelayer.Tangle().Utils.ApprovingMessageIDs(messageID, tangle.WeakApprover).ToStrings()
 
 	msgInfo.InclusionState = messagelayer.Tangle().LedgerState.BranchInclusionState(branchID).String()
 
 	// add consensus information
-	consensusMechanism := messagelayer.Tangle().Options.ConsensusMechanism.(*fcob.ConsensusMechanism)
-	if consensusMechanism != nil {
+	// only FCoB keeps these opinions, other consensus mechanisms leave the fields empty
+	if consensusMechanism, ok := messagelayer.Tangle().Options.ConsensusMechanism.(*fcob.ConsensusMechanism); ok {
 		consensusMechanism.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *fcob.MessageMetadata) {
 			msgInfo.PayloadOpinionFormed = messageMetadata.PayloadOpinionFormed()
 			msgInfo.TimestampOpinionFormed = messageMetadata.TimestampOpinionFormed()
@@ -288,50 +186,6 @@ func getDiagnosticMessageInfo(messageID tangle.MessageID) *DiagnosticMessagesInf
 	return msgInfo
 }
 
-func (d *DiagnosticMessagesInfo) toCSVRow() (row []string) {
-	row = []string{
-		d.ID,
-		d.IssuerID,
-		d.IssuerPublicKey,
-		fmt.Sprint(d.IssuanceTimestamp.UnixNano()),
-		fmt.Sprint(d.ArrivalTime.UnixNano()),
-		fmt.Sprint(d.SolidTime.UnixNano()),
-		fmt.Sprint(d.ScheduledTime.UnixNano()),
-		fmt.Sprint(d.BookedTime.UnixNano()),
-		fmt.Sprint(d.OpinionFormedTime.UnixNano()),
-		fmt.Sprint(d.FinalizedTime.UnixNano()),
-		strings.Join(d.StrongParents.ToStrings(), ";"),
-		strings.Join(d.WeakParents.ToStrings(), ";"),
-		strings.Join(d.StrongApprovers.ToStrings(), ";"),
-		strings.Join(d.WeakApprovers.ToStrings(), ";"),
-		d.BranchID,
-		d.InclusionState,
-		fmt.Sprint(d.Scheduled),
-		fmt.Sprint(d.Booked),
-		fmt.Sprint(d.Eligible),
-		fmt.Sprint(d.Invalid),
-		fmt.Sprint(d.Finalized),
-		fmt.Sprint(d.Rank),
-		fmt.Sprint(d.IsPastMarker),
-		d.PastMarkers,
-		fmt.Sprint(d.PMHI),
-		fmt.Sprint(d.PMLI),
-		d.FutureMarkers,
-		fmt.Sprint(d.FMHI),
-		fmt.Sprint(d.FMLI),
-		d.PayloadType,
-		d.TransactionID,
-		fmt.Sprint(d.PayloadOpinionFormed),
-		fmt.Sprint(d.TimestampOpinionFormed),
-		fmt.Sprint(d.MessageOpinionFormed),
-		fmt.Sprint(d.MessageOpinionTriggered),
-		d.TimestampOpinion,
-		d.TimestampLoK,
-	}
-
-	return row
-}
-
 // rankFromContext determines the marker rank from the rank parameter in an echo.Context.
 func rankFromContext(c echo.Context) (rank uint64, err error) {
 	rank, err = strconv.ParseUint(c.Param("rank"), 10, 64)
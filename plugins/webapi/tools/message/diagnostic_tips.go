@@ -1,11 +1,12 @@
 package message
 
 import (
-	"encoding/csv"
 	"net/http"
 
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/webapi/tools/diagnostic"
 
 	"github.com/cockroachdb/errors"
 	"github.com/labstack/echo"
@@ -34,30 +35,12 @@ func WeakTipsDiagnosticHandler(c echo.Context) error {
 	return runTipsDiagnostic(c, weakTipsOnly)
 }
 
-var tipsDiagnosticTableDescription = append([]string{"tipType"}, DiagnosticMessagesTableDescription...)
-
-type tipsDiagnosticInfo struct {
-	tipType tangle.TipType
-	*DiagnosticMessagesInfo
-}
-
-func (tdi *tipsDiagnosticInfo) toCSVRow() (row []string) {
-	messageRow := tdi.DiagnosticMessagesInfo.toCSVRow()
-	row = make([]string, 0, 1+len(messageRow))
-	row = append(row, tdi.tipType.String())
-	row = append(row, messageRow...)
-	return row
-}
-
 func runTipsDiagnostic(c echo.Context, diagnosticType tipsDiagnosticType) (err error) {
-	response := c.Response()
-	response.Header().Set(echo.HeaderContentType, "text/csv")
-	response.WriteHeader(http.StatusOK)
-
-	csvWriter := csv.NewWriter(response)
-	if err := csvWriter.Write(tipsDiagnosticTableDescription); err != nil {
-		return errors.Errorf("can't write table description row: %w", err)
+	writer, err := diagnostic.NewWriter(c, jsonmodels.DiagnosticTipsTableDescription)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 	}
+
 	var strongTips, weakTips tangle.MessageIDs
 	if diagnosticType == strongTipsOnly || diagnosticType == allTips {
 		strongTips = messagelayer.Tangle().TipManager.AllStrongTips()
@@ -65,27 +48,23 @@ func runTipsDiagnostic(c echo.Context, diagnosticType tipsDiagnosticType) (err e
 	if diagnosticType == weakTipsOnly || diagnosticType == allTips {
 		weakTips = messagelayer.Tangle().TipManager.AllWeakTips()
 	}
-	if err := buildAndWriteTipsDiagnostic(csvWriter, strongTips, tangle.StrongTip); err != nil {
+	if err := buildAndWriteTipsDiagnostic(writer, strongTips, tangle.StrongTip); err != nil {
 		return errors.Errorf("%w", err)
 	}
-	if err := buildAndWriteTipsDiagnostic(csvWriter, weakTips, tangle.WeakTip); err != nil {
+	if err := buildAndWriteTipsDiagnostic(writer, weakTips, tangle.WeakTip); err != nil {
 		return errors.Errorf("%w", err)
 	}
-	csvWriter.Flush()
-	if err := csvWriter.Error(); err != nil {
-		return errors.Errorf("csv writer failed after flush: %w", err)
-	}
-	return nil
+
+	return writer.Flush()
 }
 
-func buildAndWriteTipsDiagnostic(w *csv.Writer, tips tangle.MessageIDs, tipType tangle.TipType) (err error) {
+func buildAndWriteTipsDiagnostic(w *diagnostic.Writer, tips tangle.MessageIDs, tipType tangle.TipType) (err error) {
 	for _, tipID := range tips {
-		messageInfo := getDiagnosticMessageInfo(tipID)
-		tipInfo := tipsDiagnosticInfo{
-			tipType:                tipType,
-			DiagnosticMessagesInfo: messageInfo,
+		tipInfo := &jsonmodels.DiagnosticTip{
+			TipType:           tipType.String(),
+			DiagnosticMessage: *getDiagnosticMessageInfo(tipID),
 		}
-		if err := w.Write(tipInfo.toCSVRow()); err != nil {
+		if err := w.Write(tipInfo); err != nil {
 			return errors.Errorf("failed to write tip diagnostic info row: %w", err)
 		}
 	}
@@ -1,120 +1,66 @@
 package message
 
 import (
-	"fmt"
 	"net/http"
-	"strings"
-	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/datastructure/walker"
 	"github.com/labstack/echo"
 	"github.com/mr-tron/base58"
 
 	"github.com/iotaledger/goshimmer/packages/consensus/fcob"
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/webapi/tools/diagnostic"
 )
 
 // DiagnosticUTXODAGHandler runs the diagnostic over the Tangle.
 func DiagnosticUTXODAGHandler(c echo.Context) (err error) {
-	runDiagnosticUTXODAG(c)
-	return
+	return runDiagnosticUTXODAG(c)
 }
 
 // region DiagnosticUTXODAG code implementation /////////////////////////////////////////////////////////////////////////////////
 
-func runDiagnosticUTXODAG(c echo.Context) {
-	// write Header and table description
-	c.Response().Header().Set(echo.HeaderContentType, "text/csv")
-	c.Response().WriteHeader(http.StatusOK)
-
-	_, err := fmt.Fprintln(c.Response(), strings.Join(DiagnosticUTXODAGTableDescription, ","))
+func runDiagnosticUTXODAG(c echo.Context) (err error) {
+	writer, err := diagnostic.NewWriter(c, jsonmodels.DiagnosticUTXODAGTableDescription)
 	if err != nil {
-		panic(err)
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 	}
 
+	var writeErr error
 	messagelayer.Tangle().Utils.WalkMessageID(func(messageID tangle.MessageID, walker *walker.Walker) {
 		messagelayer.Tangle().Utils.ComputeIfTransaction(messageID, func(transactionID ledgerstate.TransactionID) {
-			transactionInfo := getDiagnosticUTXODAGInfo(transactionID, messageID)
-			_, err = fmt.Fprintln(c.Response(), transactionInfo.toCSV())
-			if err != nil {
-				panic(err)
+			if err := writer.Write(getDiagnosticUTXODAGInfo(transactionID, messageID)); err != nil {
+				writeErr = errors.Errorf("failed to write transaction diagnostic info row: %w", err)
+				walker.StopWalk()
 			}
-			c.Response().Flush()
 		})
 
 		messagelayer.Tangle().Storage.Approvers(messageID).Consume(func(approver *tangle.Approver) {
 			walker.Push(approver.ApproverMessageID())
 		})
 	}, tangle.MessageIDs{tangle.EmptyMessageID})
+	if writeErr != nil {
+		return writeErr
+	}
 
-	c.Response().Flush()
-}
-
-// DiagnosticUTXODAGTableDescription holds the description of the diagnostic UTXODAG.
-var DiagnosticUTXODAGTableDescription = []string{
-	"ID",
-	"IssuanceTime",
-	"SolidTime",
-	"OpinionFormedTime",
-	"AccessManaPledgeID",
-	"ConsensusManaPledgeID",
-	"Inputs",
-	"Outputs",
-	"Attachments",
-	"BranchID",
-	"BranchLiked",
-	"BranchMonotonicallyLiked",
-	"Conflicting",
-	"InclusionState",
-	"Finalized",
-	"LazyBooked",
-	"Liked",
-	"LoK",
-	"FCOB1Time",
-	"FCOB2Time",
-}
-
-// DiagnosticUTXODAGInfo holds the information of a UTXO.
-type DiagnosticUTXODAGInfo struct {
-	// transaction essence
-	ID                    string
-	IssuanceTimestamp     time.Time
-	SolidTime             time.Time
-	OpinionFormedTime     time.Time
-	AccessManaPledgeID    string
-	ConsensusManaPledgeID string
-	Inputs                ledgerstate.Inputs
-	Outputs               ledgerstate.Outputs
-	// attachments
-	Attachments []string
-	// transaction metadata
-	BranchID                 string
-	BranchLiked              bool
-	BranchMonotonicallyLiked bool
-	Conflicting              bool
-	InclusionState           string
-	Finalized                bool
-	LazyBooked               bool
-	Liked                    bool
-	LoK                      string
-	FCOBTime1                time.Time
-	FCOBTime2                time.Time
+	return writer.Flush()
 }
 
-func getDiagnosticUTXODAGInfo(transactionID ledgerstate.TransactionID, messageID tangle.MessageID) DiagnosticUTXODAGInfo {
-	txInfo := DiagnosticUTXODAGInfo{
+func getDiagnosticUTXODAGInfo(transactionID ledgerstate.TransactionID, messageID tangle.MessageID) *jsonmodels.DiagnosticTransaction {
+	txInfo := &jsonmodels.DiagnosticTransaction{
 		ID: transactionID.Base58(),
 	}
 
 	messagelayer.Tangle().LedgerState.Transaction(transactionID).Consume(func(transaction *ledgerstate.Transaction) {
-		txInfo.IssuanceTimestamp = transaction.Essence().Timestamp()
-		txInfo.OpinionFormedTime = messagelayer.ConsensusMechanism().OpinionFormedTime(messageID)
+		txInfo.IssuanceTime = transaction.Essence().Timestamp()
+		txInfo.OpinionFormedTime = messagelayer.OpinionFormedTime(messageID)
 		txInfo.AccessManaPledgeID = base58.Encode(transaction.Essence().AccessPledgeID().Bytes())
 		txInfo.ConsensusManaPledgeID = base58.Encode(transaction.Essence().ConsensusPledgeID().Bytes())
-		txInfo.Inputs = transaction.Essence().Inputs()
-		txInfo.Outputs = transaction.Essence().Outputs()
+		txInfo.Inputs = transaction.Essence().Inputs().Strings()
+		txInfo.Outputs = transaction.Essence().Outputs().Strings()
 	})
 
 	for _, messageID := range messagelayer.Tangle().Storage.AttachmentMessageIDs(transactionID) {
@@ -134,11 +80,11 @@ func getDiagnosticUTXODAGInfo(transactionID ledgerstate.TransactionID, messageID
 		txInfo.Finalized = transactionMetadata.Finalized()
 		txInfo.LazyBooked = transactionMetadata.LazyBooked()
 		txInfo.InclusionState = messagelayer.Tangle().LedgerState.BranchInclusionState(transactionMetadata.BranchID()).String()
-		txInfo.Liked = messagelayer.ConsensusMechanism().TransactionLiked(transactionID)
+		txInfo.Liked = messagelayer.Tangle().ConsensusManager.TransactionLiked(transactionID)
 	})
 
-	consensusMechanism := messagelayer.Tangle().Options.ConsensusMechanism.(*fcob.ConsensusMechanism)
-	if consensusMechanism != nil {
+	// only FCoB keeps these opinions, other consensus mechanisms leave the fields empty
+	if consensusMechanism, ok := messagelayer.Tangle().Options.ConsensusMechanism.(*fcob.ConsensusMechanism); ok {
 		consensusMechanism.Storage.Opinion(transactionID).Consume(func(opinion *fcob.Opinion) {
 			txInfo.LoK = opinion.LevelOfKnowledge().String()
 			txInfo.FCOBTime1 = opinion.FCOBTime1()
@@ -149,33 +95,4 @@ func getDiagnosticUTXODAGInfo(transactionID ledgerstate.TransactionID, messageID
 	return txInfo
 }
 
-func (d DiagnosticUTXODAGInfo) toCSV() (result string) {
-	row := []string{
-		d.ID,
-		fmt.Sprint(d.IssuanceTimestamp.UnixNano()),
-		fmt.Sprint(d.SolidTime.UnixNano()),
-		fmt.Sprint(d.OpinionFormedTime.UnixNano()),
-		d.AccessManaPledgeID,
-		d.ConsensusManaPledgeID,
-		strings.Join(d.Inputs.Strings(), ";"),
-		strings.Join(d.Outputs.Strings(), ";"),
-		strings.Join(d.Attachments, ";"),
-		d.BranchID,
-		fmt.Sprint(d.BranchLiked),
-		fmt.Sprint(d.BranchMonotonicallyLiked),
-		fmt.Sprint(d.Conflicting),
-		d.InclusionState,
-		fmt.Sprint(d.Finalized),
-		fmt.Sprint(d.LazyBooked),
-		fmt.Sprint(d.Liked),
-		d.LoK,
-		fmt.Sprint(d.FCOBTime1.UnixNano()),
-		fmt.Sprint(d.FCOBTime2.UnixNano()),
-	}
-
-	result = strings.Join(row, ",")
-
-	return
-}
-
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,61 @@
+// Package main implements a self-hostable asset registry that stores the names and symbols of colored coins. Every
+// entry needs to be signed by the minter of its color, which the registry checks against the minting transaction.
+package main
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/assetregistry"
+)
+
+const (
+	cfgBindAddress = "bind"
+	cfgDatabase    = "db"
+	cfgNode        = "node"
+	cfgNetworks    = "networks"
+)
+
+func init() {
+	defaultNetworks := make([]string, 0, len(assetregistry.Networks))
+	for network := range assetregistry.Networks {
+		defaultNetworks = append(defaultNetworks, network)
+	}
+	sort.Strings(defaultNetworks)
+
+	flag.String(cfgBindAddress, "0.0.0.0:8090", "the address the registry API is served on")
+	flag.String(cfgDatabase, "asset-registry.json", "the file the entries are stored in")
+	flag.String(cfgNode, "http://127.0.0.1:8080", "the API of the node that the minting transactions are fetched from")
+	flag.StringSlice(cfgNetworks, defaultNetworks, "the networks that the registry keeps entries for")
+}
+
+func main() {
+	flag.Parse()
+	if err := viper.BindPFlags(flag.CommandLine); err != nil {
+		panic(err)
+	}
+
+	entryStore, err := newStore(viper.GetString(cfgDatabase))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	networks := make(map[string]bool)
+	for _, network := range viper.GetStringSlice(cfgNetworks) {
+		networks[strings.TrimSpace(network)] = true
+	}
+
+	registry := &server{
+		store:     entryStore,
+		networks:  networks,
+		connector: wallet.NewWebConnector(viper.GetString(cfgNode)),
+	}
+
+	log.Printf("serving the asset registry for the networks %s on %s", strings.Join(viper.GetStringSlice(cfgNetworks), ", "), viper.GetString(cfgBindAddress))
+	log.Fatal(newServer(registry).Start(viper.GetString(cfgBindAddress)))
+}
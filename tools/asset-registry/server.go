@@ -0,0 +1,113 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+	"github.com/labstack/echo/middleware"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/assetregistry"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// server serves the asset registries of the allowed networks.
+type server struct {
+	store    *store
+	networks map[string]bool
+	// connector fetches the minting transactions that the entries are verified against.
+	connector wallet.HistoryConnector
+}
+
+// newServer creates the echo instance that serves the registry API.
+func newServer(s *server) *echo.Echo {
+	e := echo.New()
+	e.HideBanner = true
+	e.Use(middleware.Recover())
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins: []string{"*"},
+		AllowMethods: []string{http.MethodGet, http.MethodHead, http.MethodPost},
+	}))
+
+	assets := assetregistry.RegistriesEndpoint + "/:network" + assetregistry.AssetsEndpoint
+	e.POST(assets, s.saveEntry)
+	e.GET(assets, s.loadEntries)
+	e.GET(assets+"/:ID", s.loadEntry)
+
+	return e
+}
+
+// saveEntry stores an entry after verifying that it is signed by the minter of its color.
+func (s *server) saveEntry(c echo.Context) error {
+	network := c.Param("network")
+	if !s.networks[network] {
+		return c.JSON(http.StatusForbidden, assetregistry.NewErrorResponse(errors.Errorf("network %s is not allowed", network)))
+	}
+
+	entry := &assetregistry.Entry{}
+	if err := c.Bind(entry); err != nil {
+		return c.JSON(http.StatusBadRequest, assetregistry.NewErrorResponse(errors.Errorf("failed to parse entry: %w", err)))
+	}
+	if entry.Name == "" || entry.Supply == 0 {
+		return c.JSON(http.StatusBadRequest, assetregistry.NewErrorResponse(errors.New("an entry needs a name and a supply")))
+	}
+	if err := s.verifyMinting(network, entry); err != nil {
+		if errors.Is(err, assetregistry.ErrNotMinter) || errors.Is(err, assetregistry.ErrInvalidSignature) {
+			return c.JSON(http.StatusForbidden, assetregistry.NewErrorResponse(err))
+		}
+		return c.JSON(http.StatusBadRequest, assetregistry.NewErrorResponse(err))
+	}
+
+	if err := s.store.Save(network, entry); err != nil {
+		if errors.Is(err, assetregistry.ErrAlreadyRegistered) {
+			return c.JSON(http.StatusConflict, assetregistry.NewErrorResponse(err))
+		}
+		return c.JSON(http.StatusInternalServerError, assetregistry.NewErrorResponse(err))
+	}
+
+	return c.JSON(http.StatusCreated, entry)
+}
+
+// loadEntry returns the entry of a color.
+func (s *server) loadEntry(c echo.Context) error {
+	network := c.Param("network")
+	if !s.networks[network] {
+		return c.JSON(http.StatusForbidden, assetregistry.NewErrorResponse(errors.Errorf("network %s is not allowed", network)))
+	}
+
+	entry, err := s.store.Load(network, c.Param("ID"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, assetregistry.NewErrorResponse(err))
+	}
+
+	return c.JSON(http.StatusOK, entry)
+}
+
+// loadEntries returns all entries of a network.
+func (s *server) loadEntries(c echo.Context) error {
+	network := c.Param("network")
+	if !s.networks[network] {
+		return c.JSON(http.StatusForbidden, assetregistry.NewErrorResponse(errors.Errorf("network %s is not allowed", network)))
+	}
+
+	return c.JSON(http.StatusOK, s.store.LoadAll(network))
+}
+
+// verifyMinting checks the entry against its minting transaction, which is fetched from the node.
+func (s *server) verifyMinting(network string, entry *assetregistry.Entry) (err error) {
+	if _, err = entry.VerifySignature(network); err != nil {
+		return err
+	}
+
+	transactionID, err := ledgerstate.TransactionIDFromBase58(entry.TransactionID)
+	if err != nil {
+		return errors.Errorf("failed to parse transaction ID %s: %w", entry.TransactionID, err)
+	}
+	mintingTransaction, err := s.connector.GetTransactionDetails(transactionID)
+	if err != nil {
+		return errors.Errorf("failed to load minting transaction %s: %w", entry.TransactionID, err)
+	}
+
+	return entry.VerifyMinting(network, mintingTransaction.Inputs, mintingTransaction.Outputs)
+}
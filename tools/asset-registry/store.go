@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/assetregistry"
+)
+
+// store keeps the entries of the registries of all networks in memory and persists them to a JSON file.
+type store struct {
+	filename string
+	// entries contains the entries by network and color.
+	entries map[string]map[string]*assetregistry.Entry
+	mutex   sync.RWMutex
+}
+
+// newStore creates a store that is persisted to the given file and loads its entries, if the file exists.
+func newStore(filename string) (s *store, err error) {
+	s = &store{
+		filename: filename,
+		entries:  make(map[string]map[string]*assetregistry.Entry),
+	}
+
+	storeBytes, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, errors.Errorf("failed to read %s: %w", filename, err)
+	}
+	if err = json.Unmarshal(storeBytes, &s.entries); err != nil {
+		return nil, errors.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	return s, nil
+}
+
+// Save stores the entry in the registry of the given network. An entry can only be replaced by an entry that is signed
+// by the same key.
+func (s *store) Save(network string, entry *assetregistry.Entry) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if existing, exists := s.entries[network][entry.ID]; exists && existing.PublicKey != entry.PublicKey {
+		return errors.Errorf("failed to save entry of color %s: %w", entry.ID, assetregistry.ErrAlreadyRegistered)
+	}
+
+	if _, exists := s.entries[network]; !exists {
+		s.entries[network] = make(map[string]*assetregistry.Entry)
+	}
+	previous := s.entries[network][entry.ID]
+	s.entries[network][entry.ID] = entry
+
+	if err = s.persist(); err != nil {
+		if previous == nil {
+			delete(s.entries[network], entry.ID)
+		} else {
+			s.entries[network][entry.ID] = previous
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Load returns the entry of the given color from the registry of the given network.
+func (s *store) Load(network, id string) (entry *assetregistry.Entry, err error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	entry, exists := s.entries[network][id]
+	if !exists {
+		return nil, errors.Errorf("failed to load entry of color %s: %w", id, assetregistry.ErrNotFound)
+	}
+
+	return entry, nil
+}
+
+// LoadAll returns all entries of the registry of the given network ordered by their color.
+func (s *store) LoadAll(network string) (entries []*assetregistry.Entry) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	entries = make([]*assetregistry.Entry, 0, len(s.entries[network]))
+	for _, entry := range s.entries[network] {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+
+	return entries
+}
+
+// persist writes the entries to a temporary file and moves it over the store file, so that the store file is never
+// left half written.
+func (s *store) persist() (err error) {
+	storeBytes, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return errors.Errorf("failed to marshal entries: %w", err)
+	}
+	if err = os.WriteFile(s.filename+".tmp", storeBytes, 0o600); err != nil {
+		return errors.Errorf("failed to write %s: %w", s.filename+".tmp", err)
+	}
+	if err = os.Rename(s.filename+".tmp", s.filename); err != nil {
+		return errors.Errorf("failed to replace %s: %w", s.filename, err)
+	}
+
+	return nil
+}
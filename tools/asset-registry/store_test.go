@@ -0,0 +1,54 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/assetregistry"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func TestStore_Save(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "registry.json")
+	s, err := newStore(filename)
+	require.NoError(t, err)
+
+	owner, other := ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair()
+	color := ledgerstate.Color{1}.Base58()
+	require.NoError(t, s.Save("test", newSignedEntry(color, "Token", owner)))
+
+	// the owner can update the entry
+	require.NoError(t, s.Save("test", newSignedEntry(color, "Renamed Token", owner)))
+
+	// nobody else can replace it
+	err = s.Save("test", newSignedEntry(color, "Stolen Token", other))
+	assert.True(t, errors.Is(err, assetregistry.ErrAlreadyRegistered))
+	entry, err := s.Load("test", color)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed Token", entry.Name)
+
+	// the registries of the networks are independent
+	require.NoError(t, s.Save("nectar", newSignedEntry(color, "Other Token", other)))
+
+	// the entries are persisted
+	reloaded, err := newStore(filename)
+	require.NoError(t, err)
+	entry, err = reloaded.Load("test", color)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed Token", entry.Name)
+	assert.Len(t, reloaded.LoadAll("nectar"), 1)
+	_, err = reloaded.Load("test", ledgerstate.Color{2}.Base58())
+	assert.True(t, errors.Is(err, assetregistry.ErrNotFound))
+}
+
+func newSignedEntry(color, name string, keyPair ed25519.KeyPair) *assetregistry.Entry {
+	entry := &assetregistry.Entry{ID: color, Name: name, Symbol: "T", Supply: 1000, TransactionID: ledgerstate.TransactionID{1}.Base58()}
+	entry.Sign("test", keyPair)
+
+	return entry
+}
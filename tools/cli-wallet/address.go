@@ -54,13 +54,21 @@ func execAddressCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 	}
 
 	if *receivePtr {
+		receiveAddress, err := cliWallet.TryReceiveAddress()
+		if err != nil {
+			printUsage(command, err.Error())
+		}
 		fmt.Println()
-		fmt.Println("Latest Receive Address: " + cliWallet.ReceiveAddress().Address().Base58())
+		fmt.Println("Latest Receive Address: " + receiveAddress.Address().Base58())
 	}
 
 	if *newReceiveAddressPtr {
+		newReceiveAddress, err := cliWallet.TryNewReceiveAddress()
+		if err != nil {
+			printUsage(command, err.Error())
+		}
 		fmt.Println()
-		fmt.Println("New Receive Address: " + cliWallet.NewReceiveAddress().Address().Base58())
+		fmt.Println("New Receive Address: " + newReceiveAddress.Address().Base58())
 	}
 
 	if *listPtr {
@@ -54,6 +54,7 @@ func execAssetInfoCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 	_, _ = fmt.Fprintf(w, "%s\t%d\n", "Initial Supply", asset.Supply)
 	_, _ = fmt.Fprintf(w, "%s\t%s\n", "Creating Transaction", asset.TransactionID.Base58())
 	_, _ = fmt.Fprintf(w, "%s\t%s\n", "Network", cliWallet.AssetRegistry().Network())
+	_, _ = fmt.Fprintf(w, "%s\t%s\n", "Registry", cliWallet.AssetRegistry().RegistryURL())
 
 	_ = w.Flush()
 }
@@ -0,0 +1,373 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/batchpayoutoptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/coinselection"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func execBatchPayoutCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	filePtr := command.String("file", "", "CSV or JSON file with the payments (address, amount, color and an optional reference)")
+	reportPtr := command.String("report", "payout-report.json", "CSV or JSON file that the payout report is written to")
+	waitPtr := command.Bool("wait", true, "wait for the confirmation of all transactions of the payout")
+	dryRunPtr := command.Bool("dry-run", false, "only show how the payments are split into transactions")
+	maxRecipientsPtr := command.Int("max-recipients", ledgerstate.MaxOutputCount-1, "maximum number of recipients that are paid by one transaction")
+	coinSelectionPtr := command.String("coin-selection", "", "(optional) strategy that selects the outputs to spend ("+strings.Join(coinselection.Names(), ", ")+"), the coinSelection of the config by default")
+	accessManaPledgeIDPtr := command.String("access-mana-id", "", "node ID to pledge access mana to")
+	consensusManaPledgeIDPtr := command.String("consensus-mana-id", "", "node ID to pledge consensus mana to")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+	if *helpPtr {
+		printUsage(command)
+	}
+	if *filePtr == "" {
+		printUsage(command, "file has to be set")
+	}
+
+	payments, err := readPayments(*filePtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	if len(payments) == 0 {
+		printUsage(command, "the file "+*filePtr+" does not contain any payments")
+	}
+
+	if *dryRunPtr {
+		batches, planErr := wallet.PlanPayout(payments, *maxRecipientsPtr)
+		if planErr != nil {
+			printUsage(command, planErr.Error())
+		}
+		printPayoutPlan(cliWallet, batches)
+		return
+	}
+
+	options := []batchpayoutoptions.BatchPayoutOption{
+		batchpayoutoptions.AccessManaPledgeID(*accessManaPledgeIDPtr),
+		batchpayoutoptions.ConsensusManaPledgeID(*consensusManaPledgeIDPtr),
+		batchpayoutoptions.WaitForConfirmation(*waitPtr),
+		batchpayoutoptions.MaxRecipientsPerTransaction(*maxRecipientsPtr),
+	}
+	if *coinSelectionPtr != "" {
+		strategy, strategyErr := coinselection.FromString(*coinSelectionPtr)
+		if strategyErr != nil {
+			printUsage(command, strategyErr.Error())
+		}
+		options = append(options, batchpayoutoptions.CoinSelection(strategy))
+	}
+
+	fmt.Printf("Paying out %d payments...\n", len(payments))
+	report, payoutErr := cliWallet.BatchPayout(payments, options...)
+	if report != nil {
+		if err = writePayoutReport(*reportPtr, report); err != nil {
+			printUsage(nil, err.Error())
+		}
+		printPayoutReport(cliWallet, report)
+		fmt.Println()
+		fmt.Println("WRITING PAYOUT REPORT (" + *reportPtr + ") ...     [DONE]")
+	}
+	if payoutErr != nil {
+		printUsage(nil, payoutErr.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Paying out... [DONE]")
+}
+
+// region payment files ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// paymentJSON is a payment in a JSON payment file or report.
+type paymentJSON struct {
+	Address   string `json:"address"`
+	Amount    uint64 `json:"amount"`
+	Color     string `json:"color,omitempty"`
+	Reference string `json:"reference,omitempty"`
+}
+
+// readPayments reads the payments from the given CSV or JSON file, depending on its extension.
+func readPayments(filename string) (payments []*wallet.Payment, err error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, errors.Errorf("failed to open %s: %w", filename, err)
+	}
+	defer file.Close()
+
+	var rows []*paymentJSON
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		if err = json.NewDecoder(file).Decode(&rows); err != nil {
+			return nil, errors.Errorf("failed to parse %s: %w", filename, err)
+		}
+	case ".csv":
+		if rows, err = readPaymentsCSV(file); err != nil {
+			return nil, errors.Errorf("failed to parse %s: %w", filename, err)
+		}
+	default:
+		return nil, errors.Errorf("unsupported payment file %s: expected a .csv or .json file", filename)
+	}
+
+	payments = make([]*wallet.Payment, len(rows))
+	for i, row := range rows {
+		if payments[i], err = row.payment(); err != nil {
+			return nil, errors.Errorf("invalid payment %d in %s: %w", i+1, filename, err)
+		}
+	}
+
+	return payments, nil
+}
+
+// readPaymentsCSV reads payments with the columns address, amount, color and reference. The color and the reference
+// are optional and the first row is skipped if it is a header.
+func readPaymentsCSV(reader io.Reader) (rows []*paymentJSON, err error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+	for line := 1; ; line++ {
+		record, readErr := csvReader.Read()
+		if readErr == io.EOF {
+			return rows, nil
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+		if line == 1 && strings.EqualFold(record[0], "address") {
+			continue
+		}
+		if len(record) < 2 || len(record) > 4 {
+			return nil, errors.Errorf("line %d: expected address, amount, color and reference, got %d columns", line, len(record))
+		}
+
+		row := &paymentJSON{Address: record[0]}
+		if row.Amount, err = strconv.ParseUint(record[1], 10, 64); err != nil {
+			return nil, errors.Errorf("line %d: failed to parse amount %s: %w", line, record[1], err)
+		}
+		if len(record) > 2 {
+			row.Color = record[2]
+		}
+		if len(record) > 3 {
+			row.Reference = record[3]
+		}
+		rows = append(rows, row)
+	}
+}
+
+func (p *paymentJSON) payment() (payment *wallet.Payment, err error) {
+	payment = &wallet.Payment{Amount: p.Amount, Reference: p.Reference}
+	if payment.Address, err = ledgerstate.AddressFromBase58EncodedString(p.Address); err != nil {
+		return nil, errors.Errorf("failed to parse address %s: %w", p.Address, err)
+	}
+	if payment.Color, err = parseColor(p.Color); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// parseColor parses a base58 encoded color, where IOTA or an empty string stand for the color of IOTA tokens.
+func parseColor(color string) (ledgerstate.Color, error) {
+	if color == "" || color == "IOTA" {
+		return ledgerstate.ColorIOTA, nil
+	}
+
+	colorBytes, err := base58.Decode(color)
+	if err != nil {
+		return ledgerstate.Color{}, errors.Errorf("failed to parse color %s: %w", color, err)
+	}
+	parsedColor, _, err := ledgerstate.ColorFromBytes(colorBytes)
+	if err != nil {
+		return ledgerstate.Color{}, errors.Errorf("failed to parse color %s: %w", color, err)
+	}
+
+	return parsedColor, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region payout report ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// payoutReportJSON is the JSON version of a wallet.PayoutReport.
+type payoutReportJSON struct {
+	Confirmed    bool                     `json:"confirmed"`
+	Submitted    map[string]uint64        `json:"submitted"`
+	NotSubmitted map[string]uint64        `json:"notSubmitted"`
+	Transactions []*payoutTransactionJSON `json:"transactions"`
+}
+
+// payoutTransactionJSON is the JSON version of a wallet.PayoutTransaction.
+type payoutTransactionJSON struct {
+	TransactionID string         `json:"transactionID,omitempty"`
+	Status        string         `json:"status"`
+	SubmittedTime string         `json:"submittedTime,omitempty"`
+	Error         string         `json:"error,omitempty"`
+	Payments      []*paymentJSON `json:"payments"`
+}
+
+// writePayoutReport writes the report to the given CSV or JSON file, depending on its extension.
+func writePayoutReport(filename string, report *wallet.PayoutReport) (err error) {
+	var reportBytes []byte
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		var builder strings.Builder
+		writer := csv.NewWriter(&builder)
+		_ = writer.Write([]string{"address", "amount", "color", "reference", "transaction_id", "status", "error"})
+		for _, transaction := range report.Transactions {
+			for _, payment := range transaction.Payments {
+				_ = writer.Write([]string{
+					payment.Address.Base58(),
+					strconv.FormatUint(payment.Amount, 10),
+					payment.Color.Base58(),
+					payment.Reference,
+					payoutTransactionID(transaction),
+					payoutStatus(transaction),
+					payoutError(transaction),
+				})
+			}
+		}
+		writer.Flush()
+		if err = writer.Error(); err != nil {
+			return errors.Errorf("failed to write payout report: %w", err)
+		}
+		reportBytes = []byte(builder.String())
+	case ".json":
+		submitted, notSubmitted := report.Totals()
+		reportJSON := &payoutReportJSON{
+			Confirmed:    report.Confirmed(),
+			Submitted:    balancesJSON(submitted),
+			NotSubmitted: balancesJSON(notSubmitted),
+			Transactions: make([]*payoutTransactionJSON, len(report.Transactions)),
+		}
+		for i, transaction := range report.Transactions {
+			transactionJSON := &payoutTransactionJSON{
+				TransactionID: payoutTransactionID(transaction),
+				Status:        payoutStatus(transaction),
+				Error:         payoutError(transaction),
+				Payments:      make([]*paymentJSON, len(transaction.Payments)),
+			}
+			if transaction.Submitted {
+				transactionJSON.SubmittedTime = transaction.SubmittedTime.UTC().Format(time.RFC3339)
+			}
+			for j, payment := range transaction.Payments {
+				transactionJSON.Payments[j] = &paymentJSON{
+					Address:   payment.Address.Base58(),
+					Amount:    payment.Amount,
+					Color:     payment.Color.Base58(),
+					Reference: payment.Reference,
+				}
+			}
+			reportJSON.Transactions[i] = transactionJSON
+		}
+		if reportBytes, err = json.MarshalIndent(reportJSON, "", "  "); err != nil {
+			return errors.Errorf("failed to marshal payout report: %w", err)
+		}
+	default:
+		return errors.Errorf("unsupported report file %s: expected a .csv or .json file", filename)
+	}
+
+	if err = os.WriteFile(filename, reportBytes, 0o600); err != nil {
+		return errors.Errorf("failed to write %s: %w", filename, err)
+	}
+
+	return nil
+}
+
+func printPayoutPlan(cliWallet *wallet.Wallet, batches [][]*wallet.Payment) {
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	fmt.Println()
+	fmt.Printf("Payout Plan (%d transactions)\n", len(batches))
+	fmt.Println()
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", "TRANSACTION", "PAYMENTS", "AMOUNTS")
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", "-----------", "--------", "---------------")
+	for i, batch := range batches {
+		_, _ = fmt.Fprintf(w, "%d\t%d\t%s\n", i+1, len(batch), formatPayoutTotals(cliWallet, batch))
+	}
+	_ = w.Flush()
+}
+
+func printPayoutReport(cliWallet *wallet.Wallet, report *wallet.PayoutReport) {
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	fmt.Println()
+	fmt.Println("Payout Report")
+	fmt.Println()
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "STATUS", "PAYMENTS", "AMOUNTS", "TRANSACTION ID")
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "-------------", "--------", "---------------", "--------------------------------------------")
+	for _, transaction := range report.Transactions {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", payoutStatus(transaction), len(transaction.Payments), formatPayoutTotals(cliWallet, transaction.Payments), payoutTransactionID(transaction))
+	}
+	_ = w.Flush()
+}
+
+func formatPayoutTotals(cliWallet *wallet.Wallet, payments []*wallet.Payment) string {
+	totals := make(map[ledgerstate.Color]uint64)
+	for _, payment := range payments {
+		totals[payment.Color] += payment.Amount
+	}
+
+	amounts := make([]string, 0, len(totals))
+	for color, amount := range totals {
+		amounts = append(amounts, strconv.FormatUint(amount, 10)+" "+cliWallet.AssetRegistry().Symbol(color))
+	}
+
+	return strings.Join(amounts, ", ")
+}
+
+func balancesJSON(balances map[ledgerstate.Color]uint64) map[string]uint64 {
+	result := make(map[string]uint64, len(balances))
+	for color, balance := range balances {
+		result[color.Base58()] = balance
+	}
+
+	return result
+}
+
+func payoutTransactionID(transaction *wallet.PayoutTransaction) string {
+	if transaction.TransactionID == ledgerstate.GenesisTransactionID {
+		return ""
+	}
+
+	return transaction.TransactionID.Base58()
+}
+
+func payoutStatus(transaction *wallet.PayoutTransaction) string {
+	switch {
+	case !transaction.Submitted && transaction.TransactionID != ledgerstate.GenesisTransactionID:
+		// the submission failed, but the node might have accepted the transaction
+		return "unknown"
+	case !transaction.Submitted && transaction.Err != nil:
+		return "failed"
+	case !transaction.Submitted:
+		return "not submitted"
+	default:
+		return strings.ToLower(inclusionStateLabel(transaction.InclusionState))
+	}
+}
+
+func payoutError(transaction *wallet.PayoutTransaction) string {
+	if transaction.Err == nil {
+		return ""
+	}
+
+	return transaction.Err.Error()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func execChangePasswordCommand(command *flag.FlagSet) {
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		printUsage(nil, err.Error())
+	}
+
+	fmt.Println()
+
+	// the wallet was already decrypted with the current passphrase when it was loaded
+	passphrase, err := readNewPassphrase("Enter the new passphrase: ")
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	// the history is encrypted with the passphrase of the wallet as well
+	history, err := readHistoryFile(historyFile)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	walletPassphrase = passphrase
+	reencryptBackup = true
+
+	if _, err = os.Stat(historyFile); err == nil {
+		if err = writeHistoryFile(history, historyFile); err != nil {
+			printUsage(command, err.Error())
+		}
+	}
+
+	fmt.Println("CHANGING PASSPHRASE OF WALLET STATE FILE (wallet.dat) ...  [DONE]")
+}
@@ -9,11 +9,23 @@ import (
 
 // config type that defines the config structure
 type configuration struct {
-	WebAPI               string           `json:"WebAPI,omitempty"`
-	BasicAuth            client.BasicAuth `json:"basic_auth,omitempty"`
-	ReuseAddresses       bool             `json:"reuse_addresses"`
-	FaucetPowDifficulty  int              `json:"faucetPowDifficulty"`
-	AssetRegistryNetwork string           `json:"assetRegistryNetwork"`
+	WebAPI               string            `json:"WebAPI,omitempty"`
+	FallbackWebAPIs      []string          `json:"fallbackWebAPIs,omitempty"`
+	TxStreamAddress      string            `json:"txStreamAddress,omitempty"`
+	BasicAuth            client.BasicAuth  `json:"basic_auth,omitempty"`
+	ReuseAddresses       bool              `json:"reuse_addresses"`
+	FaucetPowDifficulty  int               `json:"faucetPowDifficulty"`
+	AssetRegistryNetwork string            `json:"assetRegistryNetwork"`
+	AssetRegistryURL     string            `json:"assetRegistryURL,omitempty"`
+	CoinSelection        string            `json:"coinSelection,omitempty"`
+	DustConsolidation    dustConsolidation `json:"dustConsolidation,omitempty"`
+}
+
+// dustConsolidation configures the consolidation of dust outputs after the commands in dustConsolidationCommands.
+type dustConsolidation struct {
+	Enabled        bool   `json:"enabled"`
+	DustThreshold  uint64 `json:"dustThreshold"`
+	MaxDustOutputs int    `json:"maxDustOutputs"`
 }
 
 // internal variable that holds the config
@@ -28,7 +40,14 @@ var configJSON = `{
 	},
 	"reuse_addresses": false,
 	"faucetPowDifficulty": 25,
-	"assetRegistryNetwork": "nectar"
+	"assetRegistryNetwork": "nectar",
+	"assetRegistryURL": "http://asset-registry.tokenizedassetsdemo.iota.cafe",
+	"coinSelection": "input-order",
+	"dustConsolidation": {
+	  "enabled": false,
+	  "dustThreshold": 1000,
+	  "maxDustOutputs": 50
+	}
 }`
 
 // load the config file
@@ -6,6 +6,8 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/cockroachdb/errors"
+
 	"github.com/iotaledger/goshimmer/client/wallet"
 )
 
@@ -40,10 +42,15 @@ func execCreateAssetCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		Symbol: *symbolPtr,
 		Supply: *amountPtr,
 	})
-	if err != nil {
+	if err != nil && !errors.Is(err, wallet.ErrAssetNotRegistered) {
 		printUsage(command, err.Error())
 	}
 
 	fmt.Println()
 	fmt.Println("Creating " + strconv.Itoa(int(*amountPtr)) + " tokens with the color '" + assetColor.String() + "' ...   [DONE]")
+	if err != nil {
+		fmt.Println()
+		fmt.Println("Registering the asset in the asset registry... [FAILED]: " + err.Error())
+		fmt.Println("Retry with: register-asset -id " + assetColor.Base58())
+	}
 }
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+)
+
+func execDelegationsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	command.Usage = func() {
+		printUsage(command)
+	}
+
+	helpPtr := command.Bool("help", false, "show this help screen")
+	receiverPtr := command.String("receiver", "", "delegation address to list the delegations of, the delegation address of the node by default")
+	minePtr := command.Bool("mine", false, "only list the delegations governed by this wallet")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	res, err := cliWallet.Delegations(*receiverPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	walletAddresses := make(map[string]bool)
+	for _, addr := range cliWallet.AddressManager().Addresses() {
+		walletAddresses[addr.Base58()] = true
+	}
+
+	// initialize tab writer
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	fmt.Println()
+	fmt.Printf("Delegations to %s\n", res.Receiver)
+	fmt.Println()
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "STATUS", "DELEGATION ID", "AMOUNT", "PENDING MANA", "DELEGATED UNTIL", "DELEGATOR")
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "---------", "--------------------------------------------", "---------------", "---------------", "-------------------", "--------------------------------------------")
+
+	listed := 0
+	for _, d := range res.Delegations {
+		if *minePtr && !walletAddresses[d.Delegator] {
+			continue
+		}
+		delegator := d.Delegator
+		if walletAddresses[d.Delegator] {
+			delegator += " (wallet)"
+		}
+		until := "-"
+		if d.Timelock != 0 {
+			until = time.Unix(d.Timelock, 0).Format("2006-01-02 15:04:05")
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d I\t%f\t%s\t%s\n", d.Status, d.AliasID, d.Amount, d.PendingMana, until, delegator)
+		listed++
+	}
+	if listed == 0 {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "<EMPTY>", "<EMPTY>", "<EMPTY>", "<EMPTY>", "<EMPTY>", "<EMPTY>")
+	}
+	_ = w.Flush()
+
+	fmt.Println()
+	fmt.Printf("Total delegated: %d I - consensus mana contribution: %f - pending access mana: %f\n", res.TotalAmount, res.ConsensusMana, res.PendingMana)
+	fmt.Println()
+}
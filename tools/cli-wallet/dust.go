@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+)
+
+// dustConsolidationCommands are the commands after which the dust outputs of the wallet are consolidated, if the
+// dust consolidation is enabled in the config. The cli-wallet only runs for a single command, so it does not use the
+// background consolidation of the wallet.
+var dustConsolidationCommands = map[string]bool{
+	"send-funds":            true,
+	"batch-payout":          true,
+	"claim-conditional":     true,
+	"request-funds":         true,
+	"withdraw-from-nft":     true,
+	"sweep-nft-owned-funds": true,
+}
+
+// consolidateDust applies the dust consolidation policy of the config after the commands that change the outputs of
+// the wallet. A failed consolidation does not fail the command, as it is retried after the next one.
+func consolidateDust(command string, cliWallet *wallet.Wallet) {
+	if !config.DustConsolidation.Enabled || !dustConsolidationCommands[command] || cliWallet.WatchOnly() {
+		return
+	}
+
+	txs, err := cliWallet.ConsolidateDust()
+	if err != nil {
+		fmt.Println()
+		fmt.Println("Consolidating dust... [FAILED]: " + err.Error())
+		return
+	}
+	if len(txs) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("Consolidated dust outputs via %d transaction(s):\n", len(txs))
+	for _, tx := range txs {
+		fmt.Printf("\n\tTransaction %s\n", tx.ID().Base58())
+	}
+	fmt.Println("Consolidating dust... [DONE]")
+}
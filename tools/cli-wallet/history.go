@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// historyFile is the file that the transaction history of the wallet is stored in, encrypted with the passphrase of
+// the wallet state file.
+const historyFile = "history.dat"
+
+func execHistoryCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	directionPtr := command.String("direction", "", "only show transfers with the given direction (incoming, outgoing or internal)")
+	colorPtr := command.String("color", "", "only show transfers of the given color (IOTA or the base58 encoded color)")
+	addressPtr := command.String("address", "", "only show transfers involving the given address of the wallet or counterparty")
+	statePtr := command.String("state", "", "only show transfers with the given inclusion state (pending, confirmed or rejected)")
+	sincePtr := command.String("since", "", "only show transfers issued at or after the given time (RFC3339 or YYYY-MM-DD)")
+	untilPtr := command.String("until", "", "only show transfers issued before the given time (RFC3339 or YYYY-MM-DD)")
+	refreshPtr := command.Bool("refresh", true, "update the history from the node before showing it")
+	csvPtr := command.String("csv", "", "export the transfers to the given CSV file instead of printing them")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	filters, err := parseHistoryFilters(*directionPtr, *colorPtr, *addressPtr, *statePtr, *sincePtr, *untilPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	if *refreshPtr {
+		fmt.Println("Fetching history...")
+		if err = cliWallet.RefreshHistory(); err != nil {
+			printUsage(nil, err.Error())
+		}
+		if err = writeHistoryFile(cliWallet.History(), historyFile); err != nil {
+			printUsage(nil, err.Error())
+		}
+	}
+
+	entries := cliWallet.History().Entries(filters...)
+	if *csvPtr != "" {
+		if err = writeHistoryCSV(*csvPtr, cliWallet, entries); err != nil {
+			printUsage(nil, err.Error())
+		}
+		fmt.Println()
+		fmt.Println("EXPORTING " + strconv.Itoa(len(entries)) + " TRANSFERS (" + *csvPtr + ") ...     [DONE]")
+		return
+	}
+
+	printHistory(cliWallet, entries)
+}
+
+// parseHistoryFilters translates the flags of the history command into filters of the wallet history.
+func parseHistoryFilters(direction, color, addr, state, since, until string) (filters []wallet.HistoryFilter, err error) {
+	if direction != "" {
+		transferDirection, directionErr := wallet.TransferDirectionFromString(direction)
+		if directionErr != nil {
+			return nil, directionErr
+		}
+		filters = append(filters, wallet.FilterDirection(transferDirection))
+	}
+
+	if color != "" {
+		var filterColor ledgerstate.Color
+		if color == "IOTA" {
+			filterColor = ledgerstate.ColorIOTA
+		} else {
+			colorBytes, decodeErr := base58.Decode(color)
+			if decodeErr != nil {
+				return nil, errors.Errorf("failed to parse color %s: %w", color, decodeErr)
+			}
+			if filterColor, _, err = ledgerstate.ColorFromBytes(colorBytes); err != nil {
+				return nil, errors.Errorf("failed to parse color %s: %w", color, err)
+			}
+		}
+		filters = append(filters, wallet.FilterColor(filterColor))
+	}
+
+	if addr != "" {
+		filterAddress, addressErr := ledgerstate.AddressFromBase58EncodedString(addr)
+		if addressErr != nil {
+			return nil, errors.Errorf("failed to parse address %s: %w", addr, addressErr)
+		}
+		filters = append(filters, wallet.FilterAddress(filterAddress))
+	}
+
+	if state != "" {
+		switch strings.ToLower(state) {
+		case "pending":
+			filters = append(filters, wallet.FilterInclusionState(ledgerstate.Pending))
+		case "confirmed":
+			filters = append(filters, wallet.FilterInclusionState(ledgerstate.Confirmed))
+		case "rejected":
+			filters = append(filters, wallet.FilterInclusionState(ledgerstate.Rejected))
+		default:
+			return nil, errors.Errorf("unknown inclusion state %q", state)
+		}
+	}
+
+	if since != "" {
+		sinceTime, timeErr := parseHistoryTime(since)
+		if timeErr != nil {
+			return nil, timeErr
+		}
+		filters = append(filters, wallet.FilterSince(sinceTime))
+	}
+
+	if until != "" {
+		untilTime, timeErr := parseHistoryTime(until)
+		if timeErr != nil {
+			return nil, timeErr
+		}
+		filters = append(filters, wallet.FilterUntil(untilTime))
+	}
+
+	return filters, nil
+}
+
+// parseHistoryTime parses a time given in RFC3339 format or as a date in the local time zone.
+func parseHistoryTime(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, errors.Errorf("failed to parse time %s: expected RFC3339 or YYYY-MM-DD", value)
+	}
+
+	return parsed, nil
+}
+
+func printHistory(cliWallet *wallet.Wallet, entries []*wallet.HistoryEntry) {
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	fmt.Println()
+	fmt.Println("Transaction History")
+	fmt.Println()
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "TIMESTAMP", "STATUS", "DIRECTION", "AMOUNTS", "COUNTERPARTIES", "TRANSACTION ID")
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "-------------------", "------", "---------", "---------------", "--------------------------------------------", "--------------------------------------------")
+
+	if len(entries) == 0 {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "<EMPTY>", "<EMPTY>", "<EMPTY>", "<EMPTY>", "<EMPTY>", "<EMPTY>")
+	}
+	for _, entry := range entries {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Timestamp.Format("2006-01-02 15:04:05"),
+			inclusionStateLabel(entry.InclusionState),
+			entry.Direction,
+			strings.Join(formatAmounts(cliWallet, entry), ", "),
+			strings.Join(formatCounterparties(entry), ", "),
+			entry.TransactionID.Base58(),
+		)
+	}
+	_ = w.Flush()
+}
+
+// writeHistoryCSV writes one row per transfer and color to the given file.
+func writeHistoryCSV(filename string, cliWallet *wallet.Wallet, entries []*wallet.HistoryEntry) (err error) {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Errorf("failed to create %s: %w", filename, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = errors.Errorf("failed to close %s: %w", filename, closeErr)
+		}
+	}()
+
+	writer := csv.NewWriter(file)
+	_ = writer.Write([]string{"timestamp", "transaction_id", "direction", "inclusion_state", "color", "symbol", "amount", "counterparties", "wallet_addresses"})
+	for _, entry := range entries {
+		walletAddresses := make([]string, len(entry.Addresses))
+		for i, addr := range entry.Addresses {
+			walletAddresses[i] = addr.Base58()
+		}
+		for _, color := range entry.Colors() {
+			_ = writer.Write([]string{
+				entry.Timestamp.UTC().Format(time.RFC3339),
+				entry.TransactionID.Base58(),
+				entry.Direction.String(),
+				strings.ToLower(inclusionStateLabel(entry.InclusionState)),
+				color.Base58(),
+				cliWallet.AssetRegistry().Symbol(color),
+				strconv.FormatUint(entry.Amounts[color], 10),
+				strings.Join(formatCounterparties(entry), " "),
+				strings.Join(walletAddresses, " "),
+			})
+		}
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return errors.Errorf("failed to write %s: %w", filename, err)
+	}
+
+	return nil
+}
+
+func formatAmounts(cliWallet *wallet.Wallet, entry *wallet.HistoryEntry) (amounts []string) {
+	for _, color := range entry.Colors() {
+		amounts = append(amounts, strconv.FormatUint(entry.Amounts[color], 10)+" "+cliWallet.AssetRegistry().Symbol(color))
+	}
+
+	return amounts
+}
+
+func formatCounterparties(entry *wallet.HistoryEntry) (counterparties []string) {
+	for _, counterparty := range entry.Counterparties {
+		counterparties = append(counterparties, counterparty.Base58())
+	}
+
+	return counterparties
+}
+
+func inclusionStateLabel(inclusionState ledgerstate.InclusionState) string {
+	switch inclusionState {
+	case ledgerstate.Confirmed:
+		return "CONFIRMED"
+	case ledgerstate.Rejected:
+		return "REJECTED"
+	default:
+		return "PENDING"
+	}
+}
+
+// readHistoryFile reads the transaction history from the given file, or returns an empty history if the file does not
+// exist yet.
+func readHistoryFile(filename string) (*wallet.History, error) {
+	fileBytes, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return wallet.NewHistory(), nil
+		}
+		return nil, errors.Errorf("failed to read %s: %w", filename, err)
+	}
+
+	historyBytes, err := decryptWalletState(fileBytes, walletPassphrase)
+	if err != nil {
+		return nil, errors.Errorf("failed to decrypt %s: %w", filename, err)
+	}
+
+	return wallet.HistoryFromBytes(historyBytes)
+}
+
+// writeHistoryFile encrypts the transaction history with the passphrase of the wallet and writes it to the given file.
+func writeHistoryFile(history *wallet.History, filename string) error {
+	fileBytes, err := encryptWalletState(history.Bytes(), walletPassphrase)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filename, fileBytes, 0o600); err != nil {
+		return errors.Errorf("failed to write %s: %w", filename, err)
+	}
+
+	return nil
+}
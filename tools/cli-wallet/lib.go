@@ -7,7 +7,6 @@ import (
 	"path/filepath"
 	"unsafe"
 
-	"github.com/capossele/asset-registry/pkg/registryservice"
 	"github.com/iotaledger/hive.go/bitmask"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/marshalutil"
@@ -15,6 +14,9 @@ import (
 
 	"github.com/iotaledger/goshimmer/client"
 	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/assetregistry"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/coinselection"
 	walletseed "github.com/iotaledger/goshimmer/client/wallet/packages/seed"
 )
 
@@ -23,7 +25,7 @@ func printBanner() {
 }
 
 func loadWallet() *wallet.Wallet {
-	seed, lastAddressIndex, spentAddresses, assetRegistry, err := importWalletStateFile("wallet.dat")
+	seed, watchedAddresses, lastAddressIndex, spentAddresses, assetRegistry, err := importWalletStateFile("wallet.dat")
 	if err != nil {
 		panic(err)
 	}
@@ -33,13 +35,16 @@ func loadWallet() *wallet.Wallet {
 	if config.BasicAuth.IsEnabled() {
 		options = append(options, client.WithBasicAuth(config.BasicAuth.Credentials()))
 	}
+	if len(config.FallbackWebAPIs) > 0 {
+		options = append(options, client.WithNodes(config.FallbackWebAPIs...))
+	}
 
 	if assetRegistry != nil {
 		// we do have an asset registry parsed
-		if config.AssetRegistryNetwork != assetRegistry.Network() && registryservice.Networks[config.AssetRegistryNetwork] {
+		if config.AssetRegistryNetwork != assetRegistry.Network() && assetregistry.Networks[config.AssetRegistryNetwork] {
 			assetRegistry = wallet.NewAssetRegistry(config.AssetRegistryNetwork)
 		}
-	} else if registryservice.Networks[config.AssetRegistryNetwork] {
+	} else if assetregistry.Networks[config.AssetRegistryNetwork] {
 		// when asset registry is nil, this is the first time that we load the wallet.
 		// if config.AssetRegistryNetwork is not valid, we leave assetRegistry as nil, and
 		// wallet.New() will initialize it to the default value
@@ -48,7 +53,40 @@ func loadWallet() *wallet.Wallet {
 
 	walletOptions := []wallet.Option{
 		wallet.WebAPI(config.WebAPI, options...),
-		wallet.Import(seed, lastAddressIndex, spentAddresses, assetRegistry),
+	}
+	if watchedAddresses != nil {
+		walletOptions = append(walletOptions, wallet.ImportWatchOnly(watchedAddresses, lastAddressIndex, spentAddresses, assetRegistry))
+	} else {
+		walletOptions = append(walletOptions, wallet.Import(seed, lastAddressIndex, spentAddresses, assetRegistry))
+	}
+	if config.AssetRegistryURL != "" {
+		walletOptions = append(walletOptions, wallet.AssetRegistryURL(config.AssetRegistryURL))
+	}
+	if len(os.Args) >= 2 && os.Args[1] == "history" {
+		history, historyErr := readHistoryFile(historyFile)
+		if historyErr != nil {
+			panic(historyErr)
+		}
+		walletOptions = append(walletOptions, wallet.ImportHistory(history))
+	}
+	if len(os.Args) >= 2 && offlineCommands[os.Args[1]] {
+		walletOptions = append(walletOptions, wallet.Offline(true))
+	} else if config.TxStreamAddress != "" {
+		// wait for the notifications of the node instead of polling it for confirmations
+		walletOptions = append(walletOptions, wallet.TxStream(config.TxStreamAddress))
+	}
+	if config.CoinSelection != "" {
+		strategy, strategyErr := coinselection.FromString(config.CoinSelection)
+		if strategyErr != nil {
+			panic(strategyErr)
+		}
+		walletOptions = append(walletOptions, wallet.CoinSelection(strategy))
+	}
+	if config.DustConsolidation.Enabled {
+		walletOptions = append(walletOptions, wallet.DustConsolidation(wallet.DustConsolidationPolicy{
+			DustThreshold:  config.DustConsolidation.DustThreshold,
+			MaxDustOutputs: config.DustConsolidation.MaxDustOutputs,
+		}))
 	}
 	if config.ReuseAddresses {
 		walletOptions = append(walletOptions, wallet.ReusableAddress(true))
@@ -59,21 +97,35 @@ func loadWallet() *wallet.Wallet {
 	return wallet.New(walletOptions...)
 }
 
-func importWalletStateFile(filename string) (seed *walletseed.Seed, lastAddressIndex uint64, spentAddresses []bitmask.BitMask, assetRegistry *wallet.AssetRegistry, err error) {
+func importWalletStateFile(filename string) (seed *walletseed.Seed, watchedAddresses []address.Address, lastAddressIndex uint64, spentAddresses []bitmask.BitMask, assetRegistry *wallet.AssetRegistry, err error) {
 	walletStateBytes, err := os.ReadFile(filename)
 	if err != nil {
 		if !os.IsNotExist(err) {
 			return
 		}
 
-		if len(os.Args) < 2 || os.Args[1] != "init" {
+		if len(os.Args) < 2 || (os.Args[1] != "init" && os.Args[1] != "init-watch-only") {
 			printUsage(nil, "no wallet file (wallet.dat) found: please call \""+filepath.Base(os.Args[0])+" init\"")
 		}
 
+		if os.Args[1] == "init-watch-only" {
+			if walletStateBytes, err = readWatchOnlyExport(); err != nil {
+				return
+			}
+			if watchedAddresses, lastAddressIndex, spentAddresses, assetRegistry, err = wallet.ParseWatchOnlyState(walletStateBytes); err != nil {
+				return
+			}
+			walletPassphrase, err = readNewPassphrase("Enter a passphrase to encrypt the wallet: ")
+
+			return
+		}
+
 		seed = walletseed.NewSeed()
 		lastAddressIndex = 0
 		spentAddresses = []bitmask.BitMask{}
-		err = nil
+		if walletPassphrase, err = readNewPassphrase("Enter a passphrase to encrypt the wallet: "); err != nil {
+			return
+		}
 
 		fmt.Println("GENERATING NEW WALLET ...                                 [DONE]")
 		fmt.Println()
@@ -88,10 +140,31 @@ func importWalletStateFile(filename string) (seed *walletseed.Seed, lastAddressI
 		return
 	}
 
-	if len(os.Args) >= 2 && os.Args[1] == "init" {
+	if len(os.Args) >= 2 && (os.Args[1] == "init" || os.Args[1] == "init-watch-only") {
 		printUsage(nil, "please remove the wallet.dat before trying to create a new wallet")
 	}
 
+	if isEncryptedWalletState(walletStateBytes) {
+		if walletPassphrase, err = readPassphrase("Enter the passphrase of the wallet: "); err != nil {
+			return
+		}
+		if walletStateBytes, err = decryptWalletState(walletStateBytes, walletPassphrase); err != nil {
+			return
+		}
+	} else {
+		fmt.Println("The wallet state file (" + filename + ") is not encrypted and will be encrypted with a passphrase.")
+		if walletPassphrase, err = readNewPassphrase("Enter a passphrase to encrypt the wallet: "); err != nil {
+			return
+		}
+		reencryptBackup = true
+	}
+	loadedWalletState = walletStateBytes
+
+	if wallet.IsWatchOnlyState(walletStateBytes) {
+		watchedAddresses, lastAddressIndex, spentAddresses, assetRegistry, err = wallet.ParseWatchOnlyState(walletStateBytes)
+		return
+	}
+
 	marshalUtil := marshalutil.New(walletStateBytes)
 
 	seedBytes, err := marshalUtil.ReadBytes(ed25519.SeedSize)
@@ -127,14 +200,30 @@ func writeWalletStateFile(wallet *wallet.Wallet, filename string) {
 		panic("found directory instead of file at " + filename)
 	}
 
-	if !skipRename {
+	walletStateBytes, err := encryptWalletState(wallet.ExportState(), walletPassphrase)
+	if err != nil {
+		panic(err)
+	}
+
+	switch {
+	case skipRename:
+	case reencryptBackup:
+		// the backup must not be readable without the current passphrase
+		backupBytes, backupErr := encryptWalletState(loadedWalletState, walletPassphrase)
+		if backupErr != nil {
+			panic(backupErr)
+		}
+		if err = os.WriteFile(filename+".bkp", backupBytes, 0o600); err != nil {
+			panic(err)
+		}
+	default:
 		err = os.Rename(filename, filename+".bkp")
 		if err != nil && os.IsNotExist(err) {
 			panic(err)
 		}
 	}
 
-	err = os.WriteFile(filename, wallet.ExportState(), 0o644)
+	err = os.WriteFile(filename, walletStateBytes, 0o600)
 	if err != nil {
 		panic(err)
 	}
@@ -154,8 +243,12 @@ func printUsage(command *flag.FlagSet, optionalErrorMessage ...string) {
 		fmt.Println("COMMANDS:")
 		fmt.Println("  balance")
 		fmt.Println("        show the balances held by this wallet")
+		fmt.Println("  history")
+		fmt.Println("        show the incoming, outgoing and internal transfers of this wallet")
 		fmt.Println("  send-funds")
 		fmt.Println("        initiate a value transfer")
+		fmt.Println("  batch-payout")
+		fmt.Println("        pay many recipients from a CSV or JSON file and write a payout report")
 		fmt.Println("  consolidate-funds")
 		fmt.Println("        consolidate available funds under one wallet address")
 		fmt.Println("  claim-conditional")
@@ -166,10 +259,14 @@ func printUsage(command *flag.FlagSet, optionalErrorMessage ...string) {
 		fmt.Println("        create an asset in the form of colored coins")
 		fmt.Println("  asset-info")
 		fmt.Println("        returns information about an asset")
+		fmt.Println("  register-asset")
+		fmt.Println("        register an asset created by this wallet in the asset registry")
 		fmt.Println("  delegate-funds")
 		fmt.Println("        delegate funds to an address")
 		fmt.Println("  reclaim-delegated")
 		fmt.Println("        reclaim previously delegated funds")
+		fmt.Println("  delegations")
+		fmt.Println("        list the delegations to a node with their status and mana contribution")
 		fmt.Println("  create-nft")
 		fmt.Println("        create an nft as an unforkable alias output")
 		fmt.Println("  transfer-nft")
@@ -188,6 +285,18 @@ func printUsage(command *flag.FlagSet, optionalErrorMessage ...string) {
 		fmt.Println("        start the address manager of this wallet")
 		fmt.Println("  init")
 		fmt.Println("        generate a new wallet using a random seed")
+		fmt.Println("  init-watch-only")
+		fmt.Println("        create a watch-only wallet from the addresses exported by export-watch-only")
+		fmt.Println("  export-watch-only")
+		fmt.Println("        export the addresses of this wallet for a watch-only wallet")
+		fmt.Println("  prepare-send-funds")
+		fmt.Println("        prepare an unsigned value transfer, e.g. in a watch-only wallet")
+		fmt.Println("  sign-transaction")
+		fmt.Println("        sign a prepared transaction without connecting to a node")
+		fmt.Println("  submit-transaction")
+		fmt.Println("        submit a signed transaction to the node")
+		fmt.Println("  change-password")
+		fmt.Println("        change the passphrase that the wallet state file is encrypted with")
 		fmt.Println("  server-status")
 		fmt.Println("        display the server status")
 		fmt.Println("  pledge-id")
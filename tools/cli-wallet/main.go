@@ -26,6 +26,7 @@ func main() {
 
 	// load wallet
 	wallet := loadWallet()
+	defer wallet.Close()
 	defer writeWalletStateFile(wallet, "wallet.dat")
 
 	// check if parameters potentially include sub commands
@@ -35,13 +36,17 @@ func main() {
 
 	// define sub commands
 	balanceCommand := flag.NewFlagSet("balance", flag.ExitOnError)
+	historyCommand := flag.NewFlagSet("history", flag.ExitOnError)
 	sendFundsCommand := flag.NewFlagSet("send-funds", flag.ExitOnError)
+	batchPayoutCommand := flag.NewFlagSet("batch-payout", flag.ExitOnError)
 	consolidateFundsCommand := flag.NewFlagSet("consolidate-funds", flag.ExitOnError)
 	claimConditionalFundsCommand := flag.NewFlagSet("claim-conditional", flag.ExitOnError)
 	createAssetCommand := flag.NewFlagSet("create-asset", flag.ExitOnError)
 	assetInfoCommand := flag.NewFlagSet("asset-info", flag.ExitOnError)
+	registerAssetCommand := flag.NewFlagSet("register-asset", flag.ExitOnError)
 	delegateFundsCommand := flag.NewFlagSet("delegate-funds", flag.ExitOnError)
 	reclaimDelegatedFundsCommand := flag.NewFlagSet("reclaim-delegated", flag.ExitOnError)
+	delegationsCommand := flag.NewFlagSet("delegations", flag.ExitOnError)
 	createNFTCommand := flag.NewFlagSet("create-nft", flag.ExitOnError)
 	transferNFTCommand := flag.NewFlagSet("transfer-nft", flag.ExitOnError)
 	destroyNFTCommand := flag.NewFlagSet("destroy-nft", flag.ExitOnError)
@@ -54,15 +59,28 @@ func main() {
 	serverStatusCommand := flag.NewFlagSet("server-status", flag.ExitOnError)
 	allowedPledgeIDCommand := flag.NewFlagSet("pledge-id", flag.ExitOnError)
 	pendingManaCommand := flag.NewFlagSet("pending-mana", flag.ExitOnError)
+	changePasswordCommand := flag.NewFlagSet("change-password", flag.ExitOnError)
+	exportWatchOnlyCommand := flag.NewFlagSet("export-watch-only", flag.ExitOnError)
+	prepareSendFundsCommand := flag.NewFlagSet("prepare-send-funds", flag.ExitOnError)
+	signTransactionCommand := flag.NewFlagSet("sign-transaction", flag.ExitOnError)
+	submitTransactionCommand := flag.NewFlagSet("submit-transaction", flag.ExitOnError)
+
+	if wallet.WatchOnly() && seedCommands[os.Args[1]] {
+		printUsage(nil, "the "+os.Args[1]+" command needs the seed, but the wallet is watch-only: use prepare-send-funds instead")
+	}
 
 	// switch logic according to provided sub command
 	switch os.Args[1] {
 	case "balance":
 		execBalanceCommand(balanceCommand, wallet)
+	case "history":
+		execHistoryCommand(historyCommand, wallet)
 	case "address":
 		execAddressCommand(addressCommand, wallet)
 	case "send-funds":
 		execSendFundsCommand(sendFundsCommand, wallet)
+	case "batch-payout":
+		execBatchPayoutCommand(batchPayoutCommand, wallet)
 	case "consolidate-funds":
 		execConsolidateFundsCommand(consolidateFundsCommand, wallet)
 	case "claim-conditional":
@@ -71,10 +89,14 @@ func main() {
 		execCreateAssetCommand(createAssetCommand, wallet)
 	case "asset-info":
 		execAssetInfoCommand(assetInfoCommand, wallet)
+	case "register-asset":
+		execRegisterAssetCommand(registerAssetCommand, wallet)
 	case "delegate-funds":
 		execDelegateFundsCommand(delegateFundsCommand, wallet)
 	case "reclaim-delegated":
 		execReclaimDelegatedFundsCommand(reclaimDelegatedFundsCommand, wallet)
+	case "delegations":
+		execDelegationsCommand(delegationsCommand, wallet)
 	case "create-nft":
 		execCreateNFTCommand(createNFTCommand, wallet)
 	case "transfer-nft":
@@ -98,6 +120,19 @@ func main() {
 	case "init":
 		fmt.Println()
 		fmt.Println("CREATING WALLET STATE FILE (wallet.dat) ...               [DONE]")
+	case "init-watch-only":
+		fmt.Println()
+		fmt.Println("CREATING WATCH-ONLY WALLET STATE FILE (wallet.dat) ...    [DONE]")
+	case "export-watch-only":
+		execExportWatchOnlyCommand(exportWatchOnlyCommand, wallet)
+	case "prepare-send-funds":
+		execPrepareSendFundsCommand(prepareSendFundsCommand, wallet)
+	case "sign-transaction":
+		execSignTransactionCommand(signTransactionCommand, wallet)
+	case "submit-transaction":
+		execSubmitTransactionCommand(submitTransactionCommand, wallet)
+	case "change-password":
+		execChangePasswordCommand(changePasswordCommand)
 	case "server-status":
 		execServerStatusCommand(serverStatusCommand, wallet)
 	case "help":
@@ -105,4 +140,6 @@ func main() {
 	default:
 		printUsage(nil, "unknown [COMMAND]: "+os.Args[1])
 	}
+
+	consolidateDust(os.Args[1], wallet)
 }
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func execPrepareSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	outPtr := command.String("out", "unsigned.tx", "file that the unsigned transaction is written to")
+	options := parseSendFundsOptions(command)
+
+	fmt.Println("Preparing transaction...")
+	unsignedTx, err := cliWallet.PrepareSendFunds(options...)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	if err = writeBase58File(*outPtr, unsignedTx.Bytes()); err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Preparing transaction ... [DONE]")
+	fmt.Println("Unsigned transaction written to " + *outPtr + ", sign it with the sign-transaction command.")
+}
+
+func execSignTransactionCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	inPtr := command.String("in", "unsigned.tx", "file that the unsigned transaction is read from")
+	outPtr := command.String("out", "signed.tx", "file that the signed transaction is written to")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	unsignedTxBytes, err := readBase58File(*inPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	unsignedTx, err := wallet.UnsignedTransactionFromBytes(unsignedTxBytes)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Signing transaction:")
+	printUnsignedTransaction(unsignedTx, cliWallet)
+
+	tx, err := cliWallet.SignTransaction(unsignedTx)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	if err = writeBase58File(*outPtr, tx.Bytes()); err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Signing transaction ... [DONE]")
+	fmt.Println("Signed transaction " + tx.ID().Base58() + " written to " + *outPtr + ", submit it with the submit-transaction command.")
+}
+
+func execSubmitTransactionCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	inPtr := command.String("in", "signed.tx", "file that the signed transaction is read from")
+	waitPtr := command.Bool("wait", false, "wait until the transaction is confirmed")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	txBytes, err := readBase58File(*inPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	tx, _, err := ledgerstate.TransactionFromBytes(txBytes)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println("Submitting transaction " + tx.ID().Base58() + "...")
+	if err = cliWallet.SubmitTransaction(tx, *waitPtr); err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Submitting transaction ... [DONE]")
+}
+
+// printUnsignedTransaction prints the consumed and created outputs of the transaction, so that they can be checked
+// before it is signed. The created outputs are marked with the index of the wallet address that receives them, so that
+// a remainder that does not return to the seed of the wallet stands out, and the funds that leave the wallet are
+// summed up.
+func printUnsignedTransaction(unsignedTx *wallet.UnsignedTransaction, cliWallet *wallet.Wallet) {
+	walletAddresses := make(map[[ledgerstate.AddressLength]byte]uint64)
+	for _, addr := range cliWallet.AddressManager().Addresses() {
+		walletAddresses[addr.AddressBytes] = addr.Index
+	}
+
+	fmt.Println("  Consumed outputs:")
+	for _, consumedOutput := range unsignedTx.ConsumedOutputs {
+		fmt.Printf("    %s (address %s, index %d): %s\n", consumedOutput.Output.ID().Base58(), consumedOutput.Address.Base58(),
+			consumedOutput.Address.Index, consumedOutput.Output.Balances())
+	}
+	fmt.Println("  Created outputs:")
+	leavingFunds := make(map[ledgerstate.Color]uint64)
+	for _, output := range unsignedTx.Essence.Outputs() {
+		if index, ownAddress := walletAddresses[output.Address().Array()]; ownAddress {
+			fmt.Printf("    %s (wallet address, index %d): %s\n", output.Address().Base58(), index, output.Balances())
+			continue
+		}
+
+		fmt.Printf("    %s (external address): %s\n", output.Address().Base58(), output.Balances())
+		output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+			leavingFunds[color] += balance
+			return true
+		})
+	}
+	fmt.Printf("  Funds leaving the wallet: %s\n", ledgerstate.NewColoredBalances(leavingFunds))
+}
+
+// writeBase58File writes the base58 encoded bytes to the given file.
+func writeBase58File(filename string, bytes []byte) error {
+	return os.WriteFile(filename, []byte(base58.Encode(bytes)+"\n"), 0o644)
+}
+
+// readBase58File reads the base58 encoded bytes from the given file.
+func readBase58File(filename string) ([]byte, error) {
+	fileBytes, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return base58.Decode(strings.TrimSpace(string(fileBytes)))
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func execRegisterAssetCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	command.Usage = func() {
+		printUsage(command)
+	}
+
+	helpPtr := command.Bool("help", false, "show this help screen")
+	assetID := command.String("id", "", "the assetID (color) of an asset created by this wallet")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if *assetID == "" {
+		printUsage(command, "you need to provide an assetID (color)")
+	}
+
+	color, err := ledgerstate.ColorFromBase58EncodedString(*assetID)
+	if err != nil {
+		printUsage(command, fmt.Sprintf("wrong assetID (color) provided: %s", err.Error()))
+	}
+
+	fmt.Println("Registering asset...")
+	if err = cliWallet.RegisterAsset(color); err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Registering asset " + color.Base58() + " in " + cliWallet.AssetRegistry().RegistryURL() + " ...   [DONE]")
+}
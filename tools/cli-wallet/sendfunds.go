@@ -4,17 +4,33 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/mr-tron/base58"
 
 	"github.com/iotaledger/goshimmer/client/wallet"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/coinselection"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
 
 func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	options := parseSendFundsOptions(command)
+
+	fmt.Println("Sending funds...")
+	_, err := cliWallet.SendFunds(options...)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Sending funds ... [DONE]")
+}
+
+// parseSendFundsOptions defines the flags of the send-funds command, parses them and returns the corresponding options.
+func parseSendFundsOptions(command *flag.FlagSet) []sendoptions.SendFundsOption {
 	helpPtr := command.Bool("help", false, "show this help screen")
 	addressPtr := command.String("dest-addr", "", "destination address for the transfer")
 	amountPtr := command.Int64("amount", 0, "the amount of tokens that are supposed to be sent")
@@ -24,6 +40,7 @@ func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 	fallbackDeadlinePtr := command.Int64("fallb-deadline", 0, "(optional) unix timestamp after which only the fallback address can claim the funds back")
 	accessManaPledgeIDPtr := command.String("access-mana-id", "", "node ID to pledge access mana to")
 	consensusManaPledgeIDPtr := command.String("consensus-mana-id", "", "node ID to pledge consensus mana to")
+	coinSelectionPtr := command.String("coin-selection", "", "(optional) strategy that selects the outputs to spend ("+strings.Join(coinselection.Names(), ", ")+"), the coinSelection of the config by default")
 
 	err := command.Parse(os.Args[2:])
 	if err != nil {
@@ -47,7 +64,6 @@ func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 	destinationAddress, err := ledgerstate.AddressFromBase58EncodedString(*addressPtr)
 	if err != nil {
 		printUsage(command, err.Error())
-		return
 	}
 
 	var color ledgerstate.Color
@@ -76,6 +92,14 @@ func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		sendoptions.ConsensusManaPledgeID(*consensusManaPledgeIDPtr),
 	}
 
+	if *coinSelectionPtr != "" {
+		strategy, strategyErr := coinselection.FromString(*coinSelectionPtr)
+		if strategyErr != nil {
+			printUsage(command, strategyErr.Error())
+		}
+		options = append(options, sendoptions.CoinSelection(strategy))
+	}
+
 	nowis := time.Now()
 	if *timelockPtr > 0 {
 		timelock := time.Unix(*timelockPtr, 0)
@@ -99,12 +123,6 @@ func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		}
 		options = append(options, sendoptions.Fallback(fAddy, fDeadline))
 	}
-	fmt.Println("Sending funds...")
-	_, err = cliWallet.SendFunds(options...)
-	if err != nil {
-		printUsage(command, err.Error())
-	}
 
-	fmt.Println()
-	fmt.Println("Sending funds ... [DONE]")
+	return options
 }
@@ -25,4 +25,13 @@ func execServerStatusCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 	fmt.Println("Server Synced: ", status.Synced)
 	fmt.Println("Server Version: ", status.Version)
 	fmt.Println("Delegation Address: ", status.DelegationAddress)
+
+	// the health of the nodes is only tracked if the wallet uses fallback nodes
+	if nodes := cliWallet.Nodes(); len(nodes) > 1 {
+		fmt.Println()
+		fmt.Println("Nodes:")
+		for _, node := range nodes {
+			fmt.Printf("  %s  healthy: %t, synced: %t\n", node.BaseURL, node.Healthy, node.Synced)
+		}
+	}
 }
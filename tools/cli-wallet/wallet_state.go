@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/term"
+)
+
+const (
+	// walletStateVersion is the version of the format of the encrypted wallet state file.
+	walletStateVersion byte = 1
+
+	// passphraseEnvVar is the environment variable that the passphrase is read from instead of prompting for it, so
+	// that the wallet can be used in scripts.
+	passphraseEnvVar = "CLI_WALLET_PASSPHRASE"
+
+	saltSize = 16
+	keySize  = chacha20poly1305.KeySize
+
+	// maxKDFTime and maxKDFMemory bound the argon2 parameters read from a state file, so that a corrupted header
+	// cannot make the wallet hang or run out of memory.
+	maxKDFTime   = 64
+	maxKDFMemory = 4 * 1024 * 1024
+)
+
+// walletStateMagic marks an encrypted wallet state file. Files without it are plaintext files written by older
+// versions of the wallet.
+var walletStateMagic = []byte("GOSHIMMER-WALLET")
+
+// defaultKDFParameters are the argon2id parameters that are used to derive the key of newly encrypted state files.
+var defaultKDFParameters = kdfParameters{
+	Time:    3,
+	Memory:  64 * 1024,
+	Threads: 4,
+}
+
+var (
+	// walletPassphrase is the passphrase that the state file is encrypted with when the wallet is written.
+	walletPassphrase []byte
+
+	// loadedWalletState is the decrypted state that the wallet was loaded from.
+	loadedWalletState []byte
+
+	// reencryptBackup is true if the backup of the loaded state needs to be encrypted with the current passphrase, as
+	// the loaded state file was a plaintext file or the passphrase was changed.
+	reencryptBackup bool
+
+	// stdin buffers the passphrases that are read from stdin if it is not a terminal.
+	stdin = bufio.NewReader(os.Stdin)
+)
+
+// region kdfParameters ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// kdfParameters are the argon2id parameters that the key of a state file was derived with.
+type kdfParameters struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+	Salt    [saltSize]byte
+}
+
+func (k kdfParameters) key(passphrase []byte) []byte {
+	return argon2.IDKey(passphrase, k.Salt[:], k.Time, k.Memory, k.Threads, keySize)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region encryption ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// isEncryptedWalletState returns true if the given content of a state file is encrypted.
+func isEncryptedWalletState(fileBytes []byte) bool {
+	return bytes.HasPrefix(fileBytes, walletStateMagic)
+}
+
+// encryptWalletState encrypts the exported state of a wallet with a key derived from the given passphrase. The file
+// starts with a header containing the format version and the key derivation parameters, which is authenticated
+// together with the encrypted state.
+func encryptWalletState(state, passphrase []byte) (fileBytes []byte, err error) {
+	parameters := defaultKDFParameters
+	if _, err = rand.Read(parameters.Salt[:]); err != nil {
+		return nil, errors.Errorf("failed to generate salt: %w", err)
+	}
+	aead, err := chacha20poly1305.NewX(parameters.key(passphrase))
+	if err != nil {
+		return nil, errors.Errorf("failed to create cipher: %w", err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, errors.Errorf("failed to generate nonce: %w", err)
+	}
+
+	header := marshalutil.New().
+		WriteBytes(walletStateMagic).
+		WriteByte(walletStateVersion).
+		WriteUint32(parameters.Time).
+		WriteUint32(parameters.Memory).
+		WriteUint8(parameters.Threads).
+		WriteBytes(parameters.Salt[:]).
+		WriteBytes(nonce).
+		Bytes()
+
+	return aead.Seal(header, nonce, state, header), nil
+}
+
+// decryptWalletState decrypts the content of an encrypted state file with the given passphrase.
+func decryptWalletState(fileBytes, passphrase []byte) (state []byte, err error) {
+	marshalUtil := marshalutil.New(fileBytes)
+	if _, err = marshalUtil.ReadBytes(len(walletStateMagic)); err != nil {
+		return nil, errors.Errorf("failed to parse magic: %w", err)
+	}
+	version, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse version: %w", err)
+	}
+	if version != walletStateVersion {
+		return nil, errors.Errorf("unsupported wallet state version %d, please update the wallet", version)
+	}
+
+	var parameters kdfParameters
+	if parameters.Time, err = marshalUtil.ReadUint32(); err != nil {
+		return nil, errors.Errorf("failed to parse argon2 time: %w", err)
+	}
+	if parameters.Memory, err = marshalUtil.ReadUint32(); err != nil {
+		return nil, errors.Errorf("failed to parse argon2 memory: %w", err)
+	}
+	if parameters.Threads, err = marshalUtil.ReadUint8(); err != nil {
+		return nil, errors.Errorf("failed to parse argon2 threads: %w", err)
+	}
+	salt, err := marshalUtil.ReadBytes(saltSize)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse salt: %w", err)
+	}
+	copy(parameters.Salt[:], salt)
+	if parameters.Time == 0 || parameters.Time > maxKDFTime || parameters.Memory > maxKDFMemory || parameters.Threads == 0 {
+		return nil, errors.Errorf("invalid argon2 parameters (time %d, memory %d KiB, threads %d)", parameters.Time, parameters.Memory, parameters.Threads)
+	}
+
+	aead, err := chacha20poly1305.NewX(parameters.key(passphrase))
+	if err != nil {
+		return nil, errors.Errorf("failed to create cipher: %w", err)
+	}
+	nonce, err := marshalUtil.ReadBytes(aead.NonceSize())
+	if err != nil {
+		return nil, errors.Errorf("failed to parse nonce: %w", err)
+	}
+
+	header := fileBytes[:marshalUtil.ReadOffset()]
+	if state, err = aead.Open(nil, nonce, marshalUtil.ReadRemainingBytes(), header); err != nil {
+		return nil, errors.New("wrong passphrase or corrupted wallet state file")
+	}
+
+	return state, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region passphrase ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// readPassphrase returns the passphrase from the environment or prompts for it.
+func readPassphrase(prompt string) ([]byte, error) {
+	if passphrase, exists := os.LookupEnv(passphraseEnvVar); exists {
+		return []byte(passphrase), nil
+	}
+
+	return promptPassphrase(prompt)
+}
+
+// readNewPassphrase returns the new passphrase from the environment or prompts for it twice.
+func readNewPassphrase(prompt string) ([]byte, error) {
+	if passphrase, exists := os.LookupEnv(passphraseEnvVar); exists {
+		if passphrase == "" {
+			return nil, errors.Errorf("the passphrase in %s must not be empty", passphraseEnvVar)
+		}
+		return []byte(passphrase), nil
+	}
+
+	passphrase, err := promptPassphrase(prompt)
+	if err != nil {
+		return nil, err
+	}
+	if len(passphrase) == 0 {
+		return nil, errors.New("the passphrase must not be empty")
+	}
+	confirmation, err := promptPassphrase("Repeat the passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(passphrase, confirmation) {
+		return nil, errors.New("the passphrases do not match")
+	}
+
+	return passphrase, nil
+}
+
+// promptPassphrase reads a passphrase from the terminal without echoing it, or a line from stdin if it is not a
+// terminal.
+func promptPassphrase(prompt string) ([]byte, error) {
+	fmt.Print(prompt)
+	defer fmt.Println()
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		passphrase, err := term.ReadPassword(fd)
+		if err != nil {
+			return nil, errors.Errorf("failed to read passphrase: %w", err)
+		}
+		return passphrase, nil
+	}
+
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		return nil, errors.Errorf("failed to read passphrase: %w", err)
+	}
+
+	return []byte(strings.TrimRight(line, "\r\n")), nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	walletseed "github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+)
+
+// kdfHeaderOffset is the offset of the argon2 parameters in the header of an encrypted state file.
+var kdfHeaderOffset = len(walletStateMagic) + 1
+
+func TestWalletState_RoundTrip(t *testing.T) {
+	state := []byte("exported wallet state")
+	fileBytes, err := encryptWalletState(state, []byte("passphrase"))
+	require.NoError(t, err)
+	assert.True(t, isEncryptedWalletState(fileBytes))
+	assert.NotContains(t, string(fileBytes), string(state))
+
+	decrypted, err := decryptWalletState(fileBytes, []byte("passphrase"))
+	require.NoError(t, err)
+	assert.Equal(t, state, decrypted)
+
+	// every encryption uses a new salt and nonce
+	otherFileBytes, err := encryptWalletState(state, []byte("passphrase"))
+	require.NoError(t, err)
+	assert.NotEqual(t, fileBytes, otherFileBytes)
+
+	emptyFileBytes, err := encryptWalletState(nil, []byte("passphrase"))
+	require.NoError(t, err)
+	decrypted, err = decryptWalletState(emptyFileBytes, []byte("passphrase"))
+	require.NoError(t, err)
+	assert.Empty(t, decrypted)
+}
+
+func TestWalletState_Invalid(t *testing.T) {
+	fileBytes, err := encryptWalletState([]byte("exported wallet state"), []byte("passphrase"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		modify     func(fileBytes []byte) []byte
+		passphrase string
+		err        string
+	}{
+		{
+			name:       "wrong passphrase",
+			passphrase: "wrong passphrase",
+			err:        "wrong passphrase or corrupted",
+		},
+		{
+			name:   "tampered salt",
+			modify: func(fileBytes []byte) []byte { fileBytes[kdfHeaderOffset+9]++; return fileBytes },
+			err:    "wrong passphrase or corrupted",
+		},
+		{
+			name:   "tampered ciphertext",
+			modify: func(fileBytes []byte) []byte { fileBytes[len(fileBytes)-1]++; return fileBytes },
+			err:    "wrong passphrase or corrupted",
+		},
+		{
+			name:   "truncated ciphertext",
+			modify: func(fileBytes []byte) []byte { return fileBytes[:len(fileBytes)-1] },
+			err:    "wrong passphrase or corrupted",
+		},
+		{
+			name:   "truncated header",
+			modify: func(fileBytes []byte) []byte { return fileBytes[:kdfHeaderOffset+4] },
+			err:    "failed to parse",
+		},
+		{
+			name:   "unsupported version",
+			modify: func(fileBytes []byte) []byte { fileBytes[kdfHeaderOffset-1] = walletStateVersion + 1; return fileBytes },
+			err:    "unsupported wallet state version",
+		},
+		{
+			name:   "argon2 time 0",
+			modify: func(fileBytes []byte) []byte { return withKDFParameters(fileBytes, 0, 64*1024, 4) },
+			err:    "invalid argon2 parameters",
+		},
+		{
+			name:   "argon2 time above the limit",
+			modify: func(fileBytes []byte) []byte { return withKDFParameters(fileBytes, maxKDFTime+1, 64*1024, 4) },
+			err:    "invalid argon2 parameters",
+		},
+		{
+			name:   "argon2 memory above the limit",
+			modify: func(fileBytes []byte) []byte { return withKDFParameters(fileBytes, 3, maxKDFMemory+1, 4) },
+			err:    "invalid argon2 parameters",
+		},
+		{
+			name:   "argon2 threads 0",
+			modify: func(fileBytes []byte) []byte { return withKDFParameters(fileBytes, 3, 64*1024, 0) },
+			err:    "invalid argon2 parameters",
+		},
+		{
+			// the parameters are in range, but authenticated with the state
+			name:   "tampered argon2 parameters",
+			modify: func(fileBytes []byte) []byte { return withKDFParameters(fileBytes, 1, 64*1024, 4) },
+			err:    "wrong passphrase or corrupted",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			modified := append([]byte(nil), fileBytes...)
+			if testCase.modify != nil {
+				modified = testCase.modify(modified)
+			}
+			passphrase := testCase.passphrase
+			if passphrase == "" {
+				passphrase = "passphrase"
+			}
+
+			_, err := decryptWalletState(modified, []byte(passphrase))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.err)
+		})
+	}
+}
+
+func TestIsEncryptedWalletState(t *testing.T) {
+	plaintext := wallet.New(wallet.Import(walletseed.NewSeed(), 0, nil, wallet.NewAssetRegistry("test")), wallet.Offline(true)).ExportState()
+	assert.False(t, isEncryptedWalletState(plaintext))
+	assert.False(t, isEncryptedWalletState(nil))
+	assert.False(t, isEncryptedWalletState(walletStateMagic[:len(walletStateMagic)-1]))
+	assert.True(t, isEncryptedWalletState(walletStateMagic))
+}
+
+func TestWalletStateFile_Migration(t *testing.T) {
+	defer resetWalletStateGlobals()
+	resetWalletStateGlobals()
+	require.NoError(t, os.Setenv(passphraseEnvVar, "passphrase"))
+	defer func() { require.NoError(t, os.Unsetenv(passphraseEnvVar)) }()
+
+	seed := walletseed.NewSeed()
+	plaintextWallet := wallet.New(wallet.Import(seed, 3, nil, wallet.NewAssetRegistry("test")), wallet.Offline(true))
+	filename := filepath.Join(t.TempDir(), "wallet.dat")
+	require.NoError(t, os.WriteFile(filename, plaintextWallet.ExportState(), 0o600))
+
+	// a plaintext file is loaded and encrypted with a new passphrase
+	importedSeed, _, lastAddressIndex, _, _, err := importWalletStateFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, seed.Bytes(), importedSeed.Bytes())
+	assert.Equal(t, uint64(3), lastAddressIndex)
+	assert.True(t, reencryptBackup)
+
+	writeWalletStateFile(plaintextWallet, filename)
+	for _, file := range []string{filename, filename + ".bkp"} {
+		fileBytes, readErr := os.ReadFile(file)
+		require.NoError(t, readErr)
+		// the backup of the plaintext file must not stay readable without the passphrase
+		require.True(t, isEncryptedWalletState(fileBytes), file)
+		state, decryptErr := decryptWalletState(fileBytes, []byte("passphrase"))
+		require.NoError(t, decryptErr)
+		assert.Equal(t, plaintextWallet.ExportState(), state)
+	}
+
+	// the encrypted file is loaded with the passphrase
+	resetWalletStateGlobals()
+	importedSeed, _, _, _, _, err = importWalletStateFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, seed.Bytes(), importedSeed.Bytes())
+	assert.False(t, reencryptBackup)
+
+	require.NoError(t, os.Setenv(passphraseEnvVar, "wrong passphrase"))
+	_, _, _, _, _, err = importWalletStateFile(filename)
+	assert.Error(t, err)
+}
+
+// withKDFParameters replaces the argon2 parameters in the header of the given state file.
+func withKDFParameters(fileBytes []byte, time, memory uint32, threads uint8) []byte {
+	parameters := marshalutil.New().WriteUint32(time).WriteUint32(memory).WriteUint8(threads).Bytes()
+	copy(fileBytes[kdfHeaderOffset:], parameters)
+
+	return fileBytes
+}
+
+func resetWalletStateGlobals() {
+	walletPassphrase, loadedWalletState, reencryptBackup = nil, nil, false
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+)
+
+// seedCommands are the commands that need the seed and can not be executed by a watch-only wallet.
+var seedCommands = map[string]bool{
+	"send-funds":            true,
+	"batch-payout":          true,
+	"consolidate-funds":     true,
+	"claim-conditional":     true,
+	"create-asset":          true,
+	"register-asset":        true,
+	"delegate-funds":        true,
+	"reclaim-delegated":     true,
+	"create-nft":            true,
+	"transfer-nft":          true,
+	"destroy-nft":           true,
+	"deposit-to-nft":        true,
+	"withdraw-from-nft":     true,
+	"sweep-nft-owned-funds": true,
+	"sweep-nft-owned-nfts":  true,
+	"sign-transaction":      true,
+}
+
+// offlineCommands are the commands that do not need a connection to a node.
+var offlineCommands = map[string]bool{
+	"sign-transaction":  true,
+	"export-watch-only": true,
+	"change-password":   true,
+}
+
+func execExportWatchOnlyCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	outPtr := command.String("out", "watch-only.dat", "file that the watch-only wallet state is written to")
+	additionalAddressesPtr := command.Uint64("additional-addresses", 100, "number of unused addresses that the watch-only wallet can use for remainders")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if err = writeBase58File(*outPtr, cliWallet.ExportWatchOnlyState(*additionalAddressesPtr)); err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("EXPORTING WATCH-ONLY WALLET STATE (" + *outPtr + ") ...     [DONE]")
+}
+
+// readWatchOnlyExport reads the watch-only wallet state that is given to the init-watch-only command.
+func readWatchOnlyExport() (stateBytes []byte, err error) {
+	command := flag.NewFlagSet("init-watch-only", flag.ExitOnError)
+	helpPtr := command.Bool("help", false, "show this help screen")
+	filePtr := command.String("file", "watch-only.dat", "file that was written by the export-watch-only command of the signing wallet")
+
+	if err = command.Parse(os.Args[2:]); err != nil {
+		return nil, err
+	}
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	return readBase58File(*filePtr)
+}
@@ -0,0 +1,319 @@
+// Package main implements a tool that simulates FPC in a network of honest and adversary nodes and sweeps the FPC
+// parameters, so that their influence on the agreement and termination of the vote can be studied.
+package main
+
+import (
+	"encoding/csv"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+var (
+	runs           = flag.Int("runs", 20, "the number of simulations per configuration")
+	seed           = flag.Int64("seed", 1, "the seed of the first simulation, the following simulations use the next seeds")
+	zipfExponent   = flag.Float64("zipf", 0.9, "the exponent of the zipf mana distribution")
+	maxQuerySize   = flag.Int("max-k", fpc.DefaultParameters().MaxQuerySampleSize, "the maximum number of samples drawn to find k distinct opinion givers")
+	minOpinions    = flag.Int("min-opinions", fpc.DefaultParameters().MinOpinionsReceived, "the minimum number of opinions to receive for a valid round")
+	maxRounds      = flag.Int("max-rounds", fpc.DefaultParameters().MaxRoundsPerVoteContext, "the number of rounds after which a vote fails")
+	nodes          = flag.IntSlice("nodes", []int{1000}, "the number of nodes")
+	manaModels     = flag.StringSlice("mana", []string{"uniform"}, "the mana distribution of the nodes (uniform, zipf)")
+	adversaries    = flag.StringSlice("adversary", []string{"cautious"}, "the strategy of the adversary nodes (berserk, cautious, random)")
+	adversaryShare = flag.Float64Slice("adversary-share", []float64{0, 0.1, 0.2}, "the share of the mana held by the adversary nodes")
+	initialLike    = flag.Float64Slice("initial-like", []float64{0.5}, "the share of the honest nodes that initially like the conflict")
+	querySize      = flag.IntSlice("k", []int{fpc.DefaultParameters().QuerySampleSize}, "the number of opinion givers queried per round (k)")
+	finalization   = flag.IntSlice("l", []int{fpc.DefaultParameters().TotalRoundsFinalization}, "the number of rounds an opinion needs to stay the same to become final (l)")
+	fixedRounds    = flag.IntSlice("fixed-rounds", []int{fpc.DefaultParameters().TotalRoundsFixedThreshold}, "the number of last rounds with the fixed threshold")
+	coolingOff     = flag.IntSlice("m", []int{fpc.DefaultParameters().TotalRoundsCoolingOffPeriod}, "the number of rounds without finalization checks (m)")
+	firstLower     = flag.Float64Slice("first-lower", []float64{fpc.DefaultParameters().FirstRoundLowerBoundThreshold}, "the lower bound of the threshold in the first round (a)")
+	firstUpper     = flag.Float64Slice("first-upper", []float64{fpc.DefaultParameters().FirstRoundUpperBoundThreshold}, "the upper bound of the threshold in the first round (b)")
+	lower          = flag.Float64Slice("lower", []float64{fpc.DefaultParameters().SubsequentRoundsLowerBoundThreshold}, "the lower bound of the threshold in the subsequent rounds")
+	upper          = flag.Float64Slice("upper", []float64{fpc.DefaultParameters().SubsequentRoundsUpperBoundThreshold}, "the upper bound of the threshold in the subsequent rounds")
+	fixed          = flag.Float64Slice("fixed", []float64{fpc.DefaultParameters().EndingRoundsFixedThreshold}, "the threshold of the last rounds")
+)
+
+func main() {
+	flag.Parse()
+
+	for _, name := range *adversaries {
+		if _, err := strategyByName(name); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	dimensions := sweepDimensions()
+	header := make([]string, 0, len(dimensions)+len(statisticsHeader))
+	for _, d := range dimensions {
+		header = append(header, d.name)
+	}
+	if err := w.Write(append(header, statisticsHeader...)); err != nil {
+		log.Fatal(err)
+	}
+
+	err := sweep(dimensions, func(c *configuration) error {
+		log.Printf("simulating %s...", c)
+		stats, err := simulate(c)
+		if err != nil {
+			return err
+		}
+
+		record := make([]string, 0, len(header))
+		for _, d := range dimensions {
+			record = append(record, d.value(c))
+		}
+		if err = w.Write(append(record, stats.record()...)); err != nil {
+			return err
+		}
+		w.Flush()
+
+		return w.Error()
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// region configuration ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// configuration is a single point of the parameter sweep.
+type configuration struct {
+	nodes          int
+	mana           string
+	adversary      string
+	adversaryShare float64
+	initialLike    float64
+	paras          fpc.Parameters
+}
+
+func (c *configuration) String() string {
+	return strconv.Itoa(c.nodes) + " nodes, " + c.mana + " mana, " + formatFloat(c.adversaryShare) + " " + c.adversary +
+		" adversary, k=" + strconv.Itoa(c.paras.QuerySampleSize) + ", l=" + strconv.Itoa(c.paras.TotalRoundsFinalization)
+}
+
+// dimension is a swept parameter with the list of its values.
+type dimension struct {
+	name  string
+	size  int
+	set   func(c *configuration, i int)
+	value func(c *configuration) string
+}
+
+func intDimension(name string, values []int, field func(c *configuration) *int) dimension {
+	return dimension{
+		name:  name,
+		size:  len(values),
+		set:   func(c *configuration, i int) { *field(c) = values[i] },
+		value: func(c *configuration) string { return strconv.Itoa(*field(c)) },
+	}
+}
+
+func floatDimension(name string, values []float64, field func(c *configuration) *float64) dimension {
+	return dimension{
+		name:  name,
+		size:  len(values),
+		set:   func(c *configuration, i int) { *field(c) = values[i] },
+		value: func(c *configuration) string { return formatFloat(*field(c)) },
+	}
+}
+
+func stringDimension(name string, values []string, field func(c *configuration) *string) dimension {
+	return dimension{
+		name:  name,
+		size:  len(values),
+		set:   func(c *configuration, i int) { *field(c) = values[i] },
+		value: func(c *configuration) string { return *field(c) },
+	}
+}
+
+// sweepDimensions returns the dimensions of the parameter sweep configured by the flags.
+func sweepDimensions() []dimension {
+	return []dimension{
+		intDimension("nodes", *nodes, func(c *configuration) *int { return &c.nodes }),
+		stringDimension("mana", *manaModels, func(c *configuration) *string { return &c.mana }),
+		stringDimension("adversary", *adversaries, func(c *configuration) *string { return &c.adversary }),
+		floatDimension("adversary_share", *adversaryShare, func(c *configuration) *float64 { return &c.adversaryShare }),
+		floatDimension("initial_like", *initialLike, func(c *configuration) *float64 { return &c.initialLike }),
+		intDimension("k", *querySize, func(c *configuration) *int { return &c.paras.QuerySampleSize }),
+		intDimension("l", *finalization, func(c *configuration) *int { return &c.paras.TotalRoundsFinalization }),
+		intDimension("fixed_rounds", *fixedRounds, func(c *configuration) *int { return &c.paras.TotalRoundsFixedThreshold }),
+		intDimension("m", *coolingOff, func(c *configuration) *int { return &c.paras.TotalRoundsCoolingOffPeriod }),
+		floatDimension("first_lower", *firstLower, func(c *configuration) *float64 { return &c.paras.FirstRoundLowerBoundThreshold }),
+		floatDimension("first_upper", *firstUpper, func(c *configuration) *float64 { return &c.paras.FirstRoundUpperBoundThreshold }),
+		floatDimension("lower", *lower, func(c *configuration) *float64 { return &c.paras.SubsequentRoundsLowerBoundThreshold }),
+		floatDimension("upper", *upper, func(c *configuration) *float64 { return &c.paras.SubsequentRoundsUpperBoundThreshold }),
+		floatDimension("fixed", *fixed, func(c *configuration) *float64 { return &c.paras.EndingRoundsFixedThreshold }),
+	}
+}
+
+// sweep calls the callback with every combination of the values of the given dimensions.
+func sweep(dimensions []dimension, callback func(c *configuration) error) error {
+	indices := make([]int, len(dimensions))
+	for _, d := range dimensions {
+		if d.size == 0 {
+			return errors.Errorf("no values given for %s", d.name)
+		}
+	}
+
+	for {
+		c := &configuration{paras: *fpc.DefaultParameters()}
+		c.paras.MaxQuerySampleSize = *maxQuerySize
+		c.paras.MinOpinionsReceived = *minOpinions
+		c.paras.MaxRoundsPerVoteContext = *maxRounds
+		for i, d := range dimensions {
+			d.set(c, indices[i])
+		}
+		if err := callback(c); err != nil {
+			return err
+		}
+
+		// advance the indices like an odometer, the last dimension changes fastest
+		i := len(indices) - 1
+		for ; i >= 0; i-- {
+			if indices[i]++; indices[i] < dimensions[i].size {
+				break
+			}
+			indices[i] = 0
+		}
+		if i < 0 {
+			return nil
+		}
+	}
+}
+
+// manaDistribution returns the mana of the nodes of the configuration.
+func (c *configuration) manaDistribution() ([]float64, error) {
+	distribution := make([]float64, c.nodes)
+	for i := range distribution {
+		switch c.mana {
+		case "uniform":
+			distribution[i] = 1
+		case "zipf":
+			distribution[i] = 1 / math.Pow(float64(i+1), *zipfExponent)
+		default:
+			return nil, errors.Errorf("unknown mana distribution %s, use uniform or zipf", c.mana)
+		}
+	}
+
+	return distribution, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region statistics ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+var statisticsHeader = []string{
+	"runs", "actual_adversary_share", "agreement_rate", "termination_rate", "finalized_share", "like_rate", "mean_rounds", "max_rounds",
+}
+
+// statistics are the aggregated outcomes of the simulations of a configuration.
+type statistics struct {
+	runs           int
+	adversaryShare float64
+	agreements     int
+	terminations   int
+	finalizedShare float64
+	likes          int
+	finalizations  int
+	rounds         int
+	maxRounds      int
+}
+
+// simulate runs the simulations of the given configuration.
+func simulate(c *configuration) (stats *statistics, err error) {
+	distribution, err := c.manaDistribution()
+	if err != nil {
+		return nil, err
+	}
+	adversaryStrategy, err := strategyByName(c.adversary)
+	if err != nil {
+		return nil, err
+	}
+
+	stats = &statistics{runs: *runs}
+	for run := 0; run < *runs; run++ {
+		runSeed := *seed + int64(run)
+		n, err := newNetwork(distribution, adversaryStrategy, c.adversaryShare, c.initialLike, c.paras, runSeed)
+		if err != nil {
+			return nil, err
+		}
+		if err = n.run(c.paras.MaxRoundsPerVoteContext, rand.New(rand.NewSource(runSeed))); err != nil {
+			return nil, err
+		}
+		stats.add(n)
+	}
+
+	return stats, nil
+}
+
+// add adds the outcome of the given simulated network.
+func (s *statistics) add(n *network) {
+	adversaryMana, totalMana := 0.0, 0.0
+	for _, nd := range n.nodes {
+		totalMana += nd.mana
+		if nd.adversary {
+			adversaryMana += nd.mana
+		}
+	}
+	s.adversaryShare += adversaryMana / totalMana / float64(s.runs)
+
+	finalized, likes := 0, 0
+	for _, nd := range n.honest {
+		if !nd.finalized {
+			continue
+		}
+		finalized++
+		s.rounds += nd.rounds
+		if nd.rounds > s.maxRounds {
+			s.maxRounds = nd.rounds
+		}
+		if nd.finalOpinion == opinion.Like {
+			likes++
+		}
+	}
+	s.finalizations += finalized
+	s.finalizedShare += float64(finalized) / float64(len(n.honest)) / float64(s.runs)
+
+	if finalized == len(n.honest) {
+		s.terminations++
+		if likes == 0 || likes == finalized {
+			s.agreements++
+		}
+		if likes == finalized {
+			s.likes++
+		}
+	}
+}
+
+// record returns the statistics as CSV fields in the order of the statisticsHeader.
+func (s *statistics) record() []string {
+	meanRounds := 0.0
+	if s.finalizations > 0 {
+		meanRounds = float64(s.rounds) / float64(s.finalizations)
+	}
+
+	return []string{
+		strconv.Itoa(s.runs),
+		formatFloat(s.adversaryShare),
+		formatFloat(float64(s.agreements) / float64(s.runs)),
+		formatFloat(float64(s.terminations) / float64(s.runs)),
+		formatFloat(s.finalizedShare),
+		formatFloat(float64(s.likes) / float64(s.runs)),
+		formatFloat(meanRounds),
+		strconv.Itoa(s.maxRounds),
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
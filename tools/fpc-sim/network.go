@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+// conflictID is the ID of the conflict that the simulated nodes vote on.
+const conflictID = "conflict"
+
+// queryTimeout is the timeout of the simulated queries. The queries are answered as soon as all nodes formed their
+// opinions, so it only ends the simulation if the network deadlocks.
+const queryTimeout = time.Minute
+
+// region network //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// network is a simulated network of honest nodes running FPC and adversary nodes answering queries with a strategy.
+type network struct {
+	nodes    []*node
+	honest   []*node
+	strategy strategy
+	seed     int64
+	round    *round
+}
+
+// newNetwork creates a network of nodes with the given mana. The adversary nodes are picked at random until they hold
+// the given share of the mana, a share of initialLike of the honest nodes initially likes the conflict.
+func newNetwork(manaDistribution []float64, strategy strategy, adversaryShare, initialLike float64, paras fpc.Parameters, seed int64) (n *network, err error) {
+	n = &network{
+		nodes:    make([]*node, len(manaDistribution)),
+		strategy: strategy,
+		seed:     seed,
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	totalMana := 0.0
+	for i, mana := range manaDistribution {
+		n.nodes[i] = newNode(n, i, mana)
+		totalMana += mana
+	}
+	adversaryMana := 0.0
+	for _, i := range rng.Perm(len(n.nodes)) {
+		if adversaryMana+n.nodes[i].mana > adversaryShare*totalMana {
+			continue
+		}
+		n.nodes[i].adversary = true
+		adversaryMana += n.nodes[i].mana
+	}
+	for _, nd := range n.nodes {
+		if !nd.adversary {
+			n.honest = append(n.honest, nd)
+		}
+	}
+	if len(n.honest) == 0 {
+		return nil, errors.New("the network has no honest nodes")
+	}
+
+	initialLikes := int(math.Round(initialLike * float64(len(n.honest))))
+	for i, position := range rng.Perm(len(n.honest)) {
+		initialOpinion := opinion.Dislike
+		if position < initialLikes {
+			initialOpinion = opinion.Like
+		}
+		if err = n.honest[i].setup(paras, initialOpinion, rng.Int63()); err != nil {
+			return nil, err
+		}
+	}
+
+	return n, nil
+}
+
+// run executes FPC rounds until all honest nodes finalized or failed their vote.
+func (n *network) run(maxRounds int, rng *rand.Rand) (err error) {
+	for r := 0; r <= maxRounds && !n.decided(); r++ {
+		n.round = newRound(r, len(n.honest))
+		for _, nd := range n.honest {
+			nd.formedOnce = &sync.Once{}
+		}
+		random := rng.Float64()
+
+		var wg sync.WaitGroup
+		var errMutex sync.Mutex
+		for _, nd := range n.honest {
+			if nd.decided() {
+				nd.formed()
+				continue
+			}
+
+			wg.Add(1)
+			go func(nd *node) {
+				defer wg.Done()
+				roundErr := nd.fpc.Round(random)
+				nd.formed()
+				if roundErr != nil {
+					errMutex.Lock()
+					err = errors.Errorf("round %d of node %d failed: %w", r, nd.index, roundErr)
+					errMutex.Unlock()
+				}
+			}(nd)
+		}
+		go n.round.closeWhenFormed()
+		wg.Wait()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decided returns true if all honest nodes finalized or failed their vote.
+func (n *network) decided() bool {
+	for _, nd := range n.honest {
+		if !nd.decided() {
+			return false
+		}
+	}
+	return true
+}
+
+// likeShare returns the mana weighted share of the honest nodes that like the conflict in the current round.
+func (n *network) likeShare() float64 {
+	n.round.likeShareOnce.Do(func() {
+		likedMana, totalMana := 0.0, 0.0
+		for _, nd := range n.honest {
+			totalMana += nd.mana
+			if nd.opinion() == opinion.Like {
+				likedMana += nd.mana
+			}
+		}
+		if totalMana > 0 {
+			n.round.likeShare = likedMana / totalMana
+		}
+	})
+
+	return n.round.likeShare
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region round ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// round synchronizes the nodes within a round: queries are answered once all honest nodes formed their opinions, so
+// that the outcome does not depend on the order in which the nodes are scheduled.
+type round struct {
+	index         int
+	formed        sync.WaitGroup
+	done          chan struct{}
+	likeShare     float64
+	likeShareOnce sync.Once
+}
+
+func newRound(index, honestNodes int) *round {
+	r := &round{
+		index: index,
+		done:  make(chan struct{}),
+	}
+	r.formed.Add(honestNodes)
+
+	return r
+}
+
+func (r *round) closeWhenFormed() {
+	r.formed.Wait()
+	close(r.done)
+}
+
+// wait blocks until all honest nodes formed their opinions or the context is done.
+func (r *round) wait(ctx context.Context) error {
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region node /////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// node is a simulated node. Honest nodes run an FPC instance, adversary nodes only answer queries.
+type node struct {
+	id        identity.ID
+	index     int
+	mana      float64
+	adversary bool
+
+	network       *network
+	fpc           *fpc.FPC
+	opinionGivers []opinion.OpinionGiver
+	formedOnce    *sync.Once
+
+	finalOpinion opinion.Opinion
+	finalized    bool
+	failed       bool
+	rounds       int
+}
+
+func newNode(n *network, index int, mana float64) *node {
+	nd := &node{
+		network: n,
+		index:   index,
+		mana:    mana,
+	}
+	binary.BigEndian.PutUint64(nd.id[:], uint64(index))
+
+	return nd
+}
+
+// setup creates the FPC instance of an honest node and makes it vote on the conflict.
+func (nd *node) setup(paras fpc.Parameters, initialOpinion opinion.Opinion, seed int64) error {
+	for _, other := range nd.network.nodes {
+		switch {
+		case other == nd:
+		case other.adversary:
+			nd.opinionGivers = append(nd.opinionGivers, &adversaryOpinionGiver{adversary: other, querier: nd})
+		default:
+			nd.opinionGivers = append(nd.opinionGivers, other)
+		}
+	}
+
+	paras.QueryTimeout = queryTimeout
+	nd.fpc = fpc.New(func() ([]opinion.OpinionGiver, error) {
+		nd.formed()
+		return nd.opinionGivers, nil
+	}, func() (float64, error) {
+		return nd.mana, nil
+	}, &paras)
+	nd.fpc.SetOpinionGiverRng(rand.New(rand.NewSource(seed)))
+	nd.fpc.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		nd.finalOpinion, nd.finalized, nd.rounds = ev.Opinion, true, ev.Ctx.Rounds
+	}))
+	nd.fpc.Events().Failed.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		nd.finalOpinion, nd.failed, nd.rounds = ev.Opinion, true, ev.Ctx.Rounds
+	}))
+
+	return nd.fpc.Vote(conflictID, vote.ConflictType, initialOpinion)
+}
+
+// formed marks the opinion of the node in the current round as formed. It is called before the node sends its
+// queries, or after its round if it did not query.
+func (nd *node) formed() {
+	nd.formedOnce.Do(nd.network.round.formed.Done)
+}
+
+// decided returns true if the node finalized or failed its vote.
+func (nd *node) decided() bool {
+	return nd.finalized || nd.failed
+}
+
+// opinion returns the current opinion of the node about the conflict.
+func (nd *node) opinion() opinion.Opinion {
+	if nd.decided() {
+		return nd.finalOpinion
+	}
+	o, err := nd.fpc.IntermediateOpinion(conflictID)
+	if err != nil {
+		return opinion.Unknown
+	}
+
+	return o
+}
+
+// Query answers a query with the opinion that the node formed in the current round.
+func (nd *node) Query(ctx context.Context, conflictIDs, timestampIDs []string, _ ...time.Duration) (opinion.Opinions, error) {
+	if err := nd.network.round.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return repeatOpinion(nd.opinion(), len(conflictIDs)+len(timestampIDs)), nil
+}
+
+// ID returns the ID of the node.
+func (nd *node) ID() identity.ID {
+	return nd.id
+}
+
+// Mana returns the consensus mana of the node.
+func (nd *node) Mana() float64 {
+	return nd.mana
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region adversaryOpinionGiver ////////////////////////////////////////////////////////////////////////////////////////
+
+// adversaryOpinionGiver is the view of a querier on an adversary node, so that the adversary can answer every querier
+// differently.
+type adversaryOpinionGiver struct {
+	adversary *node
+	querier   *node
+}
+
+// Query answers a query with the opinion chosen by the strategy of the network.
+func (a *adversaryOpinionGiver) Query(ctx context.Context, conflictIDs, timestampIDs []string, _ ...time.Duration) (opinion.Opinions, error) {
+	n := a.adversary.network
+	if err := n.round.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return repeatOpinion(n.strategy(n, a.adversary, a.querier), len(conflictIDs)+len(timestampIDs)), nil
+}
+
+// ID returns the ID of the adversary node.
+func (a *adversaryOpinionGiver) ID() identity.ID {
+	return a.adversary.id
+}
+
+// Mana returns the consensus mana of the adversary node.
+func (a *adversaryOpinionGiver) Mana() float64 {
+	return a.adversary.mana
+}
+
+func repeatOpinion(o opinion.Opinion, count int) opinion.Opinions {
+	opinions := make(opinion.Opinions, count)
+	for i := range opinions {
+		opinions[i] = o
+	}
+
+	return opinions
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+// strategy chooses the opinion that an adversary node answers to a query of the given querier.
+type strategy func(n *network, adversary, querier *node) opinion.Opinion
+
+// strategies contains the adversary strategies that can be simulated.
+var strategies = map[string]strategy{
+	// random answers a random opinion to every query.
+	"random": func(n *network, adversary, querier *node) opinion.Opinion {
+		return likeIf(coinFlip(n.seed, n.round.index, adversary.index, querier.index))
+	},
+	// cautious answers the opinion of the honest minority, but answers the same to every querier, so that it cannot be
+	// caught giving inconsistent opinions.
+	"cautious": func(n *network, adversary, querier *node) opinion.Opinion {
+		return likeIf(n.likeShare() < 0.5)
+	},
+	// berserk answers every querier the opposite of its own opinion, so that the honest nodes keep switching and do
+	// not agree.
+	"berserk": func(n *network, adversary, querier *node) opinion.Opinion {
+		return likeIf(querier.opinion() != opinion.Like)
+	},
+}
+
+// strategyByName returns the strategy with the given name.
+func strategyByName(name string) (strategy, error) {
+	s, exists := strategies[name]
+	if !exists {
+		return nil, errors.Errorf("unknown adversary strategy %s, use one of %v", name, strategyNames())
+	}
+
+	return s, nil
+}
+
+func strategyNames() (names []string) {
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func likeIf(like bool) opinion.Opinion {
+	if like {
+		return opinion.Like
+	}
+
+	return opinion.Dislike
+}
+
+// coinFlip returns a random bit that only depends on its inputs, so that the simulation does not depend on the order
+// in which the queries are answered.
+func coinFlip(seed int64, values ...int) bool {
+	buffer := make([]byte, 8*(len(values)+1))
+	binary.BigEndian.PutUint64(buffer, uint64(seed))
+	for i, value := range values {
+		binary.BigEndian.PutUint64(buffer[8*(i+1):], uint64(value))
+	}
+	hash := blake2b.Sum256(buffer)
+
+	return hash[0]&1 == 1
+}
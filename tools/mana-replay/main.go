@@ -0,0 +1,158 @@
+// Package main implements a tool that replays the mana event log of a node with an alternative mana model, so that
+// different mana formulas can be compared on the same data.
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/mr-tron/base58"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+const (
+	cfgDatabaseDir    = "db"
+	cfgTime           = "time"
+	cfgModel          = "model"
+	cfgEmaCoefficient = "ema"
+	cfgDecay          = "decay"
+	cfgAccrualRate    = "accrual-rate"
+	cfgDecayRate      = "decay-rate"
+	cfgCap            = "cap"
+	cfgEpoch          = "epoch"
+	cfgNodeEma        = "node-ema"
+	cfgNodeDecay      = "node-decay"
+)
+
+func init() {
+	flag.String(cfgDatabaseDir, "mainnetdb", "the database directory of a stopped node")
+	flag.String(cfgTime, "", "the RFC3339 time to compute the mana vector at (default now)")
+	flag.String(cfgModel, "exponential", "the mana model to replay with (exponential, linear)")
+	flag.Float64(cfgEmaCoefficient, 0.0057762265, "the coefficient of the moving average of the effective base mana, in 1/s")
+	flag.Float64(cfgDecay, 0.00003209, "the decay of the base mana of the exponential model, in 1/s")
+	flag.Float64(cfgAccrualRate, 0.00003209, "the fraction of the funds that accrues as mana per second in the linear model, in 1/s")
+	flag.Float64(cfgDecayRate, 1, "the base mana that is lost per second in the linear model, in mana/s")
+	flag.Float64(cfgCap, 0, "the maximum base mana of a node, 0 for no cap")
+	flag.Duration(cfgEpoch, 0, "the duration of the epochs the model is evaluated in, 0 for continuous time")
+	flag.Float64(cfgNodeEma, 0.0057762265, "the emaCoefficient2 the node was running with")
+	flag.Float64(cfgNodeDecay, 0.00003209, "the decay the node was running with")
+}
+
+func main() {
+	flag.Parse()
+	if err := viper.BindPFlags(flag.CommandLine); err != nil {
+		panic(err)
+	}
+
+	at := time.Now()
+	if timeStr := viper.GetString(cfgTime); timeStr != "" {
+		var err error
+		if at, err = time.Parse(time.RFC3339, timeStr); err != nil {
+			log.Fatalf("invalid time %s: %s", timeStr, err)
+		}
+	}
+	model, err := newModel()
+	if err != nil {
+		log.Fatal(err)
+	}
+	// the reference values are computed with the model of the node
+	mana.SetCoefficients(viper.GetFloat64(cfgNodeEma), viper.GetFloat64(cfgNodeEma), viper.GetFloat64(cfgNodeDecay))
+
+	db, err := database.NewDB(viper.GetString(cfgDatabaseDir))
+	if err != nil {
+		log.Fatalf("failed to open database: %s", err)
+	}
+	defer db.Close()
+	history := mana.NewHistory(db.NewStore().WithRealm([]byte{database.PrefixMana, mana.PrefixHistory}), time.Hour)
+
+	log.Printf("replaying access mana at %s with %s...", at.Format(time.RFC3339), model.Name())
+	replayed, err := history.Replay(model, at)
+	if err != nil {
+		log.Fatalf("failed to replay the event log: %s", err)
+	}
+	reference, err := history.ManaVectorAt(mana.AccessMana, at)
+	if err != nil {
+		log.Fatalf("failed to compute the reference vector: %s", err)
+	}
+	if err = writeCSV(reference, replayed, at); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newModel() (model mana.Model, err error) {
+	switch name := viper.GetString(cfgModel); name {
+	case "exponential":
+		model = mana.NewExponentialModel(viper.GetFloat64(cfgEmaCoefficient), viper.GetFloat64(cfgDecay))
+	case "linear":
+		model = mana.NewLinearModel(viper.GetFloat64(cfgAccrualRate), viper.GetFloat64(cfgDecayRate), viper.GetFloat64(cfgEmaCoefficient))
+	default:
+		return nil, fmt.Errorf("unknown mana model %s", name)
+	}
+	if limit := viper.GetFloat64(cfgCap); limit > 0 {
+		model = mana.NewCappedModel(model, limit)
+	}
+	if epoch := viper.GetDuration(cfgEpoch); epoch > 0 {
+		model = mana.NewEpochModel(model, epoch)
+	}
+	return model, nil
+}
+
+// writeCSV writes the reference and the replayed mana of every node to stdout, ordered by the replayed mana.
+func writeCSV(reference mana.BaseManaVector, replayed *mana.AccessBaseManaVector, at time.Time) error {
+	referenceMana, _, err := reference.GetManaMap(at)
+	if err != nil {
+		return fmt.Errorf("failed to read the reference vector: %w", err)
+	}
+	replayedMana := make(map[identity.ID]mana.BaseMana)
+	replayed.ForEach(func(nodeID identity.ID, baseMana mana.BaseMana) bool {
+		replayedMana[nodeID] = baseMana
+		return true
+	})
+
+	nodeIDs := make([]identity.ID, 0, len(replayedMana))
+	for nodeID := range replayedMana {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	for nodeID := range referenceMana {
+		if _, exists := replayedMana[nodeID]; !exists {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+	effective := func(nodeID identity.ID) float64 {
+		if baseMana, exists := replayedMana[nodeID]; exists {
+			return baseMana.EffectiveValue()
+		}
+		return 0
+	}
+	sort.Slice(nodeIDs, func(i, j int) bool { return effective(nodeIDs[i]) > effective(nodeIDs[j]) })
+
+	w := csv.NewWriter(os.Stdout)
+	if err = w.Write([]string{"nodeID", "reference", "base", "effective"}); err != nil {
+		return err
+	}
+	for _, nodeID := range nodeIDs {
+		var base float64
+		if baseMana, exists := replayedMana[nodeID]; exists {
+			base = baseMana.BaseValue()
+		}
+		if err = w.Write([]string{
+			base58.Encode(nodeID.Bytes()),
+			fmt.Sprintf("%f", referenceMana[nodeID]),
+			fmt.Sprintf("%f", base),
+			fmt.Sprintf("%f", effective(nodeID)),
+		}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}